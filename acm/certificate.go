@@ -10,12 +10,12 @@ import (
 
 // Certificate is a certificate hosted in AWS Certificate Manager.
 type Certificate struct {
-	ARN                     string
-	Status                  string
-	SubjectAlternativeNames []string
-	DomainName              string
-	Validations             []CertificateValidation
-	Type                    string
+	ARN                     string                  `json:"arn"`
+	Status                  string                  `json:"status"`
+	SubjectAlternativeNames []string                `json:"subjectAlternativeNames"`
+	DomainName              string                  `json:"domainName"`
+	Validations             []CertificateValidation `json:"validations"`
+	Type                    string                  `json:"type"`
 }
 
 // AddValidation adds a certificate validation to a certificate.
@@ -35,9 +35,9 @@ func (c Certificate) IsPendingValidation() bool {
 
 // CertificateValidation holds details about how to validate a certificate.
 type CertificateValidation struct {
-	Status         string
-	DomainName     string
-	ResourceRecord CertificateResourceRecord
+	Status         string                    `json:"status"`
+	DomainName     string                    `json:"domainName"`
+	ResourceRecord CertificateResourceRecord `json:"resourceRecord"`
 }
 
 // IsFailed returns true if a certificate validation's status is FAILED.
@@ -70,9 +70,9 @@ func (v CertificateValidation) ResourceRecordString() string {
 
 // CertificateResourceRecord contains the DNS record used to validate a certificate.
 type CertificateResourceRecord struct {
-	Type  string
-	Name  string
-	Value string
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 // Certificates is a collection of certificates.
@@ -0,0 +1,58 @@
+// Package applicationautoscaling is a client for Application Auto Scaling.
+package applicationautoscaling
+
+//go:generate mockgen -package client -destination=mock/client/client.go github.com/awslabs/fargatecli/applicationautoscaling Client
+//go:generate mockgen -package sdk -source ../vendor/github.com/aws/aws-sdk-go/service/applicationautoscaling/applicationautoscalingiface/interface.go -destination=mock/sdk/applicationautoscalingiface.go github.com/aws/aws-sdk-go/service/applicationautoscaling/applicationautoscalingiface ApplicationAutoScalingAPI
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/applicationautoscaling"
+	"github.com/aws/aws-sdk-go/service/applicationautoscaling/applicationautoscalingiface"
+)
+
+const serviceResourceIDFormat = "service/%s/%s"
+
+// Client represents a method for accessing Application Auto Scaling.
+type Client interface {
+	DeregisterScalableTarget(string) error
+	DescribeScalableTargets(string) (ScalableTargets, error)
+	DescribeScalingActivities(string, int64) (ScalingActivities, error)
+	RegisterScalableTarget(RegisterScalableTargetParameters) error
+
+	DeleteScalingPolicy(string, string) error
+	DescribeScalingPolicies(string) (ScalingPolicies, error)
+	PutTargetTrackingScalingPolicy(PutTargetTrackingScalingPolicyParameters) (string, error)
+
+	DeleteScheduledAction(string, string) error
+	DescribeScheduledActions(string) (ScheduledActions, error)
+	PutScheduledAction(PutScheduledActionParameters) error
+}
+
+// SDKClient implements access to Application Auto Scaling via the AWS SDK.
+type SDKClient struct {
+	client applicationautoscalingiface.ApplicationAutoScalingAPI
+}
+
+// New returns an SDKClient configured with the given session.
+func New(sess *session.Session) SDKClient {
+	return SDKClient{
+		client: applicationautoscaling.New(sess),
+	}
+}
+
+// ServiceResourceID returns the identifier Application Auto Scaling uses for an ECS service.
+func ServiceResourceID(clusterName, serviceName string) string {
+	return fmt.Sprintf(serviceResourceIDFormat, clusterName, serviceName)
+}
+
+// RequestCountResourceLabel returns the label identifying a load balancer and target group for the
+// ALB request count per target metric, e.g. app/web/50dc6c495c0c9188/targetgroup/web/943f017f100becff.
+func RequestCountResourceLabel(loadBalancerARN, targetGroupARN string) string {
+	loadBalancer := loadBalancerARN[strings.Index(loadBalancerARN, ":loadbalancer/")+len(":loadbalancer/"):]
+	targetGroup := targetGroupARN[strings.LastIndex(targetGroupARN, ":")+1:]
+
+	return loadBalancer + "/" + targetGroup
+}
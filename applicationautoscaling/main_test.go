@@ -0,0 +1,19 @@
+package applicationautoscaling
+
+import "testing"
+
+func TestServiceResourceID(t *testing.T) {
+	if expected, got := "service/fargate/web", ServiceResourceID("fargate", "web"); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestRequestCountResourceLabel(t *testing.T) {
+	loadBalancerARN := "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/web/50dc6c495c0c9188"
+	targetGroupARN := "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/fargate-web/943f017f100becff"
+	expected := "app/web/50dc6c495c0c9188/targetgroup/fargate-web/943f017f100becff"
+
+	if got := RequestCountResourceLabel(loadBalancerARN, targetGroupARN); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
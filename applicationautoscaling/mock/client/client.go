@@ -0,0 +1,179 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/awslabs/fargatecli/applicationautoscaling (interfaces: Client)
+
+// Package client is a generated GoMock package.
+package client
+
+import (
+	applicationautoscaling "github.com/awslabs/fargatecli/applicationautoscaling"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockClient is a mock of Client interface
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// DeleteScalingPolicy mocks base method
+func (m *MockClient) DeleteScalingPolicy(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScalingPolicy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScalingPolicy indicates an expected call of DeleteScalingPolicy
+func (mr *MockClientMockRecorder) DeleteScalingPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScalingPolicy", reflect.TypeOf((*MockClient)(nil).DeleteScalingPolicy), arg0, arg1)
+}
+
+// DeleteScheduledAction mocks base method
+func (m *MockClient) DeleteScheduledAction(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduledAction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduledAction indicates an expected call of DeleteScheduledAction
+func (mr *MockClientMockRecorder) DeleteScheduledAction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduledAction", reflect.TypeOf((*MockClient)(nil).DeleteScheduledAction), arg0, arg1)
+}
+
+// DeregisterScalableTarget mocks base method
+func (m *MockClient) DeregisterScalableTarget(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeregisterScalableTarget", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeregisterScalableTarget indicates an expected call of DeregisterScalableTarget
+func (mr *MockClientMockRecorder) DeregisterScalableTarget(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterScalableTarget", reflect.TypeOf((*MockClient)(nil).DeregisterScalableTarget), arg0)
+}
+
+// DescribeScalableTargets mocks base method
+func (m *MockClient) DescribeScalableTargets(arg0 string) (applicationautoscaling.ScalableTargets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeScalableTargets", arg0)
+	ret0, _ := ret[0].(applicationautoscaling.ScalableTargets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeScalableTargets indicates an expected call of DescribeScalableTargets
+func (mr *MockClientMockRecorder) DescribeScalableTargets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeScalableTargets", reflect.TypeOf((*MockClient)(nil).DescribeScalableTargets), arg0)
+}
+
+// DescribeScalingActivities mocks base method
+func (m *MockClient) DescribeScalingActivities(arg0 string, arg1 int64) (applicationautoscaling.ScalingActivities, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeScalingActivities", arg0, arg1)
+	ret0, _ := ret[0].(applicationautoscaling.ScalingActivities)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeScalingActivities indicates an expected call of DescribeScalingActivities
+func (mr *MockClientMockRecorder) DescribeScalingActivities(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeScalingActivities", reflect.TypeOf((*MockClient)(nil).DescribeScalingActivities), arg0, arg1)
+}
+
+// DescribeScalingPolicies mocks base method
+func (m *MockClient) DescribeScalingPolicies(arg0 string) (applicationautoscaling.ScalingPolicies, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeScalingPolicies", arg0)
+	ret0, _ := ret[0].(applicationautoscaling.ScalingPolicies)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeScalingPolicies indicates an expected call of DescribeScalingPolicies
+func (mr *MockClientMockRecorder) DescribeScalingPolicies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeScalingPolicies", reflect.TypeOf((*MockClient)(nil).DescribeScalingPolicies), arg0)
+}
+
+// DescribeScheduledActions mocks base method
+func (m *MockClient) DescribeScheduledActions(arg0 string) (applicationautoscaling.ScheduledActions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeScheduledActions", arg0)
+	ret0, _ := ret[0].(applicationautoscaling.ScheduledActions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeScheduledActions indicates an expected call of DescribeScheduledActions
+func (mr *MockClientMockRecorder) DescribeScheduledActions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeScheduledActions", reflect.TypeOf((*MockClient)(nil).DescribeScheduledActions), arg0)
+}
+
+// PutScheduledAction mocks base method
+func (m *MockClient) PutScheduledAction(arg0 applicationautoscaling.PutScheduledActionParameters) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutScheduledAction", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutScheduledAction indicates an expected call of PutScheduledAction
+func (mr *MockClientMockRecorder) PutScheduledAction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutScheduledAction", reflect.TypeOf((*MockClient)(nil).PutScheduledAction), arg0)
+}
+
+// PutTargetTrackingScalingPolicy mocks base method
+func (m *MockClient) PutTargetTrackingScalingPolicy(arg0 applicationautoscaling.PutTargetTrackingScalingPolicyParameters) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutTargetTrackingScalingPolicy", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutTargetTrackingScalingPolicy indicates an expected call of PutTargetTrackingScalingPolicy
+func (mr *MockClientMockRecorder) PutTargetTrackingScalingPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTargetTrackingScalingPolicy", reflect.TypeOf((*MockClient)(nil).PutTargetTrackingScalingPolicy), arg0)
+}
+
+// RegisterScalableTarget mocks base method
+func (m *MockClient) RegisterScalableTarget(arg0 applicationautoscaling.RegisterScalableTargetParameters) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterScalableTarget", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterScalableTarget indicates an expected call of RegisterScalableTarget
+func (mr *MockClientMockRecorder) RegisterScalableTarget(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterScalableTarget", reflect.TypeOf((*MockClient)(nil).RegisterScalableTarget), arg0)
+}
@@ -0,0 +1,129 @@
+package applicationautoscaling
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsaas "github.com/aws/aws-sdk-go/service/applicationautoscaling"
+)
+
+// ScalableTarget is the range within which Application Auto Scaling adjusts an ECS service's
+// desired count.
+type ScalableTarget struct {
+	MaxCapacity int64  `json:"maxCapacity"`
+	MinCapacity int64  `json:"minCapacity"`
+	ResourceID  string `json:"resourceId"`
+}
+
+// ScalableTargets is a collection of scalable targets.
+type ScalableTargets []ScalableTarget
+
+// ScalingActivity is a change, or attempted change, to an ECS service's desired count.
+type ScalingActivity struct {
+	Cause       string    `json:"cause"`
+	Description string    `json:"description"`
+	StartTime   time.Time `json:"startTime"`
+	StatusCode  string    `json:"statusCode"`
+}
+
+// ScalingActivities is a collection of scaling activities.
+type ScalingActivities []ScalingActivity
+
+// RegisterScalableTargetParameters are the parameters required to register or update a scalable
+// target. Capacities which are nil are left unchanged on an existing scalable target.
+type RegisterScalableTargetParameters struct {
+	MaxCapacity *int64
+	MinCapacity *int64
+	ResourceID  string
+}
+
+// RegisterScalableTarget registers an ECS service as a scalable target, or updates the capacities of
+// an already registered service.
+func (aas SDKClient) RegisterScalableTarget(p RegisterScalableTargetParameters) error {
+	_, err := aas.client.RegisterScalableTarget(
+		&awsaas.RegisterScalableTargetInput{
+			MaxCapacity:       p.MaxCapacity,
+			MinCapacity:       p.MinCapacity,
+			ResourceId:        aws.String(p.ResourceID),
+			ScalableDimension: aws.String(awsaas.ScalableDimensionEcsServiceDesiredCount),
+			ServiceNamespace:  aws.String(awsaas.ServiceNamespaceEcs),
+		},
+	)
+
+	return err
+}
+
+// DeregisterScalableTarget deregisters an ECS service, deleting its scaling policies and scheduled
+// actions.
+func (aas SDKClient) DeregisterScalableTarget(resourceID string) error {
+	_, err := aas.client.DeregisterScalableTarget(
+		&awsaas.DeregisterScalableTargetInput{
+			ResourceId:        aws.String(resourceID),
+			ScalableDimension: aws.String(awsaas.ScalableDimensionEcsServiceDesiredCount),
+			ServiceNamespace:  aws.String(awsaas.ServiceNamespaceEcs),
+		},
+	)
+
+	return err
+}
+
+// DescribeScalableTargets returns the scalable target registered for an ECS service, if any.
+func (aas SDKClient) DescribeScalableTargets(resourceID string) (ScalableTargets, error) {
+	var scalableTargets ScalableTargets
+
+	resp, err := aas.client.DescribeScalableTargets(
+		&awsaas.DescribeScalableTargetsInput{
+			ResourceIds:       aws.StringSlice([]string{resourceID}),
+			ScalableDimension: aws.String(awsaas.ScalableDimensionEcsServiceDesiredCount),
+			ServiceNamespace:  aws.String(awsaas.ServiceNamespaceEcs),
+		},
+	)
+
+	if err != nil {
+		return scalableTargets, err
+	}
+
+	for _, scalableTarget := range resp.ScalableTargets {
+		scalableTargets = append(scalableTargets,
+			ScalableTarget{
+				MaxCapacity: aws.Int64Value(scalableTarget.MaxCapacity),
+				MinCapacity: aws.Int64Value(scalableTarget.MinCapacity),
+				ResourceID:  aws.StringValue(scalableTarget.ResourceId),
+			},
+		)
+	}
+
+	return scalableTargets, nil
+}
+
+// DescribeScalingActivities returns up to max of the most recent scaling activities of an ECS
+// service, most recent first.
+func (aas SDKClient) DescribeScalingActivities(resourceID string, max int64) (ScalingActivities, error) {
+	var scalingActivities ScalingActivities
+
+	resp, err := aas.client.DescribeScalingActivities(
+		&awsaas.DescribeScalingActivitiesInput{
+			MaxResults:        aws.Int64(max),
+			ResourceId:        aws.String(resourceID),
+			ScalableDimension: aws.String(awsaas.ScalableDimensionEcsServiceDesiredCount),
+			ServiceNamespace:  aws.String(awsaas.ServiceNamespaceEcs),
+		},
+	)
+
+	if err != nil {
+		return scalingActivities, err
+	}
+
+	for _, scalingActivity := range resp.ScalingActivities {
+		scalingActivities = append(scalingActivities,
+			ScalingActivity{
+				Cause:       aws.StringValue(scalingActivity.Cause),
+				Description: aws.StringValue(scalingActivity.Description),
+				StartTime:   aws.TimeValue(scalingActivity.StartTime),
+				StatusCode:  aws.StringValue(scalingActivity.StatusCode),
+			},
+		)
+	}
+
+	return scalingActivities, nil
+}
@@ -0,0 +1,136 @@
+package applicationautoscaling
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsaas "github.com/aws/aws-sdk-go/service/applicationautoscaling"
+	"github.com/awslabs/fargatecli/applicationautoscaling/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+func TestRegisterScalableTarget(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockApplicationAutoScalingAPI(mockCtrl)
+	aas := SDKClient{client: mockAPI}
+
+	i := &awsaas.RegisterScalableTargetInput{
+		MaxCapacity:       aws.Int64(20),
+		ResourceId:        aws.String("service/fargate/web"),
+		ScalableDimension: aws.String("ecs:service:DesiredCount"),
+		ServiceNamespace:  aws.String("ecs"),
+	}
+
+	mockAPI.EXPECT().RegisterScalableTarget(i).Return(&awsaas.RegisterScalableTargetOutput{}, nil)
+
+	err := aas.RegisterScalableTarget(
+		RegisterScalableTargetParameters{
+			MaxCapacity: aws.Int64(20),
+			ResourceID:  "service/fargate/web",
+		},
+	)
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestDescribeScalableTargets(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockApplicationAutoScalingAPI(mockCtrl)
+	aas := SDKClient{client: mockAPI}
+
+	i := &awsaas.DescribeScalableTargetsInput{
+		ResourceIds:       aws.StringSlice([]string{"service/fargate/web"}),
+		ScalableDimension: aws.String("ecs:service:DesiredCount"),
+		ServiceNamespace:  aws.String("ecs"),
+	}
+	o := &awsaas.DescribeScalableTargetsOutput{
+		ScalableTargets: []*awsaas.ScalableTarget{
+			&awsaas.ScalableTarget{
+				MaxCapacity: aws.Int64(20),
+				MinCapacity: aws.Int64(2),
+				ResourceId:  aws.String("service/fargate/web"),
+			},
+		},
+	}
+
+	mockAPI.EXPECT().DescribeScalableTargets(i).Return(o, nil)
+
+	scalableTargets, err := aas.DescribeScalableTargets("service/fargate/web")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(scalableTargets) != 1 {
+		t.Fatalf("expected 1 scalable target, got %d", len(scalableTargets))
+	}
+
+	if expected := (ScalableTarget{MaxCapacity: 20, MinCapacity: 2, ResourceID: "service/fargate/web"}); scalableTargets[0] != expected {
+		t.Errorf("expected %+v, got %+v", expected, scalableTargets[0])
+	}
+}
+
+func TestDescribeScalableTargetsError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockApplicationAutoScalingAPI(mockCtrl)
+	aas := SDKClient{client: mockAPI}
+
+	mockAPI.EXPECT().DescribeScalableTargets(gomock.Any()).Return(&awsaas.DescribeScalableTargetsOutput{}, errors.New("boom"))
+
+	if _, err := aas.DescribeScalableTargets("service/fargate/web"); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+func TestDescribeScalingActivities(t *testing.T) {
+	startTime := time.Now()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockApplicationAutoScalingAPI(mockCtrl)
+	aas := SDKClient{client: mockAPI}
+
+	i := &awsaas.DescribeScalingActivitiesInput{
+		MaxResults:        aws.Int64(5),
+		ResourceId:        aws.String("service/fargate/web"),
+		ScalableDimension: aws.String("ecs:service:DesiredCount"),
+		ServiceNamespace:  aws.String("ecs"),
+	}
+	o := &awsaas.DescribeScalingActivitiesOutput{
+		ScalingActivities: []*awsaas.ScalingActivity{
+			&awsaas.ScalingActivity{
+				Cause:       aws.String("monitor alarm TargetTracking-service/fargate/web-AlarmHigh in state ALARM triggered policy fargate-cpu"),
+				Description: aws.String("Setting desired count to 4."),
+				StartTime:   aws.Time(startTime),
+				StatusCode:  aws.String("Successful"),
+			},
+		},
+	}
+
+	mockAPI.EXPECT().DescribeScalingActivities(i).Return(o, nil)
+
+	activities, err := aas.DescribeScalingActivities("service/fargate/web", 5)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(activities) != 1 {
+		t.Fatalf("expected 1 activity, got %d", len(activities))
+	}
+
+	if activities[0].Description != "Setting desired count to 4." || activities[0].StatusCode != "Successful" || !activities[0].StartTime.Equal(startTime) {
+		t.Errorf("unexpected activity %+v", activities[0])
+	}
+}
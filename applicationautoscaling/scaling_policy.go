@@ -0,0 +1,122 @@
+package applicationautoscaling
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsaas "github.com/aws/aws-sdk-go/service/applicationautoscaling"
+)
+
+// Predefined metrics which target tracking scaling policies of ECS services can track.
+const (
+	MetricCPUUtilization        = awsaas.MetricTypeEcsserviceAverageCpuutilization
+	MetricMemoryUtilization     = awsaas.MetricTypeEcsserviceAverageMemoryUtilization
+	MetricRequestCountPerTarget = awsaas.MetricTypeAlbrequestCountPerTarget
+)
+
+// ScalingPolicy is a target tracking scaling policy which adjusts an ECS service's desired count to
+// keep a metric at a target value.
+type ScalingPolicy struct {
+	Metric        string  `json:"metric"`
+	Name          string  `json:"name"`
+	ResourceLabel string  `json:"resourceLabel,omitempty"`
+	TargetValue   float64 `json:"targetValue"`
+}
+
+// ScalingPolicies is a collection of scaling policies.
+type ScalingPolicies []ScalingPolicy
+
+// PutTargetTrackingScalingPolicyParameters are the parameters required to create or update a target
+// tracking scaling policy. ResourceLabel is required only for MetricRequestCountPerTarget.
+type PutTargetTrackingScalingPolicyParameters struct {
+	Metric        string
+	PolicyName    string
+	ResourceID    string
+	ResourceLabel string
+	TargetValue   float64
+}
+
+func (p ScalingPolicy) String() string {
+	return fmt.Sprintf("%s %g", p.Metric, p.TargetValue)
+}
+
+// PutTargetTrackingScalingPolicy creates or updates a target tracking scaling policy, returning its
+// ARN.
+func (aas SDKClient) PutTargetTrackingScalingPolicy(p PutTargetTrackingScalingPolicyParameters) (string, error) {
+	metricSpecification := &awsaas.PredefinedMetricSpecification{
+		PredefinedMetricType: aws.String(p.Metric),
+	}
+
+	if p.ResourceLabel != "" {
+		metricSpecification.ResourceLabel = aws.String(p.ResourceLabel)
+	}
+
+	resp, err := aas.client.PutScalingPolicy(
+		&awsaas.PutScalingPolicyInput{
+			PolicyName:        aws.String(p.PolicyName),
+			PolicyType:        aws.String(awsaas.PolicyTypeTargetTrackingScaling),
+			ResourceId:        aws.String(p.ResourceID),
+			ScalableDimension: aws.String(awsaas.ScalableDimensionEcsServiceDesiredCount),
+			ServiceNamespace:  aws.String(awsaas.ServiceNamespaceEcs),
+			TargetTrackingScalingPolicyConfiguration: &awsaas.TargetTrackingScalingPolicyConfiguration{
+				PredefinedMetricSpecification: metricSpecification,
+				TargetValue:                   aws.Float64(p.TargetValue),
+			},
+		},
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(resp.PolicyARN), nil
+}
+
+// DeleteScalingPolicy deletes a scaling policy of an ECS service.
+func (aas SDKClient) DeleteScalingPolicy(resourceID, policyName string) error {
+	_, err := aas.client.DeleteScalingPolicy(
+		&awsaas.DeleteScalingPolicyInput{
+			PolicyName:        aws.String(policyName),
+			ResourceId:        aws.String(resourceID),
+			ScalableDimension: aws.String(awsaas.ScalableDimensionEcsServiceDesiredCount),
+			ServiceNamespace:  aws.String(awsaas.ServiceNamespaceEcs),
+		},
+	)
+
+	return err
+}
+
+// DescribeScalingPolicies returns the target tracking scaling policies of an ECS service.
+func (aas SDKClient) DescribeScalingPolicies(resourceID string) (ScalingPolicies, error) {
+	var scalingPolicies ScalingPolicies
+
+	err := aas.client.DescribeScalingPoliciesPages(
+		&awsaas.DescribeScalingPoliciesInput{
+			ResourceId:        aws.String(resourceID),
+			ScalableDimension: aws.String(awsaas.ScalableDimensionEcsServiceDesiredCount),
+			ServiceNamespace:  aws.String(awsaas.ServiceNamespaceEcs),
+		},
+		func(resp *awsaas.DescribeScalingPoliciesOutput, lastPage bool) bool {
+			for _, scalingPolicy := range resp.ScalingPolicies {
+				configuration := scalingPolicy.TargetTrackingScalingPolicyConfiguration
+
+				if configuration == nil || configuration.PredefinedMetricSpecification == nil {
+					continue
+				}
+
+				scalingPolicies = append(scalingPolicies,
+					ScalingPolicy{
+						Metric:        aws.StringValue(configuration.PredefinedMetricSpecification.PredefinedMetricType),
+						Name:          aws.StringValue(scalingPolicy.PolicyName),
+						ResourceLabel: aws.StringValue(configuration.PredefinedMetricSpecification.ResourceLabel),
+						TargetValue:   aws.Float64Value(configuration.TargetValue),
+					},
+				)
+			}
+
+			return true
+		},
+	)
+
+	return scalingPolicies, err
+}
@@ -0,0 +1,125 @@
+package applicationautoscaling
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsaas "github.com/aws/aws-sdk-go/service/applicationautoscaling"
+	"github.com/awslabs/fargatecli/applicationautoscaling/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+func TestPutTargetTrackingScalingPolicy(t *testing.T) {
+	policyARN := "arn:aws:autoscaling:us-east-1:123456789012:scalingPolicy:6d8972f3:resource/ecs/service/fargate/web:policyName/fargate-requests"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockApplicationAutoScalingAPI(mockCtrl)
+	aas := SDKClient{client: mockAPI}
+
+	i := &awsaas.PutScalingPolicyInput{
+		PolicyName:        aws.String("fargate-requests"),
+		PolicyType:        aws.String("TargetTrackingScaling"),
+		ResourceId:        aws.String("service/fargate/web"),
+		ScalableDimension: aws.String("ecs:service:DesiredCount"),
+		ServiceNamespace:  aws.String("ecs"),
+		TargetTrackingScalingPolicyConfiguration: &awsaas.TargetTrackingScalingPolicyConfiguration{
+			PredefinedMetricSpecification: &awsaas.PredefinedMetricSpecification{
+				PredefinedMetricType: aws.String("ALBRequestCountPerTarget"),
+				ResourceLabel:        aws.String("app/web/50dc6c495c0c9188/targetgroup/fargate-web/943f017f100becff"),
+			},
+			TargetValue: aws.Float64(1000),
+		},
+	}
+
+	mockAPI.EXPECT().PutScalingPolicy(i).Return(&awsaas.PutScalingPolicyOutput{PolicyARN: aws.String(policyARN)}, nil)
+
+	arn, err := aas.PutTargetTrackingScalingPolicy(
+		PutTargetTrackingScalingPolicyParameters{
+			Metric:        MetricRequestCountPerTarget,
+			PolicyName:    "fargate-requests",
+			ResourceID:    "service/fargate/web",
+			ResourceLabel: "app/web/50dc6c495c0c9188/targetgroup/fargate-web/943f017f100becff",
+			TargetValue:   1000,
+		},
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if arn != policyARN {
+		t.Errorf("expected ARN %s, got %s", policyARN, arn)
+	}
+}
+
+func TestPutTargetTrackingScalingPolicyError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockApplicationAutoScalingAPI(mockCtrl)
+	aas := SDKClient{client: mockAPI}
+
+	mockAPI.EXPECT().PutScalingPolicy(gomock.Any()).Return(&awsaas.PutScalingPolicyOutput{}, errors.New("boom"))
+
+	_, err := aas.PutTargetTrackingScalingPolicy(
+		PutTargetTrackingScalingPolicyParameters{
+			Metric:      MetricCPUUtilization,
+			PolicyName:  "fargate-cpu",
+			ResourceID:  "service/fargate/web",
+			TargetValue: 60,
+		},
+	)
+
+	if err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+func TestDescribeScalingPolicies(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockApplicationAutoScalingAPI(mockCtrl)
+	aas := SDKClient{client: mockAPI}
+
+	mockAPI.EXPECT().DescribeScalingPoliciesPages(gomock.Any(), gomock.Any()).Do(
+		func(input *awsaas.DescribeScalingPoliciesInput, fn func(*awsaas.DescribeScalingPoliciesOutput, bool) bool) {
+			fn(
+				&awsaas.DescribeScalingPoliciesOutput{
+					ScalingPolicies: []*awsaas.ScalingPolicy{
+						&awsaas.ScalingPolicy{
+							PolicyName: aws.String("fargate-cpu"),
+							TargetTrackingScalingPolicyConfiguration: &awsaas.TargetTrackingScalingPolicyConfiguration{
+								PredefinedMetricSpecification: &awsaas.PredefinedMetricSpecification{
+									PredefinedMetricType: aws.String("ECSServiceAverageCPUUtilization"),
+								},
+								TargetValue: aws.Float64(60),
+							},
+						},
+						&awsaas.ScalingPolicy{
+							PolicyName: aws.String("step-scaling"),
+						},
+					},
+				},
+				true,
+			)
+		},
+	).Return(nil)
+
+	policies, err := aas.DescribeScalingPolicies("service/fargate/web")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(policies) != 1 {
+		t.Fatalf("expected 1 policy, got %d", len(policies))
+	}
+
+	if expected := "ECSServiceAverageCPUUtilization 60"; policies[0].String() != expected {
+		t.Errorf("expected %s, got %s", expected, policies[0])
+	}
+}
@@ -0,0 +1,98 @@
+package applicationautoscaling
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsaas "github.com/aws/aws-sdk-go/service/applicationautoscaling"
+)
+
+// ScheduledAction changes the capacities of an ECS service's scalable target on a schedule.
+type ScheduledAction struct {
+	MaxCapacity int64  `json:"maxCapacity"`
+	MinCapacity int64  `json:"minCapacity"`
+	Name        string `json:"name"`
+	Schedule    string `json:"schedule"`
+}
+
+// ScheduledActions is a collection of scheduled actions.
+type ScheduledActions []ScheduledAction
+
+// PutScheduledActionParameters are the parameters required to create or update a scheduled action.
+// Schedule is an at(), rate(), or cron() expression in UTC.
+type PutScheduledActionParameters struct {
+	MaxCapacity int64
+	MinCapacity int64
+	Name        string
+	ResourceID  string
+	Schedule    string
+}
+
+func (a ScheduledAction) String() string {
+	return fmt.Sprintf("%s %s min=%d max=%d", a.Name, a.Schedule, a.MinCapacity, a.MaxCapacity)
+}
+
+// PutScheduledAction creates or updates a scheduled action of an ECS service.
+func (aas SDKClient) PutScheduledAction(p PutScheduledActionParameters) error {
+	_, err := aas.client.PutScheduledAction(
+		&awsaas.PutScheduledActionInput{
+			ResourceId:        aws.String(p.ResourceID),
+			ScalableDimension: aws.String(awsaas.ScalableDimensionEcsServiceDesiredCount),
+			ScalableTargetAction: &awsaas.ScalableTargetAction{
+				MaxCapacity: aws.Int64(p.MaxCapacity),
+				MinCapacity: aws.Int64(p.MinCapacity),
+			},
+			Schedule:            aws.String(p.Schedule),
+			ScheduledActionName: aws.String(p.Name),
+			ServiceNamespace:    aws.String(awsaas.ServiceNamespaceEcs),
+		},
+	)
+
+	return err
+}
+
+// DeleteScheduledAction deletes a scheduled action of an ECS service.
+func (aas SDKClient) DeleteScheduledAction(resourceID, name string) error {
+	_, err := aas.client.DeleteScheduledAction(
+		&awsaas.DeleteScheduledActionInput{
+			ResourceId:          aws.String(resourceID),
+			ScalableDimension:   aws.String(awsaas.ScalableDimensionEcsServiceDesiredCount),
+			ScheduledActionName: aws.String(name),
+			ServiceNamespace:    aws.String(awsaas.ServiceNamespaceEcs),
+		},
+	)
+
+	return err
+}
+
+// DescribeScheduledActions returns the scheduled actions of an ECS service.
+func (aas SDKClient) DescribeScheduledActions(resourceID string) (ScheduledActions, error) {
+	var scheduledActions ScheduledActions
+
+	err := aas.client.DescribeScheduledActionsPages(
+		&awsaas.DescribeScheduledActionsInput{
+			ResourceId:        aws.String(resourceID),
+			ScalableDimension: aws.String(awsaas.ScalableDimensionEcsServiceDesiredCount),
+			ServiceNamespace:  aws.String(awsaas.ServiceNamespaceEcs),
+		},
+		func(resp *awsaas.DescribeScheduledActionsOutput, lastPage bool) bool {
+			for _, scheduledAction := range resp.ScheduledActions {
+				action := ScheduledAction{
+					Name:     aws.StringValue(scheduledAction.ScheduledActionName),
+					Schedule: aws.StringValue(scheduledAction.Schedule),
+				}
+
+				if scalableTargetAction := scheduledAction.ScalableTargetAction; scalableTargetAction != nil {
+					action.MaxCapacity = aws.Int64Value(scalableTargetAction.MaxCapacity)
+					action.MinCapacity = aws.Int64Value(scalableTargetAction.MinCapacity)
+				}
+
+				scheduledActions = append(scheduledActions, action)
+			}
+
+			return true
+		},
+	)
+
+	return scheduledActions, err
+}
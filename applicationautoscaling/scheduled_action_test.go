@@ -0,0 +1,88 @@
+package applicationautoscaling
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsaas "github.com/aws/aws-sdk-go/service/applicationautoscaling"
+	"github.com/awslabs/fargatecli/applicationautoscaling/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+func TestPutScheduledAction(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockApplicationAutoScalingAPI(mockCtrl)
+	aas := SDKClient{client: mockAPI}
+
+	i := &awsaas.PutScheduledActionInput{
+		ResourceId:        aws.String("service/fargate/web"),
+		ScalableDimension: aws.String("ecs:service:DesiredCount"),
+		ScalableTargetAction: &awsaas.ScalableTargetAction{
+			MaxCapacity: aws.Int64(20),
+			MinCapacity: aws.Int64(4),
+		},
+		Schedule:            aws.String("cron(0 8 ? * MON-FRI *)"),
+		ScheduledActionName: aws.String("weekdays"),
+		ServiceNamespace:    aws.String("ecs"),
+	}
+
+	mockAPI.EXPECT().PutScheduledAction(i).Return(&awsaas.PutScheduledActionOutput{}, nil)
+
+	err := aas.PutScheduledAction(
+		PutScheduledActionParameters{
+			MaxCapacity: 20,
+			MinCapacity: 4,
+			Name:        "weekdays",
+			ResourceID:  "service/fargate/web",
+			Schedule:    "cron(0 8 ? * MON-FRI *)",
+		},
+	)
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestDescribeScheduledActions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockApplicationAutoScalingAPI(mockCtrl)
+	aas := SDKClient{client: mockAPI}
+
+	mockAPI.EXPECT().DescribeScheduledActionsPages(gomock.Any(), gomock.Any()).Do(
+		func(input *awsaas.DescribeScheduledActionsInput, fn func(*awsaas.DescribeScheduledActionsOutput, bool) bool) {
+			fn(
+				&awsaas.DescribeScheduledActionsOutput{
+					ScheduledActions: []*awsaas.ScheduledAction{
+						&awsaas.ScheduledAction{
+							ScalableTargetAction: &awsaas.ScalableTargetAction{
+								MaxCapacity: aws.Int64(20),
+								MinCapacity: aws.Int64(4),
+							},
+							Schedule:            aws.String("cron(0 8 ? * MON-FRI *)"),
+							ScheduledActionName: aws.String("weekdays"),
+						},
+					},
+				},
+				true,
+			)
+		},
+	).Return(nil)
+
+	actions, err := aas.DescribeScheduledActions("service/fargate/web")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(actions) != 1 {
+		t.Fatalf("expected 1 scheduled action, got %d", len(actions))
+	}
+
+	if expected := "weekdays cron(0 8 ? * MON-FRI *) min=4 max=20"; actions[0].String() != expected {
+		t.Errorf("expected %s, got %s", expected, actions[0])
+	}
+}
@@ -0,0 +1,670 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/awslabs/fargatecli/acm"
+	"github.com/awslabs/fargatecli/ec2"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	"github.com/awslabs/fargatecli/elbv2"
+	"github.com/awslabs/fargatecli/manifest"
+	"github.com/spf13/cobra"
+)
+
+const (
+	applyActionCreate = "create"
+	applyActionUpdate = "update"
+
+	defaultManifestFile = "fargate.yml"
+	taskStatusRunning   = "RUNNING"
+)
+
+// applyChange is a single change required to converge a resource with its manifest.
+type applyChange struct {
+	Action   string   `json:"action"`
+	Details  []string `json:"details,omitempty"`
+	Name     string   `json:"name"`
+	Resource string   `json:"resource"`
+
+	apply func()
+}
+
+// serviceDiff holds the differences between a running service and its manifest.
+type serviceDiff struct {
+	changes        []string
+	cpu            string
+	desiredCount   int64
+	envVarsToSet   []ECS.EnvVar
+	envVarsToUnset []string
+	image          string
+	memory         string
+	rulesToAdd     []elbv2.Rule
+	rulesToRemove  []elbv2.Rule
+}
+
+func (d serviceDiff) empty() bool {
+	return len(d.changes) == 0
+}
+
+func (d serviceDiff) changesTaskDefinition() bool {
+	return d.image != "" || d.cpu != "" || d.memory != "" || len(d.envVarsToSet) > 0 || len(d.envVarsToUnset) > 0
+}
+
+type applyOperation struct {
+	dryRun   bool
+	manifest manifest.Manifest
+	output   Output
+}
+
+func (o applyOperation) validate() (errs []error) {
+	errs = o.manifest.Validate()
+
+	for _, s := range o.manifest.Services {
+		if err := validateCpuAndMemory(s.Cpu, s.Memory); err != nil {
+			errs = append(errs, invalidCpuAndMemoryError("service", s.Name, s.Cpu, s.Memory, err))
+		}
+
+		if s.Port != "" {
+			port, err := inflatePort(s.Port)
+
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				errs = append(errs, validatePort(port)...)
+			}
+		}
+
+		_, ruleErrs := inflateRules(s.Rules)
+		errs = append(errs, ruleErrs...)
+	}
+
+	for _, t := range o.manifest.TaskGroups {
+		if err := validateCpuAndMemory(t.Cpu, t.Memory); err != nil {
+			errs = append(errs, invalidCpuAndMemoryError("task group", t.Name, t.Cpu, t.Memory, err))
+		}
+	}
+
+	return
+}
+
+// invalidCpuAndMemoryError describes the invalid CPU and memory settings of a resource in the
+// manifest, suggesting a supported combination.
+func invalidCpuAndMemoryError(resource, name, cpu, memory string, err error) error {
+	msg := fmt.Sprintf("%s %s has invalid cpu and memory settings (%s CPU units / %s MiB)", resource, name, cpu, memory)
+
+	if suggestion := cpuAndMemorySuggestion(err); suggestion != "" {
+		msg += fmt.Sprintf(" [%s]", suggestion)
+	}
+
+	return errors.New(msg)
+}
+
+func (o applyOperation) execute() {
+	var changes []applyChange
+
+	changes = append(changes, o.planCertificates()...)
+	changes = append(changes, o.planLoadBalancers()...)
+	changes = append(changes, o.planServices()...)
+	changes = append(changes, o.planTaskGroups()...)
+
+	o.output.Document(changes)
+
+	if len(changes) == 0 {
+		o.output.Info("No changes required, resources match the manifest")
+		return
+	}
+
+	o.display(changes)
+
+	if o.dryRun {
+		o.output.Info("Dry run: %d changes would be applied", len(changes))
+		return
+	}
+
+	for _, change := range changes {
+		change.apply()
+	}
+
+	o.output.Info("Applied %d changes", len(changes))
+}
+
+func (o applyOperation) display(changes []applyChange) {
+	for _, change := range changes {
+		o.output.Say("%s %s %s", 0, Titleize(change.Action), change.Resource, change.Name)
+
+		for _, detail := range change.Details {
+			o.output.Say("- %s", 1, detail)
+		}
+	}
+
+	o.output.LineBreak()
+}
+
+func (o applyOperation) planCertificates() (changes []applyChange) {
+	if len(o.manifest.Certificates) == 0 {
+		return
+	}
+
+	client := acm.New(sess)
+
+	o.output.Debug("Listing certificates [API=acm Action=ListCertificates]")
+	certificates, err := client.ListCertificates()
+
+	if err != nil {
+		o.output.Fatal(err, "Could not list certificates")
+		return
+	}
+
+	for _, c := range o.manifest.Certificates {
+		if len(certificates.GetCertificates(c.DomainName)) > 0 {
+			continue
+		}
+
+		operation := certificateRequestOperation{
+			acm:        client,
+			aliases:    c.Aliases,
+			output:     o.output,
+			domainName: c.DomainName,
+		}
+
+		changes = append(changes,
+			applyChange{
+				Action:   applyActionCreate,
+				Details:  c.Aliases,
+				Name:     c.DomainName,
+				Resource: "certificate",
+				apply:    operation.execute,
+			},
+		)
+	}
+
+	return
+}
+
+func (o applyOperation) planLoadBalancers() (changes []applyChange) {
+	if len(o.manifest.LoadBalancers) == 0 {
+		return
+	}
+
+	client := elbv2.New(sess)
+
+	o.output.Debug("Describing load balancers [API=elbv2 Action=DescribeLoadBalancers]")
+	loadBalancers, err := client.DescribeLoadBalancers()
+
+	if err != nil {
+		o.output.Fatal(err, "Could not describe load balancers")
+		return
+	}
+
+	existing := make(map[string]elbv2.LoadBalancer)
+
+	for _, loadBalancer := range loadBalancers {
+		existing[loadBalancer.Name] = loadBalancer
+	}
+
+	for _, lb := range o.manifest.LoadBalancers {
+		lb := lb
+
+		if loadBalancer, ok := existing[lb.Name]; ok {
+			o.output.Debug("Describing listeners [API=elbv2 Action=DescribeListeners LoadBalancerArn=%s]", loadBalancer.ARN)
+			listeners, err := client.DescribeListeners(loadBalancer.ARN)
+
+			if err != nil {
+				o.output.Fatal(err, "Could not describe listeners for load balancer %s", lb.Name)
+				return
+			}
+
+			ports, _ := inflatePorts(lb.Ports)
+
+			if missing := missingPorts(ports, listeners); len(missing) > 0 {
+				o.output.Warn("Load balancer %s is missing ports %s which cannot be added by apply", lb.Name, strings.Join(missing, ", "))
+			}
+
+			continue
+		}
+
+		changes = append(changes,
+			applyChange{
+				Action:   applyActionCreate,
+				Details:  lb.Ports,
+				Name:     lb.Name,
+				Resource: "load balancer",
+				apply: func() {
+					operation, errs := newLBCreateOperation(
+						lb.Name,
+						lb.Scheme,
+						lb.Certificates,
+						lb.Ports,
+						lb.SecurityGroupIDs,
+						lb.SubnetIDs,
+						o.output,
+						acm.New(sess),
+						ec2.New(sess),
+						client,
+					)
+
+					if len(errs) > 0 {
+						o.output.Fatals(errs, "Could not create load balancer %s", lb.Name)
+						return
+					}
+
+					operation.execute()
+				},
+			},
+		)
+	}
+
+	return
+}
+
+func (o applyOperation) planServices() (changes []applyChange) {
+	if len(o.manifest.Services) == 0 {
+		return
+	}
+
+	ecs := ECS.New(sess, clusterName)
+	client := elbv2.New(sess)
+	existing := make(map[string]ECS.Service)
+
+	o.output.Debug("Listing services [API=ecs Action=ListServices]")
+
+	for _, service := range ecs.ListServices() {
+		existing[service.Name] = service
+	}
+
+	for _, s := range o.manifest.Services {
+		s := s
+		envVars := envVarsFromMap(s.Env)
+		rules, _ := inflateRules(s.Rules)
+		service, ok := existing[s.Name]
+
+		if !ok {
+			changes = append(changes,
+				applyChange{
+					Action:   applyActionCreate,
+					Details:  []string{fmt.Sprintf("image: %s", s.Image)},
+					Name:     s.Name,
+					Resource: "service",
+					apply: func() {
+						values := serviceCreateFlagValues{
+							assignPublicIP:   *s.AssignPublicIP,
+							cpu:              s.Cpu,
+							envVars:          envVarArgsFromMap(s.Env),
+							image:            s.Image,
+							lb:               s.LoadBalancer,
+							memory:           s.Memory,
+							num:              s.Num,
+							pinDigest:        true,
+							port:             s.Port,
+							rules:            s.Rules,
+							securityGroupIds: s.SecurityGroupIDs,
+							subnetIds:        s.SubnetIDs,
+							taskCommand:      s.Command,
+							taskRole:         s.TaskRole,
+						}
+
+						operation := values.operation(s.Name, nil)
+						operation.Validate()
+
+						createService(operation)
+					},
+				},
+			)
+
+			continue
+		}
+
+		var (
+			currentRules        []elbv2.Rule
+			loadBalancerARN     string
+			loadBalancerName    string
+			serviceListenerARNs []string
+		)
+
+		if service.TargetGroupArn != "" {
+			if loadBalancerARN = client.GetTargetGroupLoadBalancerArn(service.TargetGroupArn); loadBalancerARN != "" {
+				loadBalancerName = client.DescribeLoadBalancerByARN(loadBalancerARN).Name
+
+				for _, listener := range client.GetListeners(loadBalancerARN) {
+					serviceListenerARNs = append(serviceListenerARNs, listener.ARN)
+
+					for _, rule := range client.DescribeRules(listener.ARN) {
+						if rule.TargetGroupARN == service.TargetGroupArn && !rule.IsDefault {
+							currentRules = append(currentRules, rule)
+						}
+					}
+				}
+			}
+		}
+
+		if loadBalancerName != s.LoadBalancer {
+			o.output.Warn("Service %s cannot be moved from load balancer %q to %q without being recreated", s.Name, loadBalancerName, s.LoadBalancer)
+			rules = nil
+			currentRules = nil
+		}
+
+		diff := diffService(service, currentRules, s, envVars, rules)
+
+		if diff.empty() {
+			continue
+		}
+
+		changes = append(changes,
+			applyChange{
+				Action:   applyActionUpdate,
+				Details:  diff.changes,
+				Name:     s.Name,
+				Resource: "service",
+				apply: func() {
+					if diff.changesTaskDefinition() {
+						taskDefinitionArn := service.TaskDefinitionArn
+
+						if diff.image != "" {
+							image, requestedImage := pinImage(diff.image)
+							taskDefinitionArn = ecs.UpdateTaskDefinitionImage(taskDefinitionArn, "", image, requestedImage, nil)
+						}
+
+						if diff.cpu != "" || diff.memory != "" {
+							taskDefinitionArn = ecs.UpdateTaskDefinitionCpuAndMemory(taskDefinitionArn, diff.cpu, diff.memory)
+						}
+
+						if len(diff.envVarsToUnset) > 0 {
+							taskDefinitionArn = ecs.RemoveEnvVarsFromTaskDefinition(taskDefinitionArn, "", diff.envVarsToUnset)
+						}
+
+						if len(diff.envVarsToSet) > 0 {
+							taskDefinitionArn = ecs.AddEnvVarsToTaskDefinition(taskDefinitionArn, "", diff.envVarsToSet)
+						}
+
+						ecs.UpdateServiceTaskDefinition(s.Name, taskDefinitionArn)
+					}
+
+					if diff.desiredCount != service.DesiredCount {
+						ecs.SetDesiredCount(s.Name, diff.desiredCount)
+					}
+
+					for _, rule := range diff.rulesToRemove {
+						client.DeleteRule(rule.ARN)
+					}
+
+					for _, rule := range diff.rulesToAdd {
+						for _, listenerARN := range serviceListenerARNs {
+							client.AddRuleToListener(listenerARN, service.TargetGroupArn, rule)
+						}
+					}
+
+					o.output.Info("Updated service %s", s.Name)
+				},
+			},
+		)
+	}
+
+	return
+}
+
+func (o applyOperation) planTaskGroups() (changes []applyChange) {
+	if len(o.manifest.TaskGroups) == 0 {
+		return
+	}
+
+	ecs := ECS.New(sess, clusterName)
+
+	for _, t := range o.manifest.TaskGroups {
+		var running int64
+
+		t := t
+
+		o.output.Debug("Listing tasks [API=ecs Action=ListTasks StartedBy=fargate:%s]", t.Name)
+
+		for _, task := range ecs.DescribeTasksForTaskGroup(t.Name) {
+			if task.DesiredStatus == taskStatusRunning {
+				running++
+			}
+		}
+
+		if running >= t.Num {
+			continue
+		}
+
+		changes = append(changes,
+			applyChange{
+				Action:   applyActionCreate,
+				Details:  []string{fmt.Sprintf("instances: %d => %d", running, t.Num)},
+				Name:     t.Name,
+				Resource: "task group",
+				apply: func() {
+					values := taskRunFlagValues{
+						cpu:              t.Cpu,
+						envVars:          envVarArgsFromMap(t.Env),
+						image:            t.Image,
+						memory:           t.Memory,
+						num:              t.Num - running,
+						pinDigest:        true,
+						securityGroupIds: t.SecurityGroupIDs,
+						subnetIds:        t.SubnetIDs,
+						taskCommand:      t.Command,
+						taskRole:         t.TaskRole,
+					}
+
+					operation := values.operation(t.Name)
+					operation.Validate()
+
+					runTask(operation)
+				},
+			},
+		)
+	}
+
+	return
+}
+
+// diffService compares a running service and its listener rules with the desired configuration
+// from a manifest.
+func diffService(
+	current ECS.Service,
+	currentRules []elbv2.Rule,
+	desired manifest.Service,
+	desiredEnvVars []ECS.EnvVar,
+	desiredRules []elbv2.Rule,
+) (diff serviceDiff) {
+	diff.desiredCount = current.DesiredCount
+
+	// A service whose image is pinned to a digest runs the image it was pinned from, so the manifest's
+	// image is compared with that rather than with the digest.
+	currentImage := current.Image
+
+	if current.RequestedImage != "" && current.Image != desired.Image {
+		currentImage = current.RequestedImage
+	}
+
+	if currentImage != desired.Image {
+		diff.image = desired.Image
+		diff.changes = append(diff.changes, fmt.Sprintf("image: %s => %s", currentImage, desired.Image))
+	}
+
+	if current.Cpu != desired.Cpu {
+		diff.cpu = desired.Cpu
+		diff.changes = append(diff.changes, fmt.Sprintf("cpu: %s => %s", current.Cpu, desired.Cpu))
+	}
+
+	if current.Memory != desired.Memory {
+		diff.memory = desired.Memory
+		diff.changes = append(diff.changes, fmt.Sprintf("memory: %s => %s", current.Memory, desired.Memory))
+	}
+
+	if current.DesiredCount != desired.Num {
+		diff.desiredCount = desired.Num
+		diff.changes = append(diff.changes, fmt.Sprintf("num: %d => %d", current.DesiredCount, desired.Num))
+	}
+
+	currentEnvVars := make(map[string]string)
+	desiredEnvVarKeys := make(map[string]bool)
+
+	for _, envVar := range current.EnvVars {
+		currentEnvVars[envVar.Key] = envVar.Value
+	}
+
+	for _, envVar := range desiredEnvVars {
+		desiredEnvVarKeys[envVar.Key] = true
+
+		if value, ok := currentEnvVars[envVar.Key]; !ok {
+			diff.envVarsToSet = append(diff.envVarsToSet, envVar)
+			diff.changes = append(diff.changes, fmt.Sprintf("env: set %s", envVar.Key))
+		} else if value != envVar.Value {
+			diff.envVarsToUnset = append(diff.envVarsToUnset, envVar.Key)
+			diff.envVarsToSet = append(diff.envVarsToSet, envVar)
+			diff.changes = append(diff.changes, fmt.Sprintf("env: change %s", envVar.Key))
+		}
+	}
+
+	for _, envVar := range current.EnvVars {
+		if !desiredEnvVarKeys[envVar.Key] {
+			diff.envVarsToUnset = append(diff.envVarsToUnset, envVar.Key)
+			diff.changes = append(diff.changes, fmt.Sprintf("env: unset %s", envVar.Key))
+		}
+	}
+
+	currentRuleSet := make(map[string]bool)
+	desiredRuleSet := make(map[string]bool)
+
+	for _, rule := range currentRules {
+		currentRuleSet[rule.String()] = true
+	}
+
+	for _, rule := range desiredRules {
+		desiredRuleSet[rule.String()] = true
+
+		if !currentRuleSet[rule.String()] {
+			diff.rulesToAdd = append(diff.rulesToAdd, rule)
+			diff.changes = append(diff.changes, fmt.Sprintf("rule: add %s", rule))
+		}
+	}
+
+	removed := make(map[string]bool)
+
+	for _, rule := range currentRules {
+		if !desiredRuleSet[rule.String()] {
+			diff.rulesToRemove = append(diff.rulesToRemove, rule)
+
+			if !removed[rule.String()] {
+				diff.changes = append(diff.changes, fmt.Sprintf("rule: remove %s", rule))
+				removed[rule.String()] = true
+			}
+		}
+	}
+
+	return
+}
+
+func envVarsFromMap(env map[string]string) []ECS.EnvVar {
+	return extractEnvVars(envVarArgsFromMap(env))
+}
+
+// envVarArgsFromMap returns environment variables from a manifest in the KEY=value form given to
+// the --env flag, sorted by key.
+func envVarArgsFromMap(env map[string]string) []string {
+	var inputEnvVars []string
+
+	for key, value := range env {
+		inputEnvVars = append(inputEnvVars, key+"="+value)
+	}
+
+	sort.Strings(inputEnvVars)
+
+	return inputEnvVars
+}
+
+func missingPorts(ports []Port, listeners elbv2.Listeners) (missing []string) {
+OUTER:
+	for _, port := range ports {
+		for _, listener := range listeners {
+			if listener.Port == port.Number && listener.Protocol == port.Protocol {
+				continue OUTER
+			}
+		}
+
+		missing = append(missing, port.String())
+	}
+
+	return
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply [--file <manifest>]",
+	Short: "Apply a manifest",
+	Long: `Apply a manifest
+
+Reads a manifest describing certificates, load balancers, services, and task
+groups and creates or updates resources so that they match it. By default the
+manifest is read from fargate.yml in the current working directory; pass the
+--file flag with a path to read a different manifest.
+
+Resources declared in the manifest which do not exist are created. Services
+which already exist are updated in place when their image, CPU, memory,
+environment variables, desired count, or load balancer rules differ from the
+manifest. Task groups are started with enough instances to reach the number
+given in the manifest. Certificates and load balancers are only created; changes
+to existing certificates and load balancers must be made manually.
+
+Services and task groups are created and validated the same way as by fargate
+service create and fargate task run with the settings in the manifest, and
+images in Amazon ECR are run by the sha256 digest their tag refers to.
+
+Resources which exist but are not declared in the manifest are left untouched.
+Pass the global --dry-run flag to display the changes without applying them.
+
+An example manifest:
+
+  certificates:
+    - domainName: www.example.com
+  loadBalancers:
+    - name: web
+      ports: [HTTP:80, HTTPS:443]
+      certificates: [www.example.com]
+  services:
+    - name: api
+      image: example/api:1.4.2
+      cpu: "512"
+      memory: "1024"
+      num: 2
+      port: HTTP:8080
+      lb: web
+      rules: [path=/api/*]
+      env:
+        LOG_LEVEL: info
+  tasks:
+    - name: worker
+      image: example/worker:1.4.2`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{dryRunAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		m, err := manifest.Load(applyFlags.file)
+
+		if err != nil {
+			output.Fatal(err, "Could not read manifest %s", applyFlags.file)
+			return
+		}
+
+		operation := applyOperation{dryRun: dryRun, manifest: m, output: output}
+
+		if errs := operation.validate(); len(errs) > 0 {
+			output.Fatals(errs, "Invalid manifest %s", applyFlags.file)
+			return
+		}
+
+		operation.execute()
+	},
+}
+
+var applyFlags struct {
+	file string
+}
+
+func init() {
+	applyCmd.Flags().StringVarP(&applyFlags.file, "file", "f", defaultManifestFile, "Path to the manifest to apply")
+
+	rootCmd.AddCommand(applyCmd)
+}
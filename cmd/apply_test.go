@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+	"github.com/awslabs/fargatecli/elbv2"
+	"github.com/awslabs/fargatecli/manifest"
+)
+
+func TestDiffServiceNoChanges(t *testing.T) {
+	current := ECS.Service{
+		Cpu:          "256",
+		DesiredCount: 2,
+		EnvVars:      []ECS.EnvVar{{Key: "A", Value: "1"}},
+		Image:        "example/api:1.0",
+		Memory:       "512",
+	}
+	desired := manifest.Service{Cpu: "256", Image: "example/api:1.0", Memory: "512", Num: 2}
+	rules := []elbv2.Rule{{Type: "PATH", Value: "/api/*"}}
+
+	diff := diffService(current, rules, desired, []ECS.EnvVar{{Key: "A", Value: "1"}}, rules)
+
+	if !diff.empty() {
+		t.Errorf("expected no changes, got %v", diff.changes)
+	}
+}
+
+func TestDiffServicePinnedImage(t *testing.T) {
+	current := ECS.Service{
+		Cpu:            "256",
+		DesiredCount:   1,
+		Image:          "123456789012.dkr.ecr.us-east-1.amazonaws.com/api@sha256:abc",
+		Memory:         "512",
+		RequestedImage: "123456789012.dkr.ecr.us-east-1.amazonaws.com/api:1.0",
+	}
+	desired := manifest.Service{Cpu: "256", Image: "123456789012.dkr.ecr.us-east-1.amazonaws.com/api:1.0", Memory: "512", Num: 1}
+
+	if diff := diffService(current, nil, desired, nil, nil); !diff.empty() {
+		t.Errorf("expected no changes, got %v", diff.changes)
+	}
+
+	desired.Image = "123456789012.dkr.ecr.us-east-1.amazonaws.com/api:2.0"
+	diff := diffService(current, nil, desired, nil, nil)
+
+	if diff.image != desired.Image {
+		t.Errorf("expected image %s, got %s", desired.Image, diff.image)
+	}
+
+	if expected := []string{"image: 123456789012.dkr.ecr.us-east-1.amazonaws.com/api:1.0 => 123456789012.dkr.ecr.us-east-1.amazonaws.com/api:2.0"}; !reflect.DeepEqual(diff.changes, expected) {
+		t.Errorf("expected changes %v, got %v", expected, diff.changes)
+	}
+}
+
+func TestDiffService(t *testing.T) {
+	current := ECS.Service{
+		Cpu:          "256",
+		DesiredCount: 1,
+		EnvVars: []ECS.EnvVar{
+			{Key: "KEEP", Value: "1"},
+			{Key: "CHANGE", Value: "old"},
+			{Key: "REMOVE", Value: "1"},
+		},
+		Image:  "example/api:1.0",
+		Memory: "512",
+	}
+	currentRules := []elbv2.Rule{
+		{ARN: "arn:1", Type: "PATH", Value: "/old"},
+		{ARN: "arn:2", Type: "HOST", Value: "example.com"},
+	}
+	desired := manifest.Service{Cpu: "512", Image: "example/api:2.0", Memory: "1024", Num: 3}
+	desiredEnvVars := []ECS.EnvVar{
+		{Key: "ADD", Value: "1"},
+		{Key: "CHANGE", Value: "new"},
+		{Key: "KEEP", Value: "1"},
+	}
+	desiredRules := []elbv2.Rule{
+		{Type: "HOST", Value: "example.com"},
+		{Type: "PATH", Value: "/new"},
+	}
+
+	diff := diffService(current, currentRules, desired, desiredEnvVars, desiredRules)
+
+	if diff.image != "example/api:2.0" || diff.cpu != "512" || diff.memory != "1024" || diff.desiredCount != 3 {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+
+	if !diff.changesTaskDefinition() {
+		t.Errorf("expected task definition changes")
+	}
+
+	if expected := []ECS.EnvVar{{Key: "ADD", Value: "1"}, {Key: "CHANGE", Value: "new"}}; !reflect.DeepEqual(diff.envVarsToSet, expected) {
+		t.Errorf("expected env vars to set %v, got %v", expected, diff.envVarsToSet)
+	}
+
+	if expected := []string{"CHANGE", "REMOVE"}; !reflect.DeepEqual(diff.envVarsToUnset, expected) {
+		t.Errorf("expected env vars to unset %v, got %v", expected, diff.envVarsToUnset)
+	}
+
+	if expected := []elbv2.Rule{{Type: "PATH", Value: "/new"}}; !reflect.DeepEqual(diff.rulesToAdd, expected) {
+		t.Errorf("expected rules to add %v, got %v", expected, diff.rulesToAdd)
+	}
+
+	if len(diff.rulesToRemove) != 1 || diff.rulesToRemove[0].ARN != "arn:1" {
+		t.Errorf("expected rule arn:1 to be removed, got %v", diff.rulesToRemove)
+	}
+
+	expectedChanges := []string{
+		"image: example/api:1.0 => example/api:2.0",
+		"cpu: 256 => 512",
+		"memory: 512 => 1024",
+		"num: 1 => 3",
+		"env: set ADD",
+		"env: change CHANGE",
+		"env: unset REMOVE",
+		"rule: add PATH=/new",
+		"rule: remove PATH=/old",
+	}
+
+	if !reflect.DeepEqual(diff.changes, expectedChanges) {
+		t.Errorf("expected changes %v, got %v", expectedChanges, diff.changes)
+	}
+}
+
+func TestDiffServiceScaleOnly(t *testing.T) {
+	current := ECS.Service{Cpu: "256", DesiredCount: 1, Image: "api", Memory: "512"}
+	desired := manifest.Service{Cpu: "256", Image: "api", Memory: "512", Num: 4}
+
+	diff := diffService(current, nil, desired, nil, nil)
+
+	if diff.changesTaskDefinition() {
+		t.Errorf("expected no task definition changes")
+	}
+
+	if diff.desiredCount != 4 {
+		t.Errorf("expected desired count 4, got %d", diff.desiredCount)
+	}
+}
+
+func TestEnvVarsFromMap(t *testing.T) {
+	envVars := envVarsFromMap(map[string]string{"b": "2", "A": "x=y"})
+	expected := []ECS.EnvVar{{Key: "A", Value: "x=y"}, {Key: "B", Value: "2"}}
+
+	if !reflect.DeepEqual(envVars, expected) {
+		t.Errorf("expected %v, got %v", expected, envVars)
+	}
+}
+
+func TestMissingPorts(t *testing.T) {
+	ports := []Port{{80, "HTTP"}, {443, "HTTPS"}}
+	listeners := elbv2.Listeners{{Port: 80, Protocol: "HTTP"}}
+
+	if expected, got := []string{"HTTPS:443"}, missingPorts(ports, listeners); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestApplyOperationValidate(t *testing.T) {
+	operation := applyOperation{
+		manifest: manifest.Manifest{
+			Services: []manifest.Service{
+				{Name: "api", Image: "api", Cpu: "256", Memory: "4096", Num: 1, Port: "HTTP:80", LoadBalancer: "web", Rules: []string{"query=x"}},
+			},
+			TaskGroups: []manifest.TaskGroup{
+				{Name: "worker", Image: "worker", Cpu: "256", Memory: "512", Num: 1},
+			},
+		},
+	}
+
+	errs := operation.validate()
+
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", len(errs), errs)
+	}
+
+	if expected, got := "service api has invalid cpu and memory settings (256 CPU units / 4096 MiB) [did you mean 256/2048?]", errs[0].Error(); expected != got {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
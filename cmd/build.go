@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/awslabs/fargatecli/docker"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	"github.com/spf13/pflag"
+)
+
+// buildFlagValues holds the options for building an image given on the command line.
+type buildFlagValues struct {
+	args       []string
+	builder    string
+	cacheFrom  []string
+	cacheTo    []string
+	context    string
+	dockerfile string
+	target     string
+}
+
+// addBuildFlags adds the flags which configure how an image is built to a flag set.
+func addBuildFlags(flags *pflag.FlagSet, values *buildFlagValues) {
+	flags.StringVar(&values.dockerfile, "dockerfile", "", "Path to the Dockerfile to build the image from (default: Dockerfile in the build context)")
+	flags.StringArrayVar(&values.args, "build-arg", []string{}, "Build-time variable to pass to the build [e.g. VERSION=1.2.3, or NPM_TOKEN to pass its value from the environment] (can be specified multiple times)")
+	flags.StringVar(&values.target, "target", "", "Stage of a multi-stage Dockerfile to build")
+	flags.StringVar(&values.context, "context", ".", "Directory to use as the build context")
+	flags.StringArrayVar(&values.cacheFrom, "cache-from", []string{}, "Image or cache source to use as a build cache [e.g. 123456789012.dkr.ecr.us-east-1.amazonaws.com/web:latest] (can be specified multiple times)")
+	flags.StringArrayVar(&values.cacheTo, "cache-to", []string{}, "Cache destination to export the build cache to using docker buildx [e.g. type=inline] (can be specified multiple times)")
+	flags.StringVar(&values.builder, "builder", "", fmt.Sprintf("Tool to build the image with [%s] (default: the first installed)", strings.Join(docker.Builders, ", ")))
+}
+
+// isEmpty returns whether no build flags were given.
+func (v buildFlagValues) isEmpty() bool {
+	return len(v.args) == 0 && v.builder == "" && len(v.cacheFrom) == 0 && len(v.cacheTo) == 0 &&
+		(v.context == "" || v.context == ".") && v.dockerfile == "" && v.target == ""
+}
+
+// buildOptions returns the options to build an image given on the command line. Build flags cannot
+// be given alongside an image, as no image is built.
+func (v buildFlagValues) buildOptions(image string) (docker.BuildOptions, []error) {
+	var errs []error
+
+	if image != "" && !v.isEmpty() {
+		errs = append(errs, fmt.Errorf("build flags cannot be used with --image"))
+	}
+
+	for _, arg := range v.args {
+		if key := strings.SplitN(arg, "=", 2)[0]; key == "" || strings.ContainsAny(key, " \t") {
+			errs = append(errs, fmt.Errorf("Invalid build argument %s [specify KEY=value or KEY]", arg))
+		}
+	}
+
+	if v.builder != "" {
+		if _, err := docker.NewBuilder(v.builder); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	options := docker.BuildOptions{
+		Args:       v.args,
+		Builder:    v.builder,
+		CacheFrom:  v.cacheFrom,
+		CacheTo:    v.cacheTo,
+		Context:    v.context,
+		Dockerfile: v.dockerfile,
+		Target:     v.target,
+	}
+
+	return options, errs
+}
+
+// platformBuildOptions returns build options to build an image for a runtime platform, labelled
+// with the git revision it is built from and the time of the build.
+func platformBuildOptions(options docker.BuildOptions, runtimePlatform ECS.RuntimePlatform, revision gitRevision) docker.BuildOptions {
+	options.Platform = dockerPlatform(runtimePlatform)
+	options.Labels = revision.labels(time.Now())
+
+	return options
+}
+
+// buildChanges describes the options an image is built with.
+func buildChanges(options docker.BuildOptions) (changes []string) {
+	if options.Builder != "" {
+		changes = append(changes, fmt.Sprintf("builder: %s", options.Builder))
+	}
+
+	if options.Dockerfile != "" {
+		changes = append(changes, fmt.Sprintf("dockerfile: %s", options.Dockerfile))
+	}
+
+	if options.Context != "" && options.Context != "." {
+		changes = append(changes, fmt.Sprintf("context: %s", options.Context))
+	}
+
+	if options.Target != "" {
+		changes = append(changes, fmt.Sprintf("target: %s", options.Target))
+	}
+
+	if options.Platform != "" {
+		changes = append(changes, fmt.Sprintf("platform: %s", options.Platform))
+	}
+
+	for _, arg := range options.Args {
+		changes = append(changes, fmt.Sprintf("build arg: %s", strings.SplitN(arg, "=", 2)[0]))
+	}
+
+	return
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/awslabs/fargatecli/docker"
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+func TestBuildFlagValuesBuildOptions(t *testing.T) {
+	values := buildFlagValues{
+		args:       []string{"VERSION=1.2.3", "NPM_TOKEN"},
+		builder:    "podman",
+		cacheFrom:  []string{"example/web:cache"},
+		context:    "app",
+		dockerfile: "app/Dockerfile",
+		target:     "release",
+	}
+
+	options, errs := values.buildOptions("")
+
+	if len(errs) > 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	expected := docker.BuildOptions{
+		Args:       []string{"VERSION=1.2.3", "NPM_TOKEN"},
+		Builder:    "podman",
+		CacheFrom:  []string{"example/web:cache"},
+		Context:    "app",
+		Dockerfile: "app/Dockerfile",
+		Target:     "release",
+	}
+
+	if !reflect.DeepEqual(expected, options) {
+		t.Errorf("expected %+v, got %+v", expected, options)
+	}
+
+	expectedChanges := []string{
+		"builder: podman",
+		"dockerfile: app/Dockerfile",
+		"context: app",
+		"target: release",
+		"build arg: VERSION",
+		"build arg: NPM_TOKEN",
+	}
+
+	if changes := buildChanges(options); !reflect.DeepEqual(expectedChanges, changes) {
+		t.Errorf("expected %v, got %v", expectedChanges, changes)
+	}
+}
+
+func TestBuildFlagValuesBuildOptionsErrors(t *testing.T) {
+	if _, errs := (buildFlagValues{args: []string{"=value", "MY KEY=value"}}).buildOptions(""); len(errs) != 2 {
+		t.Errorf("expected 2 errors, got %v", errs)
+	}
+
+	if _, errs := (buildFlagValues{builder: "kaniko"}).buildOptions(""); len(errs) != 1 {
+		t.Errorf("expected 1 error, got %v", errs)
+	}
+
+	if _, errs := (buildFlagValues{target: "release"}).buildOptions("nginx:latest"); len(errs) != 1 {
+		t.Errorf("expected 1 error, got %v", errs)
+	}
+
+	if _, errs := (buildFlagValues{context: "."}).buildOptions("nginx:latest"); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestPlatformBuildOptions(t *testing.T) {
+	revision := gitRevision{SHA: "0123456789abcdef0123456789abcdef01234567", Branch: "main"}
+	options := platformBuildOptions(docker.BuildOptions{}, ECS.RuntimePlatform{CPUArchitecture: "ARM64"}, revision)
+
+	if options.Platform != "linux/arm64" {
+		t.Errorf("expected platform linux/arm64, got %s", options.Platform)
+	}
+
+	if options.Labels[docker.LabelCreated] == "" {
+		t.Errorf("expected created label, got %v", options.Labels)
+	}
+
+	if options.Labels[docker.LabelRevision] != revision.SHA || options.Labels[docker.LabelBranch] != "main" {
+		t.Errorf("expected revision and branch labels, got %v", options.Labels)
+	}
+}
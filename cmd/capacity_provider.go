@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+const (
+	maxCapacityProviderBase   = 100000
+	maxCapacityProviderWeight = 1000
+)
+
+// spotCapacityProviderStrategy places all tasks on Fargate Spot.
+var spotCapacityProviderStrategy = ECS.CapacityProviderStrategy{
+	ECS.CapacityProviderStrategyItem{CapacityProvider: ECS.CapacityProviderFargateSpot, Weight: 1},
+}
+
+// inflateCapacityProviderStrategy parses a capacity provider strategy expression in the form of
+// PROVIDER[:base=N][,weight=N][,PROVIDER...], e.g. FARGATE_SPOT:weight=3,FARGATE:base=1,weight=1.
+// Settings which follow a provider belong to it until the next provider is named. The weight of a
+// provider defaults to 1.
+func inflateCapacityProviderStrategy(strategyExpr string) (ECS.CapacityProviderStrategy, error) {
+	var strategy ECS.CapacityProviderStrategy
+
+	weightSet := make(map[int]bool)
+
+	for _, field := range strings.Split(strategyExpr, ",") {
+		field = strings.TrimSpace(field)
+
+		if provider := strings.SplitN(field, ":", 2); !strings.Contains(provider[0], "=") {
+			name := strings.ToUpper(provider[0])
+
+			if name != ECS.CapacityProviderFargate && name != ECS.CapacityProviderFargateSpot {
+				return strategy, fmt.Errorf("invalid capacity provider %s [specify FARGATE or FARGATE_SPOT]", provider[0])
+			}
+
+			strategy = append(strategy, ECS.CapacityProviderStrategyItem{CapacityProvider: name})
+
+			if len(provider) == 1 {
+				continue
+			}
+
+			field = provider[1]
+		}
+
+		if len(strategy) == 0 {
+			return strategy, fmt.Errorf("%s must follow a capacity provider", field)
+		}
+
+		setting := strings.SplitN(field, "=", 2)
+
+		if len(setting) != 2 {
+			return strategy, fmt.Errorf("%s must be in the form of key=value", field)
+		}
+
+		value, err := strconv.ParseInt(setting[1], 10, 64)
+
+		if err != nil || value < 0 {
+			return strategy, fmt.Errorf("%s must be a non-negative number", field)
+		}
+
+		item := &strategy[len(strategy)-1]
+
+		switch strings.ToLower(setting[0]) {
+		case "base":
+			item.Base = value
+		case "weight":
+			item.Weight = value
+			weightSet[len(strategy)-1] = true
+		default:
+			return strategy, fmt.Errorf("invalid capacity provider setting %s [specify base or weight]", setting[0])
+		}
+	}
+
+	for i := range strategy {
+		if !weightSet[i] {
+			strategy[i].Weight = 1
+		}
+	}
+
+	return strategy, validateCapacityProviderStrategy(strategy)
+}
+
+func validateCapacityProviderStrategy(strategy ECS.CapacityProviderStrategy) error {
+	var bases, weight int64
+
+	seen := make(map[string]bool)
+
+	for _, item := range strategy {
+		if seen[item.CapacityProvider] {
+			return fmt.Errorf("capacity provider %s is given more than once", item.CapacityProvider)
+		}
+
+		seen[item.CapacityProvider] = true
+
+		if item.Base > maxCapacityProviderBase {
+			return fmt.Errorf("base of capacity provider %s must be %d or less", item.CapacityProvider, maxCapacityProviderBase)
+		}
+
+		if item.Weight > maxCapacityProviderWeight {
+			return fmt.Errorf("weight of capacity provider %s must be %d or less", item.CapacityProvider, maxCapacityProviderWeight)
+		}
+
+		if item.Base > 0 {
+			bases++
+		}
+
+		weight += item.Weight
+	}
+
+	if bases > 1 {
+		return fmt.Errorf("only one capacity provider can have a base")
+	}
+
+	if weight == 0 {
+		return fmt.Errorf("at least one capacity provider must have a weight greater than 0")
+	}
+
+	return nil
+}
+
+// capacityProviderStrategyString returns a description of where a service's tasks are placed.
+func capacityProviderStrategyString(strategy ECS.CapacityProviderStrategy) string {
+	if len(strategy) == 0 {
+		return ECS.CapacityProviderFargate
+	}
+
+	return strategy.String()
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+func TestInflateCapacityProviderStrategy(t *testing.T) {
+	var tests = []struct {
+		input    string
+		strategy ECS.CapacityProviderStrategy
+	}{
+		{
+			"FARGATE_SPOT:weight=3,FARGATE:base=1,weight=1",
+			ECS.CapacityProviderStrategy{
+				ECS.CapacityProviderStrategyItem{CapacityProvider: "FARGATE_SPOT", Weight: 3},
+				ECS.CapacityProviderStrategyItem{CapacityProvider: "FARGATE", Base: 1, Weight: 1},
+			},
+		},
+		{
+			"fargate_spot",
+			ECS.CapacityProviderStrategy{
+				ECS.CapacityProviderStrategyItem{CapacityProvider: "FARGATE_SPOT", Weight: 1},
+			},
+		},
+		{
+			"FARGATE:base=2, FARGATE_SPOT:weight=4",
+			ECS.CapacityProviderStrategy{
+				ECS.CapacityProviderStrategyItem{CapacityProvider: "FARGATE", Base: 2, Weight: 1},
+				ECS.CapacityProviderStrategyItem{CapacityProvider: "FARGATE_SPOT", Weight: 4},
+			},
+		},
+		{
+			"FARGATE:weight=0,FARGATE_SPOT",
+			ECS.CapacityProviderStrategy{
+				ECS.CapacityProviderStrategyItem{CapacityProvider: "FARGATE", Weight: 0},
+				ECS.CapacityProviderStrategyItem{CapacityProvider: "FARGATE_SPOT", Weight: 1},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		strategy, err := inflateCapacityProviderStrategy(test.input)
+
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", test.input, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(test.strategy, strategy) {
+			t.Errorf("%s: expected %+v, got %+v", test.input, test.strategy, strategy)
+		}
+	}
+}
+
+func TestInflateCapacityProviderStrategyErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"EC2:weight=1",
+		"weight=1,FARGATE",
+		"FARGATE:weight",
+		"FARGATE:weight=-1",
+		"FARGATE:priority=1",
+		"FARGATE:weight=1001",
+		"FARGATE:base=100001",
+		"FARGATE:base=1,FARGATE_SPOT:base=1",
+		"FARGATE:weight=0",
+		"FARGATE,FARGATE",
+	} {
+		if _, err := inflateCapacityProviderStrategy(input); err == nil {
+			t.Errorf("%s: expected error, got none", input)
+		}
+	}
+}
+
+func TestCapacityProviderStrategyString(t *testing.T) {
+	if expected, got := "FARGATE", capacityProviderStrategyString(nil); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if expected, got := "FARGATE_SPOT:weight=1", capacityProviderStrategyString(spotCapacityProviderStrategy); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
@@ -33,6 +33,7 @@ func (o certificateInfoOperation) execute() {
 }
 
 func (o certificateInfoOperation) display(certificate acm.Certificate) {
+	o.output.Document(certificate)
 	o.output.KeyValue("Domain Name", certificate.DomainName, 0)
 	o.output.KeyValue("Status", Titleize(certificate.Status), 0)
 	o.output.KeyValue("Type", Titleize(certificate.Type), 0)
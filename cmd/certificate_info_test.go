@@ -62,6 +62,14 @@ func TestCertificateInfoOperation(t *testing.T) {
 		t.Errorf("Expected key value output from operation, got none")
 	}
 
+	if len(mockOutput.Documents) != 1 {
+		t.Fatalf("Expected document, got %d", len(mockOutput.Documents))
+	}
+
+	if document, ok := mockOutput.Documents[0].(acm.Certificate); !ok || document.DomainName != domainName {
+		t.Errorf("Expected document for certificate %s, got %+v", domainName, mockOutput.Documents[0])
+	}
+
 	if mockOutput.KeyValueMsgs["Domain Name"] != domainName {
 		t.Errorf("Expected Domain Name == %s, got %s", domainName, mockOutput.KeyValueMsgs["Domain Name"])
 	}
@@ -24,6 +24,12 @@ func (o certificateListOperation) execute() {
 		return
 	}
 
+	sort.Slice(certificates, func(i, j int) bool {
+		return certificates[i].DomainName < certificates[j].DomainName
+	})
+
+	o.output.Document(certificates)
+
 	if len(certificates) == 0 {
 		o.output.Info("No certificates found")
 		return
@@ -33,10 +39,6 @@ func (o certificateListOperation) execute() {
 		[]string{"CERTIFICATE", "TYPE", "STATUS", "SUBJECT ALTERNATIVE NAMES"},
 	}
 
-	sort.Slice(certificates, func(i, j int) bool {
-		return certificates[i].DomainName < certificates[j].DomainName
-	})
-
 	for _, certificate := range certificates {
 		rows = append(rows,
 			[]string{
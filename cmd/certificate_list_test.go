@@ -40,6 +40,14 @@ func TestCertificateListOperation(t *testing.T) {
 		t.Errorf("Expected table, got none")
 	}
 
+	if len(mockOutput.Documents) != 1 {
+		t.Fatalf("Expected document, got %d", len(mockOutput.Documents))
+	}
+
+	if !reflect.DeepEqual(mockOutput.Documents[0], certificateList) {
+		t.Errorf("Expected document %+v, got %+v", certificateList, mockOutput.Documents[0])
+	}
+
 	if len(mockOutput.Tables[0].Rows) != 2 {
 		t.Errorf("Expected table with 2 rows, got %d", len(mockOutput.Tables[0].Rows))
 	}
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/awslabs/fargatecli/console"
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+const (
+	defaultContainerDependencyCondition = "START"
+	validContainerNamePattern           = `\A[a-zA-Z0-9_-]{1,255}\z`
+)
+
+var (
+	validContainerDependencyConditions = []string{"START", "COMPLETE", "SUCCESS", "HEALTHY"}
+	validContainerName                 = regexp.MustCompile(validContainerNamePattern)
+)
+
+// inflateSidecars parses sidecar container expressions and checks that the containers' names are
+// unique and their dependencies exist within a task whose main container has the given name.
+func inflateSidecars(mainContainer string, sidecarExprs []string) ([]ECS.Container, []error) {
+	var (
+		errs     []error
+		sidecars []ECS.Container
+	)
+
+	names := map[string]bool{mainContainer: true}
+
+	for _, sidecarExpr := range sidecarExprs {
+		sidecar, sidecarErrs := inflateSidecar(sidecarExpr)
+		errs = append(errs, sidecarErrs...)
+
+		if names[sidecar.Name] {
+			errs = append(errs, fmt.Errorf("Duplicate container name %s", sidecar.Name))
+		}
+
+		names[sidecar.Name] = true
+		sidecars = append(sidecars, sidecar)
+	}
+
+	for _, sidecar := range sidecars {
+		for _, dependency := range sidecar.DependsOn {
+			if !names[dependency.Container] {
+				errs = append(errs, fmt.Errorf("Container %s depends on unknown container %s", sidecar.Name, dependency.Container))
+			}
+		}
+	}
+
+	return sidecars, errs
+}
+
+// inflateSidecar parses a sidecar container expression of comma separated key=value pairs in the
+// form name=NAME,image=IMAGE[,port=PORT][,essential=BOOL][,env=KEY=value]...[,depends-on=CONTAINER[:CONDITION]]...
+func inflateSidecar(sidecarExpr string) (ECS.Container, []error) {
+	var errs []error
+
+	sidecar := ECS.Container{Essential: true}
+
+	for _, pair := range strings.Split(sidecarExpr, ",") {
+		splitPair := strings.SplitN(pair, "=", 2)
+
+		if len(splitPair) != 2 {
+			errs = append(errs, fmt.Errorf("%s must be in the form of key=value", pair))
+			continue
+		}
+
+		key, value := strings.ToLower(strings.TrimSpace(splitPair[0])), splitPair[1]
+
+		switch key {
+		case "name":
+			sidecar.Name = value
+		case "image":
+			sidecar.Image = value
+		case "port":
+			port, err := strconv.ParseInt(value, 10, 64)
+
+			if err != nil || port < 1 || port > 65535 {
+				errs = append(errs, fmt.Errorf("Invalid port %s [specify within 1 - 65535]", value))
+			}
+
+			sidecar.Port = port
+		case "essential":
+			essential, err := strconv.ParseBool(value)
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf("Invalid essential %s [specify true or false]", value))
+			}
+
+			sidecar.Essential = essential
+		case "env":
+			splitEnvVar := strings.SplitN(value, "=", 2)
+
+			if len(splitEnvVar) != 2 {
+				errs = append(errs, fmt.Errorf("env %s must be in the form of KEY=value", value))
+				continue
+			}
+
+			sidecar.EnvVars = append(sidecar.EnvVars,
+				ECS.EnvVar{
+					Key:   strings.ToUpper(splitEnvVar[0]),
+					Value: splitEnvVar[1],
+				},
+			)
+		case "depends-on":
+			dependency, err := inflateContainerDependency(value)
+
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			sidecar.DependsOn = append(sidecar.DependsOn, dependency)
+		default:
+			errs = append(errs, fmt.Errorf("Invalid sidecar key %s [must be name, image, port, essential, env, or depends-on]", key))
+		}
+	}
+
+	if !validContainerName.MatchString(sidecar.Name) {
+		errs = append(errs, fmt.Errorf("Invalid container name %q [must be letters, numbers, hyphens, and underscores]", sidecar.Name))
+	}
+
+	if sidecar.Image == "" {
+		errs = append(errs, fmt.Errorf("Sidecar %s must have an image", sidecar.Name))
+	}
+
+	return sidecar, errs
+}
+
+func inflateContainerDependency(dependencyExpr string) (ECS.ContainerDependency, error) {
+	dependency := ECS.ContainerDependency{Condition: defaultContainerDependencyCondition}
+	splitDependency := strings.SplitN(dependencyExpr, ":", 2)
+
+	dependency.Container = splitDependency[0]
+
+	if len(splitDependency) == 2 {
+		dependency.Condition = strings.ToUpper(splitDependency[1])
+	}
+
+	for _, condition := range validContainerDependencyConditions {
+		if dependency.Condition == condition {
+			return dependency, nil
+		}
+	}
+
+	return dependency, fmt.Errorf(
+		"Invalid dependency condition %s [must be %s]",
+		dependency.Condition, strings.Join(validContainerDependencyConditions, ", "),
+	)
+}
+
+// sidecarChanges describes the sidecar containers added to a new task definition.
+func sidecarChanges(sidecars []ECS.Container) (changes []string) {
+	for _, sidecar := range sidecars {
+		changes = append(changes, fmt.Sprintf("sidecar: add %s (%s)", sidecar.Name, sidecar.Image))
+	}
+
+	return
+}
+
+// findServiceContainer returns the named container of a service, or its first container if no name
+// is given, exiting if the service has no such container.
+func findServiceContainer(service ECS.Service, name string) ECS.Container {
+	container, ok := ECS.FindContainer(service.Containers, name)
+
+	if !ok {
+		console.ErrorExit(fmt.Errorf("container %s not found in service %s", name, service.Name), "Invalid container")
+	}
+
+	return container
+}
+
+// selectContainer returns the named container among the containers of a task definition, or the
+// first container if no name is given. The error names the containers which can be selected.
+func selectContainer(containers []ECS.Container, name string) (ECS.Container, error) {
+	if container, ok := ECS.FindContainer(containers, name); ok {
+		return container, nil
+	}
+
+	var names []string
+
+	for _, container := range containers {
+		names = append(names, container.Name)
+	}
+
+	return ECS.Container{}, fmt.Errorf("container %s not found, must be one of: %s", name, strings.Join(names, ", "))
+}
+
+// displaySidecars shows the containers of a task definition after the first at the given indent.
+func displaySidecars(o Output, containers []ECS.Container, indent int) {
+	if len(containers) < 2 {
+		return
+	}
+
+	o.KeyValue("Sidecars", "", indent)
+
+	for _, sidecar := range containers[1:] {
+		o.KeyValue(sidecar.Name, "", indent+1)
+		o.KeyValue("Image", sidecar.Image, indent+2)
+		o.KeyValue("Essential", "%t", indent+2, sidecar.Essential)
+
+		if sidecar.Port != 0 {
+			o.KeyValue("Port", "%d", indent+2, sidecar.Port)
+		}
+
+		if len(sidecar.DependsOn) > 0 {
+			var dependsOn []string
+
+			for _, dependency := range sidecar.DependsOn {
+				dependsOn = append(dependsOn, dependency.String())
+			}
+
+			o.KeyValue("Depends On", strings.Join(dependsOn, ", "), indent+2)
+		}
+
+		if len(sidecar.EnvVars) > 0 {
+			o.KeyValue("Environment Variables", "", indent+2)
+
+			for _, envVar := range sidecar.EnvVars {
+				o.Say("%s=%s", indent+3, envVar.Key, envVar.Value)
+			}
+		}
+	}
+}
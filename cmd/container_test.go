@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+func TestInflateSidecar(t *testing.T) {
+	sidecar, errs := inflateSidecar("name=proxy,image=envoyproxy/envoy:v1.14,port=9901,essential=false,env=log_level=debug,depends-on=web:healthy,depends-on=init:success")
+
+	if len(errs) > 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	expected := ECS.Container{
+		DependsOn: []ECS.ContainerDependency{
+			ECS.ContainerDependency{Condition: "HEALTHY", Container: "web"},
+			ECS.ContainerDependency{Condition: "SUCCESS", Container: "init"},
+		},
+		EnvVars:   []ECS.EnvVar{ECS.EnvVar{Key: "LOG_LEVEL", Value: "debug"}},
+		Essential: false,
+		Image:     "envoyproxy/envoy:v1.14",
+		Name:      "proxy",
+		Port:      9901,
+	}
+
+	if !reflect.DeepEqual(expected, sidecar) {
+		t.Errorf("expected %+v, got %+v", expected, sidecar)
+	}
+}
+
+func TestInflateSidecarDefaults(t *testing.T) {
+	sidecar, errs := inflateSidecar("name=proxy,image=envoyproxy/envoy,depends-on=web")
+
+	if len(errs) > 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	if !sidecar.Essential {
+		t.Errorf("expected sidecar to be essential by default")
+	}
+
+	if expected, got := "web:START", sidecar.DependsOn[0].String(); expected != got {
+		t.Errorf("expected dependency %s, got %s", expected, got)
+	}
+}
+
+func TestInflateSidecarErrors(t *testing.T) {
+	var tests = []struct {
+		expr string
+		errs int
+	}{
+		{"image=envoyproxy/envoy", 1},
+		{"name=proxy", 1},
+		{"name=proxy,image=envoyproxy/envoy,port=99999", 1},
+		{"name=proxy,image=envoyproxy/envoy,essential=maybe", 1},
+		{"name=proxy,image=envoyproxy/envoy,env=LOG_LEVEL", 1},
+		{"name=proxy,image=envoyproxy/envoy,depends-on=web:READY", 1},
+		{"name=proxy,image=envoyproxy/envoy,volume=data", 1},
+		{"name=proxy,image", 2},
+		{"name=prox y", 2},
+	}
+
+	for _, test := range tests {
+		if _, errs := inflateSidecar(test.expr); len(errs) != test.errs {
+			t.Errorf("expected %d errors for %s, got %v", test.errs, test.expr, errs)
+		}
+	}
+}
+
+func TestInflateSidecars(t *testing.T) {
+	sidecars, errs := inflateSidecars(
+		"web",
+		[]string{
+			"name=init,image=busybox,essential=false",
+			"name=proxy,image=envoyproxy/envoy,depends-on=web:HEALTHY,depends-on=init:SUCCESS",
+		},
+	)
+
+	if len(errs) > 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	if len(sidecars) != 2 {
+		t.Fatalf("expected 2 sidecars, got %d", len(sidecars))
+	}
+
+	if expected, got := []string{"sidecar: add init (busybox)", "sidecar: add proxy (envoyproxy/envoy)"}, sidecarChanges(sidecars); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestInflateSidecarsErrors(t *testing.T) {
+	_, errs := inflateSidecars(
+		"web",
+		[]string{
+			"name=web,image=nginx",
+			"name=proxy,image=envoyproxy/envoy,depends-on=db",
+		},
+	)
+
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+
+	if expected, got := "Duplicate container name web", errs[0].Error(); expected != got {
+		t.Errorf("expected error %q, got %q", expected, got)
+	}
+
+	if expected, got := "Container proxy depends on unknown container db", errs[1].Error(); expected != got {
+		t.Errorf("expected error %q, got %q", expected, got)
+	}
+}
+
+func TestSelectContainer(t *testing.T) {
+	containers := []ECS.Container{ECS.Container{Name: "web"}, ECS.Container{Name: "proxy"}}
+
+	if container, err := selectContainer(containers, ""); err != nil || container.Name != "web" {
+		t.Errorf("expected the first container, got %+v, %v", container, err)
+	}
+
+	if container, err := selectContainer(containers, "proxy"); err != nil || container.Name != "proxy" {
+		t.Errorf("expected container proxy, got %+v, %v", container, err)
+	}
+
+	_, err := selectContainer(containers, "missing")
+
+	if err == nil {
+		t.Fatalf("expected error, got none")
+	}
+
+	if expected := "container missing not found, must be one of: web, proxy"; err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err)
+	}
+}
+
+func TestSelectTaskContainers(t *testing.T) {
+	proxy := ECS.Container{
+		Name:    "proxy",
+		Image:   "envoy:v1",
+		EnvVars: []ECS.EnvVar{ECS.EnvVar{Key: "PORT", Value: "9901"}},
+	}
+	tasks := []ECS.Task{
+		ECS.Task{TaskId: "1", Image: "web:2", Containers: []ECS.Container{ECS.Container{Name: "web", Image: "web:2"}, proxy}},
+		ECS.Task{TaskId: "2", Image: "web:1", Containers: []ECS.Container{ECS.Container{Name: "web", Image: "web:1"}}},
+	}
+
+	selected, err := selectTaskContainers(tasks, "proxy")
+
+	if err == nil || err.Error() != "task 2: container proxy not found, must be one of: web" {
+		t.Errorf("expected error for task 2, got %v", err)
+	}
+
+	if selected[0].Image != "envoy:v1" || !reflect.DeepEqual(selected[0].EnvVars, proxy.EnvVars) {
+		t.Errorf("expected task 1 to show container proxy, got %+v", selected[0])
+	}
+
+	if selected[1].Image != "" {
+		t.Errorf("expected no image for task 2, got %s", selected[1].Image)
+	}
+
+	if tasks[0].Image != "web:2" {
+		t.Errorf("expected tasks to be left unchanged, got %s", tasks[0].Image)
+	}
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	cpuAndMemoryTableCpuHeader    = "CPU (CPU Units)"
+	cpuAndMemoryTableMemoryHeader = "Memory (MiB)"
+)
+
+// cpuAndMemoryCombinations are the amounts of memory Fargate supports for each amount of CPU units.
+var cpuAndMemoryCombinations = []cpuAndMemoryCombination{
+	cpuAndMemoryCombination{CpuUnits: 256, Mebibytes: []int64{512, 1024, 2048}},
+	cpuAndMemoryCombination{CpuUnits: 512, Min: 1024, Max: 4096, Increment: 1024},
+	cpuAndMemoryCombination{CpuUnits: 1024, Min: 2048, Max: 8192, Increment: 1024},
+	cpuAndMemoryCombination{CpuUnits: 2048, Min: 4096, Max: 16384, Increment: 1024},
+	cpuAndMemoryCombination{CpuUnits: 4096, Min: 8192, Max: 30720, Increment: 1024},
+	cpuAndMemoryCombination{CpuUnits: 8192, Min: 16384, Max: 61440, Increment: 4096},
+	cpuAndMemoryCombination{CpuUnits: 16384, Min: 32768, Max: 122880, Increment: 8192},
+}
+
+var InvalidCpuAndMemoryCombination = fmt.Errorf("Invalid CPU and Memory settings\n\n%s", cpuAndMemoryTable())
+
+// cpuAndMemoryCombination is the memory supported for an amount of CPU units, given either as a list
+// of amounts or as a range from Min to Max in increments.
+type cpuAndMemoryCombination struct {
+	CpuUnits  int64
+	Increment int64
+	Max       int64
+	Mebibytes []int64
+	Min       int64
+}
+
+func (c cpuAndMemoryCombination) mebibytes() []int64 {
+	if len(c.Mebibytes) > 0 {
+		return c.Mebibytes
+	}
+
+	var mebibytes []int64
+
+	for m := c.Min; m <= c.Max; m += c.Increment {
+		mebibytes = append(mebibytes, m)
+	}
+
+	return mebibytes
+}
+
+func (c cpuAndMemoryCombination) supports(mebibytes int64) bool {
+	for _, m := range c.mebibytes() {
+		if m == mebibytes {
+			return true
+		}
+	}
+
+	return false
+}
+
+// closest returns the supported amount of memory closest to the given amount, preferring more memory.
+func (c cpuAndMemoryCombination) closest(mebibytes int64) int64 {
+	supported := c.mebibytes()
+	closest := supported[0]
+
+	for _, m := range supported[1:] {
+		if abs(m-mebibytes) <= abs(closest-mebibytes) {
+			closest = m
+		}
+	}
+
+	return closest
+}
+
+// memoryString describes the supported memory, e.g. 512, 1024, or 2048 or 1024 through 4096 in 1GiB
+// increments.
+func (c cpuAndMemoryCombination) memoryString() string {
+	if len(c.Mebibytes) == 0 {
+		return fmt.Sprintf("%d through %d in %dGiB increments", c.Min, c.Max, c.Increment/mebibytesInGibibyte)
+	}
+
+	var mebibytes []string
+
+	for _, m := range c.Mebibytes {
+		mebibytes = append(mebibytes, strconv.FormatInt(m, 10))
+	}
+
+	if len(mebibytes) == 1 {
+		return mebibytes[0]
+	}
+
+	return strings.Join(mebibytes[:len(mebibytes)-1], ", ") + ", or " + mebibytes[len(mebibytes)-1]
+}
+
+// cpuAndMemoryError is returned for a combination of CPU and memory which Fargate does not support,
+// suggesting the closest combination which it does.
+type cpuAndMemoryError struct {
+	CpuUnits  int64
+	Mebibytes int64
+}
+
+func (e cpuAndMemoryError) Error() string {
+	return fmt.Sprintf("Invalid CPU and Memory settings [%s]\n\n%s", e.Suggestion(), cpuAndMemoryTable())
+}
+
+func (e cpuAndMemoryError) Unwrap() error {
+	return InvalidCpuAndMemoryCombination
+}
+
+// Suggestion returns the closest supported combination in the form of did you mean CPU/MEMORY?
+func (e cpuAndMemoryError) Suggestion() string {
+	return fmt.Sprintf("did you mean %d/%d?", e.CpuUnits, e.Mebibytes)
+}
+
+func validateCpuAndMemory(inputCpuUnits, inputMebibytes string) error {
+	cpuUnits, err := strconv.ParseInt(inputCpuUnits, 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	mebibytes, err := strconv.ParseInt(inputMebibytes, 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	if combination, ok := findCpuAndMemoryCombination(cpuUnits); ok && combination.supports(mebibytes) {
+		return nil
+	}
+
+	return suggestCpuAndMemory(cpuUnits, mebibytes)
+}
+
+// suggestCpuAndMemory returns an error suggesting a supported combination for the given CPU units
+// and memory. If the CPU units are supported, the closest amount of memory they support is
+// suggested. Otherwise, the smallest combination with at least the given CPU units and memory is
+// suggested, or the largest combination if none has enough.
+func suggestCpuAndMemory(cpuUnits, mebibytes int64) cpuAndMemoryError {
+	if combination, ok := findCpuAndMemoryCombination(cpuUnits); ok {
+		return cpuAndMemoryError{CpuUnits: cpuUnits, Mebibytes: combination.closest(mebibytes)}
+	}
+
+	for _, combination := range cpuAndMemoryCombinations {
+		if combination.CpuUnits < cpuUnits {
+			continue
+		}
+
+		for _, m := range combination.mebibytes() {
+			if m >= mebibytes {
+				return cpuAndMemoryError{CpuUnits: combination.CpuUnits, Mebibytes: m}
+			}
+		}
+	}
+
+	largest := cpuAndMemoryCombinations[len(cpuAndMemoryCombinations)-1]
+
+	return cpuAndMemoryError{CpuUnits: largest.CpuUnits, Mebibytes: largest.closest(mebibytes)}
+}
+
+// cpuAndMemorySuggestion returns the suggestion of an invalid CPU and memory error, if it has one.
+func cpuAndMemorySuggestion(err error) string {
+	var cpuAndMemoryErr cpuAndMemoryError
+
+	if errors.As(err, &cpuAndMemoryErr) {
+		return cpuAndMemoryErr.Suggestion()
+	}
+
+	return ""
+}
+
+func findCpuAndMemoryCombination(cpuUnits int64) (cpuAndMemoryCombination, bool) {
+	for _, combination := range cpuAndMemoryCombinations {
+		if combination.CpuUnits == cpuUnits {
+			return combination, true
+		}
+	}
+
+	return cpuAndMemoryCombination{}, false
+}
+
+// cpuAndMemoryTable returns the supported combinations of CPU and memory as an aligned table for use
+// in help text and errors.
+func cpuAndMemoryTable() string {
+	var table strings.Builder
+
+	cpuWidth := len(cpuAndMemoryTableCpuHeader)
+	format := fmt.Sprintf("%%-%ds    %%s\n", cpuWidth)
+
+	fmt.Fprintf(&table, format, cpuAndMemoryTableCpuHeader, cpuAndMemoryTableMemoryHeader)
+	fmt.Fprintf(&table, format, strings.Repeat("-", cpuWidth), strings.Repeat("-", len(cpuAndMemoryTableMemoryHeader)))
+
+	for _, combination := range cpuAndMemoryCombinations {
+		fmt.Fprintf(&table, format, strconv.FormatInt(combination.CpuUnits, 10), combination.memoryString())
+	}
+
+	return table.String()
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
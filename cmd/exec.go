@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/awslabs/fargatecli/console"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	IAM "github.com/awslabs/fargatecli/iam"
+	"github.com/awslabs/fargatecli/ssmmessages"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const (
+	defaultExecCommand  = "/bin/sh"
+	fargateTaskRoleName = "fargateTaskRole"
+)
+
+// execCommand returns the command given after -- on the command line, or the default shell.
+func execCommand(args []string, argsLenAtDash int) string {
+	if argsLenAtDash < 0 || argsLenAtDash >= len(args) {
+		return defaultExecCommand
+	}
+
+	return strings.Join(args[argsLenAtDash:], " ")
+}
+
+// findExecTask returns the task with the given ID, or the first running task if no ID is given.
+func findExecTask(tasks []ECS.Task, taskID string) (ECS.Task, error) {
+	for _, task := range tasks {
+		if taskID == "" && task.LastStatus == taskStatusRunning {
+			return task, nil
+		}
+
+		if taskID != "" && task.TaskId == taskID {
+			if task.LastStatus != taskStatusRunning {
+				return task, fmt.Errorf("task %s is %s", taskID, Humanize(task.LastStatus))
+			}
+
+			return task, nil
+		}
+	}
+
+	if taskID != "" {
+		return ECS.Task{}, fmt.Errorf("task %s not found", taskID)
+	}
+
+	return ECS.Task{}, errors.New("no running tasks found")
+}
+
+// execInTask runs a command interactively in a container of a task using ECS Exec.
+func execInTask(task ECS.Task, containerName, command string) {
+	ecs := ECS.New(sess, clusterName)
+	container, ok := ECS.FindContainer(task.Containers, containerName)
+
+	if !ok {
+		console.ErrorExit(fmt.Errorf("container %s not found in task %s", containerName, task.TaskId), "Invalid container")
+	}
+
+	execSession, err := ecs.ExecuteCommand(task.TaskId, container.Name, command)
+
+	if err != nil {
+		console.ErrorExit(err, "Could not execute command in task %s", task.TaskId)
+	}
+
+	session, err := ssmmessages.Open(execSession.StreamURL, execSession.TokenValue)
+
+	if err != nil {
+		console.ErrorExit(err, "Could not open session %s", execSession.SessionID)
+	}
+
+	defer session.Close()
+
+	var (
+		cols, rows int
+		state      *terminal.State
+	)
+
+	fd := int(os.Stdin.Fd())
+
+	if terminal.IsTerminal(fd) {
+		if state, err = terminal.MakeRaw(fd); err != nil {
+			console.ErrorExit(err, "Could not configure terminal")
+		}
+
+		cols, rows, _ = terminal.GetSize(fd)
+
+		resize := make(chan os.Signal, 1)
+		notifyResize(resize)
+
+		defer func() {
+			signal.Stop(resize)
+			close(resize)
+		}()
+
+		go func() {
+			for range resize {
+				if cols, rows, err := terminal.GetSize(fd); err == nil {
+					session.Resize(cols, rows)
+				}
+			}
+		}()
+	}
+
+	err = session.Run(os.Stdin, os.Stdout, cols, rows)
+
+	if state != nil {
+		terminal.Restore(fd, state)
+	}
+
+	if err != nil {
+		console.ErrorExit(err, "Session %s failed", execSession.SessionID)
+	}
+}
+
+// execTaskRole returns the task role to run tasks with, creating fargate's default task role if
+// none is given, after granting it the permissions ECS Exec requires.
+func execTaskRole(taskRole string) string {
+	iam := IAM.New(sess)
+
+	if taskRole == "" {
+		roleArn, err := iam.CreateFargateTaskRole()
+
+		if err != nil {
+			console.ErrorExit(err, "Could not create task role %s", fargateTaskRoleName)
+		}
+
+		taskRole = roleArn
+	}
+
+	if err := iam.GrantExecuteCommandAccess(taskRole); err != nil {
+		console.Error(err, "Could not grant task role %s access to ECS Exec", IAM.RoleName(taskRole))
+	}
+
+	return taskRole
+}
+
+// execTaskRoleName returns the name of the task role tasks will run with for display.
+func execTaskRoleName(taskRole string) string {
+	if taskRole == "" {
+		return fargateTaskRoleName
+	}
+
+	return IAM.RoleName(taskRole)
+}
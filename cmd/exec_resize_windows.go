@@ -0,0 +1,6 @@
+package cmd
+
+import "os"
+
+// notifyResize does nothing on Windows, which does not signal changes to the size of the console.
+func notifyResize(c chan<- os.Signal) {}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"testing"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+func TestExecCommand(t *testing.T) {
+	var tests = []struct {
+		args     []string
+		dash     int
+		expected string
+	}{
+		{[]string{"web"}, -1, "/bin/sh"},
+		{[]string{"web"}, 1, "/bin/sh"},
+		{[]string{"web", "ls", "-la", "/app"}, 1, "ls -la /app"},
+	}
+
+	for _, test := range tests {
+		if got := execCommand(test.args, test.dash); got != test.expected {
+			t.Errorf("expected %q for %v, got %q", test.expected, test.args, got)
+		}
+	}
+}
+
+func TestFindExecTask(t *testing.T) {
+	tasks := []ECS.Task{
+		ECS.Task{TaskId: "1", LastStatus: "PROVISIONING"},
+		ECS.Task{TaskId: "2", LastStatus: "RUNNING"},
+		ECS.Task{TaskId: "3", LastStatus: "RUNNING"},
+	}
+
+	var tests = []struct {
+		taskID   string
+		expected string
+		err      bool
+	}{
+		{"", "2", false},
+		{"3", "3", false},
+		{"1", "1", true},
+		{"4", "", true},
+	}
+
+	for _, test := range tests {
+		task, err := findExecTask(tasks, test.taskID)
+
+		if (err != nil) != test.err {
+			t.Errorf("expected error %t for %q, got %v", test.err, test.taskID, err)
+		}
+
+		if task.TaskId != test.expected {
+			t.Errorf("expected task %q for %q, got %q", test.expected, test.taskID, task.TaskId)
+		}
+	}
+
+	if _, err := findExecTask(tasks[:1], ""); err == nil {
+		t.Errorf("expected error without running tasks, got none")
+	}
+}
+
+func TestExecTaskRoleName(t *testing.T) {
+	if expected, got := "fargateTaskRole", execTaskRoleName(""); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if expected, got := "app", execTaskRoleName("arn:aws:iam::123456789012:role/app"); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/awslabs/fargatecli/docker"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	"github.com/awslabs/fargatecli/git"
+	"github.com/spf13/pflag"
+)
+
+const maxImageTagLength = 128
+
+// imageTagInvalidCharacters matches the characters which are not allowed in image tags.
+var imageTagInvalidCharacters = regexp.MustCompile(`[^\w.-]+`)
+
+// gitFlagValues holds the options for tagging images with the git state of the current working
+// directory given on the command line.
+type gitFlagValues struct {
+	refuseDirty bool
+	tagRefs     bool
+}
+
+// addGitFlags adds the flags which configure how images are tagged from git to a flag set.
+func addGitFlags(flags *pflag.FlagSet, values *gitFlagValues) {
+	flags.BoolVar(&values.refuseDirty, "refuse-dirty", false, "Refuse to build or push an image from a git working tree with uncommitted changes")
+	flags.BoolVar(&values.tagRefs, "tag-git-refs", false, "Also tag the image with the git branch and any git tags pointing at HEAD")
+}
+
+// gitRevision is the git commit of the current working directory an image is built or pushed from,
+// and whether the working tree has uncommitted changes. The zero value is used outside of a git
+// repository.
+type gitRevision struct {
+	Author   string
+	Branch   string
+	Dirty    bool
+	Message  string
+	SHA      string
+	ShortSHA string
+	Tags     []string
+
+	tagRefs bool
+}
+
+// newGitRevision returns the git revision of the current working directory. Working trees with
+// uncommitted changes are refused if requested.
+func newGitRevision(values gitFlagValues) (gitRevision, error) {
+	if !git.IsCwdGitRepo() {
+		return gitRevision{}, nil
+	}
+
+	revision := gitRevision{
+		Author:   git.GetAuthor(),
+		Branch:   git.GetBranch(),
+		Dirty:    git.IsDirty(),
+		Message:  git.GetMessage(),
+		SHA:      git.GetSha(),
+		ShortSHA: git.GetShortSha(),
+		Tags:     git.GetTags(),
+		tagRefs:  values.tagRefs,
+	}
+
+	if revision.Dirty && values.refuseDirty {
+		return gitRevision{}, fmt.Errorf("git working tree has uncommitted changes [commit or stash them, or omit --refuse-dirty]")
+	}
+
+	return revision, nil
+}
+
+// tag returns the tag of an image built or pushed from the revision: the short SHA of the commit,
+// followed by -dirty- and the current time if the working tree has uncommitted changes, or else the
+// current time outside of a git repository.
+func (r gitRevision) tag() string {
+	switch {
+	case r.SHA == "":
+		return docker.GenerateTag()
+	case r.Dirty:
+		return r.ShortSHA + "-dirty-" + docker.GenerateTag()
+	default:
+		return r.ShortSHA
+	}
+}
+
+// additionalTags returns the tags to add to an image besides its own tag if requested: the branch
+// and the git tags pointing at the commit. Images built from working trees with uncommitted changes
+// are not given additional tags as they do not match the commit.
+func (r gitRevision) additionalTags() []string {
+	var tags []string
+
+	if !r.tagRefs || r.Dirty {
+		return tags
+	}
+
+	seen := map[string]bool{r.tag(): true}
+
+	for _, ref := range append([]string{r.Branch}, r.Tags...) {
+		if tag := imageTagName(ref); tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// labels returns the labels to apply to an image built from the revision.
+func (r gitRevision) labels(createdAt time.Time) map[string]string {
+	return docker.NewLabels(r.SHA, r.Branch, r.Author, r.Message, createdAt)
+}
+
+// commit returns the commit to record in the tags of a task definition running an image built from
+// the revision, or nil outside of a git repository.
+func (r gitRevision) commit() *ECS.Commit {
+	if r.SHA == "" {
+		return nil
+	}
+
+	return &ECS.Commit{
+		Author:  r.Author,
+		Branch:  r.Branch,
+		Message: r.Message,
+		SHA:     r.SHA,
+	}
+}
+
+// imageTagName returns a git ref as a valid image tag, replacing the characters which are not allowed
+// in image tags with dashes, e.g. feature-labels for feature/labels.
+func imageTagName(ref string) string {
+	tag := strings.TrimLeft(imageTagInvalidCharacters.ReplaceAllString(ref, "-"), ".-")
+
+	if len(tag) > maxImageTagLength {
+		tag = tag[:maxImageTagLength]
+	}
+
+	return tag
+}
+
+// tagChanges describes the additional tags an image is pushed with.
+func tagChanges(tags []string) (changes []string) {
+	if len(tags) > 0 {
+		changes = append(changes, fmt.Sprintf("tags: %s", strings.Join(tags, ", ")))
+	}
+
+	return
+}
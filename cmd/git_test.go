@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestGitRevisionTag(t *testing.T) {
+	if tag := (gitRevision{}).tag(); !regexp.MustCompile(`^\d{14}$`).MatchString(tag) {
+		t.Errorf("expected generated tag outside of a git repository, got %s", tag)
+	}
+
+	revision := gitRevision{SHA: "0123456789abcdef0123456789abcdef01234567", ShortSHA: "0123456"}
+
+	if tag := revision.tag(); tag != "0123456" {
+		t.Errorf("expected tag 0123456, got %s", tag)
+	}
+
+	revision.Dirty = true
+
+	if tag := revision.tag(); !regexp.MustCompile(`^0123456-dirty-\d{14}$`).MatchString(tag) {
+		t.Errorf("expected dirty tag, got %s", tag)
+	}
+}
+
+func TestGitRevisionAdditionalTags(t *testing.T) {
+	revision := gitRevision{
+		Branch:   "feature/labels",
+		SHA:      "0123456789abcdef0123456789abcdef01234567",
+		ShortSHA: "0123456",
+		Tags:     []string{"v1.0.0", "0123456", "feature-labels"},
+	}
+
+	if tags := revision.additionalTags(); len(tags) != 0 {
+		t.Errorf("expected no additional tags unless requested, got %v", tags)
+	}
+
+	revision.tagRefs = true
+
+	if tags, expected := revision.additionalTags(), []string{"feature-labels", "v1.0.0"}; !reflect.DeepEqual(tags, expected) {
+		t.Errorf("expected %v, got %v", expected, tags)
+	}
+
+	revision.Dirty = true
+
+	if tags := revision.additionalTags(); len(tags) != 0 {
+		t.Errorf("expected no additional tags for a dirty working tree, got %v", tags)
+	}
+}
+
+func TestGitRevisionCommit(t *testing.T) {
+	if commit := (gitRevision{}).commit(); commit != nil {
+		t.Errorf("expected no commit outside of a git repository, got %+v", commit)
+	}
+
+	revision := gitRevision{Author: "Jane Doe <jane@example.com>", Branch: "main", Message: "Add labels", SHA: "0123456789abcdef0123456789abcdef01234567"}
+	commit := revision.commit()
+
+	if commit == nil || commit.SHA != revision.SHA || commit.Author != revision.Author || commit.Message != revision.Message || commit.Branch != "main" {
+		t.Errorf("expected commit of revision, got %+v", commit)
+	}
+}
+
+func TestImageTagName(t *testing.T) {
+	var tests = []struct {
+		ref      string
+		expected string
+	}{
+		{"main", "main"},
+		{"feature/labels", "feature-labels"},
+		{"v1.0.0", "v1.0.0"},
+		{".hidden", "hidden"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if got := imageTagName(test.ref); got != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.ref, test.expected, got)
+		}
+	}
+}
+
+func TestNewGitRevisionRefuseDirty(t *testing.T) {
+	cwd, _ := os.Getwd()
+	dir, _ := ioutil.TempDir("", "fargate-test")
+	defer os.RemoveAll(dir)
+
+	os.Chdir(dir)
+	defer os.Chdir(cwd)
+
+	exec.Command("git", "init").Run()
+
+	if output, err := exec.Command("git", "commit", "--allow-empty", "--message", "Add labels").CombinedOutput(); err != nil {
+		t.Fatalf("Could not create dummy git commit: %v: %s", err, output)
+	}
+
+	exec.Command("git", "tag", "v1.0.0").Run()
+
+	revision, err := newGitRevision(gitFlagValues{refuseDirty: true, tagRefs: true})
+
+	if err != nil {
+		t.Fatalf("expected no error for a clean working tree, got %v", err)
+	}
+
+	if revision.Dirty || revision.Message != "Add labels" || !reflect.DeepEqual(revision.Tags, []string{"v1.0.0"}) {
+		t.Errorf("expected clean revision tagged v1.0.0, got %+v", revision)
+	}
+
+	ioutil.WriteFile("Dockerfile", []byte("FROM scratch\n"), 0600)
+
+	if _, err := newGitRevision(gitFlagValues{refuseDirty: true}); err == nil {
+		t.Errorf("expected error for a dirty working tree, got none")
+	}
+
+	if revision, err := newGitRevision(gitFlagValues{}); err != nil || !revision.Dirty {
+		t.Errorf("expected dirty revision, got %+v, %v", revision, err)
+	}
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+	ELBV2 "github.com/awslabs/fargatecli/elbv2"
+	"github.com/spf13/pflag"
+)
+
+const (
+	deregistrationDelayFlag   = "deregistration-delay"
+	healthCheckMatcherPattern = `\A\d{3}((,\d{3})*|-\d{3})\z`
+	maxDeregistrationDelay    = 3600
+	maxHealthCheckInterval    = 300
+	maxHealthCheckRetries     = 10
+	maxHealthCheckStartPeriod = 300
+	maxHealthCheckThreshold   = 10
+	minHealthCheckInterval    = 5
+	minHealthCheckRetries     = 1
+	minHealthCheckThreshold   = 2
+)
+
+var validHealthCheckMatcher = regexp.MustCompile(healthCheckMatcherPattern)
+
+// healthCheckFlagValues holds the target group health check and deregistration settings given on
+// the command line.
+type healthCheckFlagValues struct {
+	deregistrationDelay int64
+	healthCheck         ELBV2.HealthCheck
+}
+
+// addHealthCheckFlags adds the target group health check and deregistration flags to a flag set.
+func addHealthCheckFlags(flags *pflag.FlagSet, values *healthCheckFlagValues) {
+	flags.StringVar(&values.healthCheck.Path, "health-check-path", "", "Path the load balancer requests to check the health of each task [e.g. /healthz]")
+	flags.Int64Var(&values.healthCheck.IntervalSeconds, "health-check-interval", 0, "Seconds between health checks of each task (5-300)")
+	flags.Int64Var(&values.healthCheck.HealthyThreshold, "healthy-threshold", 0, "Consecutive successful health checks before a task is considered healthy (2-10)")
+	flags.Int64Var(&values.healthCheck.UnhealthyThreshold, "unhealthy-threshold", 0, "Consecutive failed health checks before a task is considered unhealthy (2-10)")
+	flags.StringVar(&values.healthCheck.Matcher, "health-check-matcher", "", "HTTP codes of a successful health check [e.g. 200, 200,204, 200-299]")
+	flags.Int64Var(&values.deregistrationDelay, deregistrationDelayFlag, 0, "Seconds to wait for in-flight requests to complete before deregistering a task (0-3600)")
+}
+
+// containerHealthCheckFlagValues holds the container health check settings given on the command line.
+// The interval is shared with the load balancer's health check flags.
+type containerHealthCheckFlagValues struct {
+	command     string
+	retries     int64
+	startPeriod int64
+}
+
+// addContainerHealthCheckFlags adds the container health check flags to a flag set, except for the
+// interval which is added alongside the load balancer's health check flags.
+func addContainerHealthCheckFlags(flags *pflag.FlagSet, values *containerHealthCheckFlagValues) {
+	flags.StringVar(&values.command, "health-check-command", "", "Command run in the container to check its health; a non-zero exit status is unhealthy [e.g. \"curl -f http://localhost/ || exit 1\"]")
+	flags.Int64Var(&values.retries, "health-check-retries", 0, "Consecutive failed container health checks before the container is considered unhealthy (1-10)")
+	flags.Int64Var(&values.startPeriod, "health-check-start-period", 0, "Seconds to wait after the container starts before failed health checks count towards the retries (0-300)")
+}
+
+// isEmpty returns whether no container health check flags were given.
+func (v containerHealthCheckFlagValues) isEmpty() bool {
+	return v.command == "" && v.retries == 0 && v.startPeriod == 0
+}
+
+// healthCheck returns the container health check given on the command line with the given interval.
+func (v containerHealthCheckFlagValues) healthCheck(interval int64) ECS.HealthCheck {
+	return ECS.HealthCheck{
+		Command:     v.command,
+		Interval:    interval,
+		Retries:     v.retries,
+		StartPeriod: v.startPeriod,
+	}
+}
+
+// deregistrationDelayValue returns the deregistration delay given on the command line, or nil if the
+// flag was not given.
+func (v healthCheckFlagValues) deregistrationDelayValue(flags *pflag.FlagSet) *int64 {
+	if !flags.Changed(deregistrationDelayFlag) {
+		return nil
+	}
+
+	deregistrationDelay := v.deregistrationDelay
+
+	return &deregistrationDelay
+}
+
+// validateHealthCheck returns an error describing each invalid health check or deregistration
+// setting for a target group using the given protocol.
+func validateHealthCheck(healthCheck ELBV2.HealthCheck, deregistrationDelay *int64, protocol string) error {
+	var msgs []string
+
+	if healthCheck.Path != "" {
+		if strings.EqualFold(protocol, protocolTcp) {
+			msgs = append(msgs, "health check path cannot be used with TCP")
+		} else if !strings.HasPrefix(healthCheck.Path, "/") {
+			msgs = append(msgs, fmt.Sprintf("Invalid health check path %s [must begin with /]", healthCheck.Path))
+		}
+	}
+
+	if healthCheck.Matcher != "" {
+		if strings.EqualFold(protocol, protocolTcp) {
+			msgs = append(msgs, "health check matcher cannot be used with TCP")
+		} else if !validHealthCheckMatcher.MatchString(healthCheck.Matcher) {
+			msgs = append(msgs, fmt.Sprintf("Invalid health check matcher %s [e.g. 200, 200,204, 200-299]", healthCheck.Matcher))
+		}
+	}
+
+	if i := healthCheck.IntervalSeconds; i != 0 && (i < minHealthCheckInterval || i > maxHealthCheckInterval) {
+		msgs = append(msgs, fmt.Sprintf("Invalid health check interval %d [specify within %d - %d]", i, minHealthCheckInterval, maxHealthCheckInterval))
+	}
+
+	if t := healthCheck.HealthyThreshold; t != 0 && (t < minHealthCheckThreshold || t > maxHealthCheckThreshold) {
+		msgs = append(msgs, fmt.Sprintf("Invalid healthy threshold %d [specify within %d - %d]", t, minHealthCheckThreshold, maxHealthCheckThreshold))
+	}
+
+	if t := healthCheck.UnhealthyThreshold; t != 0 && (t < minHealthCheckThreshold || t > maxHealthCheckThreshold) {
+		msgs = append(msgs, fmt.Sprintf("Invalid unhealthy threshold %d [specify within %d - %d]", t, minHealthCheckThreshold, maxHealthCheckThreshold))
+	}
+
+	if d := deregistrationDelay; d != nil && (*d < 0 || *d > maxDeregistrationDelay) {
+		msgs = append(msgs, fmt.Sprintf("Invalid deregistration delay %d [specify within 0 - %d]", *d, maxDeregistrationDelay))
+	}
+
+	if len(msgs) > 0 {
+		return fmt.Errorf(strings.Join(msgs, ", "))
+	}
+
+	return nil
+}
+
+// validateContainerHealthCheck returns an error describing each invalid container health check
+// setting.
+func validateContainerHealthCheck(healthCheck ECS.HealthCheck) error {
+	var msgs []string
+
+	if i := healthCheck.Interval; i != 0 && (i < minHealthCheckInterval || i > maxHealthCheckInterval) {
+		msgs = append(msgs, fmt.Sprintf("Invalid health check interval %d [specify within %d - %d]", i, minHealthCheckInterval, maxHealthCheckInterval))
+	}
+
+	if r := healthCheck.Retries; r != 0 && (r < minHealthCheckRetries || r > maxHealthCheckRetries) {
+		msgs = append(msgs, fmt.Sprintf("Invalid health check retries %d [specify within %d - %d]", r, minHealthCheckRetries, maxHealthCheckRetries))
+	}
+
+	if p := healthCheck.StartPeriod; p < 0 || p > maxHealthCheckStartPeriod {
+		msgs = append(msgs, fmt.Sprintf("Invalid health check start period %d [specify within 0 - %d]", p, maxHealthCheckStartPeriod))
+	}
+
+	if len(msgs) > 0 {
+		return fmt.Errorf(strings.Join(msgs, ", "))
+	}
+
+	return nil
+}
+
+// containerHealthCheckChanges describes the changes made to a container's health check by the
+// given health check. If current is nil, the container has no health check.
+func containerHealthCheckChanges(current *ECS.HealthCheck, healthCheck ECS.HealthCheck) (changes []string) {
+	var from ECS.HealthCheck
+
+	if current != nil {
+		from = *current
+	}
+
+	change := func(name, from, to string) {
+		if current == nil {
+			changes = append(changes, fmt.Sprintf("%s: %s", name, to))
+		} else {
+			changes = append(changes, diffField(name, from, to)...)
+		}
+	}
+
+	if healthCheck.Command != "" {
+		change("container health check command", from.Command, healthCheck.Command)
+	}
+
+	if healthCheck.Interval != 0 {
+		change("container health check interval", fmt.Sprint(from.Interval), fmt.Sprint(healthCheck.Interval))
+	}
+
+	if healthCheck.Retries != 0 {
+		change("container health check retries", fmt.Sprint(from.Retries), fmt.Sprint(healthCheck.Retries))
+	}
+
+	if healthCheck.StartPeriod != 0 {
+		change("container health check start period", fmt.Sprint(from.StartPeriod), fmt.Sprint(healthCheck.StartPeriod))
+	}
+
+	return
+}
+
+// healthCheckChanges describes the changes made to a target group's settings by the given health
+// check and deregistration delay. If current is nil, the target group is being created.
+func healthCheckChanges(current *ELBV2.TargetGroupSettings, healthCheck ELBV2.HealthCheck, deregistrationDelay *int64) (changes []string) {
+	var from ELBV2.TargetGroupSettings
+
+	if current != nil {
+		from = *current
+	}
+
+	change := func(name, from, to string) {
+		if current == nil {
+			changes = append(changes, fmt.Sprintf("%s: %s", name, to))
+		} else {
+			changes = append(changes, diffField(name, from, to)...)
+		}
+	}
+
+	if healthCheck.Path != "" {
+		change("health check path", from.HealthCheck.Path, healthCheck.Path)
+	}
+
+	if healthCheck.IntervalSeconds != 0 {
+		change("health check interval", fmt.Sprint(from.HealthCheck.IntervalSeconds), fmt.Sprint(healthCheck.IntervalSeconds))
+	}
+
+	if healthCheck.HealthyThreshold != 0 {
+		change("healthy threshold", fmt.Sprint(from.HealthCheck.HealthyThreshold), fmt.Sprint(healthCheck.HealthyThreshold))
+	}
+
+	if healthCheck.UnhealthyThreshold != 0 {
+		change("unhealthy threshold", fmt.Sprint(from.HealthCheck.UnhealthyThreshold), fmt.Sprint(healthCheck.UnhealthyThreshold))
+	}
+
+	if healthCheck.Matcher != "" {
+		change("health check matcher", from.HealthCheck.Matcher, healthCheck.Matcher)
+	}
+
+	if deregistrationDelay != nil {
+		change("deregistration delay", fmt.Sprint(from.DeregistrationDelay), fmt.Sprint(*deregistrationDelay))
+	}
+
+	return
+}
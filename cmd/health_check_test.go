@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+	ELBV2 "github.com/awslabs/fargatecli/elbv2"
+)
+
+func TestValidateHealthCheck(t *testing.T) {
+	var (
+		delay        = int64(30)
+		invalidDelay = int64(3601)
+	)
+
+	var tests = []struct {
+		healthCheck         ELBV2.HealthCheck
+		deregistrationDelay *int64
+		protocol            string
+		valid               bool
+	}{
+		{ELBV2.HealthCheck{}, nil, "HTTP", true},
+		{ELBV2.HealthCheck{Path: "/healthz", Matcher: "200-299", IntervalSeconds: 10, HealthyThreshold: 2, UnhealthyThreshold: 10}, &delay, "HTTP", true},
+		{ELBV2.HealthCheck{Matcher: "200,204"}, nil, "HTTPS", true},
+		{ELBV2.HealthCheck{IntervalSeconds: 30}, nil, "TCP", true},
+		{ELBV2.HealthCheck{Path: "healthz"}, nil, "HTTP", false},
+		{ELBV2.HealthCheck{Path: "/healthz"}, nil, "TCP", false},
+		{ELBV2.HealthCheck{Matcher: "200"}, nil, "TCP", false},
+		{ELBV2.HealthCheck{Matcher: "ok"}, nil, "HTTP", false},
+		{ELBV2.HealthCheck{IntervalSeconds: 4}, nil, "HTTP", false},
+		{ELBV2.HealthCheck{IntervalSeconds: 301}, nil, "HTTP", false},
+		{ELBV2.HealthCheck{HealthyThreshold: 1}, nil, "HTTP", false},
+		{ELBV2.HealthCheck{UnhealthyThreshold: 11}, nil, "HTTP", false},
+		{ELBV2.HealthCheck{}, &invalidDelay, "HTTP", false},
+	}
+
+	for _, test := range tests {
+		err := validateHealthCheck(test.healthCheck, test.deregistrationDelay, test.protocol)
+
+		if valid := err == nil; valid != test.valid {
+			t.Errorf("expected %+v (%s) valid == %t, got %v", test.healthCheck, test.protocol, test.valid, err)
+		}
+	}
+}
+
+func TestHealthCheckChanges(t *testing.T) {
+	delay := int64(30)
+	current := &ELBV2.TargetGroupSettings{
+		DeregistrationDelay: 300,
+		HealthCheck:         ELBV2.HealthCheck{Path: "/", IntervalSeconds: 30, HealthyThreshold: 5, UnhealthyThreshold: 2, Matcher: "200"},
+	}
+
+	var tests = []struct {
+		current             *ELBV2.TargetGroupSettings
+		healthCheck         ELBV2.HealthCheck
+		deregistrationDelay *int64
+		changes             []string
+	}{
+		{nil, ELBV2.HealthCheck{}, nil, nil},
+		{
+			nil,
+			ELBV2.HealthCheck{Path: "/healthz", IntervalSeconds: 10},
+			&delay,
+			[]string{"health check path: /healthz", "health check interval: 10", "deregistration delay: 30"},
+		},
+		{
+			current,
+			ELBV2.HealthCheck{Path: "/healthz", HealthyThreshold: 5, UnhealthyThreshold: 3, Matcher: "200-299"},
+			&delay,
+			[]string{
+				"health check path: / => /healthz",
+				"unhealthy threshold: 2 => 3",
+				"health check matcher: 200 => 200-299",
+				"deregistration delay: 300 => 30",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		changes := healthCheckChanges(test.current, test.healthCheck, test.deregistrationDelay)
+
+		if !reflect.DeepEqual(changes, test.changes) {
+			t.Errorf("expected changes %v, got %v", test.changes, changes)
+		}
+	}
+}
+
+func TestValidateContainerHealthCheck(t *testing.T) {
+	var tests = []struct {
+		healthCheck ECS.HealthCheck
+		valid       bool
+	}{
+		{ECS.HealthCheck{Command: "pgrep server"}, true},
+		{ECS.HealthCheck{Command: "pgrep server", Interval: 10, Retries: 3, StartPeriod: 60}, true},
+		{ECS.HealthCheck{Command: "pgrep server", Interval: 301}, false},
+		{ECS.HealthCheck{Command: "pgrep server", Retries: 11}, false},
+		{ECS.HealthCheck{Command: "pgrep server", StartPeriod: 301}, false},
+		{ECS.HealthCheck{Command: "pgrep server", StartPeriod: -1}, false},
+	}
+
+	for _, test := range tests {
+		err := validateContainerHealthCheck(test.healthCheck)
+
+		if valid := err == nil; valid != test.valid {
+			t.Errorf("expected %+v valid == %t, got %v", test.healthCheck, test.valid, err)
+		}
+	}
+}
+
+func TestContainerHealthCheckChanges(t *testing.T) {
+	current := &ECS.HealthCheck{Command: "pgrep server", Interval: 30, Retries: 3}
+
+	var tests = []struct {
+		current     *ECS.HealthCheck
+		healthCheck ECS.HealthCheck
+		changes     []string
+	}{
+		{
+			nil,
+			ECS.HealthCheck{Command: "pgrep server", Retries: 3},
+			[]string{"container health check command: pgrep server", "container health check retries: 3"},
+		},
+		{
+			current,
+			ECS.HealthCheck{Interval: 10, Retries: 3, StartPeriod: 60},
+			[]string{"container health check interval: 30 => 10", "container health check start period: 0 => 60"},
+		},
+	}
+
+	for _, test := range tests {
+		changes := containerHealthCheckChanges(test.current, test.healthCheck)
+
+		if !reflect.DeepEqual(changes, test.changes) {
+			t.Errorf("expected changes %v, got %v", test.changes, changes)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	ECR "github.com/awslabs/fargatecli/ecr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var imageCmd = &cobra.Command{
+	Use:   "image",
+	Short: "Manage images",
+	Long: `Manage images
+
+Images built or pushed by fargate are stored in an Amazon ECR repository named
+after the service or task group they were built for. Every deployment pushes a
+new image, so repositories grow until old images are pruned.`,
+}
+
+// repositoryFlagValues holds the options for creating an image repository given on the command line.
+type repositoryFlagValues struct {
+	lifecyclePolicy string
+	scanOnPush      bool
+}
+
+// addRepositoryFlags adds the flags which configure an image repository when it is created to a flag
+// set.
+func addRepositoryFlags(flags *pflag.FlagSet, values *repositoryFlagValues) {
+	flags.StringVar(&values.lifecyclePolicy, "lifecycle-policy", "", "Path to a JSON lifecycle policy to apply to the image repository when it is created")
+	flags.BoolVar(&values.scanOnPush, "scan-on-push", false, "Scan images for vulnerabilities when they are pushed to a newly created image repository")
+}
+
+// repositoryOptions returns the options to create an image repository with given on the command line,
+// reading the lifecycle policy from its file.
+func (v repositoryFlagValues) repositoryOptions() (ECR.RepositoryOptions, []error) {
+	var errs []error
+
+	options := ECR.RepositoryOptions{ScanOnPush: v.scanOnPush}
+
+	if v.lifecyclePolicy != "" {
+		policy, err := ioutil.ReadFile(v.lifecyclePolicy)
+
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("could not read lifecycle policy %s", v.lifecyclePolicy))
+		case !json.Valid(policy):
+			errs = append(errs, fmt.Errorf("lifecycle policy %s is not valid JSON", v.lifecyclePolicy))
+		default:
+			options.LifecyclePolicy = string(policy)
+		}
+	}
+
+	return options, errs
+}
+
+// repositoryChanges describes the options an image repository is created with.
+func repositoryChanges(options ECR.RepositoryOptions) (changes []string) {
+	if options.ScanOnPush {
+		changes = append(changes, "scan on push: enabled")
+	}
+
+	if options.LifecyclePolicy != "" {
+		changes = append(changes, "lifecycle policy: set")
+	}
+
+	return
+}
+
+// imagesInUse returns the digests of the images in a repository which are referred to by any of the
+// given image references, either by digest or by one of their tags.
+func imagesInUse(repository ECR.Repository, images ECR.Images, references []string) map[string]bool {
+	inUse := make(map[string]bool)
+	tags := make(map[string]string)
+
+	for _, image := range images {
+		for _, tag := range image.Tags {
+			tags[tag] = image.Digest
+		}
+	}
+
+	for _, reference := range references {
+		uri, ok := ECR.ParseImageUri(reference)
+
+		if !ok || uri.Repository != repository.URI {
+			continue
+		}
+
+		if uri.Digest != "" {
+			inUse[uri.Digest] = true
+		} else if digest, ok := tags[uri.Tag]; ok {
+			inUse[digest] = true
+		}
+	}
+
+	return inUse
+}
+
+func init() {
+	rootCmd.AddCommand(imageCmd)
+}
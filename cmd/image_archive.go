@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/awslabs/fargatecli/docker"
+	ECR "github.com/awslabs/fargatecli/ecr"
+)
+
+// validateImageArchive checks an image archive given on the command line. Image archives cannot be
+// given alongside an image or build flags, as no image is built.
+func validateImageArchive(path, image string, build buildFlagValues) []error {
+	var errs []error
+
+	if image != "" {
+		errs = append(errs, fmt.Errorf("--image-archive cannot be used with --image"))
+	}
+
+	if !build.isEmpty() {
+		errs = append(errs, fmt.Errorf("build flags cannot be used with --image-archive"))
+	}
+
+	if info, err := os.Stat(path); err != nil {
+		errs = append(errs, fmt.Errorf("could not read image archive %s", path))
+	} else if info.IsDir() {
+		errs = append(errs, fmt.Errorf("image archive %s is a directory [specify a tarball]", path))
+	}
+
+	return errs
+}
+
+// pushImageArchive pushes the image in an archive to the ECR repository with the given name, tagged
+// from the given git revision, creating the repository with the given options if it does not exist.
+// The URI of the image pinned to its digest is returned along with the URI of the tag it was pushed
+// with.
+func pushImageArchive(repositoryName, path string, options ECR.RepositoryOptions, revision gitRevision, plan *changePlan) (string, string) {
+	var repositoryUri string
+
+	ecr := ECR.New(sess)
+	tag := revision.tag()
+	additionalTags := revision.additionalTags()
+
+	if ecr.IsRepositoryCreated(repositoryName) {
+		repositoryUri = ecr.GetRepositoryUri(repositoryName)
+	} else {
+		repositoryUri = knownAfterApply
+
+		plan.do(
+			changeStep{
+				API:      "ecr",
+				Action:   "CreateRepository",
+				Resource: "repository",
+				Name:     repositoryName,
+				Changes:  repositoryChanges(options),
+			},
+			func() { repositoryUri = ecr.CreateRepository(repositoryName, options) },
+		)
+	}
+
+	image := repositoryUri + "@" + knownAfterApply
+
+	plan.do(
+		changeStep{
+			API:      "docker",
+			Action:   "Push",
+			Resource: "image",
+			Name:     repositoryUri + ":" + tag,
+			Changes:  append([]string{fmt.Sprintf("archive: %s", path)}, tagChanges(additionalTags)...),
+		},
+		func() {
+			repository := docker.NewRepository(repositoryUri)
+			username, password := ecr.GetUsernameAndPassword()
+
+			repository.Login(username, password)
+			image = repository.UriForDigest(repository.PushArchive(path, tag, additionalTags...))
+		},
+	)
+
+	return image, repositoryUri + ":" + tag
+}
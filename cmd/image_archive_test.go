@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateImageArchive(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "fargate-test")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "image.tar")
+	ioutil.WriteFile(path, []byte{}, 0600)
+
+	var tests = []struct {
+		path   string
+		image  string
+		build  buildFlagValues
+		errors int
+	}{
+		{path, "", buildFlagValues{context: "."}, 0},
+		{path, "nginx:latest", buildFlagValues{}, 1},
+		{path, "", buildFlagValues{target: "release"}, 1},
+		{filepath.Join(dir, "missing.tar"), "", buildFlagValues{}, 1},
+		{dir, "nginx:latest", buildFlagValues{dockerfile: "Dockerfile"}, 3},
+	}
+
+	for _, test := range tests {
+		if errs := validateImageArchive(test.path, test.image, test.build); len(errs) != test.errors {
+			t.Errorf("%s, %q, %+v: expected %d errors, got %v", test.path, test.image, test.build, test.errors, errs)
+		}
+	}
+}
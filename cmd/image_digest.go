@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/awslabs/fargatecli/console"
+	ECR "github.com/awslabs/fargatecli/ecr"
+)
+
+// pinImage returns the image to run for an image given on the command line, and the image it was
+// pinned from, if any. Images in Amazon ECR referred to by tag are pinned to the digest of the image
+// the tag refers to now, so that tasks keep running the same image if the tag is later moved. Other
+// images are returned as they are.
+func pinImage(image string) (string, string) {
+	uri, ok := ECR.ParseImageUri(image)
+
+	if !ok || uri.Digest != "" {
+		return image, ""
+	}
+
+	ecr := ECR.New(sess.Copy(&aws.Config{Region: aws.String(uri.Region)}))
+	digest, err := ecr.DescribeImageDigest(uri.RegistryID, uri.RepositoryName, uri.Tag)
+
+	if err != nil {
+		console.ErrorExit(err, "Couldn't pin image %s to its digest", image)
+	}
+
+	return uri.Repository + "@" + digest, image
+}
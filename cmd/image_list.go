@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ECR "github.com/awslabs/fargatecli/ecr"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	"github.com/spf13/cobra"
+)
+
+// findingSeverities are the severities of image scan findings, most severe first.
+var findingSeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFORMATIONAL", "UNDEFINED"}
+
+// imageListDocument is the structured representation of a repository and its images.
+type imageListDocument struct {
+	Repository ECR.Repository  `json:"repository"`
+	Images     []imageDocument `json:"images"`
+}
+
+// imageDocument is the structured representation of an image along with whether an active task
+// definition refers to it.
+type imageDocument struct {
+	ECR.Image
+	InUse bool `json:"inUse"`
+}
+
+// ScanString summarizes the findings of the image's latest scan, most severe first.
+func (d imageDocument) ScanString() string {
+	var findings []string
+
+	if d.ScanStatus != "COMPLETE" {
+		return Humanize(d.ScanStatus)
+	}
+
+	for _, severity := range findingSeverities {
+		if count := d.ScanFindings[severity]; count > 0 {
+			findings = append(findings, fmt.Sprintf("%d %s", count, Humanize(severity)))
+		}
+	}
+
+	if len(findings) == 0 {
+		return "no findings"
+	}
+
+	return strings.Join(findings, ", ")
+}
+
+type imageListOperation struct {
+	ecr            ECR.Client
+	ecs            ECS.Client
+	output         Output
+	repositoryName string
+}
+
+func (o imageListOperation) execute() {
+	o.output.Debug("Describing repository [API=ecr Action=DescribeRepositories]")
+	repository, err := o.ecr.DescribeRepository(o.repositoryName)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not list images in repository %s", o.repositoryName)
+		return
+	}
+
+	o.output.Debug("Listing images [API=ecr Action=DescribeImages]")
+	images, err := o.ecr.ListImages(o.repositoryName)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not list images in repository %s", o.repositoryName)
+		return
+	}
+
+	o.output.Debug("Listing images of active task definitions [API=ecs Action=DescribeTaskDefinition]")
+	references, err := o.ecs.ListActiveTaskDefinitionImages()
+
+	if err != nil {
+		o.output.Fatal(err, "Could not list images in repository %s", o.repositoryName)
+		return
+	}
+
+	sortImagesByPushedAt(images)
+
+	inUse := imagesInUse(repository, images, references)
+	document := imageListDocument{Repository: repository, Images: []imageDocument{}}
+
+	for _, image := range images {
+		document.Images = append(document.Images, imageDocument{Image: image, InUse: inUse[image.Digest]})
+	}
+
+	o.output.Document(document)
+	o.output.KeyValue("Repository", repository.URI, 0)
+	o.output.KeyValue("Scan on Push", enabledString(repository.ScanOnPush), 0)
+
+	if len(images) == 0 {
+		o.output.Info("No images found")
+		return
+	}
+
+	rows := [][]string{
+		[]string{"DIGEST", "TAGS", "PUSHED", "SIZE", "IN USE", "SCAN"},
+	}
+
+	for _, image := range document.Images {
+		rows = append(rows,
+			[]string{
+				image.Digest,
+				strings.Join(image.Tags, ", "),
+				image.PushedAt.Format(timeFormatWithZone),
+				imageSizeString(image.SizeInBytes),
+				yesNoString(image.InUse),
+				image.ScanString(),
+			},
+		)
+	}
+
+	o.output.LineBreak()
+	o.output.Table("", rows)
+}
+
+// sortImagesByPushedAt sorts images from the most to the least recently pushed.
+func sortImagesByPushedAt(images ECR.Images) {
+	sort.SliceStable(images, func(i, j int) bool {
+		return images[i].PushedAt.After(images[j].PushedAt)
+	})
+}
+
+func imageSizeString(bytes int64) string {
+	return fmt.Sprintf("%.1f MiB", float64(bytes)/(1<<20))
+}
+
+func enabledString(enabled bool) string {
+	if enabled {
+		return "Enabled"
+	}
+
+	return "Disabled"
+}
+
+func yesNoString(b bool) string {
+	if b {
+		return "Yes"
+	}
+
+	return "No"
+}
+
+var imageListCmd = &cobra.Command{
+	Use:   "list <service or task group name>",
+	Short: "List images",
+	Long: `List images
+
+Lists the images in the repository of a service or task group, most recently
+pushed first, along with their tags, size, and whether an active task
+definition of the service or task group, or one deployed by any service in the
+cluster, refers to them. Images which are referred to are never removed by
+image prune.
+
+For repositories which scan images on push, the findings of each image's
+latest scan are summarized by severity.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		imageListOperation{
+			ecr:            ECR.New(sess),
+			ecs:            ECS.New(sess, clusterName),
+			output:         output,
+			repositoryName: args[0],
+		}.execute()
+	},
+}
+
+func init() {
+	imageCmd.AddCommand(imageListCmd)
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/awslabs/fargatecli/cmd/mock"
+	ECR "github.com/awslabs/fargatecli/ecr"
+	ecrclient "github.com/awslabs/fargatecli/ecr/mock/client"
+	ecsclient "github.com/awslabs/fargatecli/ecs/mock/client"
+	"github.com/golang/mock/gomock"
+)
+
+func TestImageListOperation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECR := ecrclient.NewMockClient(mockCtrl)
+	mockECS := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+	pushedAt := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	images := ECR.Images{
+		ECR.Image{Digest: testDigestA, PushedAt: pushedAt.Add(-time.Hour), SizeInBytes: 1 << 20, Tags: []string{"abc1234"}},
+		ECR.Image{
+			Digest:       testDigestB,
+			PushedAt:     pushedAt,
+			ScanFindings: map[string]int64{"LOW": 5, "CRITICAL": 1},
+			ScanStatus:   "COMPLETE",
+			SizeInBytes:  3 << 19,
+			Tags:         []string{"def5678", "latest"},
+		},
+	}
+
+	mockECR.EXPECT().DescribeRepository("web").Return(ECR.Repository{Name: "web", ScanOnPush: true, URI: testRepositoryURI}, nil)
+	mockECR.EXPECT().ListImages("web").Return(images, nil)
+	mockECS.EXPECT().ListActiveTaskDefinitionImages().Return([]string{testRepositoryURI + ":abc1234"}, nil)
+
+	imageListOperation{
+		ecr:            mockECR,
+		ecs:            mockECS,
+		output:         mockOutput,
+		repositoryName: "web",
+	}.execute()
+
+	if len(mockOutput.FatalMsgs) > 0 {
+		t.Fatalf("expected no fatal errors, got %v", mockOutput.FatalMsgs)
+	}
+
+	if mockOutput.KeyValueMsgs["Scan on Push"] != "Enabled" {
+		t.Errorf("expected scan on push enabled, got %v", mockOutput.KeyValueMsgs)
+	}
+
+	if len(mockOutput.Tables) != 1 {
+		t.Fatalf("expected 1 table, got %d", len(mockOutput.Tables))
+	}
+
+	rows := mockOutput.Tables[0].Rows
+
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+
+	expected := []string{testDigestB, "def5678, latest", "2020-04-01 12:00:00 UTC", "1.5 MiB", "No", "1 critical, 5 low"}
+
+	for n, column := range expected {
+		if rows[1][n] != column {
+			t.Errorf("expected column %d to be %q, got %q", n, column, rows[1][n])
+		}
+	}
+
+	if rows[2][0] != testDigestA || rows[2][4] != "Yes" || rows[2][5] != "" {
+		t.Errorf("expected older image in use and not scanned, got %v", rows[2])
+	}
+}
+
+func TestImageListOperationNoImages(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECR := ecrclient.NewMockClient(mockCtrl)
+	mockECS := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockECR.EXPECT().DescribeRepository("web").Return(ECR.Repository{Name: "web", URI: testRepositoryURI}, nil)
+	mockECR.EXPECT().ListImages("web").Return(ECR.Images{}, nil)
+	mockECS.EXPECT().ListActiveTaskDefinitionImages().Return([]string{}, nil)
+
+	imageListOperation{
+		ecr:            mockECR,
+		ecs:            mockECS,
+		output:         mockOutput,
+		repositoryName: "web",
+	}.execute()
+
+	if len(mockOutput.InfoMsgs) != 1 || mockOutput.InfoMsgs[0] != "No images found" {
+		t.Errorf("expected no images message, got %v", mockOutput.InfoMsgs)
+	}
+}
+
+func TestImageListOperationError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECR := ecrclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockECR.EXPECT().DescribeRepository("web").Return(ECR.Repository{}, errors.New("RepositoryNotFoundException"))
+
+	imageListOperation{
+		ecr:            mockECR,
+		output:         mockOutput,
+		repositoryName: "web",
+	}.execute()
+
+	if !mockOutput.Exited {
+		t.Fatalf("expected exit, didn't")
+	}
+
+	if mockOutput.FatalMsgs[0].Msg != "Could not list images in repository web" {
+		t.Errorf("unexpected fatal message %s", mockOutput.FatalMsgs[0].Msg)
+	}
+}
+
+func TestImageDocumentScanString(t *testing.T) {
+	var tests = []struct {
+		image    ECR.Image
+		expected string
+	}{
+		{ECR.Image{}, ""},
+		{ECR.Image{ScanStatus: "IN_PROGRESS"}, "in progress"},
+		{ECR.Image{ScanStatus: "COMPLETE"}, "no findings"},
+		{ECR.Image{ScanStatus: "COMPLETE", ScanFindings: map[string]int64{"MEDIUM": 2, "HIGH": 3}}, "3 high, 2 medium"},
+	}
+
+	for _, test := range tests {
+		if got := (imageDocument{Image: test.image}).ScanString(); got != test.expected {
+			t.Errorf("%+v: expected %q, got %q", test.image, test.expected, got)
+		}
+	}
+}
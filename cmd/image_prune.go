@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ECR "github.com/awslabs/fargatecli/ecr"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	"github.com/spf13/cobra"
+)
+
+const defaultImagePruneKeep = 20
+
+type imagePruneOperation struct {
+	dryRun         bool
+	ecr            ECR.Client
+	ecs            ECS.Client
+	keep           int
+	now            time.Time
+	olderThan      time.Duration
+	output         Output
+	repositoryName string
+}
+
+// setOlderThan sets the minimum age of images to prune from a duration such as 12h, or a number of
+// days such as 30d.
+func (o *imagePruneOperation) setOlderThan(inputOlderThan string) error {
+	if inputOlderThan == "" {
+		return nil
+	}
+
+	if strings.HasSuffix(inputOlderThan, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(inputOlderThan, "d"))
+
+		if err != nil {
+			return fmt.Errorf("invalid age %s [specify a number of days, e.g. 30d, or a duration, e.g. 12h]", inputOlderThan)
+		}
+
+		o.olderThan = time.Duration(days) * 24 * time.Hour
+
+		return nil
+	}
+
+	olderThan, err := time.ParseDuration(inputOlderThan)
+
+	if err != nil {
+		return fmt.Errorf("invalid age %s [specify a number of days, e.g. 30d, or a duration, e.g. 12h]", inputOlderThan)
+	}
+
+	o.olderThan = olderThan
+
+	return nil
+}
+
+func (o imagePruneOperation) validate() error {
+	if o.keep < 0 {
+		return fmt.Errorf("--keep must be 0 or greater, got %d", o.keep)
+	}
+
+	if o.olderThan < 0 {
+		return fmt.Errorf("--older-than must not be negative, got %s", o.olderThan)
+	}
+
+	return nil
+}
+
+// prunable returns the images to prune: those beyond the most recently pushed images to keep which
+// were pushed before the minimum age and are not in use.
+func (o imagePruneOperation) prunable(images ECR.Images, inUse map[string]bool) ECR.Images {
+	var prunable ECR.Images
+
+	sortImagesByPushedAt(images)
+
+	for n, image := range images {
+		if n < o.keep || inUse[image.Digest] {
+			continue
+		}
+
+		if o.olderThan > 0 && image.PushedAt.After(o.now.Add(-o.olderThan)) {
+			continue
+		}
+
+		prunable = append(prunable, image)
+	}
+
+	return prunable
+}
+
+func (o imagePruneOperation) execute() {
+	o.output.Debug("Describing repository [API=ecr Action=DescribeRepositories]")
+	repository, err := o.ecr.DescribeRepository(o.repositoryName)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not prune images in repository %s", o.repositoryName)
+		return
+	}
+
+	o.output.Debug("Listing images [API=ecr Action=DescribeImages]")
+	images, err := o.ecr.ListImages(o.repositoryName)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not prune images in repository %s", o.repositoryName)
+		return
+	}
+
+	o.output.Debug("Listing images of active task definitions [API=ecs Action=DescribeTaskDefinition]")
+	references, err := o.ecs.ListActiveTaskDefinitionImages()
+
+	if err != nil {
+		o.output.Fatal(err, "Could not prune images in repository %s", o.repositoryName)
+		return
+	}
+
+	inUse := imagesInUse(repository, images, references)
+	prunable := o.prunable(images, inUse)
+
+	if len(prunable) == 0 {
+		o.output.Info("No images to prune in repository %s", o.repositoryName)
+		return
+	}
+
+	plan := &changePlan{DryRun: o.dryRun}
+	digests := []string{}
+
+	for _, image := range prunable {
+		var changes []string
+
+		if len(image.Tags) > 0 {
+			changes = append(changes, "tags: "+strings.Join(image.Tags, ", "))
+		}
+
+		plan.Steps = append(plan.Steps,
+			changeStep{
+				API:      "ecr",
+				Action:   "BatchDeleteImage",
+				Resource: "image",
+				Name:     repository.URI + "@" + image.Digest,
+				Changes:  changes,
+			},
+		)
+		digests = append(digests, image.Digest)
+	}
+
+	if o.dryRun {
+		plan.display(o.output)
+		return
+	}
+
+	for _, step := range plan.Steps {
+		o.output.Debug("%s", step.String())
+	}
+
+	if err := o.ecr.DeleteImages(o.repositoryName, digests); err != nil {
+		o.output.Fatal(err, "Could not prune images in repository %s", o.repositoryName)
+		return
+	}
+
+	o.output.Info("Pruned %d images from repository %s", len(digests), o.repositoryName)
+}
+
+var imagePruneFlags struct {
+	keep      int
+	olderThan string
+}
+
+var imagePruneCmd = &cobra.Command{
+	Use:   "prune <service or task group name>",
+	Short: "Remove old images",
+	Long: `Remove old images
+
+Deletes images from the repository of a service or task group, keeping the
+most recently pushed images. By default the 20 most recent images are kept; use
+--keep to change how many. Pass --older-than to only delete images pushed
+before a given age, as a number of days (e.g. 30d) or a duration (e.g. 12h).
+
+Images referred to by any active task definition, or by a task definition
+deployed by any service in the cluster, by digest or by tag, are never deleted,
+so services can always be rolled back to a previous revision. If any of these
+task definitions cannot be described, no images are deleted. Use --dry-run to
+see which images would be deleted.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		operation := imagePruneOperation{
+			dryRun:         dryRun,
+			ecr:            ECR.New(sess),
+			ecs:            ECS.New(sess, clusterName),
+			keep:           imagePruneFlags.keep,
+			now:            time.Now(),
+			output:         output,
+			repositoryName: args[0],
+		}
+
+		if err := operation.setOlderThan(imagePruneFlags.olderThan); err != nil {
+			output.Fatal(err, "Invalid command line flags")
+			return
+		}
+
+		if err := operation.validate(); err != nil {
+			output.Fatal(err, "Invalid command line flags")
+			return
+		}
+
+		operation.execute()
+	},
+}
+
+func init() {
+	imagePruneCmd.Flags().IntVar(&imagePruneFlags.keep, "keep", defaultImagePruneKeep, "Number of most recently pushed images to keep")
+	imagePruneCmd.Flags().StringVar(&imagePruneFlags.olderThan, "older-than", "", "Only delete images pushed before this age [e.g. 30d, 12h]")
+
+	imageCmd.AddCommand(imagePruneCmd)
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/awslabs/fargatecli/cmd/mock"
+	ECR "github.com/awslabs/fargatecli/ecr"
+	ecrclient "github.com/awslabs/fargatecli/ecr/mock/client"
+	ecsclient "github.com/awslabs/fargatecli/ecs/mock/client"
+	"github.com/golang/mock/gomock"
+)
+
+var (
+	pruneNow    = time.Date(2020, 4, 30, 12, 0, 0, 0, time.UTC)
+	pruneImages = ECR.Images{
+		ECR.Image{Digest: testDigestC, PushedAt: pruneNow.Add(-40 * 24 * time.Hour), Tags: []string{"abc1234"}},
+		ECR.Image{Digest: testDigestA, PushedAt: pruneNow.Add(-time.Hour), Tags: []string{"def5678", "latest"}},
+		ECR.Image{Digest: testDigestB, PushedAt: pruneNow.Add(-10 * 24 * time.Hour), Tags: []string{}},
+	}
+)
+
+func TestImagePruneOperationSetOlderThan(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected time.Duration
+		err      bool
+	}{
+		{"", 0, false},
+		{"30d", 30 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"1w", 0, true},
+		{"d", 0, true},
+	}
+
+	for _, test := range tests {
+		operation := imagePruneOperation{}
+		err := operation.setOlderThan(test.input)
+
+		if test.err != (err != nil) {
+			t.Errorf("%s: expected error %t, got %v", test.input, test.err, err)
+		}
+
+		if operation.olderThan != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.input, test.expected, operation.olderThan)
+		}
+	}
+}
+
+func TestImagePruneOperationValidate(t *testing.T) {
+	if err := (imagePruneOperation{keep: -1}).validate(); err == nil {
+		t.Errorf("expected error for negative keep, got none")
+	}
+
+	if err := (imagePruneOperation{olderThan: -time.Hour}).validate(); err == nil {
+		t.Errorf("expected error for negative age, got none")
+	}
+
+	if err := (imagePruneOperation{keep: 20, olderThan: time.Hour}).validate(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestImagePruneOperationPrunable(t *testing.T) {
+	var tests = []struct {
+		keep      int
+		olderThan time.Duration
+		inUse     map[string]bool
+		expected  []string
+	}{
+		{0, 0, nil, []string{testDigestA, testDigestB, testDigestC}},
+		{1, 0, nil, []string{testDigestB, testDigestC}},
+		{1, 0, map[string]bool{testDigestC: true}, []string{testDigestB}},
+		{0, 30 * 24 * time.Hour, nil, []string{testDigestC}},
+		{5, 0, nil, nil},
+	}
+
+	for _, test := range tests {
+		images := append(ECR.Images{}, pruneImages...)
+		prunable := imagePruneOperation{keep: test.keep, now: pruneNow, olderThan: test.olderThan}.prunable(images, test.inUse)
+
+		if len(prunable) != len(test.expected) {
+			t.Errorf("keep %d, older than %s: expected %d images, got %d", test.keep, test.olderThan, len(test.expected), len(prunable))
+			continue
+		}
+
+		for n, digest := range test.expected {
+			if prunable[n].Digest != digest {
+				t.Errorf("keep %d, older than %s: expected image %d to be %s, got %s", test.keep, test.olderThan, n, digest, prunable[n].Digest)
+			}
+		}
+	}
+}
+
+func TestImagePruneOperation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECR := ecrclient.NewMockClient(mockCtrl)
+	mockECS := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	gomock.InOrder(
+		mockECR.EXPECT().DescribeRepository("web").Return(ECR.Repository{Name: "web", URI: testRepositoryURI}, nil),
+		mockECR.EXPECT().ListImages("web").Return(append(ECR.Images{}, pruneImages...), nil),
+		mockECS.EXPECT().ListActiveTaskDefinitionImages().Return([]string{testRepositoryURI + ":abc1234"}, nil),
+		mockECR.EXPECT().DeleteImages("web", []string{testDigestB}).Return(nil),
+	)
+
+	imagePruneOperation{
+		ecr:            mockECR,
+		ecs:            mockECS,
+		keep:           1,
+		now:            pruneNow,
+		output:         mockOutput,
+		repositoryName: "web",
+	}.execute()
+
+	if len(mockOutput.FatalMsgs) > 0 {
+		t.Fatalf("expected no fatal errors, got %v", mockOutput.FatalMsgs)
+	}
+
+	if len(mockOutput.InfoMsgs) != 1 || mockOutput.InfoMsgs[0] != "Pruned 1 images from repository web" {
+		t.Errorf("expected pruned message, got %v", mockOutput.InfoMsgs)
+	}
+}
+
+func TestImagePruneOperationDryRun(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECR := ecrclient.NewMockClient(mockCtrl)
+	mockECS := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockECR.EXPECT().DescribeRepository("web").Return(ECR.Repository{Name: "web", URI: testRepositoryURI}, nil)
+	mockECR.EXPECT().ListImages("web").Return(append(ECR.Images{}, pruneImages...), nil)
+	mockECS.EXPECT().ListActiveTaskDefinitionImages().Return([]string{}, nil)
+
+	imagePruneOperation{
+		dryRun:         true,
+		ecr:            mockECR,
+		ecs:            mockECS,
+		keep:           2,
+		now:            pruneNow,
+		output:         mockOutput,
+		repositoryName: "web",
+	}.execute()
+
+	if len(mockOutput.Documents) != 1 {
+		t.Fatalf("expected 1 document, got %d", len(mockOutput.Documents))
+	}
+
+	plan := mockOutput.Documents[0].(*changePlan)
+
+	if len(plan.Steps) != 1 || plan.Steps[0].Action != "BatchDeleteImage" || plan.Steps[0].Name != testRepositoryURI+"@"+testDigestC {
+		t.Fatalf("expected BatchDeleteImage step, got %+v", plan.Steps)
+	}
+
+	if len(plan.Steps[0].Changes) != 1 || plan.Steps[0].Changes[0] != "tags: abc1234" {
+		t.Errorf("expected tags change, got %v", plan.Steps[0].Changes)
+	}
+}
+
+func TestImagePruneOperationNothingToPrune(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECR := ecrclient.NewMockClient(mockCtrl)
+	mockECS := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockECR.EXPECT().DescribeRepository("web").Return(ECR.Repository{Name: "web", URI: testRepositoryURI}, nil)
+	mockECR.EXPECT().ListImages("web").Return(append(ECR.Images{}, pruneImages...), nil)
+	mockECS.EXPECT().ListActiveTaskDefinitionImages().Return([]string{}, nil)
+
+	imagePruneOperation{
+		ecr:            mockECR,
+		ecs:            mockECS,
+		keep:           defaultImagePruneKeep,
+		now:            pruneNow,
+		output:         mockOutput,
+		repositoryName: "web",
+	}.execute()
+
+	if len(mockOutput.InfoMsgs) != 1 || mockOutput.InfoMsgs[0] != "No images to prune in repository web" {
+		t.Errorf("expected nothing to prune message, got %v", mockOutput.InfoMsgs)
+	}
+}
+
+func TestImagePruneOperationError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECR := ecrclient.NewMockClient(mockCtrl)
+	mockECS := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockECR.EXPECT().DescribeRepository("web").Return(ECR.Repository{Name: "web", URI: testRepositoryURI}, nil)
+	mockECR.EXPECT().ListImages("web").Return(append(ECR.Images{}, pruneImages...), nil)
+	mockECS.EXPECT().ListActiveTaskDefinitionImages().Return(nil, errors.New("throttled"))
+
+	imagePruneOperation{
+		ecr:            mockECR,
+		ecs:            mockECS,
+		output:         mockOutput,
+		repositoryName: "web",
+	}.execute()
+
+	if !mockOutput.Exited {
+		t.Fatalf("expected exit, didn't")
+	}
+
+	if mockOutput.FatalMsgs[0].Msg != "Could not prune images in repository web" {
+		t.Errorf("unexpected fatal message %s", mockOutput.FatalMsgs[0].Msg)
+	}
+}
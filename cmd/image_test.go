@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	ECR "github.com/awslabs/fargatecli/ecr"
+)
+
+const (
+	testRepositoryURI = "123456789012.dkr.ecr.us-east-1.amazonaws.com/web"
+	testDigestA       = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	testDigestB       = "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	testDigestC       = "sha256:cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"
+)
+
+func TestRepositoryOptions(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "fargate-test")
+	defer os.RemoveAll(dir)
+
+	policy := filepath.Join(dir, "policy.json")
+	invalid := filepath.Join(dir, "invalid.json")
+	ioutil.WriteFile(policy, []byte(`{"rules":[]}`), 0600)
+	ioutil.WriteFile(invalid, []byte(`{"rules":`), 0600)
+
+	options, errs := repositoryFlagValues{lifecyclePolicy: policy, scanOnPush: true}.repositoryOptions()
+
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	if expected := (ECR.RepositoryOptions{LifecyclePolicy: `{"rules":[]}`, ScanOnPush: true}); options != expected {
+		t.Errorf("expected %+v, got %+v", expected, options)
+	}
+
+	for _, path := range []string{invalid, filepath.Join(dir, "missing.json")} {
+		if _, errs := (repositoryFlagValues{lifecyclePolicy: path}).repositoryOptions(); len(errs) != 1 {
+			t.Errorf("%s: expected 1 error, got %v", path, errs)
+		}
+	}
+}
+
+func TestRepositoryChanges(t *testing.T) {
+	if changes := repositoryChanges(ECR.RepositoryOptions{}); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+
+	changes := repositoryChanges(ECR.RepositoryOptions{LifecyclePolicy: "{}", ScanOnPush: true})
+
+	if expected := []string{"scan on push: enabled", "lifecycle policy: set"}; !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected %v, got %v", expected, changes)
+	}
+}
+
+func TestImagesInUse(t *testing.T) {
+	repository := ECR.Repository{Name: "web", URI: testRepositoryURI}
+	images := ECR.Images{
+		ECR.Image{Digest: testDigestA, Tags: []string{"abc1234"}},
+		ECR.Image{Digest: testDigestB, Tags: []string{"latest"}},
+		ECR.Image{Digest: testDigestC, Tags: []string{"def5678"}},
+	}
+	references := []string{
+		testRepositoryURI + "@" + testDigestA,
+		testRepositoryURI,
+		"123456789012.dkr.ecr.us-east-1.amazonaws.com/worker:def5678",
+		"nginx:latest",
+	}
+
+	inUse := imagesInUse(repository, images, references)
+
+	if expected := map[string]bool{testDigestA: true, testDigestB: true}; !reflect.DeepEqual(inUse, expected) {
+		t.Errorf("expected %v, got %v", expected, inUse)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/awslabs/fargatecli/acm"
 	"github.com/awslabs/fargatecli/ec2"
@@ -12,6 +13,7 @@ import (
 type lbCreateOperation struct {
 	certificateARNs []string
 	certificateOperation
+	dryRun   bool
 	elbv2    elbv2.Client
 	lbType   string
 	lbScheme string
@@ -122,7 +124,62 @@ func (o lbCreateOperation) validate() (errs []error) {
 	return
 }
 
+func (o lbCreateOperation) plan() *changePlan {
+	plan := &changePlan{DryRun: true}
+	changes := []string{
+		fmt.Sprintf("type: %s", o.lbType),
+		fmt.Sprintf("subnets: %s", strings.Join(o.subnetIDs, ", ")),
+	}
+
+	if o.lbScheme != "" {
+		changes = append(changes, fmt.Sprintf("scheme: %s", o.lbScheme))
+	}
+
+	if len(o.securityGroupIDs) > 0 {
+		changes = append(changes, fmt.Sprintf("security groups: %s", strings.Join(o.securityGroupIDs, ", ")))
+	}
+
+	plan.Steps = append(plan.Steps,
+		changeStep{
+			API:      "elbv2",
+			Action:   "CreateLoadBalancer",
+			Resource: "load balancer",
+			Name:     o.lbName,
+			Changes:  changes,
+		},
+		changeStep{
+			API:      "elbv2",
+			Action:   "CreateTargetGroup",
+			Resource: "target group",
+			Name:     fmt.Sprintf(defaultTargetGroupFormat, o.lbName),
+			Changes:  []string{fmt.Sprintf("port: %s", o.ports[0]), fmt.Sprintf("vpc: %s", o.vpcID)},
+		},
+	)
+
+	for _, port := range o.ports {
+		step := changeStep{
+			API:      "elbv2",
+			Action:   "CreateListener",
+			Resource: "listener",
+			Name:     port.String(),
+		}
+
+		for _, certificateARN := range o.certificateARNs {
+			step.Changes = append(step.Changes, fmt.Sprintf("certificate: %s", certificateARN))
+		}
+
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	return plan
+}
+
 func (o lbCreateOperation) execute() {
+	if o.dryRun {
+		o.plan().display(o.output)
+		return
+	}
+
 	defaultTargetGroupName := fmt.Sprintf(defaultTargetGroupFormat, o.lbName)
 
 	loadBalancerARN, err := o.elbv2.CreateLoadBalancer(
@@ -190,6 +247,7 @@ func newLBCreateOperation(
 ) (operation lbCreateOperation, errors []error) {
 	operation = lbCreateOperation{
 		certificateOperation: certificateOperation{acm: acm, output: output},
+		dryRun:               dryRun,
 		elbv2:                elbv2,
 		lbName:               lbName,
 		lbScheme:             lbScheme,
@@ -235,9 +293,10 @@ func newLBCreateOperation(
 }
 
 var lbCreateCmd = &cobra.Command{
-	Use:   "create <load-balancer-name> --port <port-expression>",
-	Args:  cobra.ExactArgs(1),
-	Short: "Create a load balancer",
+	Use:         "create <load-balancer-name> --port <port-expression>",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
+	Short:       "Create a load balancer",
 	Long: `Create a load balancer
 
 At least one port must be specified for the load balancer listener via the
@@ -1074,3 +1074,48 @@ func TestNewLBCreateOperationCertificateError(t *testing.T) {
 		t.Errorf("expected error %s, got: %v", expected, errs)
 	}
 }
+
+func TestLBCreateOperationDryRun(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockELBV2Client := elbv2client.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	operation := lbCreateOperation{
+		certificateARNs: []string{"arn:aws:acm:us-east-1:123456789012:certificate/1"},
+		dryRun:          true,
+		vpcOperation: vpcOperation{
+			subnetIDs: []string{"subnet-1234567", "subnet-abcdef8"},
+			vpcID:     "vpc-1234567",
+		},
+		elbv2:  mockELBV2Client,
+		lbType: "application",
+		lbName: "web",
+		output: mockOutput,
+		ports:  []Port{Port{80, "HTTP"}, Port{443, "HTTPS"}},
+	}
+
+	operation.execute()
+
+	if len(mockOutput.Documents) != 1 {
+		t.Fatalf("expected 1 document, got %d", len(mockOutput.Documents))
+	}
+
+	plan := mockOutput.Documents[0].(*changePlan)
+	var actions []string
+
+	for _, step := range plan.Steps {
+		actions = append(actions, step.Action)
+	}
+
+	expected := []string{"CreateLoadBalancer", "CreateTargetGroup", "CreateListener", "CreateListener"}
+
+	if !reflect.DeepEqual(actions, expected) {
+		t.Errorf("expected actions %v, got %v", expected, actions)
+	}
+
+	if expected, got := []string{"Dry run: the following 4 changes would be made"}, mockOutput.InfoMsgs; !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/awslabs/fargatecli/console"
 	ELBV2 "github.com/awslabs/fargatecli/elbv2"
 	"github.com/spf13/cobra"
 )
@@ -13,9 +12,10 @@ type LoadBalancerDestroyOperation struct {
 }
 
 var loadBalancerDestroyCmd = &cobra.Command{
-	Use:   "destroy <load-balancer-name>",
-	Short: "Destroy load balancer",
-	Args:  cobra.ExactArgs(1),
+	Use:         "destroy <load-balancer-name>",
+	Short:       "Destroy load balancer",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
 	Run: func(cmd *cobra.Command, args []string) {
 		operation := &LoadBalancerDestroyOperation{
 			LoadBalancerName: args[0],
@@ -31,8 +31,22 @@ func init() {
 
 func destroyLoadBalancer(operation *LoadBalancerDestroyOperation) {
 	elbv2 := ELBV2.New(sess)
+	plan := newChangePlan()
+	defaultTargetGroupName := fmt.Sprintf(defaultTargetGroupFormat, operation.LoadBalancerName)
 
-	elbv2.DeleteLoadBalancer(operation.LoadBalancerName)
-	elbv2.DeleteTargetGroup(fmt.Sprintf(defaultTargetGroupFormat, operation.LoadBalancerName))
-	console.Info("Destroyed load balancer %s", operation.LoadBalancerName)
+	plan.do(
+		changeStep{API: "elbv2", Action: "DeleteLoadBalancer", Resource: "load balancer", Name: operation.LoadBalancerName},
+		func() { elbv2.DeleteLoadBalancer(operation.LoadBalancerName) },
+	)
+
+	plan.do(
+		changeStep{API: "elbv2", Action: "DeleteTargetGroup", Resource: "target group", Name: defaultTargetGroupName},
+		func() { elbv2.DeleteTargetGroup(defaultTargetGroupName) },
+	)
+
+	if plan.display(output) {
+		return
+	}
+
+	output.Info("Destroyed load balancer %s", operation.LoadBalancerName)
 }
@@ -2,13 +2,10 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"sort"
 	"strings"
-	"text/tabwriter"
 
 	ACM "github.com/awslabs/fargatecli/acm"
-	"github.com/awslabs/fargatecli/console"
 	ECS "github.com/awslabs/fargatecli/ecs"
 	ELBV2 "github.com/awslabs/fargatecli/elbv2"
 	"github.com/spf13/cobra"
@@ -42,39 +39,48 @@ func getLoadBalancerInfo(operation *LbInfoOperation) {
 	loadBalancer := elbv2.DescribeLoadBalancer(operation.LoadBalancerName)
 	services := ecs.ListServices()
 
-	console.KeyValue("Load Balancer Name", "%s\n", loadBalancer.Name)
-	console.KeyValue("Status", "%s\n", Humanize(loadBalancer.Status))
-	console.KeyValue("Type", "%s\n", Humanize(loadBalancer.Type))
-	console.KeyValue("DNS Name", "%s\n", loadBalancer.DNSName)
-	console.KeyValue("Subnets", "%s\n", strings.Join(loadBalancer.SubnetIDs, ", "))
-	console.KeyValue("Security Groups", "%s\n", strings.Join(loadBalancer.SecurityGroupIDs, ", "))
-	console.KeyValue("Ports", "\n")
-
 	for _, listener := range elbv2.GetListeners(loadBalancer.ARN) {
-		var ruleCount int
+		rules := elbv2.DescribeRules(listener.ARN)
 
-		console.KeyValue("  "+listener.String(), "\n")
+		sort.Slice(rules, func(i, j int) bool { return rules[i].Priority > rules[j].Priority })
 
-		if len(listener.CertificateARNs) > 0 {
-			certificateDomains := acm.ListCertificateDomainNames(listener.CertificateARNs)
-			console.KeyValue("    Certificates", "%s\n", strings.Join(certificateDomains, ", "))
+		for _, rule := range rules {
+			if strings.Contains(rule.TargetGroupARN, fmt.Sprintf("/%s-default/", loadBalancer.Name)) {
+				continue
+			}
+
+			listener.Rules = append(listener.Rules, rule)
 		}
 
-		w := new(tabwriter.Writer)
-		w.Init(os.Stdout, 4, 2, 2, ' ', 0)
+		loadBalancer.Listeners = append(loadBalancer.Listeners, listener)
+	}
 
-		console.KeyValue("    Rules", "\n")
+	output.Document(loadBalancer)
+	output.KeyValue("Load Balancer Name", loadBalancer.Name, 0)
+	output.KeyValue("Status", Humanize(loadBalancer.Status), 0)
+	output.KeyValue("Type", Humanize(loadBalancer.Type), 0)
+	output.KeyValue("DNS Name", loadBalancer.DNSName, 0)
+	output.KeyValue("Subnets", strings.Join(loadBalancer.SubnetIDs, ", "), 0)
+	output.KeyValue("Security Groups", strings.Join(loadBalancer.SecurityGroupIDs, ", "), 0)
+	output.KeyValue("Ports", "", 0)
 
-		rules := elbv2.DescribeRules(listener.ARN)
+	for _, listener := range loadBalancer.Listeners {
+		output.KeyValue(listener.String(), "", 1)
 
-		sort.Slice(rules, func(i, j int) bool { return rules[i].Priority > rules[j].Priority })
+		if len(listener.CertificateARNs) > 0 {
+			certificateDomains := acm.ListCertificateDomainNames(listener.CertificateARNs)
+			output.KeyValue("Certificates", strings.Join(certificateDomains, ", "), 2)
+		}
 
-		for _, rule := range rules {
-			serviceName := fmt.Sprintf("Unknown (%s)", rule.TargetGroupARN)
+		output.KeyValue("Rules", "", 2)
 
-			if strings.Contains(rule.TargetGroupARN, fmt.Sprintf("/%s-default/", loadBalancer.Name)) {
-				continue
-			}
+		if len(listener.Rules) == 0 {
+			output.Say("None", 3)
+			continue
+		}
+
+		for _, rule := range listener.Rules {
+			serviceName := fmt.Sprintf("Unknown (%s)", rule.TargetGroupARN)
 
 			for _, service := range services {
 				if service.TargetGroupArn == rule.TargetGroupARN {
@@ -82,16 +88,7 @@ func getLoadBalancerInfo(operation *LbInfoOperation) {
 				}
 			}
 
-			fmt.Fprintf(w, "     %d\t%s\t%s\n", rule.Priority, rule.String(), serviceName)
-
-			ruleCount++
+			output.Say("%d  %s  %s", 3, rule.Priority, rule.String(), serviceName)
 		}
-
-		if ruleCount == 0 {
-			fmt.Println("      None")
-		}
-
-		w.Flush()
 	}
-
 }
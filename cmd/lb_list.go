@@ -24,6 +24,12 @@ func (o lbListOperation) execute() {
 		return
 	}
 
+	sort.Slice(loadBalancers, func(i, j int) bool {
+		return loadBalancers[i].Name < loadBalancers[j].Name
+	})
+
+	o.output.Document(loadBalancers)
+
 	if len(loadBalancers) == 0 {
 		o.output.Info("No load balancers found")
 		return
@@ -33,10 +39,6 @@ func (o lbListOperation) execute() {
 		[]string{"NAME", "TYPE", "STATUS", "DNS NAME", "PORTS"},
 	}
 
-	sort.Slice(loadBalancers, func(i, j int) bool {
-		return loadBalancers[i].Name < loadBalancers[j].Name
-	})
-
 	for _, loadBalancer := range loadBalancers {
 		rows = append(rows,
 			[]string{
@@ -59,6 +59,14 @@ func TestLBListOperation(t *testing.T) {
 		t.Fatalf("expected table, got none")
 	}
 
+	if len(mockOutput.Documents) != 1 {
+		t.Fatalf("expected document, got %d", len(mockOutput.Documents))
+	}
+
+	if document, ok := mockOutput.Documents[0].(elbv2.LoadBalancers); !ok || len(document) != 2 {
+		t.Errorf("expected document with 2 load balancers, got %+v", mockOutput.Documents[0])
+	}
+
 	if len(mockOutput.Tables[0].Rows) != 3 {
 		t.Errorf("expected table with 3 rows, got %d", len(mockOutput.Tables[0].Rows))
 	}
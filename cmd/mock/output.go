@@ -7,6 +7,7 @@ import (
 
 type Output struct {
 	DebugMsgs    []string
+	Documents    []interface{}
 	Exited       bool
 	FatalMsgs    []Fatal
 	InfoMsgs     []string
@@ -67,6 +68,13 @@ func (o *Output) Debug(msg string, a ...interface{}) {
 	o.DebugMsgs = append(o.DebugMsgs, fmt.Sprintf(msg, a...))
 }
 
+func (o *Output) Document(v interface{}) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.Documents = append(o.Documents, v)
+}
+
 func (o *Output) KeyValue(key, value string, indent int, a ...interface{}) {
 	o.lock.Lock()
 	defer o.lock.Unlock()
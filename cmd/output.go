@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -8,6 +9,13 @@ import (
 
 	"github.com/kyokomi/emoji"
 	"github.com/mgutz/ansi"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	outputFormatJSON  = "json"
+	outputFormatTable = "table"
+	outputFormatYAML  = "yaml"
 )
 
 var (
@@ -25,6 +33,7 @@ var (
 // Output represents a channel for sending messages to a user.
 type Output interface {
 	Debug(string, ...interface{})
+	Document(interface{})
 	Fatal(error, string, ...interface{})
 	Fatals([]error, string, ...interface{})
 	Info(string, ...interface{})
@@ -60,6 +69,11 @@ func (c ConsoleOutput) Debug(msg string, a ...interface{}) {
 	}
 }
 
+// Document is a no-op for console output as human-readable output is produced by the KeyValue,
+// Table, and Say methods.
+func (c ConsoleOutput) Document(v interface{}) {
+}
+
 // Say prints an optionally indented, formatted message followed by a line break to standard
 // output.
 func (c ConsoleOutput) Say(msg string, indent int, a ...interface{}) {
@@ -164,3 +178,104 @@ func (c ConsoleOutput) Table(header string, rows [][]string) {
 func (c ConsoleOutput) LineBreak() {
 	fmt.Print("\n")
 }
+
+// StructuredOutput implements a channel for sending machine-readable documents to a user over
+// standard output. Messages intended for humans are sent over standard error so that standard
+// output can be consumed by other programs.
+type StructuredOutput struct {
+	Format  string
+	Verbose bool
+	Test    bool
+}
+
+// Debug prints a formatted message to standard error if `Verbose` is set to `true`.
+func (s StructuredOutput) Debug(msg string, a ...interface{}) {
+	if s.Verbose {
+		fmt.Fprintf(os.Stderr, "[d] "+msg+"\n", a...)
+	}
+}
+
+// Document encodes a value in the configured format and prints it to standard output.
+func (s StructuredOutput) Document(v interface{}) {
+	var (
+		b   []byte
+		err error
+	)
+
+	switch s.Format {
+	case outputFormatYAML:
+		b, err = marshalYAML(v)
+	default:
+		b, err = json.MarshalIndent(v, "", "  ")
+		b = append(b, '\n')
+	}
+
+	if err != nil {
+		s.Fatal(err, "Could not encode output as %s", s.Format)
+		return
+	}
+
+	os.Stdout.Write(b)
+}
+
+// Info prints a formatted message to standard error.
+func (s StructuredOutput) Info(msg string, a ...interface{}) {
+	fmt.Fprintf(os.Stderr, "[i] "+msg+"\n", a...)
+}
+
+// Warn prints a formatted message to standard error.
+func (s StructuredOutput) Warn(msg string, a ...interface{}) {
+	fmt.Fprintf(os.Stderr, "[!] "+msg+"\n", a...)
+}
+
+// Fatal prints a formatted message and an error string to standard error.
+func (s StructuredOutput) Fatal(err error, msg string, a ...interface{}) {
+	s.Fatals([]error{err}, msg, a...)
+}
+
+// Fatals prints a formatted message and one or more error strings to standard error.
+func (s StructuredOutput) Fatals(errs []error, msg string, a ...interface{}) {
+	s.Warn(msg, a...)
+
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "    - %v\n", err)
+	}
+
+	if !s.Test {
+		os.Exit(1)
+	}
+}
+
+// KeyValue is a no-op for structured output; the data is emitted via Document.
+func (s StructuredOutput) KeyValue(key, value string, indent int, a ...interface{}) {
+}
+
+// LineBreak is a no-op for structured output.
+func (s StructuredOutput) LineBreak() {
+}
+
+// Say is a no-op for structured output; the data is emitted via Document.
+func (s StructuredOutput) Say(msg string, indent int, a ...interface{}) {
+}
+
+// Table is a no-op for structured output; the data is emitted via Document.
+func (s StructuredOutput) Table(header string, rows [][]string) {
+}
+
+// marshalYAML encodes a value as YAML using its JSON representation so that documents are keyed
+// consistently regardless of the format requested.
+func marshalYAML(v interface{}) ([]byte, error) {
+	var document interface{}
+
+	b, err := json.Marshal(v)
+
+	if err != nil {
+		return []byte{}, err
+	}
+
+	if err := json.Unmarshal(b, &document); err != nil {
+		return []byte{}, err
+	}
+
+	return yaml.Marshal(document)
+}
@@ -1,6 +1,11 @@
 package cmd
 
-import "errors"
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+)
 
 var consoleOutput = ConsoleOutput{
 	Color:   false,
@@ -81,3 +86,101 @@ func ExampleConsoleOutput_Table() {
 	// Jinglebell	House Frey
 	// Moon Boy	House Baratheon
 }
+
+func ExampleStructuredOutput_Document_json() {
+	structuredOutput := StructuredOutput{Format: outputFormatJSON, Test: true}
+	structuredOutput.Document(
+		[]struct {
+			Name       string `json:"name"`
+			Allegiance string `json:"allegiance"`
+		}{
+			{"Butterbumps", "House Tyrell"},
+			{"Moon Boy", "House Baratheon"},
+		},
+	)
+	// Output:
+	// [
+	//   {
+	//     "name": "Butterbumps",
+	//     "allegiance": "House Tyrell"
+	//   },
+	//   {
+	//     "name": "Moon Boy",
+	//     "allegiance": "House Baratheon"
+	//   }
+	// ]
+}
+
+func ExampleStructuredOutput_Document_yaml() {
+	structuredOutput := StructuredOutput{Format: outputFormatYAML, Test: true}
+	structuredOutput.Document(
+		struct {
+			Name       string   `json:"name"`
+			Allegiance string   `json:"allegiance"`
+			Titles     []string `json:"titles"`
+		}{
+			"Jinglebell",
+			"House Frey",
+			[]string{"Fool", "Jester"},
+		},
+	)
+	// Output:
+	// allegiance: House Frey
+	// name: Jinglebell
+	// titles:
+	// - Fool
+	// - Jester
+}
+
+func ExampleStructuredOutput_Table() {
+	structuredOutput := StructuredOutput{Format: outputFormatJSON, Test: true}
+	structuredOutput.KeyValue("Name", "Staten Island", 0)
+	structuredOutput.Table("Fools of Westeros", [][]string{{"NAME"}, {"Butterbumps"}})
+	structuredOutput.Say("Hi, my name is Werner Brandes.", 0)
+	// Output:
+}
+
+// captureOutput returns what fn writes to standard output and standard error.
+func captureOutput(t *testing.T, fn func()) ([]byte, []byte) {
+	var stdout, stderr []byte
+
+	stdoutReader, stdoutWriter, err := os.Pipe()
+
+	if err != nil {
+		t.Fatalf("could not create pipe: %v", err)
+	}
+
+	stderrReader, stderrWriter, err := os.Pipe()
+
+	if err != nil {
+		t.Fatalf("could not create pipe: %v", err)
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		stdout, _ = ioutil.ReadAll(stdoutReader)
+		done <- struct{}{}
+	}()
+
+	go func() {
+		stderr, _ = ioutil.ReadAll(stderrReader)
+		done <- struct{}{}
+	}()
+
+	originalStdout, originalStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdoutWriter, stderrWriter
+
+	defer func() {
+		os.Stdout, os.Stderr = originalStdout, originalStderr
+	}()
+
+	fn()
+
+	stdoutWriter.Close()
+	stderrWriter.Close()
+	<-done
+	<-done
+
+	return stdout, stderr
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+const (
+	dryRunAnnotation = "dryRun"
+
+	// knownAfterApply is displayed in place of identifiers which are only known once a change is made.
+	knownAfterApply = "(known after apply)"
+)
+
+// changeStep is a single AWS API action performed by an operation.
+type changeStep struct {
+	API      string   `json:"api"`
+	Action   string   `json:"action"`
+	Resource string   `json:"resource"`
+	Name     string   `json:"name"`
+	ARN      string   `json:"arn,omitempty"`
+	Changes  []string `json:"changes,omitempty"`
+}
+
+// String returns a friendly representation of a change step in the same format used for debug
+// output.
+func (s changeStep) String() string {
+	return fmt.Sprintf("%s %s [API=%s Action=%s]", Titleize(s.Resource), s.Name, s.API, s.Action)
+}
+
+// changePlan records the AWS API actions performed by an operation. When the plan is a dry run, the
+// actions are recorded but not performed so that the plan can be displayed instead.
+type changePlan struct {
+	DryRun bool         `json:"dryRun"`
+	Steps  []changeStep `json:"steps"`
+}
+
+func newChangePlan() *changePlan {
+	return &changePlan{DryRun: dryRun}
+}
+
+// do records a step and, unless the plan is a dry run, performs it.
+func (p *changePlan) do(step changeStep, action func()) {
+	p.Steps = append(p.Steps, step)
+
+	if !p.DryRun {
+		action()
+	}
+}
+
+// display writes the plan to the given output if it is a dry run, returning whether it did so.
+func (p *changePlan) display(output Output) bool {
+	if !p.DryRun {
+		return false
+	}
+
+	output.Document(p)
+
+	if len(p.Steps) == 0 {
+		output.Info("Dry run: no changes would be made")
+		return true
+	}
+
+	output.Info("Dry run: the following %d changes would be made", len(p.Steps))
+
+	for _, step := range p.Steps {
+		output.Say("%s", 1, step.String())
+
+		if step.ARN != "" {
+			output.Say("ARN: %s", 2, step.ARN)
+		}
+
+		for _, change := range step.Changes {
+			output.Say("- %s", 2, change)
+		}
+	}
+
+	return true
+}
+
+// diffField returns a field-level change description, or nothing if the values are equal.
+func diffField(name, from, to string) []string {
+	if from == to {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("%s: %s => %s", name, from, to)}
+}
+
+func dryRunSupported(annotations map[string]string) bool {
+	return annotations[dryRunAnnotation] == "true"
+}
+
+// envVarChanges returns the field-level changes made to a task definition's environment variables by
+// setting and unsetting the given variables.
+func envVarChanges(current, set []ECS.EnvVar, unset []string) (changes []string) {
+	values := make(map[string]string)
+
+	for _, envVar := range current {
+		values[envVar.Key] = envVar.Value
+	}
+
+	for _, envVar := range set {
+		if value, ok := values[envVar.Key]; !ok {
+			changes = append(changes, fmt.Sprintf("env: set %s", envVar.Key))
+		} else if value != envVar.Value {
+			changes = append(changes, fmt.Sprintf("env: change %s", envVar.Key))
+		}
+	}
+
+	for _, key := range unset {
+		if _, ok := values[key]; ok {
+			changes = append(changes, fmt.Sprintf("env: unset %s", key))
+		}
+	}
+
+	return
+}
+
+// kmsKeyChanges describes the KMS keys a task execution role is allowed to decrypt secrets with.
+func kmsKeyChanges(kmsKeys []string) (changes []string) {
+	for _, kmsKey := range kmsKeys {
+		changes = append(changes, fmt.Sprintf("kms key: allow decrypt %s", kmsKey))
+	}
+
+	return
+}
+
+// secretChanges describes the changes to a task definition's secrets from setting and unsetting the
+// given secrets.
+func secretChanges(current, set []ECS.Secret, unset []string) (changes []string) {
+	valueFroms := make(map[string]string)
+
+	for _, secret := range current {
+		valueFroms[secret.Key] = secret.ValueFrom
+	}
+
+	for _, secret := range set {
+		if valueFrom, ok := valueFroms[secret.Key]; !ok {
+			changes = append(changes, fmt.Sprintf("secret: set %s", secret.Key))
+		} else if valueFrom != secret.ValueFrom {
+			changes = append(changes, fmt.Sprintf("secret: change %s", secret.Key))
+		}
+	}
+
+	for _, key := range unset {
+		if _, ok := valueFroms[key]; ok {
+			changes = append(changes, fmt.Sprintf("secret: unset %s", key))
+		}
+	}
+
+	return
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/awslabs/fargatecli/cmd/mock"
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+func TestChangePlanDo(t *testing.T) {
+	var performed bool
+
+	plan := &changePlan{}
+	plan.do(changeStep{API: "ecs", Action: "CreateService", Resource: "service", Name: "web"}, func() { performed = true })
+
+	if !performed {
+		t.Errorf("expected step to be performed")
+	}
+
+	if len(plan.Steps) != 1 {
+		t.Errorf("expected 1 step, got %d", len(plan.Steps))
+	}
+}
+
+func TestChangePlanDoDryRun(t *testing.T) {
+	var performed bool
+
+	plan := &changePlan{DryRun: true}
+	plan.do(changeStep{API: "ecs", Action: "CreateService", Resource: "service", Name: "web"}, func() { performed = true })
+
+	if performed {
+		t.Errorf("expected step not to be performed")
+	}
+
+	if len(plan.Steps) != 1 {
+		t.Errorf("expected 1 step, got %d", len(plan.Steps))
+	}
+}
+
+func TestChangePlanDisplay(t *testing.T) {
+	mockOutput := &mock.Output{}
+	plan := &changePlan{
+		DryRun: true,
+		Steps: []changeStep{
+			changeStep{
+				API:      "ecs",
+				Action:   "RegisterTaskDefinition",
+				Resource: "task definition",
+				Name:     "web",
+				ARN:      "arn:aws:ecs:us-east-1:123456789012:task-definition/web:1",
+				Changes:  []string{"image: web:1 => web:2"},
+			},
+		},
+	}
+
+	if !plan.display(mockOutput) {
+		t.Fatalf("expected dry run plan to be displayed")
+	}
+
+	if len(mockOutput.Documents) != 1 {
+		t.Errorf("expected 1 document, got %d", len(mockOutput.Documents))
+	}
+
+	expected := []string{
+		"Task Definition web [API=ecs Action=RegisterTaskDefinition]",
+		"ARN: arn:aws:ecs:us-east-1:123456789012:task-definition/web:1",
+		"- image: web:1 => web:2",
+	}
+
+	if !reflect.DeepEqual(mockOutput.SayMsgs, expected) {
+		t.Errorf("expected %v, got %v", expected, mockOutput.SayMsgs)
+	}
+}
+
+func TestChangePlanDisplayNotDryRun(t *testing.T) {
+	mockOutput := &mock.Output{}
+	plan := &changePlan{Steps: []changeStep{changeStep{API: "ecs", Action: "CreateService"}}}
+
+	if plan.display(mockOutput) {
+		t.Errorf("expected plan not to be displayed")
+	}
+
+	if len(mockOutput.SayMsgs) != 0 || len(mockOutput.Documents) != 0 {
+		t.Errorf("expected no output, got %v %v", mockOutput.SayMsgs, mockOutput.Documents)
+	}
+}
+
+func TestDiffField(t *testing.T) {
+	if changes := diffField("cpu", "256", "256"); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+
+	if expected, got := []string{"cpu: 256 => 512"}, diffField("cpu", "256", "512"); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestEnvVarChanges(t *testing.T) {
+	current := []ECS.EnvVar{{Key: "A", Value: "1"}, {Key: "B", Value: "2"}}
+	set := []ECS.EnvVar{{Key: "A", Value: "1"}, {Key: "B", Value: "3"}, {Key: "C", Value: "4"}}
+	unset := []string{"A", "D"}
+	expected := []string{"env: change B", "env: set C", "env: unset A"}
+
+	if got := envVarChanges(current, set, unset); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestSecretChanges(t *testing.T) {
+	current := []ECS.Secret{{Key: "A", ValueFrom: "a"}, {Key: "B", ValueFrom: "b"}}
+	set := []ECS.Secret{{Key: "A", ValueFrom: "a"}, {Key: "B", ValueFrom: "/prod/b"}, {Key: "C", ValueFrom: "c"}}
+	unset := []string{"A", "D"}
+	expected := []string{"secret: change B", "secret: set C", "secret: unset A"}
+
+	if got := secretChanges(current, set, unset); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestDryRunSupported(t *testing.T) {
+	if dryRunSupported(nil) {
+		t.Errorf("expected dry run to be unsupported without annotation")
+	}
+
+	if !dryRunSupported(map[string]string{dryRunAnnotation: "true"}) {
+		t.Errorf("expected dry run to be supported with annotation")
+	}
+}
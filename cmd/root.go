@@ -1,10 +1,10 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
-	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -12,6 +12,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/awslabs/fargatecli/console"
 	ECS "github.com/awslabs/fargatecli/ecs"
+	IAM "github.com/awslabs/fargatecli/iam"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh/terminal"
 )
@@ -34,25 +35,16 @@ const (
 	describeRequestLimitRate = 10
 )
 
-var InvalidCpuAndMemoryCombination = fmt.Errorf(`Invalid CPU and Memory settings
-
-CPU (CPU Units)    Memory (MiB)
----------------    ------------
-256                512, 1024, or 2048
-512                1024 through 4096 in 1GiB increments
-1024               2048 through 8192 in 1GiB increments
-2048               4096 through 16384 in 1GiB increments
-4096               8192 through 30720 in 1GiB increments
-`)
-
 var (
-	clusterName string
-	noColor     bool
-	noEmoji     bool
-	output      ConsoleOutput
-	region      string
-	sess        *session.Session
-	verbose     bool
+	clusterName  string
+	dryRun       bool
+	noColor      bool
+	noEmoji      bool
+	output       Output
+	outputFormat string
+	region       string
+	sess         *session.Session
+	verbose      bool
 )
 
 var rootCmd = &cobra.Command{
@@ -67,29 +59,47 @@ including Amazon Elastic Container Service (ECS), Amazon Elastic Container
 Registry (ECR), Elastic Load Balancing, AWS Certificate Manager, Amazon
 CloudWatch Logs, and Amazon Route 53 into an easy-to-use CLI.`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		output = ConsoleOutput{}
+		consoleOutput := ConsoleOutput{}
+		output = consoleOutput
 
-		if cmd.Parent().Name() == "fargate" {
+		if cmd.Name() == "help" || !cmd.Parent().HasParent() && cmd.HasSubCommands() {
 			return
 		}
 
 		if verbose {
 			verbose = true
 			console.Verbose = true
-			output.Verbose = true
+			consoleOutput.Verbose = true
 		}
 
 		if terminal.IsTerminal(int(os.Stdout.Fd())) {
 			if !noColor {
 				console.Color = true
-				output.Color = true
+				consoleOutput.Color = true
 			}
 
 			if runtime.GOOS == runtimeMacOS && !noEmoji {
-				output.Emoji = true
+				consoleOutput.Emoji = true
 			}
 		}
 
+		switch strings.ToLower(outputFormat) {
+		case outputFormatTable:
+			output = consoleOutput
+		case outputFormatJSON, outputFormatYAML:
+			console.Color = false
+			output = StructuredOutput{Format: strings.ToLower(outputFormat), Verbose: verbose}
+		default:
+			consoleOutput.Fatal(
+				fmt.Errorf("--output must be one of %s, %s, or %s", outputFormatTable, outputFormatJSON, outputFormatYAML),
+				"Invalid command line flags",
+			)
+		}
+
+		if dryRun && !dryRunSupported(cmd.Annotations) {
+			output.Fatal(fmt.Errorf("--dry-run is not supported by %s", cmd.CommandPath()), "Invalid command line flags")
+		}
+
 		envAwsDefaultRegion := os.Getenv("AWS_DEFAULT_REGION")
 		envAwsRegion := os.Getenv("AWS_REGION")
 
@@ -125,10 +135,10 @@ CloudWatch Logs, and Amazon Route 53 into an easy-to-use CLI.`,
 			switch aerr.Code() {
 			case "NoCredentialProviders":
 				console.Issue("Could not find your AWS credentials")
-				console.Info("Your AWS credentials could not be found. Please configure your environment with your access key")
-				console.Info("   ID and secret access key using either the shared configuration file or environment variables.")
-				console.Info("   See http://docs.aws.amazon.com/sdk-for-go/v1/developer-guide/configuring-sdk.html#specifying-credentials")
-				console.Info("   for more details.")
+				output.Info("Your AWS credentials could not be found. Please configure your environment with your access key")
+				output.Info("   ID and secret access key using either the shared configuration file or environment variables.")
+				output.Info("   See http://docs.aws.amazon.com/sdk-for-go/v1/developer-guide/configuring-sdk.html#specifying-credentials")
+				output.Info("   for more details.")
 				console.Exit(1)
 			default:
 				console.ErrorExit(err, "Could not create create AWS session")
@@ -137,6 +147,11 @@ CloudWatch Logs, and Amazon Route 53 into an easy-to-use CLI.`,
 
 		if clusterName == "" {
 			clusterName = defaultClusterName
+
+			if dryRun {
+				return
+			}
+
 			ecs := ECS.New(sess, clusterName)
 
 			output.Debug("Creating default cluster [API=ecs Action=CreateCluster]")
@@ -162,6 +177,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&region, "region", "", `AWS region (default "us-east-1")`)
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable color output")
 	rootCmd.PersistentFlags().StringVar(&clusterName, "cluster", "", `ECS cluster name (default "fargate")`)
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Show the changes that would be made without making them")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputFormatTable, "Output format [table, json, yaml]")
 
 	if runtime.GOOS == runtimeMacOS {
 		rootCmd.PersistentFlags().BoolVar(&noEmoji, "no-emoji", false, "Disable emoji output")
@@ -193,45 +210,63 @@ func extractEnvVars(inputEnvVars []string) []ECS.EnvVar {
 	return envVars
 }
 
-func validateCpuAndMemory(inputCpuUnits, inputMebibytes string) error {
-	cpuUnits, err := strconv.ParseInt(inputCpuUnits, 10, 16)
+func extractSecrets(inputSecrets []string) []ECS.Secret {
+	var secrets []ECS.Secret
 
-	if err != nil {
-		return err
-	}
+	for _, inputSecret := range inputSecrets {
+		splitInputSecret := strings.SplitN(inputSecret, "=", 2)
 
-	mebibytes, err := strconv.ParseInt(inputMebibytes, 10, 16)
+		if len(splitInputSecret) != 2 || splitInputSecret[1] == "" {
+			console.ErrorExit(fmt.Errorf("%s must be in the form of KEY=parameter-name or KEY=arn", inputSecret), "Invalid secret")
+		}
 
-	if err != nil {
-		return err
+		secret := ECS.Secret{
+			Key:       strings.ToUpper(splitInputSecret[0]),
+			ValueFrom: splitInputSecret[1],
+		}
+
+		secrets = append(secrets, secret)
 	}
 
-	switch cpuUnits {
-	case 256:
-		if mebibytes == 512 || validateMebibytes(mebibytes, 1024, 2048) {
-			return nil
-		}
-	case 512:
-		if validateMebibytes(mebibytes, 1024, 4096) {
-			return nil
-		}
-	case 1024:
-		if validateMebibytes(mebibytes, 2048, 8192) {
-			return nil
-		}
-	case 2048:
-		if validateMebibytes(mebibytes, 4096, 16384) {
-			return nil
-		}
-	case 4096:
-		if validateMebibytes(mebibytes, 8192, 30720) {
-			return nil
+	return secrets
+}
+
+// validateKMSKeys returns an error describing each KMS key which is not a key ARN, as kms:Decrypt
+// can only be granted on keys by their ARN.
+func validateKMSKeys(kmsKeys []string) error {
+	var msgs []string
+
+	for _, kmsKey := range kmsKeys {
+		fields := strings.Split(kmsKey, ":")
+
+		if len(fields) != 6 || fields[0] != "arn" || fields[2] != "kms" || !strings.HasPrefix(fields[5], "key/") {
+			msgs = append(msgs, fmt.Sprintf("%s is not a KMS key ARN [e.g. arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab]", kmsKey))
 		}
 	}
 
-	return InvalidCpuAndMemoryCombination
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, ", "))
+	}
+
+	return nil
 }
 
-func validateMebibytes(mebibytes, min, max int64) bool {
-	return mebibytes >= min && mebibytes <= max && mebibytes%mebibytesInGibibyte == 0
+// grantSecretsAccess allows a task execution role to read the values of the given secrets and to
+// decrypt them with the given KMS keys.
+func grantSecretsAccess(executionRoleArn string, secrets []ECS.Secret, kmsKeys []string) {
+	var valueFroms []string
+
+	if len(secrets) == 0 && len(kmsKeys) == 0 {
+		return
+	}
+
+	iam := IAM.New(sess)
+
+	for _, secret := range secrets {
+		valueFroms = append(valueFroms, secret.ValueFrom)
+	}
+
+	if err := iam.GrantSecretsAccess(executionRoleArn, region, valueFroms, kmsKeys); err != nil {
+		console.ErrorExit(err, "Could not grant task execution role access to secrets")
+	}
 }
@@ -1,6 +1,13 @@
 package cmd
 
-import "testing"
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
 
 var validateCpuAndMemoryTests = []struct {
 	CpuUnits  string
@@ -43,6 +50,24 @@ var validateCpuAndMemoryTests = []struct {
 	{"4096", "30720", nil},
 	{"4096", "1024", InvalidCpuAndMemoryCombination},
 	{"4096", "31744", InvalidCpuAndMemoryCombination},
+
+	// 8 vCpu
+	{"8192", "16384", nil},
+	{"8192", "20480", nil},
+	{"8192", "61440", nil},
+	{"8192", "17408", InvalidCpuAndMemoryCombination},
+	{"8192", "65536", InvalidCpuAndMemoryCombination},
+
+	// 16 vCpu
+	{"16384", "32768", nil},
+	{"16384", "40960", nil},
+	{"16384", "122880", nil},
+	{"16384", "36864", InvalidCpuAndMemoryCombination},
+	{"16384", "131072", InvalidCpuAndMemoryCombination},
+
+	// Unsupported CPU units
+	{"3072", "8192", InvalidCpuAndMemoryCombination},
+	{"32768", "122880", InvalidCpuAndMemoryCombination},
 }
 
 func TestValidateCpuAndMemoryWithValidParameters(t *testing.T) {
@@ -65,8 +90,88 @@ func TestValidateCpuAndMemory(t *testing.T) {
 	for _, test := range validateCpuAndMemoryTests {
 		s := validateCpuAndMemory(test.CpuUnits, test.Mebibytes)
 
-		if s != test.Out {
+		if !errors.Is(s, test.Out) {
 			t.Errorf("validateCpuAndMemory(%s, %s) => %#v, want %s", test.CpuUnits, test.Mebibytes, s, test.Out)
 		}
 	}
 }
+
+func TestValidateCpuAndMemorySuggestions(t *testing.T) {
+	var tests = []struct {
+		CpuUnits   string
+		Mebibytes  string
+		Suggestion string
+	}{
+		{"1024", "3000", "did you mean 1024/3072?"},
+		{"1024", "1024", "did you mean 1024/2048?"},
+		{"256", "4096", "did you mean 256/2048?"},
+		{"8192", "18000", "did you mean 8192/16384?"},
+		{"3072", "8192", "did you mean 4096/8192?"},
+		{"3000", "6144", "did you mean 4096/8192?"},
+		{"5", "23849", "did you mean 4096/24576?"},
+		{"5", "512", "did you mean 256/512?"},
+		{"32768", "1024", "did you mean 16384/32768?"},
+	}
+
+	for _, test := range tests {
+		err := validateCpuAndMemory(test.CpuUnits, test.Mebibytes)
+
+		if got := cpuAndMemorySuggestion(err); got != test.Suggestion {
+			t.Errorf("validateCpuAndMemory(%s, %s) => %q, want %q", test.CpuUnits, test.Mebibytes, got, test.Suggestion)
+		}
+	}
+}
+
+func TestValidateCpuAndMemoryParsesLargeValues(t *testing.T) {
+	if err := validateCpuAndMemory("16384", "122880"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	if err := validateCpuAndMemory("256", "five"); err == nil || cpuAndMemorySuggestion(err) != "" {
+		t.Errorf("expected a parse error without a suggestion, got %v", err)
+	}
+}
+
+func TestCpuAndMemoryTable(t *testing.T) {
+	table := cpuAndMemoryTable()
+
+	for _, row := range []string{
+		"CPU (CPU Units)    Memory (MiB)\n---------------    ------------\n",
+		"256                512, 1024, or 2048\n",
+		"8192               16384 through 61440 in 4GiB increments\n",
+		"16384              32768 through 122880 in 8GiB increments\n",
+	} {
+		if !strings.Contains(table, row) {
+			t.Errorf("expected table to contain %q, got:\n%s", row, table)
+		}
+	}
+}
+
+func TestExtractSecrets(t *testing.T) {
+	secrets := extractSecrets(
+		[]string{
+			"db_password=/prod/db/password",
+			"API_KEY=arn:aws:secretsmanager:us-east-1:123456789012:secret:api-AbCdEf:key::",
+		},
+	)
+	expected := []ECS.Secret{
+		ECS.Secret{Key: "DB_PASSWORD", ValueFrom: "/prod/db/password"},
+		ECS.Secret{Key: "API_KEY", ValueFrom: "arn:aws:secretsmanager:us-east-1:123456789012:secret:api-AbCdEf:key::"},
+	}
+
+	if !reflect.DeepEqual(secrets, expected) {
+		t.Errorf("expected %v, got %v", expected, secrets)
+	}
+}
+
+func TestValidateKMSKeys(t *testing.T) {
+	if err := validateKMSKeys([]string{"arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	for _, kmsKey := range []string{"1234abcd-12ab-34cd-56ef-1234567890ab", "alias/secrets", "arn:aws:kms:us-east-1:123456789012:alias/secrets"} {
+		if err := validateKMSKeys([]string{kmsKey}); err == nil {
+			t.Errorf("expected error for %s, got none", kmsKey)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+const (
+	defaultEphemeralStorage = 20
+	maxEphemeralStorage     = 200
+	minEphemeralStorage     = 21
+	minWindowsCpuUnits      = 1024
+
+	platformOSLinux   = "linux"
+	platformOSWindows = "windows"
+)
+
+var operatingSystemFamilies = []string{
+	ECS.OperatingSystemFamilyLinux,
+	ECS.OperatingSystemFamilyWindowsServer2019Full,
+	ECS.OperatingSystemFamilyWindowsServer2019Core,
+	ECS.OperatingSystemFamilyWindowsServer2022Full,
+	ECS.OperatingSystemFamilyWindowsServer2022Core,
+}
+
+// platformArchitectures maps the architectures of Docker platforms to CPU architectures.
+var platformArchitectures = map[string]string{
+	"amd64":   ECS.CPUArchitectureX86_64,
+	"x86_64":  ECS.CPUArchitectureX86_64,
+	"arm64":   ECS.CPUArchitectureARM64,
+	"aarch64": ECS.CPUArchitectureARM64,
+}
+
+// inflateRuntimePlatform parses a Docker platform in the form of os/arch (e.g. linux/arm64) and an
+// operating system family (e.g. WINDOWS_SERVER_2019_CORE) into a runtime platform. Either can be
+// empty. A Windows platform requires a Windows operating system family.
+func inflateRuntimePlatform(platform, osFamily string) (ECS.RuntimePlatform, []error) {
+	var (
+		errs            []error
+		platformOS      string
+		runtimePlatform ECS.RuntimePlatform
+	)
+
+	if platform != "" {
+		splitPlatform := strings.Split(strings.ToLower(platform), "/")
+
+		if len(splitPlatform) != 2 {
+			return runtimePlatform, []error{fmt.Errorf("platform %s must be in the form of os/arch [e.g. linux/arm64]", platform)}
+		}
+
+		platformOS = splitPlatform[0]
+
+		if platformOS != platformOSLinux && platformOS != platformOSWindows {
+			errs = append(errs, fmt.Errorf("Invalid platform OS %s [specify linux or windows]", splitPlatform[0]))
+		}
+
+		if architecture, ok := platformArchitectures[splitPlatform[1]]; ok {
+			runtimePlatform.CPUArchitecture = architecture
+		} else {
+			errs = append(errs, fmt.Errorf("Invalid platform architecture %s [specify amd64 or arm64]", splitPlatform[1]))
+		}
+
+		if platformOS == platformOSLinux {
+			runtimePlatform.OperatingSystemFamily = ECS.OperatingSystemFamilyLinux
+		}
+	}
+
+	if osFamily != "" {
+		osFamily = strings.ToUpper(osFamily)
+
+		if !validOperatingSystemFamily(osFamily) {
+			errs = append(errs, fmt.Errorf("Invalid OS family %s [specify %s]", osFamily, strings.Join(operatingSystemFamilies, ", ")))
+		}
+
+		if runtimePlatform.OperatingSystemFamily != "" && runtimePlatform.OperatingSystemFamily != osFamily {
+			errs = append(errs, fmt.Errorf("OS family %s does not match platform %s", osFamily, platform))
+		}
+
+		runtimePlatform.OperatingSystemFamily = osFamily
+	}
+
+	if platformOS == platformOSWindows && !runtimePlatform.IsWindows() {
+		errs = append(errs, fmt.Errorf("platform %s requires a Windows OS family [e.g. --os-family %s]", platform, ECS.OperatingSystemFamilyWindowsServer2019Core))
+	}
+
+	if runtimePlatform.IsWindows() {
+		if runtimePlatform.CPUArchitecture == ECS.CPUArchitectureARM64 {
+			errs = append(errs, fmt.Errorf("Windows tasks must run on %s", ECS.CPUArchitectureX86_64))
+		}
+
+		runtimePlatform.CPUArchitecture = ECS.CPUArchitectureX86_64
+	}
+
+	return runtimePlatform, errs
+}
+
+func validOperatingSystemFamily(osFamily string) bool {
+	for _, family := range operatingSystemFamilies {
+		if osFamily == family {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateEphemeralStorage checks that an amount of ephemeral storage in GiB is supported by Fargate.
+// Zero uses the default of 20 GiB.
+func validateEphemeralStorage(gibibytes int64) error {
+	if gibibytes == 0 || gibibytes >= minEphemeralStorage && gibibytes <= maxEphemeralStorage {
+		return nil
+	}
+
+	return fmt.Errorf("Invalid ephemeral storage %d GiB [specify %d through %d]", gibibytes, minEphemeralStorage, maxEphemeralStorage)
+}
+
+// validateRuntimePlatform checks that the CPU units are supported by the runtime platform. Windows
+// tasks need at least 1 vCPU.
+func validateRuntimePlatform(runtimePlatform ECS.RuntimePlatform, inputCpuUnits string) error {
+	if !runtimePlatform.IsWindows() {
+		return nil
+	}
+
+	cpuUnits, err := strconv.ParseInt(inputCpuUnits, 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	if cpuUnits < minWindowsCpuUnits {
+		return fmt.Errorf("Windows tasks require at least %d CPU units", minWindowsCpuUnits)
+	}
+
+	return nil
+}
+
+// validateBuildPlatform checks that an image can be built for the runtime platform when no image is
+// given. Windows images cannot be built on the platforms fargate supports building on.
+func validateBuildPlatform(runtimePlatform ECS.RuntimePlatform, image string) error {
+	if image == "" && runtimePlatform.IsWindows() {
+		return errors.New("Windows images cannot be built by fargate [specify an image with --image]")
+	}
+
+	return nil
+}
+
+// dockerPlatform returns the Docker platform to build images for a runtime platform, or an empty
+// string to build for the platform Docker runs on.
+func dockerPlatform(runtimePlatform ECS.RuntimePlatform) string {
+	switch {
+	case runtimePlatform.IsWindows():
+		return platformOSWindows + "/amd64"
+	case runtimePlatform.CPUArchitecture == ECS.CPUArchitectureARM64:
+		return platformOSLinux + "/arm64"
+	case runtimePlatform.CPUArchitecture == ECS.CPUArchitectureX86_64:
+		return platformOSLinux + "/amd64"
+	}
+
+	return ""
+}
+
+// runtimePlatformString returns the operating system family and CPU architecture of a runtime
+// platform, using the Fargate defaults for empty values.
+func runtimePlatformString(runtimePlatform ECS.RuntimePlatform) string {
+	osFamily, architecture := runtimePlatform.OperatingSystemFamily, runtimePlatform.CPUArchitecture
+
+	if osFamily == "" {
+		osFamily = ECS.OperatingSystemFamilyLinux
+	}
+
+	if architecture == "" {
+		architecture = ECS.CPUArchitectureX86_64
+	}
+
+	return osFamily + "/" + architecture
+}
+
+// ephemeralStorageString returns an amount of ephemeral storage, using the Fargate default for zero.
+func ephemeralStorageString(gibibytes int64) string {
+	if gibibytes == 0 {
+		gibibytes = defaultEphemeralStorage
+	}
+
+	return fmt.Sprintf("%d GiB", gibibytes)
+}
+
+// runtimePlatformChanges describes the ephemeral storage and runtime platform of a task definition.
+func runtimePlatformChanges(gibibytes int64, runtimePlatform ECS.RuntimePlatform) (changes []string) {
+	if gibibytes != 0 {
+		changes = append(changes, fmt.Sprintf("ephemeral storage: %s", ephemeralStorageString(gibibytes)))
+	}
+
+	if runtimePlatform.CPUArchitecture != "" {
+		changes = append(changes, fmt.Sprintf("cpu architecture: %s", runtimePlatform.CPUArchitecture))
+	}
+
+	if runtimePlatform.OperatingSystemFamily != "" {
+		changes = append(changes, fmt.Sprintf("os family: %s", runtimePlatform.OperatingSystemFamily))
+	}
+
+	return
+}
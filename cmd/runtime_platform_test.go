@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+func TestInflateRuntimePlatform(t *testing.T) {
+	var tests = []struct {
+		platform        string
+		osFamily        string
+		runtimePlatform ECS.RuntimePlatform
+	}{
+		{"linux/arm64", "", ECS.RuntimePlatform{CPUArchitecture: "ARM64", OperatingSystemFamily: "LINUX"}},
+		{"Linux/AMD64", "", ECS.RuntimePlatform{CPUArchitecture: "X86_64", OperatingSystemFamily: "LINUX"}},
+		{"linux/aarch64", "linux", ECS.RuntimePlatform{CPUArchitecture: "ARM64", OperatingSystemFamily: "LINUX"}},
+		{"", "windows_server_2019_core", ECS.RuntimePlatform{CPUArchitecture: "X86_64", OperatingSystemFamily: "WINDOWS_SERVER_2019_CORE"}},
+		{"windows/amd64", "WINDOWS_SERVER_2022_FULL", ECS.RuntimePlatform{CPUArchitecture: "X86_64", OperatingSystemFamily: "WINDOWS_SERVER_2022_FULL"}},
+		{"", "LINUX", ECS.RuntimePlatform{OperatingSystemFamily: "LINUX"}},
+	}
+
+	for _, test := range tests {
+		runtimePlatform, errs := inflateRuntimePlatform(test.platform, test.osFamily)
+
+		if len(errs) > 0 {
+			t.Errorf("%s %s: expected no errors, got %v", test.platform, test.osFamily, errs)
+			continue
+		}
+
+		if !reflect.DeepEqual(test.runtimePlatform, runtimePlatform) {
+			t.Errorf("%s %s: expected %+v, got %+v", test.platform, test.osFamily, test.runtimePlatform, runtimePlatform)
+		}
+	}
+}
+
+func TestInflateRuntimePlatformErrors(t *testing.T) {
+	var tests = []struct {
+		platform string
+		osFamily string
+	}{
+		{"arm64", ""},
+		{"linux/arm64/v8", ""},
+		{"darwin/arm64", ""},
+		{"linux/386", ""},
+		{"", "WINDOWS_SERVER_2016_FULL"},
+		{"linux/amd64", "WINDOWS_SERVER_2019_CORE"},
+		{"windows/amd64", ""},
+		{"windows/arm64", "WINDOWS_SERVER_2019_CORE"},
+	}
+
+	for _, test := range tests {
+		if _, errs := inflateRuntimePlatform(test.platform, test.osFamily); len(errs) == 0 {
+			t.Errorf("%s %s: expected errors, got none", test.platform, test.osFamily)
+		}
+	}
+}
+
+func TestValidateEphemeralStorage(t *testing.T) {
+	for _, gibibytes := range []int64{0, 21, 100, 200} {
+		if err := validateEphemeralStorage(gibibytes); err != nil {
+			t.Errorf("%d: expected no error, got %v", gibibytes, err)
+		}
+	}
+
+	for _, gibibytes := range []int64{-1, 20, 201} {
+		if err := validateEphemeralStorage(gibibytes); err == nil {
+			t.Errorf("%d: expected error, got none", gibibytes)
+		}
+	}
+}
+
+func TestValidateRuntimePlatform(t *testing.T) {
+	windows := ECS.RuntimePlatform{CPUArchitecture: "X86_64", OperatingSystemFamily: "WINDOWS_SERVER_2019_CORE"}
+
+	if err := validateRuntimePlatform(windows, "1024"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	if err := validateRuntimePlatform(windows, "512"); err == nil {
+		t.Error("expected error for Windows with 512 CPU units, got none")
+	}
+
+	if err := validateRuntimePlatform(ECS.RuntimePlatform{CPUArchitecture: "ARM64"}, "256"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	if err := validateBuildPlatform(windows, ""); err == nil {
+		t.Error("expected error building a Windows image, got none")
+	}
+
+	if err := validateBuildPlatform(windows, "mcr.microsoft.com/windows/servercore/iis"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestDockerPlatform(t *testing.T) {
+	var tests = []struct {
+		runtimePlatform ECS.RuntimePlatform
+		platform        string
+	}{
+		{ECS.RuntimePlatform{}, ""},
+		{ECS.RuntimePlatform{OperatingSystemFamily: "LINUX"}, ""},
+		{ECS.RuntimePlatform{CPUArchitecture: "ARM64", OperatingSystemFamily: "LINUX"}, "linux/arm64"},
+		{ECS.RuntimePlatform{CPUArchitecture: "X86_64"}, "linux/amd64"},
+		{ECS.RuntimePlatform{CPUArchitecture: "X86_64", OperatingSystemFamily: "WINDOWS_SERVER_2019_CORE"}, "windows/amd64"},
+	}
+
+	for _, test := range tests {
+		if got := dockerPlatform(test.runtimePlatform); got != test.platform {
+			t.Errorf("%+v: expected %q, got %q", test.runtimePlatform, test.platform, got)
+		}
+	}
+}
+
+func TestRuntimePlatformString(t *testing.T) {
+	if expected, got := "LINUX/X86_64", runtimePlatformString(ECS.RuntimePlatform{}); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if expected, got := "LINUX/ARM64", runtimePlatformString(ECS.RuntimePlatform{CPUArchitecture: "ARM64"}); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if expected, got := "20 GiB", ephemeralStorageString(0); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,523 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	AAS "github.com/awslabs/fargatecli/applicationautoscaling"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	ELBV2 "github.com/awslabs/fargatecli/elbv2"
+	"github.com/spf13/cobra"
+)
+
+const (
+	autoscaleTargetCPU      = "cpu"
+	autoscaleTargetMemory   = "memory"
+	autoscaleTargetRequests = "requests"
+
+	autoscalePolicyNameFormat = "fargate-%s"
+	scalingActivitiesLimit    = 5
+)
+
+var autoscaleTargetMetrics = map[string]string{
+	autoscaleTargetCPU:      AAS.MetricCPUUtilization,
+	autoscaleTargetMemory:   AAS.MetricMemoryUtilization,
+	autoscaleTargetRequests: AAS.MetricRequestCountPerTarget,
+}
+
+// serviceAutoscalingDocument describes the autoscaling configuration and recent activity of a
+// service.
+type serviceAutoscalingDocument struct {
+	AAS.ScalableTarget
+	Activities       AAS.ScalingActivities `json:"activities"`
+	Policies         AAS.ScalingPolicies   `json:"policies"`
+	ScheduledActions AAS.ScheduledActions  `json:"scheduledActions"`
+}
+
+type autoscaleChange struct {
+	step  changeStep
+	apply func() error
+}
+
+type serviceAutoscaleOperation struct {
+	autoscaling     AAS.Client
+	disable         bool
+	dryRun          bool
+	max             *int64
+	min             *int64
+	output          Output
+	removeSchedules []string
+	removeTargets   []string
+	resourceID      string
+	resourceLabel   string
+	schedules       []AAS.ScheduledAction
+	serviceName     string
+	targets         map[string]float64
+}
+
+func (o *serviceAutoscaleOperation) setSchedules(inputSchedules []string) error {
+	for _, inputSchedule := range inputSchedules {
+		schedule, err := inflateScheduledAction(inputSchedule)
+
+		if err != nil {
+			return err
+		}
+
+		o.schedules = append(o.schedules, schedule)
+	}
+
+	return nil
+}
+
+func (o serviceAutoscaleOperation) validate() error {
+	changes := o.min != nil || o.max != nil || len(o.targets) > 0 || len(o.schedules) > 0 ||
+		len(o.removeSchedules) > 0 || len(o.removeTargets) > 0
+
+	if o.disable && changes {
+		return fmt.Errorf("--disable cannot be combined with other autoscaling flags")
+	}
+
+	if !o.disable && !changes {
+		return fmt.Errorf("no autoscaling changes specified")
+	}
+
+	if o.min != nil && *o.min < 0 {
+		return fmt.Errorf("--min must be 0 or greater, got %d", *o.min)
+	}
+
+	if o.max != nil && *o.max < 1 {
+		return fmt.Errorf("--max must be 1 or greater, got %d", *o.max)
+	}
+
+	if o.min != nil && o.max != nil && *o.min > *o.max {
+		return fmt.Errorf("--min (%d) must not be greater than --max (%d)", *o.min, *o.max)
+	}
+
+	for target, value := range o.targets {
+		if value <= 0 || (target != autoscaleTargetRequests && value > 100) {
+			return fmt.Errorf("%s target %g is out of range", target, value)
+		}
+	}
+
+	for _, target := range o.removeTargets {
+		if _, ok := autoscaleTargetMetrics[target]; !ok {
+			return fmt.Errorf("invalid target %s to remove [specify cpu, memory, or requests]", target)
+		}
+
+		if _, ok := o.targets[target]; ok {
+			return fmt.Errorf("target %s cannot be both set and removed", target)
+		}
+	}
+
+	for _, schedule := range o.schedules {
+		if schedule.MinCapacity > schedule.MaxCapacity {
+			return fmt.Errorf("schedule %s min (%d) must not be greater than max (%d)", schedule.Name, schedule.MinCapacity, schedule.MaxCapacity)
+		}
+	}
+
+	return nil
+}
+
+func (o serviceAutoscaleOperation) execute() {
+	o.output.Debug("Describing scalable target [API=applicationautoscaling Action=DescribeScalableTargets ResourceId=%s]", o.resourceID)
+	scalableTargets, err := o.autoscaling.DescribeScalableTargets(o.resourceID)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not describe autoscaling of service %s", o.serviceName)
+		return
+	}
+
+	changes, err := o.changes(scalableTargets)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not configure autoscaling of service %s", o.serviceName)
+		return
+	}
+
+	if o.dryRun {
+		plan := &changePlan{DryRun: true}
+
+		for _, change := range changes {
+			plan.Steps = append(plan.Steps, change.step)
+		}
+
+		plan.display(o.output)
+		return
+	}
+
+	for _, change := range changes {
+		o.output.Debug("%s", change.step.String())
+
+		if err := change.apply(); err != nil {
+			o.output.Fatal(err, "Could not configure autoscaling of service %s", o.serviceName)
+			return
+		}
+	}
+
+	if o.disable {
+		if len(changes) == 0 {
+			o.output.Info("Autoscaling is not enabled for service %s", o.serviceName)
+		} else {
+			o.output.Info("Disabled autoscaling for service %s", o.serviceName)
+		}
+
+		return
+	}
+
+	o.output.Info("Configured autoscaling for service %s", o.serviceName)
+}
+
+// changes returns the changes required to configure autoscaling given the service's currently
+// registered scalable targets.
+func (o serviceAutoscaleOperation) changes(scalableTargets AAS.ScalableTargets) ([]autoscaleChange, error) {
+	var changes []autoscaleChange
+
+	registered := len(scalableTargets) > 0
+
+	if o.disable {
+		if registered {
+			changes = append(changes,
+				autoscaleChange{
+					step: changeStep{
+						API:      "applicationautoscaling",
+						Action:   "DeregisterScalableTarget",
+						Resource: "scalable target",
+						Name:     o.resourceID,
+					},
+					apply: func() error { return o.autoscaling.DeregisterScalableTarget(o.resourceID) },
+				},
+			)
+		}
+
+		return changes, nil
+	}
+
+	if !registered && (o.min == nil || o.max == nil) {
+		return changes, fmt.Errorf("--min and --max are required to enable autoscaling")
+	}
+
+	if o.min != nil || o.max != nil {
+		var current AAS.ScalableTarget
+
+		if registered {
+			current = scalableTargets[0]
+		}
+
+		min, max := current.MinCapacity, current.MaxCapacity
+
+		if o.min != nil {
+			min = *o.min
+		}
+
+		if o.max != nil {
+			max = *o.max
+		}
+
+		if min > max {
+			return changes, fmt.Errorf("min (%d) must not be greater than max (%d)", min, max)
+		}
+
+		step := changeStep{
+			API:      "applicationautoscaling",
+			Action:   "RegisterScalableTarget",
+			Resource: "scalable target",
+			Name:     o.resourceID,
+		}
+
+		if registered {
+			step.Changes = append(
+				diffField("min", strconv.FormatInt(current.MinCapacity, 10), strconv.FormatInt(min, 10)),
+				diffField("max", strconv.FormatInt(current.MaxCapacity, 10), strconv.FormatInt(max, 10))...,
+			)
+		} else {
+			step.Changes = []string{fmt.Sprintf("min: %d", min), fmt.Sprintf("max: %d", max)}
+		}
+
+		changes = append(changes,
+			autoscaleChange{
+				step: step,
+				apply: func() error {
+					return o.autoscaling.RegisterScalableTarget(
+						AAS.RegisterScalableTargetParameters{
+							MaxCapacity: o.max,
+							MinCapacity: o.min,
+							ResourceID:  o.resourceID,
+						},
+					)
+				},
+			},
+		)
+	}
+
+	for _, target := range []string{autoscaleTargetCPU, autoscaleTargetMemory, autoscaleTargetRequests} {
+		value, ok := o.targets[target]
+
+		if !ok {
+			continue
+		}
+
+		parameters := AAS.PutTargetTrackingScalingPolicyParameters{
+			Metric:      autoscaleTargetMetrics[target],
+			PolicyName:  fmt.Sprintf(autoscalePolicyNameFormat, target),
+			ResourceID:  o.resourceID,
+			TargetValue: value,
+		}
+
+		if target == autoscaleTargetRequests {
+			parameters.ResourceLabel = o.resourceLabel
+		}
+
+		changes = append(changes,
+			autoscaleChange{
+				step: changeStep{
+					API:      "applicationautoscaling",
+					Action:   "PutScalingPolicy",
+					Resource: "scaling policy",
+					Name:     parameters.PolicyName,
+					Changes:  []string{fmt.Sprintf("target: %s %g", parameters.Metric, value)},
+				},
+				apply: func() error {
+					_, err := o.autoscaling.PutTargetTrackingScalingPolicy(parameters)
+					return err
+				},
+			},
+		)
+	}
+
+	for _, target := range o.removeTargets {
+		policyName := fmt.Sprintf(autoscalePolicyNameFormat, target)
+
+		changes = append(changes,
+			autoscaleChange{
+				step: changeStep{
+					API:      "applicationautoscaling",
+					Action:   "DeleteScalingPolicy",
+					Resource: "scaling policy",
+					Name:     policyName,
+				},
+				apply: func() error { return o.autoscaling.DeleteScalingPolicy(o.resourceID, policyName) },
+			},
+		)
+	}
+
+	for _, schedule := range o.schedules {
+		parameters := AAS.PutScheduledActionParameters{
+			MaxCapacity: schedule.MaxCapacity,
+			MinCapacity: schedule.MinCapacity,
+			Name:        schedule.Name,
+			ResourceID:  o.resourceID,
+			Schedule:    schedule.Schedule,
+		}
+
+		changes = append(changes,
+			autoscaleChange{
+				step: changeStep{
+					API:      "applicationautoscaling",
+					Action:   "PutScheduledAction",
+					Resource: "scheduled action",
+					Name:     schedule.Name,
+					Changes: []string{
+						fmt.Sprintf("schedule: %s", schedule.Schedule),
+						fmt.Sprintf("min: %d", schedule.MinCapacity),
+						fmt.Sprintf("max: %d", schedule.MaxCapacity),
+					},
+				},
+				apply: func() error { return o.autoscaling.PutScheduledAction(parameters) },
+			},
+		)
+	}
+
+	for _, name := range o.removeSchedules {
+		name := name
+
+		changes = append(changes,
+			autoscaleChange{
+				step: changeStep{
+					API:      "applicationautoscaling",
+					Action:   "DeleteScheduledAction",
+					Resource: "scheduled action",
+					Name:     name,
+				},
+				apply: func() error { return o.autoscaling.DeleteScheduledAction(o.resourceID, name) },
+			},
+		)
+	}
+
+	return changes, nil
+}
+
+// inflateScheduledAction parses a schedule expression in the form of name:expression:min:max. The
+// expression may itself contain colons, e.g. at(2019-11-28T08:00:00).
+func inflateScheduledAction(inputSchedule string) (AAS.ScheduledAction, error) {
+	var scheduledAction AAS.ScheduledAction
+
+	fields := strings.Split(inputSchedule, ":")
+
+	if len(fields) < 4 {
+		return scheduledAction, fmt.Errorf("schedule %s must be in the form of name:expression:min:max", inputSchedule)
+	}
+
+	min, minErr := strconv.ParseInt(fields[len(fields)-2], 10, 64)
+	max, maxErr := strconv.ParseInt(fields[len(fields)-1], 10, 64)
+
+	if minErr != nil || maxErr != nil || min < 0 || max < 0 {
+		return scheduledAction, fmt.Errorf("schedule %s must have numeric min and max capacities", inputSchedule)
+	}
+
+	scheduledAction = AAS.ScheduledAction{
+		MaxCapacity: max,
+		MinCapacity: min,
+		Name:        fields[0],
+		Schedule:    strings.Join(fields[1:len(fields)-2], ":"),
+	}
+
+	if scheduledAction.Name == "" || scheduledAction.Schedule == "" {
+		return scheduledAction, fmt.Errorf("schedule %s must be in the form of name:expression:min:max", inputSchedule)
+	}
+
+	return scheduledAction, nil
+}
+
+// describeServiceAutoscaling returns the autoscaling configuration of a service, or nil if
+// autoscaling is not enabled.
+func describeServiceAutoscaling(autoscaling AAS.Client, resourceID string) (*serviceAutoscalingDocument, error) {
+	scalableTargets, err := autoscaling.DescribeScalableTargets(resourceID)
+
+	if err != nil || len(scalableTargets) == 0 {
+		return nil, err
+	}
+
+	document := &serviceAutoscalingDocument{ScalableTarget: scalableTargets[0]}
+
+	if document.Policies, err = autoscaling.DescribeScalingPolicies(resourceID); err != nil {
+		return nil, err
+	}
+
+	if document.ScheduledActions, err = autoscaling.DescribeScheduledActions(resourceID); err != nil {
+		return nil, err
+	}
+
+	if document.Activities, err = autoscaling.DescribeScalingActivities(resourceID, scalingActivitiesLimit); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+var serviceAutoscaleFlags struct {
+	disable         bool
+	max             int64
+	min             int64
+	removeSchedules []string
+	removeTargets   []string
+	schedules       []string
+	targetCPU       float64
+	targetMemory    float64
+	targetRequests  float64
+}
+
+var serviceAutoscaleCmd = &cobra.Command{
+	Use:   "autoscale <service-name>",
+	Short: "Configure service autoscaling",
+	Long: `Configure service autoscaling
+
+Registers the service with Application Auto Scaling so that the number of tasks
+it runs is adjusted automatically between a minimum and maximum. The minimum
+and maximum number of tasks are set via the --min and --max flags, both of
+which are required when autoscaling is first enabled.
+
+Target tracking policies add or remove tasks to keep a metric near a target
+value. Use --target-cpu and --target-memory to track the average CPU and memory
+utilization of the service's tasks as a percentage, and --target-requests to
+track the number of requests per task received from the service's Application
+Load Balancer. Remove a policy by passing --remove-target with cpu, memory, or
+requests.
+
+Scheduled actions change the minimum and maximum number of tasks at given
+times. Pass --schedule with an expression in the form of name:expression:min:max
+where expression is an at(), rate(), or cron() expression in UTC, e.g.
+business-hours:cron(0 8 ? * MON-FRI *):4:20. Remove a scheduled action by
+passing --remove-schedule with its name.
+
+Pass --disable to deregister the service from Application Auto Scaling,
+removing all of its policies and scheduled actions. The current autoscaling
+configuration and recent scaling activity are shown by fargate service info.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		operation := serviceAutoscaleOperation{
+			autoscaling:     AAS.New(sess),
+			disable:         serviceAutoscaleFlags.disable,
+			dryRun:          dryRun,
+			output:          output,
+			removeSchedules: serviceAutoscaleFlags.removeSchedules,
+			removeTargets:   Map(serviceAutoscaleFlags.removeTargets, strings.ToLower),
+			resourceID:      AAS.ServiceResourceID(clusterName, args[0]),
+			serviceName:     args[0],
+			targets:         make(map[string]float64),
+		}
+
+		if cmd.Flags().Changed("min") {
+			operation.min = &serviceAutoscaleFlags.min
+		}
+
+		if cmd.Flags().Changed("max") {
+			operation.max = &serviceAutoscaleFlags.max
+		}
+
+		if cmd.Flags().Changed("target-cpu") {
+			operation.targets[autoscaleTargetCPU] = serviceAutoscaleFlags.targetCPU
+		}
+
+		if cmd.Flags().Changed("target-memory") {
+			operation.targets[autoscaleTargetMemory] = serviceAutoscaleFlags.targetMemory
+		}
+
+		if cmd.Flags().Changed("target-requests") {
+			operation.targets[autoscaleTargetRequests] = serviceAutoscaleFlags.targetRequests
+		}
+
+		if err := operation.setSchedules(serviceAutoscaleFlags.schedules); err != nil {
+			output.Fatal(err, "Invalid command line flags")
+			return
+		}
+
+		if err := operation.validate(); err != nil {
+			output.Fatal(err, "Invalid command line flags")
+			return
+		}
+
+		if _, ok := operation.targets[autoscaleTargetRequests]; ok {
+			ecs := ECS.New(sess, clusterName)
+			elbv2 := ELBV2.New(sess)
+			service := ecs.DescribeService(args[0])
+
+			if service.TargetGroupArn == "" {
+				output.Fatal(fmt.Errorf("service %s has no load balancer", args[0]), "Invalid command line flags")
+				return
+			}
+
+			operation.resourceLabel = AAS.RequestCountResourceLabel(
+				elbv2.GetTargetGroupLoadBalancerArn(service.TargetGroupArn),
+				service.TargetGroupArn,
+			)
+		}
+
+		operation.execute()
+	},
+}
+
+func init() {
+	serviceAutoscaleCmd.Flags().Int64Var(&serviceAutoscaleFlags.min, "min", 1, "Minimum number of tasks")
+	serviceAutoscaleCmd.Flags().Int64Var(&serviceAutoscaleFlags.max, "max", 1, "Maximum number of tasks")
+	serviceAutoscaleCmd.Flags().Float64Var(&serviceAutoscaleFlags.targetCPU, "target-cpu", 0, "Target average CPU utilization percentage")
+	serviceAutoscaleCmd.Flags().Float64Var(&serviceAutoscaleFlags.targetMemory, "target-memory", 0, "Target average memory utilization percentage")
+	serviceAutoscaleCmd.Flags().Float64Var(&serviceAutoscaleFlags.targetRequests, "target-requests", 0, "Target number of load balancer requests per task")
+	serviceAutoscaleCmd.Flags().StringSliceVar(&serviceAutoscaleFlags.removeTargets, "remove-target", []string{}, "Target tracking policy to remove [cpu, memory, requests] (can be specified multiple times)")
+	serviceAutoscaleCmd.Flags().StringArrayVar(&serviceAutoscaleFlags.schedules, "schedule", []string{}, "Scheduled action to set [e.g. business-hours:cron(0 8 ? * MON-FRI *):4:20] (can be specified multiple times)")
+	serviceAutoscaleCmd.Flags().StringSliceVar(&serviceAutoscaleFlags.removeSchedules, "remove-schedule", []string{}, "Name of a scheduled action to remove (can be specified multiple times)")
+	serviceAutoscaleCmd.Flags().BoolVar(&serviceAutoscaleFlags.disable, "disable", false, "Disable autoscaling, removing all policies and scheduled actions")
+
+	serviceCmd.AddCommand(serviceAutoscaleCmd)
+}
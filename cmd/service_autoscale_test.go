@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	AAS "github.com/awslabs/fargatecli/applicationautoscaling"
+	aasclient "github.com/awslabs/fargatecli/applicationautoscaling/mock/client"
+	"github.com/awslabs/fargatecli/cmd/mock"
+	"github.com/golang/mock/gomock"
+)
+
+const autoscaleResourceID = "service/fargate/web"
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func TestInflateScheduledAction(t *testing.T) {
+	var tests = []struct {
+		input  string
+		action AAS.ScheduledAction
+		err    bool
+	}{
+		{
+			"business-hours:cron(0 8 ? * MON-FRI *):4:20",
+			AAS.ScheduledAction{Name: "business-hours", Schedule: "cron(0 8 ? * MON-FRI *)", MinCapacity: 4, MaxCapacity: 20},
+			false,
+		},
+		{
+			"launch:at(2019-11-28T08:00:00):10:40",
+			AAS.ScheduledAction{Name: "launch", Schedule: "at(2019-11-28T08:00:00)", MinCapacity: 10, MaxCapacity: 40},
+			false,
+		},
+		{"night:cron(0 20 * * ? *):1", AAS.ScheduledAction{}, true},
+		{"night:cron(0 20 * * ? *):one:2", AAS.ScheduledAction{}, true},
+		{":cron(0 20 * * ? *):1:2", AAS.ScheduledAction{}, true},
+	}
+
+	for _, test := range tests {
+		action, err := inflateScheduledAction(test.input)
+
+		if (err != nil) != test.err {
+			t.Errorf("%s: expected error == %t, got %v", test.input, test.err, err)
+		}
+
+		if !test.err && action != test.action {
+			t.Errorf("%s: expected %+v, got %+v", test.input, test.action, action)
+		}
+	}
+}
+
+func TestServiceAutoscaleOperationValidate(t *testing.T) {
+	var tests = []struct {
+		operation serviceAutoscaleOperation
+		valid     bool
+	}{
+		{serviceAutoscaleOperation{min: int64Ptr(2), max: int64Ptr(20)}, true},
+		{serviceAutoscaleOperation{targets: map[string]float64{"cpu": 60}}, true},
+		{serviceAutoscaleOperation{disable: true}, true},
+		{serviceAutoscaleOperation{}, false},
+		{serviceAutoscaleOperation{disable: true, min: int64Ptr(1)}, false},
+		{serviceAutoscaleOperation{min: int64Ptr(5), max: int64Ptr(2)}, false},
+		{serviceAutoscaleOperation{max: int64Ptr(0)}, false},
+		{serviceAutoscaleOperation{targets: map[string]float64{"cpu": 150}}, false},
+		{serviceAutoscaleOperation{targets: map[string]float64{"requests": 1500}}, true},
+		{serviceAutoscaleOperation{removeTargets: []string{"disk"}}, false},
+		{serviceAutoscaleOperation{targets: map[string]float64{"cpu": 60}, removeTargets: []string{"cpu"}}, false},
+		{serviceAutoscaleOperation{schedules: []AAS.ScheduledAction{AAS.ScheduledAction{Name: "a", MinCapacity: 3, MaxCapacity: 1}}}, false},
+	}
+
+	for i, test := range tests {
+		if err := test.operation.validate(); (err == nil) != test.valid {
+			t.Errorf("test %d: expected valid == %t, got %v", i, test.valid, err)
+		}
+	}
+}
+
+func TestServiceAutoscaleOperation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := aasclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	gomock.InOrder(
+		mockClient.EXPECT().DescribeScalableTargets(autoscaleResourceID).Return(AAS.ScalableTargets{}, nil),
+		mockClient.EXPECT().RegisterScalableTarget(
+			AAS.RegisterScalableTargetParameters{
+				MaxCapacity: int64Ptr(20),
+				MinCapacity: int64Ptr(2),
+				ResourceID:  autoscaleResourceID,
+			},
+		).Return(nil),
+		mockClient.EXPECT().PutTargetTrackingScalingPolicy(
+			AAS.PutTargetTrackingScalingPolicyParameters{
+				Metric:      AAS.MetricCPUUtilization,
+				PolicyName:  "fargate-cpu",
+				ResourceID:  autoscaleResourceID,
+				TargetValue: 60,
+			},
+		).Return("arn:policy", nil),
+		mockClient.EXPECT().PutTargetTrackingScalingPolicy(
+			AAS.PutTargetTrackingScalingPolicyParameters{
+				Metric:        AAS.MetricRequestCountPerTarget,
+				PolicyName:    "fargate-requests",
+				ResourceID:    autoscaleResourceID,
+				ResourceLabel: "app/web/1/targetgroup/web/2",
+				TargetValue:   1000,
+			},
+		).Return("arn:policy", nil),
+		mockClient.EXPECT().PutScheduledAction(
+			AAS.PutScheduledActionParameters{
+				MaxCapacity: 20,
+				MinCapacity: 4,
+				Name:        "business-hours",
+				ResourceID:  autoscaleResourceID,
+				Schedule:    "cron(0 8 ? * MON-FRI *)",
+			},
+		).Return(nil),
+	)
+
+	serviceAutoscaleOperation{
+		autoscaling:   mockClient,
+		max:           int64Ptr(20),
+		min:           int64Ptr(2),
+		output:        mockOutput,
+		resourceID:    autoscaleResourceID,
+		resourceLabel: "app/web/1/targetgroup/web/2",
+		schedules: []AAS.ScheduledAction{
+			AAS.ScheduledAction{Name: "business-hours", Schedule: "cron(0 8 ? * MON-FRI *)", MinCapacity: 4, MaxCapacity: 20},
+		},
+		serviceName: "web",
+		targets:     map[string]float64{"cpu": 60, "requests": 1000},
+	}.execute()
+
+	if len(mockOutput.FatalMsgs) > 0 {
+		t.Fatalf("expected no fatal messages, got %v", mockOutput.FatalMsgs)
+	}
+
+	if expected, got := "Configured autoscaling for service web", mockOutput.InfoMsgs[0]; expected != got {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestServiceAutoscaleOperationRequiresMinAndMax(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := aasclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().DescribeScalableTargets(autoscaleResourceID).Return(AAS.ScalableTargets{}, nil)
+
+	serviceAutoscaleOperation{
+		autoscaling: mockClient,
+		output:      mockOutput,
+		resourceID:  autoscaleResourceID,
+		serviceName: "web",
+		targets:     map[string]float64{"cpu": 60},
+	}.execute()
+
+	if len(mockOutput.FatalMsgs) != 1 {
+		t.Fatalf("expected 1 fatal message, got %d", len(mockOutput.FatalMsgs))
+	}
+}
+
+func TestServiceAutoscaleOperationDryRun(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := aasclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().DescribeScalableTargets(autoscaleResourceID).Return(
+		AAS.ScalableTargets{AAS.ScalableTarget{MinCapacity: 2, MaxCapacity: 10, ResourceID: autoscaleResourceID}},
+		nil,
+	)
+
+	serviceAutoscaleOperation{
+		autoscaling:     mockClient,
+		dryRun:          true,
+		max:             int64Ptr(20),
+		output:          mockOutput,
+		removeSchedules: []string{"nightly"},
+		removeTargets:   []string{"memory"},
+		resourceID:      autoscaleResourceID,
+		serviceName:     "web",
+	}.execute()
+
+	if len(mockOutput.Documents) != 1 {
+		t.Fatalf("expected 1 document, got %d", len(mockOutput.Documents))
+	}
+
+	plan := mockOutput.Documents[0].(*changePlan)
+	actions := []string{}
+
+	for _, step := range plan.Steps {
+		actions = append(actions, step.Action)
+	}
+
+	if expected := []string{"RegisterScalableTarget", "DeleteScalingPolicy", "DeleteScheduledAction"}; !reflect.DeepEqual(expected, actions) {
+		t.Errorf("expected actions %v, got %v", expected, actions)
+	}
+
+	if expected := []string{"max: 10 => 20"}; !reflect.DeepEqual(expected, plan.Steps[0].Changes) {
+		t.Errorf("expected changes %v, got %v", expected, plan.Steps[0].Changes)
+	}
+}
+
+func TestServiceAutoscaleOperationDisable(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := aasclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().DescribeScalableTargets(autoscaleResourceID).Return(
+		AAS.ScalableTargets{AAS.ScalableTarget{MinCapacity: 2, MaxCapacity: 10, ResourceID: autoscaleResourceID}},
+		nil,
+	)
+	mockClient.EXPECT().DeregisterScalableTarget(autoscaleResourceID).Return(errors.New("boom"))
+
+	serviceAutoscaleOperation{
+		autoscaling: mockClient,
+		disable:     true,
+		output:      mockOutput,
+		resourceID:  autoscaleResourceID,
+		serviceName: "web",
+	}.execute()
+
+	if len(mockOutput.FatalMsgs) != 1 {
+		t.Fatalf("expected 1 fatal message, got %d", len(mockOutput.FatalMsgs))
+	}
+
+	if expected, got := "Could not configure autoscaling of service web", mockOutput.FatalMsgs[0].Msg; expected != got {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestDescribeServiceAutoscaling(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := aasclient.NewMockClient(mockCtrl)
+	policies := AAS.ScalingPolicies{AAS.ScalingPolicy{Name: "fargate-cpu", Metric: AAS.MetricCPUUtilization, TargetValue: 60}}
+
+	mockClient.EXPECT().DescribeScalableTargets(autoscaleResourceID).Return(
+		AAS.ScalableTargets{AAS.ScalableTarget{MinCapacity: 2, MaxCapacity: 10, ResourceID: autoscaleResourceID}},
+		nil,
+	)
+	mockClient.EXPECT().DescribeScalingPolicies(autoscaleResourceID).Return(policies, nil)
+	mockClient.EXPECT().DescribeScheduledActions(autoscaleResourceID).Return(AAS.ScheduledActions{}, nil)
+	mockClient.EXPECT().DescribeScalingActivities(autoscaleResourceID, int64(5)).Return(AAS.ScalingActivities{}, nil)
+
+	document, err := describeServiceAutoscaling(mockClient, autoscaleResourceID)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if document.MaxCapacity != 10 || !reflect.DeepEqual(document.Policies, policies) {
+		t.Errorf("unexpected document %+v", document)
+	}
+}
+
+func TestDescribeServiceAutoscalingNotEnabled(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := aasclient.NewMockClient(mockCtrl)
+
+	mockClient.EXPECT().DescribeScalableTargets(autoscaleResourceID).Return(AAS.ScalableTargets{}, nil)
+
+	if document, err := describeServiceAutoscaling(mockClient, autoscaleResourceID); document != nil || err != nil {
+		t.Errorf("expected no document and no error, got %v, %v", document, err)
+	}
+}
@@ -12,29 +12,46 @@ import (
 	ECR "github.com/awslabs/fargatecli/ecr"
 	ECS "github.com/awslabs/fargatecli/ecs"
 	ELBV2 "github.com/awslabs/fargatecli/elbv2"
-	"github.com/awslabs/fargatecli/git"
 	IAM "github.com/awslabs/fargatecli/iam"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 const typeService = "service"
 
 type ServiceCreateOperation struct {
-	Cpu              string
-	EnvVars          []ECS.EnvVar
-	Image            string
-	LoadBalancerArn  string
-	LoadBalancerName string
-	Memory           string
-	Num              int64
-	Port             Port
-	Rules            []ELBV2.Rule
-	SecurityGroupIds []string
-	ServiceName      string
-	SubnetIds        []string
-	TaskRole         string
-	TaskCommand      []string
-  AssignPublicIPEnabled bool
+	BuildOptions             docker.BuildOptions
+	CapacityProviderStrategy ECS.CapacityProviderStrategy
+	ContainerHealthCheck     *ECS.HealthCheck
+	Cpu                      string
+	DeregistrationDelay      *int64
+	EnableExec               bool
+	EnvVars                  []ECS.EnvVar
+	EphemeralStorage         int64
+	HealthCheck              ELBV2.HealthCheck
+	Image                    string
+	KMSKeys                  []string
+	LoadBalancerArn          string
+	LoadBalancerName         string
+	Memory                   string
+	Num                      int64
+	PinDigest                bool
+	Port                     Port
+	RepositoryOptions        ECR.RepositoryOptions
+	RequestedImage           string
+	Revision                 gitRevision
+	Rules                    []ELBV2.Rule
+	RuntimePlatform          ECS.RuntimePlatform
+	Secrets                  []ECS.Secret
+	SecurityGroupIds         []string
+	ServiceName              string
+	Sidecars                 []ECS.Container
+	SubnetIds                []string
+	TaskRole                 string
+	TaskCommand              []string
+	MountPoints              []ECS.MountPoint
+	Volumes                  []ECS.Volume
+	AssignPublicIPEnabled    bool
 }
 
 func (o *ServiceCreateOperation) SetPort(inputPort string) {
@@ -64,6 +81,18 @@ func (o *ServiceCreateOperation) Validate() {
 		console.ErrorExit(err, "Invalid settings: %s CPU units / %s MiB", o.Cpu, o.Memory)
 	}
 
+	if err := validateEphemeralStorage(o.EphemeralStorage); err != nil {
+		console.ErrorExit(err, "Invalid settings")
+	}
+
+	if err := validateRuntimePlatform(o.RuntimePlatform, o.Cpu); err != nil {
+		console.ErrorExit(err, "Invalid settings: %s CPU units on %s", o.Cpu, runtimePlatformString(o.RuntimePlatform))
+	}
+
+	if err := validateBuildPlatform(o.RuntimePlatform, o.Image); err != nil {
+		console.ErrorExit(err, "Invalid settings")
+	}
+
 	if o.Num < 1 {
 		console.ErrorExit(err, "Invalid number of tasks to keep running: %d, num must be > 1", o.Num)
 	}
@@ -94,24 +123,201 @@ func (o *ServiceCreateOperation) SetLoadBalancer(lb string) {
 }
 
 func (o *ServiceCreateOperation) SetRules(inputRules []string) {
-	var rules []ELBV2.Rule
 	var msgs []string
 
-	validRuleTypes := regexp.MustCompile(validRuleTypesPattern)
-
 	if len(inputRules) > 0 && o.LoadBalancerArn == "" {
 		msgs = append(msgs, "lb must be configured if rules are specified")
 	}
 
+	rules, errs := inflateRules(inputRules)
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid rule")
+	}
+
+	o.Rules = rules
+}
+
+func (o *ServiceCreateOperation) SetHealthCheck(healthCheck ELBV2.HealthCheck, deregistrationDelay *int64) {
+	if o.LoadBalancerArn == "" {
+		// Without a load balancer, the interval applies only to the container health check.
+		if o.ContainerHealthCheck != nil && deregistrationDelay == nil && healthCheck == (ELBV2.HealthCheck{IntervalSeconds: healthCheck.IntervalSeconds}) {
+			return
+		}
+
+		console.IssueExit("Setting a health check or deregistration delay requires a load balancer")
+	}
+
+	if err := validateHealthCheck(healthCheck, deregistrationDelay, o.Port.Protocol); err != nil {
+		console.ErrorExit(err, "Invalid health check")
+	}
+
+	o.HealthCheck = healthCheck
+	o.DeregistrationDelay = deregistrationDelay
+}
+
+func (o *ServiceCreateOperation) SetContainerHealthCheck(healthCheck ECS.HealthCheck) {
+	if healthCheck.Command == "" {
+		console.IssueExit("Setting container health check retries or start period requires --health-check-command")
+	}
+
+	if err := validateContainerHealthCheck(healthCheck); err != nil {
+		console.ErrorExit(err, "Invalid health check")
+	}
+
+	o.ContainerHealthCheck = &healthCheck
+}
+
+func (o *ServiceCreateOperation) SetSidecars(inputSidecars []string) {
+	var msgs []string
+
+	sidecars, errs := inflateSidecars(o.ServiceName, inputSidecars)
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid sidecar")
+	}
+
+	o.Sidecars = sidecars
+}
+
+func (o *ServiceCreateOperation) SetVolumes(inputVolumes, inputMountPoints []string) {
+	var msgs []string
+
+	volumes, errs := inflateVolumes(inputVolumes)
+	mountPoints, mountErrs := inflateMountPoints(inputMountPoints, volumes)
+
+	for _, err := range append(errs, mountErrs...) {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid volume")
+	}
+
+	o.Volumes = volumes
+	o.MountPoints = mountPoints
+}
+
+func (o *ServiceCreateOperation) SetCapacityProviderStrategy(inputStrategy string) {
+	strategy, err := inflateCapacityProviderStrategy(inputStrategy)
+
+	if err != nil {
+		console.ErrorExit(err, "Invalid capacity provider strategy")
+	}
+
+	o.CapacityProviderStrategy = strategy
+}
+
+func (o *ServiceCreateOperation) SetRuntimePlatform(inputPlatform, inputOSFamily string) {
+	var msgs []string
+
+	runtimePlatform, errs := inflateRuntimePlatform(inputPlatform, inputOSFamily)
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid platform")
+	}
+
+	o.RuntimePlatform = runtimePlatform
+}
+
+// SetRepositoryOptions sets the options to create the image repository with if it does not exist.
+func (o *ServiceCreateOperation) SetRepositoryOptions(values repositoryFlagValues) {
+	var msgs []string
+
+	options, errs := values.repositoryOptions()
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid repository options")
+	}
+
+	o.RepositoryOptions = options
+}
+
+// SetRevision sets the git revision of the current working directory to tag and label the image
+// built or pushed from it with.
+func (o *ServiceCreateOperation) SetRevision(values gitFlagValues) {
+	revision, err := newGitRevision(values)
+
+	if err != nil {
+		console.ErrorExit(err, "Invalid git working tree")
+	}
+
+	o.Revision = revision
+}
+
+// SetBuildOptions sets the options to build the image with when no image is given.
+func (o *ServiceCreateOperation) SetBuildOptions(values buildFlagValues) {
+	var msgs []string
+
+	options, errs := values.buildOptions(o.Image)
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid build options")
+	}
+
+	o.BuildOptions = options
+}
+
+func (o *ServiceCreateOperation) SetEnvVars(inputEnvVars []string) {
+	o.EnvVars = extractEnvVars(inputEnvVars)
+}
+
+func (o *ServiceCreateOperation) SetSecrets(inputSecrets []string) {
+	o.Secrets = extractSecrets(inputSecrets)
+}
+
+// SetKMSKeys sets the customer managed KMS keys the task execution role is allowed to decrypt
+// secrets with.
+func (o *ServiceCreateOperation) SetKMSKeys(inputKMSKeys []string) {
+	if err := validateKMSKeys(inputKMSKeys); err != nil {
+		console.ErrorExit(err, "Invalid KMS key")
+	}
+
+	o.KMSKeys = inputKMSKeys
+}
+
+func (o *ServiceCreateOperation) SetSecurityGroupIds(securityGroupIds []string) {
+	o.SecurityGroupIds = securityGroupIds
+}
+
+func inflateRules(inputRules []string) ([]ELBV2.Rule, []error) {
+	var (
+		errs  []error
+		rules []ELBV2.Rule
+	)
+
+	validRuleTypes := regexp.MustCompile(validRuleTypesPattern)
+
 	for _, inputRule := range inputRules {
 		splitInputRule := strings.SplitN(inputRule, "=", 2)
 
 		if len(splitInputRule) != 2 {
-			msgs = append(msgs, "rules must be in the form of type=value")
+			errs = append(errs, fmt.Errorf("rules must be in the form of type=value"))
+			continue
 		}
 
 		if !validRuleTypes.MatchString(splitInputRule[0]) {
-			msgs = append(msgs, fmt.Sprintf("Invalid rule type %s [must be path or host]", splitInputRule[0]))
+			errs = append(errs, fmt.Errorf("Invalid rule type %s [must be path or host]", splitInputRule[0]))
 		}
 
 		rules = append(rules,
@@ -122,36 +328,155 @@ func (o *ServiceCreateOperation) SetRules(inputRules []string) {
 		)
 	}
 
-	if len(msgs) > 0 {
-		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid rule")
-	}
+	return rules, errs
+}
 
-	o.Rules = rules
+// serviceCreateFlagValues holds the settings of the service and task definition fargate service
+// create is given on the command line.
+type serviceCreateFlagValues struct {
+	assignPublicIP           bool
+	build                    buildFlagValues
+	capacityProviderStrategy string
+	containerHealth          containerHealthCheckFlagValues
+	cpu                      string
+	enableExec               bool
+	envVars                  []string
+	ephemeralStorage         int64
+	git                      gitFlagValues
+	healthCheck              healthCheckFlagValues
+	image                    string
+	kmsKeys                  []string
+	lb                       string
+	memory                   string
+	mountPoints              []string
+	num                      int64
+	osFamily                 string
+	pinDigest                bool
+	platform                 string
+	port                     string
+	repository               repositoryFlagValues
+	rules                    []string
+	secrets                  []string
+	securityGroupIds         []string
+	sidecars                 []string
+	subnetIds                []string
+	taskCommand              []string
+	taskRole                 string
+	volumes                  []string
 }
 
-func (o *ServiceCreateOperation) SetEnvVars(inputEnvVars []string) {
-	o.EnvVars = extractEnvVars(inputEnvVars)
+// addServiceCreateFlags adds the flags which configure a service and its task definition to a flag
+// set.
+func addServiceCreateFlags(flags *pflag.FlagSet, values *serviceCreateFlagValues) {
+	flags.StringVarP(&values.cpu, "cpu", "c", "256", "Amount of cpu units to allocate for each task")
+	flags.StringVarP(&values.memory, "memory", "m", "512", "Amount of MiB to allocate for each task")
+	flags.Int64Var(&values.ephemeralStorage, "ephemeral-storage", 0, "Amount of GiB of ephemeral storage to allocate for each task [21 - 200] (default 20)")
+	flags.StringVar(&values.platform, "platform", "", "Platform to run tasks on and build the image for [e.g. linux/amd64, linux/arm64]")
+	flags.StringVar(&values.osFamily, "os-family", "", "Operating system family to run tasks on [e.g. LINUX, WINDOWS_SERVER_2019_CORE]")
+	flags.StringSliceVarP(&values.envVars, "env", "e", []string{}, "Environment variables to set [e.g. KEY=value] (can be specified multiple times)")
+	flags.StringSliceVar(&values.secrets, "secret", []string{}, "Secrets to set from SSM parameters or Secrets Manager secrets [e.g. KEY=parameter-name, KEY=arn] (can be specified multiple times)")
+	flags.StringSliceVar(&values.kmsKeys, "kms-key", []string{}, "ARN of a customer managed KMS key the task execution role may decrypt secrets with (can be specified multiple times)")
+	flags.StringArrayVar(&values.sidecars, "sidecar", []string{}, "Sidecar container to run alongside the service's container [e.g. name=proxy,image=envoyproxy/envoy,port=9901] (can be specified multiple times)")
+	flags.StringArrayVar(&values.volumes, "volume", []string{}, "EFS volume to define [e.g. uploads=efs:fs-12345678:/uploads,access-point=fsap-12345678,readonly] (can be specified multiple times)")
+	flags.StringArrayVar(&values.mountPoints, "mount", []string{}, "Volume to mount in the service's container [e.g. uploads:/var/uploads] (can be specified multiple times)")
+	flags.StringVarP(&values.port, "port", "p", "", "Port to listen on [e.g., 80, 443, http:8080, https:8443, tcp:1935]")
+	flags.StringVarP(&values.image, "image", "i", "", "Docker image to run in the service; if omitted Fargate will build an image from the Dockerfile in the current directory")
+	addBuildFlags(flags, &values.build)
+	addRepositoryFlags(flags, &values.repository)
+	addGitFlags(flags, &values.git)
+	flags.BoolVar(&values.pinDigest, "pin-digest", true, "Run images in Amazon ECR by the digest their tag refers to rather than by the tag")
+	flags.StringVarP(&values.lb, "lb", "l", "", "Name of a load balancer to use")
+	flags.StringSliceVarP(&values.rules, "rule", "r", []string{}, "Routing rule for the load balancer [e.g. host=api.example.com, path=/api/*]; if omitted service will be the default route (can be specified multiple times)")
+	addHealthCheckFlags(flags, &values.healthCheck)
+	addContainerHealthCheckFlags(flags, &values.containerHealth)
+	flags.Int64VarP(&values.num, "num", "n", 1, "Number of tasks instances to keep running")
+	flags.StringVar(&values.capacityProviderStrategy, "capacity-provider-strategy", "", "Capacity providers to run tasks on [e.g. FARGATE_SPOT:weight=3,FARGATE:base=1,weight=1]")
+	flags.StringSliceVar(&values.securityGroupIds, "security-group-id", []string{}, "ID of a security group to apply to the service (can be specified multiple times)")
+	flags.StringSliceVar(&values.subnetIds, "subnet-id", []string{}, "ID of a subnet in which to place the service (can be specified multiple times)")
+	flags.StringVarP(&values.taskRole, "task-role", "", "", "Name or ARN of an IAM role that the service's tasks can assume")
+	flags.StringSliceVar(&values.taskCommand, "task-command", []string{}, "Command to run inside container instead of the one specified in the docker image")
+	flags.BoolVarP(&values.assignPublicIP, "assign-public-ip", "", true, "Assign public ip address")
+	flags.BoolVar(&values.enableExec, "enable-exec", false, "Enable ECS Exec so that commands can be run in the service's tasks with fargate service exec")
 }
 
-func (o *ServiceCreateOperation) SetSecurityGroupIds(securityGroupIds []string) {
-	o.SecurityGroupIds = securityGroupIds
+// operation returns the operation to create a service with the given settings, exiting if any are
+// invalid. The deregistration delay is nil unless one was given.
+func (v serviceCreateFlagValues) operation(serviceName string, deregistrationDelay *int64) *ServiceCreateOperation {
+	operation := &ServiceCreateOperation{
+		Cpu:                   v.cpu,
+		EnableExec:            v.enableExec,
+		EphemeralStorage:      v.ephemeralStorage,
+		Image:                 v.image,
+		Memory:                v.memory,
+		Num:                   v.num,
+		PinDigest:             v.pinDigest,
+		SecurityGroupIds:      v.securityGroupIds,
+		ServiceName:           serviceName,
+		SubnetIds:             v.subnetIds,
+		TaskRole:              v.taskRole,
+		TaskCommand:           v.taskCommand,
+		AssignPublicIPEnabled: v.assignPublicIP,
+	}
+
+	if v.port != "" {
+		operation.SetPort(v.port)
+	}
+
+	if v.lb != "" {
+		operation.SetLoadBalancer(v.lb)
+	}
+
+	if len(v.rules) > 0 {
+		operation.SetRules(v.rules)
+	}
+
+	if !v.containerHealth.isEmpty() {
+		operation.SetContainerHealthCheck(v.containerHealth.healthCheck(v.healthCheck.healthCheck.IntervalSeconds))
+	}
+
+	if !v.healthCheck.healthCheck.IsEmpty() || deregistrationDelay != nil {
+		operation.SetHealthCheck(v.healthCheck.healthCheck, deregistrationDelay)
+	}
+
+	if len(v.envVars) > 0 {
+		operation.SetEnvVars(v.envVars)
+	}
+
+	if len(v.secrets) > 0 {
+		operation.SetSecrets(v.secrets)
+	}
+
+	if len(v.kmsKeys) > 0 {
+		operation.SetKMSKeys(v.kmsKeys)
+	}
+
+	if len(v.sidecars) > 0 {
+		operation.SetSidecars(v.sidecars)
+	}
+
+	if len(v.volumes) > 0 || len(v.mountPoints) > 0 {
+		operation.SetVolumes(v.volumes, v.mountPoints)
+	}
+
+	if v.capacityProviderStrategy != "" {
+		operation.SetCapacityProviderStrategy(v.capacityProviderStrategy)
+	}
+
+	if v.platform != "" || v.osFamily != "" {
+		operation.SetRuntimePlatform(v.platform, v.osFamily)
+	}
+
+	operation.SetBuildOptions(v.build)
+	operation.SetRepositoryOptions(v.repository)
+
+	if operation.Image == "" {
+		operation.SetRevision(v.git)
+	}
+
+	return operation
 }
 
-var (
-	flagServiceCreateCpu              string
-	flagServiceCreateEnvVars          []string
-	flagServiceCreateImage            string
-	flagServiceCreateLb               string
-	flagServiceCreateMemory           string
-	flagServiceCreateNum              int64
-	flagServiceCreatePort             string
-	flagServiceCreateRules            []string
-	flagServiceCreateSecurityGroupIds []string
-	flagServiceCreateSubnetIds        []string
-	flagServiceCreateTaskRole         string
-  flagServiceCreateTaskCommand      []string
-	flagServiceAssignPublicIP         bool
-)
+var flagServiceCreate serviceCreateFlagValues
 
 var serviceCreateCmd = &cobra.Command{
 	Use:   "create <service-name>",
@@ -163,17 +488,22 @@ respectively using the --cpu and --memory flags. Every 1024 CPU units is
 equivilent to a single vCPU. AWS Fargate only supports certain combinations of
 CPU and memory configurations:
 
-| CPU (CPU Units) | Memory (MiB)                          |
-| --------------- | ------------------------------------- |
-| 256             | 512, 1024, or 2048                    |
-| 512             | 1024 through 4096 in 1GiB increments  |
-| 1024            | 2048 through 8192 in 1GiB increments  |
-| 2048            | 4096 through 16384 in 1GiB increments |
-| 4096            | 8192 through 30720 in 1GiB increments |
-
+` + cpuAndMemoryTable() + `
 If not specified, fargate will launch minimally sized tasks at 0.25 vCPU (256
 CPU units) and 0.5GB (512 MiB) of memory.
 
+Tasks are given 20 GiB of ephemeral storage for their containers' writable
+layers and bind mounts. Up to 200 GiB can be allocated with the
+--ephemeral-storage flag.
+
+Tasks run on Linux on x86_64 (amd64) CPUs unless a platform is specified with
+the --platform flag in the form of os/arch. Pass --platform linux/arm64 to run
+tasks on AWS Graviton (ARM64) processors. When fargate builds the image, it is
+built for the platform using docker buildx. Windows tasks are run by passing
+the --os-family flag with WINDOWS_SERVER_2019_FULL, WINDOWS_SERVER_2019_CORE,
+WINDOWS_SERVER_2022_FULL, or WINDOWS_SERVER_2022_CORE. Windows tasks require at
+least 1024 CPU units and an image passed with the --image flag.
+
 The Docker container image to use in the service can be optionally specified
 via the --image flag. If not specified, fargate will build a new Docker
 container image from the current working directory and push it to Amazon ECR in
@@ -181,6 +511,37 @@ a repository named for the task group. If the current working directory is a
 git repository, the container image will be tagged with the short ref of the
 HEAD commit. If not, a timestamp in the format of YYYYMMDDHHMMSS will be used.
 
+Images are built from the Dockerfile in the current working directory. Use
+--dockerfile and --context to build from another Dockerfile or directory,
+--target to build a stage of a multi-stage Dockerfile, and --build-arg to pass
+build-time variables. --cache-from imports a build cache from an image or cache
+source and --cache-to exports the build cache using docker buildx. Images are
+labelled with the git commit SHA, branch, author, and message they were built
+from and the time they were built.
+
+If the git working tree has uncommitted changes, the image tag is suffixed with
+-dirty-<timestamp> so it is never mistaken for an image of the committed code.
+Use --refuse-dirty to refuse to build from a working tree with uncommitted
+changes instead. Use --tag-git-refs to also tag the image with the current
+branch and any git tags pointing at HEAD. The commit is recorded as tags on the
+task definition, and service info shows the commit each deployment runs.
+
+Images are built with docker, podman, or buildah, using the first one installed
+unless another is passed with the --builder flag. Images are pushed to Amazon
+ECR by fargate itself, authenticating with a token from ECR, so docker login and
+docker push are not used.
+
+Images in Amazon ECR, whether built by fargate or passed with --image, are run
+by the sha256 digest their tag refers to when the task definition is
+registered, so that the service keeps running the same image if the tag is
+later moved. Pass --pin-digest=false to run images by tag instead.
+
+Repositories created by fargate can be given a lifecycle policy which expires
+old images by passing the --lifecycle-policy flag with the path to a JSON
+policy document, and can scan images for vulnerabilities as they are pushed
+with --scan-on-push. Neither flag changes a repository which already exists.
+Images are listed by fargate image list and removed by fargate image prune.
+
 To use the service with a load balancer, a port must be specified when the
 service is created. Specify a port by passing the --port flag and a port
 expression of protocol:port-number. For example, if the service listens on port
@@ -200,9 +561,67 @@ request. Both PATH and HOST types can include up to three wildcard characters:
 * to match multiple characters and ? to match a single character. If rules are
 omitted, the service will be the load balancer's default action.
 
+The health checks the load balancer performs on the service's tasks can be
+configured with the --health-check-path, --health-check-interval,
+--healthy-threshold, --unhealthy-threshold, and --health-check-matcher flags.
+The matcher is the HTTP codes a task must respond with to be considered
+healthy (e.g. 200, 200,204, or 200-299). The path and matcher are not supported
+with TCP. The time given to in-flight requests to complete before a stopping
+task is deregistered from the load balancer can be set in seconds using the
+--deregistration-delay flag. Settings which are omitted use the AWS defaults.
+
+A health check command can be run inside the service's containers by passing
+the --health-check-command flag with a shell command which exits with a
+non-zero status when the container is unhealthy. Tasks whose containers are
+unhealthy are replaced, which detects hung processes even when the service does
+not use a load balancer or uses a network load balancer. The number of
+consecutive failures before a container is unhealthy can be set with the
+--health-check-retries flag and the time to allow a container to start before
+failures are counted with the --health-check-start-period flag. The
+--health-check-interval flag applies to both the container and load balancer
+health checks.
+
 Environment variables can be specified via the --env flag. Specify --env with a
 key=value parameter multiple times to add multiple variables.
 
+Secrets can be specified via the --secret flag. Secrets are set as environment
+variables in the container whose values are read from AWS Systems Manager
+Parameter Store or AWS Secrets Manager when the container starts, so that their
+values are not stored in the task definition. Specify --secret with a
+KEY=parameter-name or KEY=arn parameter multiple times to add multiple secrets.
+The task execution role is granted access to read the referenced parameters and
+secrets. Parameters and secrets encrypted with a customer managed KMS key also
+require kms:Decrypt permission on the key, which is granted by passing the
+--kms-key flag with the key's ARN.
+
+Sidecar containers can be run alongside the service's container by passing
+the --sidecar flag with a comma separated list of key=value pairs, such as
+name=proxy,image=envoyproxy/envoy,port=9901. The name and image keys are
+required. The port, essential (default true), env=KEY=value, and
+depends-on=container[:START|COMPLETE|SUCCESS|HEALTHY] keys are optional, and
+env and depends-on can be given more than once. The service's own container is
+named for the service. Specify --sidecar multiple times to add multiple
+sidecars. Sidecars log to the service's log group.
+
+Amazon EFS file systems can be mounted into the service's container to share
+files between tasks and keep them when tasks are replaced. Define a volume by
+passing the --volume flag with an expression of NAME=efs:FILE-SYSTEM-ID[:/path],
+optionally followed by ,access-point=ACCESS-POINT-ID to mount through an EFS
+access point, which enables encryption in transit, or ,readonly to mount the
+volume read-only. Mount a volume into the container by passing the --mount
+flag with NAME:/container/path. Both flags can be specified multiple times.
+The file system's mount targets must allow NFS traffic (TCP port 2049) from the
+service's security groups.
+
+Tasks can be run on Fargate Spot, which runs interruptible tasks at a discount,
+by passing the --capacity-provider-strategy flag with a comma separated list of
+capacity providers, FARGATE or FARGATE_SPOT, each followed by an optional base
+and weight. For example, FARGATE_SPOT:weight=3,FARGATE:base=1,weight=1 runs the
+first task on Fargate and places three of every four additional tasks on Fargate
+Spot. The base is the minimum number of tasks run by a capacity provider and the
+weight is its share of the remaining tasks (default 1). Only one capacity
+provider can have a base. If omitted, tasks are run on Fargate.
+
 Specify the desired count of tasks the service should maintain by passing the
 --num flag with a number. If you omit this flag, fargate will configure a
 service with a desired number of tasks of 1.
@@ -221,6 +640,11 @@ A task role can be optionally specified via the --task-role flag by providing
 eith a full IAM role ARN or the name of an IAM role. The tasks run by the
 service will be able to assume this role.
 
+Commands can be run in the service's tasks with fargate service exec when ECS
+Exec is enabled by passing the --enable-exec flag. The task role is then granted
+the Session Manager permissions ECS Exec requires, and tasks created without a
+task role assume the fargateTaskRole role, which fargate creates.
+
 The default command of the docker image can be overridden using the
 --task-command flag, where the value is a string of comma seperated values
 representing the command. These values will be placed into an array as per
@@ -229,37 +653,10 @@ the requirements of the docker CMD syntax
 Services can be configured to have only private ip address via the
 --assign-public-ip=false flag.`,
 
-	Args: cobra.ExactArgs(1),
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
 	Run: func(cmd *cobra.Command, args []string) {
-
-		operation := &ServiceCreateOperation{
-			Cpu:                   flagServiceCreateCpu,
-			Image:                 flagServiceCreateImage,
-			Memory:                flagServiceCreateMemory,
-			Num:                   flagServiceCreateNum,
-			SecurityGroupIds:      flagServiceCreateSecurityGroupIds,
-			ServiceName:           args[0],
-			SubnetIds:             flagServiceCreateSubnetIds,
-			TaskRole:              flagServiceCreateTaskRole,
-      TaskCommand:      flagServiceCreateTaskCommand,
-			AssignPublicIPEnabled: flagServiceAssignPublicIP,
-		}
-
-		if flagServiceCreatePort != "" {
-			operation.SetPort(flagServiceCreatePort)
-		}
-
-		if flagServiceCreateLb != "" {
-			operation.SetLoadBalancer(flagServiceCreateLb)
-		}
-
-		if len(flagServiceCreateRules) > 0 {
-			operation.SetRules(flagServiceCreateRules)
-		}
-
-		if len(flagServiceCreateEnvVars) > 0 {
-			operation.SetEnvVars(flagServiceCreateEnvVars)
-		}
+		operation := flagServiceCreate.operation(args[0], flagServiceCreate.healthCheck.deregistrationDelayValue(cmd.Flags()))
 
 		operation.Validate()
 		createService(operation)
@@ -267,24 +664,15 @@ Services can be configured to have only private ip address via the
 }
 
 func init() {
-	serviceCreateCmd.Flags().StringVarP(&flagServiceCreateCpu, "cpu", "c", "256", "Amount of cpu units to allocate for each task")
-	serviceCreateCmd.Flags().StringVarP(&flagServiceCreateMemory, "memory", "m", "512", "Amount of MiB to allocate for each task")
-	serviceCreateCmd.Flags().StringSliceVarP(&flagServiceCreateEnvVars, "env", "e", []string{}, "Environment variables to set [e.g. KEY=value] (can be specified multiple times)")
-	serviceCreateCmd.Flags().StringVarP(&flagServiceCreatePort, "port", "p", "", "Port to listen on [e.g., 80, 443, http:8080, https:8443, tcp:1935]")
-	serviceCreateCmd.Flags().StringVarP(&flagServiceCreateImage, "image", "i", "", "Docker image to run in the service; if omitted Fargate will build an image from the Dockerfile in the current directory")
-	serviceCreateCmd.Flags().StringVarP(&flagServiceCreateLb, "lb", "l", "", "Name of a load balancer to use")
-	serviceCreateCmd.Flags().StringSliceVarP(&flagServiceCreateRules, "rule", "r", []string{}, "Routing rule for the load balancer [e.g. host=api.example.com, path=/api/*]; if omitted service will be the default route (can be specified multiple times)")
-	serviceCreateCmd.Flags().Int64VarP(&flagServiceCreateNum, "num", "n", 1, "Number of tasks instances to keep running")
-	serviceCreateCmd.Flags().StringSliceVar(&flagServiceCreateSecurityGroupIds, "security-group-id", []string{}, "ID of a security group to apply to the service (can be specified multiple times)")
-	serviceCreateCmd.Flags().StringSliceVar(&flagServiceCreateSubnetIds, "subnet-id", []string{}, "ID of a subnet in which to place the service (can be specified multiple times)")
-	serviceCreateCmd.Flags().StringVarP(&flagServiceCreateTaskRole, "task-role", "", "", "Name or ARN of an IAM role that the service's tasks can assume")
-	serviceCreateCmd.Flags().StringSliceVar(&flagServiceCreateTaskCommand, "task-command", []string{}, "Command to run inside container instead of the one specified in the docker image")
-  serviceCreateCmd.Flags().BoolVarP(&flagServiceAssignPublicIP, "assign-public-ip", "", true, "Assign public ip address")
+	addServiceCreateFlags(serviceCreateCmd.Flags(), &flagServiceCreate)
 	serviceCmd.AddCommand(serviceCreateCmd)
 }
 
 func createService(operation *ServiceCreateOperation) {
-	var targetGroupArn string
+	var (
+		ecsTaskExecutionRoleArn, logGroupName, taskDefinitionArn string
+		targetGroupArn                                           string
+	)
 
 	cwl := CWL.New(sess)
 	ec2 := EC2.New(sess)
@@ -292,8 +680,48 @@ func createService(operation *ServiceCreateOperation) {
 	elbv2 := ELBV2.New(sess)
 	ecs := ECS.New(sess, clusterName)
 	iam := IAM.New(sess)
-	ecsTaskExecutionRoleArn := iam.CreateEcsTaskExecutionRole()
-	logGroupName := cwl.CreateLogGroup(serviceLogGroupFormat, operation.ServiceName)
+	plan := newChangePlan()
+
+	plan.do(
+		changeStep{API: "iam", Action: "CreateRole", Resource: "role", Name: "ecsTaskExecutionRole"},
+		func() { ecsTaskExecutionRoleArn = iam.CreateEcsTaskExecutionRole() },
+	)
+
+	if operation.EnableExec {
+		plan.do(
+			changeStep{
+				API:      "iam",
+				Action:   "PutRolePolicy",
+				Resource: "role",
+				Name:     execTaskRoleName(operation.TaskRole),
+				Changes:  []string{"policy: fargate-exec"},
+			},
+			func() { operation.TaskRole = execTaskRole(operation.TaskRole) },
+		)
+	}
+
+	if len(operation.Secrets) > 0 || len(operation.KMSKeys) > 0 {
+		plan.do(
+			changeStep{
+				API:      "iam",
+				Action:   "PutRolePolicy",
+				Resource: "role",
+				Name:     "ecsTaskExecutionRole",
+				Changes:  append(secretChanges(nil, operation.Secrets, nil), kmsKeyChanges(operation.KMSKeys)...),
+			},
+			func() { grantSecretsAccess(ecsTaskExecutionRoleArn, operation.Secrets, operation.KMSKeys) },
+		)
+	}
+
+	plan.do(
+		changeStep{
+			API:      "cloudwatchlogs",
+			Action:   "CreateLogGroup",
+			Resource: "log group",
+			Name:     fmt.Sprintf(serviceLogGroupFormat, operation.ServiceName),
+		},
+		func() { logGroupName = cwl.CreateLogGroup(serviceLogGroupFormat, operation.ServiceName) },
+	)
 
 	if len(operation.SecurityGroupIds) == 0 {
 		defaultSecurityGroupID, _ := ec2.GetDefaultSecurityGroupID()
@@ -305,80 +733,254 @@ func createService(operation *ServiceCreateOperation) {
 	}
 
 	if operation.Image == "" {
-		var tag, repositoryUri string
+		var repositoryUri string
 
 		if ecr.IsRepositoryCreated(operation.ServiceName) {
 			repositoryUri = ecr.GetRepositoryUri(operation.ServiceName)
 		} else {
-			repositoryUri = ecr.CreateRepository(operation.ServiceName)
+			repositoryUri = knownAfterApply
+
+			plan.do(
+				changeStep{
+					API:      "ecr",
+					Action:   "CreateRepository",
+					Resource: "repository",
+					Name:     operation.ServiceName,
+					Changes:  repositoryChanges(operation.RepositoryOptions),
+				},
+				func() { repositoryUri = ecr.CreateRepository(operation.ServiceName, operation.RepositoryOptions) },
+			)
 		}
 
-		if git.IsCwdGitRepo() {
-			tag = git.GetShortSha()
-		} else {
-			tag = docker.GenerateTag()
-		}
+		tag := operation.Revision.tag()
+		additionalTags := operation.Revision.additionalTags()
+
+		buildOptions := platformBuildOptions(operation.BuildOptions, operation.RuntimePlatform, operation.Revision)
+
+		plan.do(
+			changeStep{
+				API:      "docker",
+				Action:   "BuildAndPush",
+				Resource: "image",
+				Name:     repositoryUri + ":" + tag,
+				Changes:  append(buildChanges(buildOptions), tagChanges(additionalTags)...),
+			},
+			func() {
+				repository := docker.NewRepository(repositoryUri)
+				username, password := ecr.GetUsernameAndPassword()
+
+				repository.Login(username, password)
+				repository.Build(tag, buildOptions)
+				digest := repository.Push(tag, additionalTags...)
 
-		repository := docker.NewRepository(repositoryUri)
-		username, password := ecr.GetUsernameAndPassword()
+				operation.Image = repository.UriFor(tag)
 
-		repository.Login(username, password)
-		repository.Build(tag)
-		repository.Push(tag)
+				if operation.PinDigest {
+					operation.Image = repository.UriForDigest(digest)
+					operation.RequestedImage = repository.UriFor(tag)
+				}
+			},
+		)
 
-		operation.Image = repository.UriFor(tag)
+		if plan.DryRun {
+			operation.Image = repositoryUri + ":" + tag
+
+			if operation.PinDigest {
+				operation.Image = repositoryUri + "@" + knownAfterApply
+				operation.RequestedImage = repositoryUri + ":" + tag
+			}
+		}
+	} else if operation.PinDigest {
+		operation.Image, operation.RequestedImage = pinImage(operation.Image)
 	}
 
 	if operation.LoadBalancerArn != "" {
-		vpcId, _ := ec2.GetSubnetVPCID(operation.SubnetIds[0])
-		targetGroupArn, _ = elbv2.CreateTargetGroup(
-			ELBV2.CreateTargetGroupParameters{
-				Name:     fmt.Sprintf("%s-%s", clusterName, operation.ServiceName),
-				Port:     operation.Port.Number,
-				Protocol: operation.Port.Protocol,
-				VPCID:    vpcId,
+		targetGroupName := fmt.Sprintf("%s-%s", clusterName, operation.ServiceName)
+
+		plan.do(
+			changeStep{
+				API:      "elbv2",
+				Action:   "CreateTargetGroup",
+				Resource: "target group",
+				Name:     targetGroupName,
+				Changes: append(
+					[]string{fmt.Sprintf("port: %s", operation.Port)},
+					healthCheckChanges(nil, operation.HealthCheck, operation.DeregistrationDelay)...,
+				),
+			},
+			func() {
+				var err error
+
+				vpcId, _ := ec2.GetSubnetVPCID(operation.SubnetIds[0])
+				targetGroupArn, err = elbv2.CreateTargetGroup(
+					ELBV2.CreateTargetGroupParameters{
+						DeregistrationDelay: operation.DeregistrationDelay,
+						HealthCheck:         operation.HealthCheck,
+						Name:                targetGroupName,
+						Port:                operation.Port.Number,
+						Protocol:            operation.Port.Protocol,
+						VPCID:               vpcId,
+					},
+				)
+
+				if err != nil {
+					console.ErrorExit(err, "Could not create target group %s", targetGroupName)
+				}
 			},
 		)
 
 		if len(operation.Rules) > 0 {
 			for _, rule := range operation.Rules {
-				elbv2.AddRule(operation.LoadBalancerArn, targetGroupArn, rule)
+				rule := rule
+
+				plan.do(
+					changeStep{
+						API:      "elbv2",
+						Action:   "CreateRule",
+						Resource: "load balancer",
+						Name:     operation.LoadBalancerName,
+						ARN:      operation.LoadBalancerArn,
+						Changes:  []string{fmt.Sprintf("rule: add %s => %s", rule, targetGroupName)},
+					},
+					func() { elbv2.AddRule(operation.LoadBalancerArn, targetGroupArn, rule) },
+				)
 			}
 		} else {
-			elbv2.ModifyLoadBalancerDefaultAction(operation.LoadBalancerArn, targetGroupArn)
+			plan.do(
+				changeStep{
+					API:      "elbv2",
+					Action:   "ModifyListener",
+					Resource: "load balancer",
+					Name:     operation.LoadBalancerName,
+					ARN:      operation.LoadBalancerArn,
+					Changes:  []string{fmt.Sprintf("default action: => %s", targetGroupName)},
+				},
+				func() { elbv2.ModifyLoadBalancerDefaultAction(operation.LoadBalancerArn, targetGroupArn) },
+			)
 		}
 	}
 
-	taskDefinitionArn := ecs.CreateTaskDefinition(
-		&ECS.CreateTaskDefinitionInput{
-			Cpu:              operation.Cpu,
-			EnvVars:          operation.EnvVars,
-			ExecutionRoleArn: ecsTaskExecutionRoleArn,
-			Image:            operation.Image,
-			Memory:           operation.Memory,
-			Name:             operation.ServiceName,
-			Port:             operation.Port.Number,
-			LogGroupName:     logGroupName,
-			LogRegion:        region,
-			TaskRole:         operation.TaskRole,
-			Type:             typeService,
-			TaskCommand:      operation.TaskCommand,
+	plan.do(
+		changeStep{
+			API:      "ecs",
+			Action:   "RegisterTaskDefinition",
+			Resource: "task definition",
+			Name:     operation.ServiceName,
+			Changes:  taskDefinitionChanges(operation),
+		},
+		func() {
+			taskDefinitionArn = ecs.CreateTaskDefinition(
+				&ECS.CreateTaskDefinitionInput{
+					Commit:           operation.Revision.commit(),
+					Cpu:              operation.Cpu,
+					EnvVars:          operation.EnvVars,
+					EphemeralStorage: operation.EphemeralStorage,
+					ExecutionRoleArn: ecsTaskExecutionRoleArn,
+					HealthCheck:      operation.ContainerHealthCheck,
+					Image:            operation.Image,
+					Memory:           operation.Memory,
+					Name:             operation.ServiceName,
+					Port:             operation.Port.Number,
+					RequestedImage:   operation.RequestedImage,
+					LogGroupName:     logGroupName,
+					LogRegion:        region,
+					MountPoints:      operation.MountPoints,
+					RuntimePlatform:  operation.RuntimePlatform,
+					Secrets:          operation.Secrets,
+					Sidecars:         operation.Sidecars,
+					TaskRole:         operation.TaskRole,
+					Type:             typeService,
+					TaskCommand:      operation.TaskCommand,
+					Volumes:          operation.Volumes,
+				},
+			)
 		},
 	)
 
-	ecs.CreateService(
-		&ECS.CreateServiceInput{
-			Cluster:               clusterName,
-			DesiredCount:          operation.Num,
-			Name:                  operation.ServiceName,
-			Port:                  operation.Port.Number,
-			SecurityGroupIds:      operation.SecurityGroupIds,
-			SubnetIds:             operation.SubnetIds,
-			TargetGroupArn:        targetGroupArn,
-			TaskDefinitionArn:     taskDefinitionArn,
-			AssignPublicIpEnabled: operation.AssignPublicIPEnabled,
+	if len(operation.CapacityProviderStrategy) > 0 {
+		plan.do(
+			changeStep{
+				API:      "ecs",
+				Action:   "PutClusterCapacityProviders",
+				Resource: "cluster",
+				Name:     clusterName,
+				Changes:  []string{"capacity providers: FARGATE, FARGATE_SPOT"},
+			},
+			func() {
+				if _, err := ecs.CreateCluster(); err != nil {
+					console.ErrorExit(err, "Could not attach capacity providers to cluster %s", clusterName)
+				}
+			},
+		)
+	}
+
+	plan.do(
+		changeStep{
+			API:      "ecs",
+			Action:   "CreateService",
+			Resource: "service",
+			Name:     operation.ServiceName,
+			Changes:  serviceChanges(operation),
+		},
+		func() {
+			ecs.CreateService(
+				&ECS.CreateServiceInput{
+					CapacityProviderStrategy: operation.CapacityProviderStrategy,
+					Cluster:                  clusterName,
+					DesiredCount:             operation.Num,
+					EnableExecuteCommand:     operation.EnableExec,
+					Name:                     operation.ServiceName,
+					Port:                     operation.Port.Number,
+					SecurityGroupIds:         operation.SecurityGroupIds,
+					SubnetIds:                operation.SubnetIds,
+					TargetGroupArn:           targetGroupArn,
+					TaskDefinitionArn:        taskDefinitionArn,
+					AssignPublicIpEnabled:    operation.AssignPublicIPEnabled,
+				},
+			)
 		},
 	)
 
-	console.Info("Created service %s", operation.ServiceName)
+	if plan.display(output) {
+		return
+	}
+
+	output.Info("Created service %s", operation.ServiceName)
+}
+
+func taskDefinitionChanges(operation *ServiceCreateOperation) []string {
+	changes := []string{
+		fmt.Sprintf("image: %s", operation.Image),
+		fmt.Sprintf("cpu: %s", operation.Cpu),
+		fmt.Sprintf("memory: %s", operation.Memory),
+	}
+
+	changes = append(changes, runtimePlatformChanges(operation.EphemeralStorage, operation.RuntimePlatform)...)
+
+	for _, envVar := range operation.EnvVars {
+		changes = append(changes, fmt.Sprintf("env: set %s", envVar.Key))
+	}
+
+	if operation.ContainerHealthCheck != nil {
+		changes = append(changes, containerHealthCheckChanges(nil, *operation.ContainerHealthCheck)...)
+	}
+
+	changes = append(changes, secretChanges(nil, operation.Secrets, nil)...)
+	changes = append(changes, volumeChanges(operation.Volumes, operation.MountPoints, nil, nil)...)
+
+	return append(changes, sidecarChanges(operation.Sidecars)...)
+}
+
+func serviceChanges(operation *ServiceCreateOperation) []string {
+	changes := []string{fmt.Sprintf("num: %d", operation.Num)}
+
+	if len(operation.CapacityProviderStrategy) > 0 {
+		changes = append(changes, fmt.Sprintf("capacity provider strategy: %s", operation.CapacityProviderStrategy))
+	}
+
+	if operation.EnableExec {
+		changes = append(changes, "exec: enabled")
+	}
+
+	return changes
 }
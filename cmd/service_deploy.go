@@ -1,20 +1,141 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/awslabs/fargatecli/console"
 	"github.com/awslabs/fargatecli/docker"
 	ECR "github.com/awslabs/fargatecli/ecr"
 	ECS "github.com/awslabs/fargatecli/ecs"
-	"github.com/awslabs/fargatecli/git"
+	ELBV2 "github.com/awslabs/fargatecli/elbv2"
 	"github.com/spf13/cobra"
 )
 
 type ServiceDeployOperation struct {
-	ServiceName string
-	Image       string
+	BuildOptions      docker.BuildOptions
+	ServiceName       string
+	Container         string
+	Image             string
+	ImageArchive      string
+	PinDigest         bool
+	RepositoryOptions ECR.RepositoryOptions
+	RequestedImage    string
+	Revision          gitRevision
+	Rollback          bool
+	StepInterval      time.Duration
+	Strategy          string
+	Timeout           time.Duration
+	TrafficSteps      []int64
+	Wait              bool
+}
+
+// SetStrategy sets the deployment strategy and, for blue-green deployments, the percentages of traffic
+// to shift and how long the new image must stay healthy after each shift.
+func (o *ServiceDeployOperation) SetStrategy(strategy, inputTrafficSteps string, stepInterval time.Duration) {
+	var msgs []string
+
+	if strategy != deployStrategyRolling && strategy != deployStrategyBlueGreen {
+		msgs = append(msgs, fmt.Sprintf("Invalid strategy %s [specify %s or %s]", strategy, deployStrategyRolling, deployStrategyBlueGreen))
+	}
+
+	trafficSteps, err := parseTrafficSteps(inputTrafficSteps)
+
+	if err != nil {
+		msgs = append(msgs, err.Error())
+	}
+
+	if stepInterval < 0 {
+		msgs = append(msgs, fmt.Sprintf("Invalid step interval %s [specify a positive duration]", stepInterval))
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid deployment strategy")
+	}
+
+	o.Strategy = strategy
+	o.TrafficSteps = trafficSteps
+	o.StepInterval = stepInterval
+}
+
+// SetRepositoryOptions sets the options to create the image repository with if it does not exist.
+func (o *ServiceDeployOperation) SetRepositoryOptions(values repositoryFlagValues) {
+	var msgs []string
+
+	options, errs := values.repositoryOptions()
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid repository options")
+	}
+
+	o.RepositoryOptions = options
+}
+
+// SetRevision sets the git revision of the current working directory to tag and label the image
+// built or pushed from it with.
+func (o *ServiceDeployOperation) SetRevision(values gitFlagValues) {
+	revision, err := newGitRevision(values)
+
+	if err != nil {
+		console.ErrorExit(err, "Invalid git working tree")
+	}
+
+	o.Revision = revision
+}
+
+// SetBuildOptions sets the options to build the image with when no image is given.
+func (o *ServiceDeployOperation) SetBuildOptions(values buildFlagValues) {
+	var msgs []string
+
+	options, errs := values.buildOptions(o.Image)
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid build options")
+	}
+
+	o.BuildOptions = options
 }
 
-var flagServiceDeployImage string
+// SetImageArchive sets the image archive to push and deploy instead of building an image.
+func (o *ServiceDeployOperation) SetImageArchive(path string, build buildFlagValues) {
+	var msgs []string
+
+	for _, err := range validateImageArchive(path, o.Image, build) {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid image archive")
+	}
+
+	o.ImageArchive = path
+}
+
+var (
+	flagServiceDeployBuild        buildFlagValues
+	flagServiceDeployRepository   repositoryFlagValues
+	flagServiceDeployGit          gitFlagValues
+	flagServiceDeployContainer    string
+	flagServiceDeployImage        string
+	flagServiceDeployImageArchive string
+	flagServiceDeployPinDigest    bool
+	flagServiceDeployRollback     bool
+	flagServiceDeployStepInterval time.Duration
+	flagServiceDeployStrategy     string
+	flagServiceDeployTimeout      time.Duration
+	flagServiceDeployTrafficSteps string
+	flagServiceDeployWait         bool
+)
 
 var serviceDeployCmd = &cobra.Command{
 	Use:   "deploy <service-name>",
@@ -26,14 +147,99 @@ via the --image flag. If not specified, fargate will build a new Docker
 container image from the current working directory and push it to Amazon ECR in
 a repository named for the task group. If the current working directory is a
 git repository, the container image will be tagged with the short ref of the
-HEAD commit. If not, a timestamp in the format of YYYYMMDDHHMMSS will be used.`,
-	Args: cobra.ExactArgs(1),
+HEAD commit. If not, a timestamp in the format of YYYYMMDDHHMMSS will be used.
+The image is built for the service's platform, such as linux/arm64, using docker
+buildx if the service was created with the --platform flag.
+
+Images are built from the Dockerfile in the current working directory. Use
+--dockerfile and --context to build from another Dockerfile or directory,
+--target to build a stage of a multi-stage Dockerfile, and --build-arg to pass
+build-time variables. --cache-from imports a build cache from an image or cache
+source and --cache-to exports the build cache using docker buildx. Images are
+labelled with the git commit SHA, branch, author, and message they were built
+from and the time they were built.
+
+If the git working tree has uncommitted changes, the image tag is suffixed with
+-dirty-<timestamp> so it is never mistaken for an image of the committed code.
+Use --refuse-dirty to refuse to build from a working tree with uncommitted
+changes instead. Use --tag-git-refs to also tag the image with the current
+branch and any git tags pointing at HEAD. The commit is recorded as tags on the
+task definition, and service info shows the commit each deployment runs.
+
+Images are built with docker, podman, or buildah, using the first one installed
+unless another is passed with the --builder flag. Images are pushed to Amazon
+ECR by fargate itself, authenticating with a token from ECR, so docker login and
+docker push are not used.
+
+Images built elsewhere, such as in an earlier CI job, can be deployed by passing
+the --image-archive flag with the path to an archive written by docker save or
+an OCI image layout tarball. The image is pushed to the service's Amazon ECR
+repository, which is created if needed, and deployed by its digest.
+
+Images in Amazon ECR, whether built by fargate or passed with --image, are
+deployed by the sha256 digest their tag refers to when the task definition is
+registered, so that the service keeps running the same image if the tag is
+later moved. Pass --pin-digest=false to deploy by tag instead. fargate service
+info shows both the tag and the digest of each deployment.
+
+When --image-archive creates the service's repository, it is configured by the
+--lifecycle-policy and --scan-on-push flags as it is by fargate service create.
+
+The image is deployed to the service's first container. To deploy to a sidecar
+container instead, pass the --container flag with the name of the container.
+
+Pass the --wait flag to wait for the deployment to become stable, displaying
+the progress of the deployment and new service events along the way. The
+command exits with a non-zero status if the deployment does not become stable
+within the time given by --timeout (default 10m) or if tasks from the new
+deployment repeatedly stop.
+
+If the deployment fails while waiting, the service is rolled back to the task
+definition it ran before the deployment. Pass --rollback=false to leave the
+service on the failed deployment.
+
+Pass --strategy blue-green to deploy without replacing the service's tasks
+until the new image has served traffic. The service must be behind a load
+balancer. fargate creates a second target group next to the service's own and
+starts the new image in a shadow service named <service-name>-green registered
+with it. Once the shadow service is healthy, traffic is shifted to it in steps
+using weighted forward actions on the load balancer's rules, by default 10%,
+50%, and then 100%. Use --traffic-steps to change the percentages (e.g.
+--traffic-steps 5,25,100).
+
+After each step, the shadow service must pass a health gate for the time given
+by --step-interval (default 1m): its targets must stay healthy and none of its
+tasks may stop. If it fails, all traffic is cut back to the service at once and
+the shadow service and target group are removed. Once all traffic has shifted,
+the new image is deployed to the service itself, traffic is shifted back to it,
+and the shadow service and target group are removed. Blue/green deployments
+always wait and cut back on failure, so --wait and --rollback are ignored.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
 	Run: func(cmd *cobra.Command, args []string) {
 		operation := &ServiceDeployOperation{
 			ServiceName: args[0],
+			Container:   flagServiceDeployContainer,
 			Image:       flagServiceDeployImage,
+			PinDigest:   flagServiceDeployPinDigest,
+			Rollback:    flagServiceDeployRollback,
+			Timeout:     flagServiceDeployTimeout,
+			Wait:        flagServiceDeployWait,
+		}
+
+		operation.SetBuildOptions(flagServiceDeployBuild)
+		operation.SetRepositoryOptions(flagServiceDeployRepository)
+
+		if operation.Image == "" {
+			operation.SetRevision(flagServiceDeployGit)
+		}
+
+		if flagServiceDeployImageArchive != "" {
+			operation.SetImageArchive(flagServiceDeployImageArchive, flagServiceDeployBuild)
 		}
 
+		operation.SetStrategy(flagServiceDeployStrategy, flagServiceDeployTrafficSteps, flagServiceDeployStepInterval)
+
 		deployService(operation)
 	},
 }
@@ -41,35 +247,153 @@ HEAD commit. If not, a timestamp in the format of YYYYMMDDHHMMSS will be used.`,
 func init() {
 	serviceDeployCmd.Flags().StringVarP(&flagServiceDeployImage, "image", "i", "", "Docker image to run in the service; if omitted Fargate will build an image from the Dockerfile in the current directory")
 
+	serviceDeployCmd.Flags().StringVar(&flagServiceDeployImageArchive, "image-archive", "", "Image archive written by docker save or in OCI image layout to push and deploy instead of building an image")
+	serviceDeployCmd.Flags().BoolVar(&flagServiceDeployPinDigest, "pin-digest", true, "Deploy images in Amazon ECR by the digest their tag refers to rather than by the tag")
+
+	addBuildFlags(serviceDeployCmd.Flags(), &flagServiceDeployBuild)
+	addRepositoryFlags(serviceDeployCmd.Flags(), &flagServiceDeployRepository)
+	addGitFlags(serviceDeployCmd.Flags(), &flagServiceDeployGit)
+
+	serviceDeployCmd.Flags().BoolVarP(&flagServiceDeployWait, "wait", "w", false, "Wait for the deployment to become stable")
+	serviceDeployCmd.Flags().DurationVar(&flagServiceDeployTimeout, "timeout", defaultDeploymentTimeout, "Time to wait for the deployment to become stable")
+	serviceDeployCmd.Flags().BoolVar(&flagServiceDeployRollback, "rollback", true, "Roll back to the previous task definition if the deployment fails while waiting")
+
+	serviceDeployCmd.Flags().StringVar(&flagServiceDeployContainer, "container", "", "Name of the container to deploy the image to (default: the first container)")
+
+	serviceDeployCmd.Flags().StringVar(&flagServiceDeployStrategy, "strategy", deployStrategyRolling, "Deployment strategy [rolling, blue-green]")
+	serviceDeployCmd.Flags().StringVar(&flagServiceDeployTrafficSteps, "traffic-steps", defaultTrafficSteps, "Percentages of traffic to shift to the new image in a blue-green deployment")
+	serviceDeployCmd.Flags().DurationVar(&flagServiceDeployStepInterval, "step-interval", defaultStepInterval, "Time the new image must stay healthy after each traffic step in a blue-green deployment")
+
 	serviceCmd.AddCommand(serviceDeployCmd)
 }
 
 func deployService(operation *ServiceDeployOperation) {
+	var taskDefinitionArn string
+
 	ecs := ECS.New(sess, clusterName)
 	service := ecs.DescribeService(operation.ServiceName)
+	container := findServiceContainer(service, operation.Container)
+	plan := newChangePlan()
+
+	if operation.Strategy == deployStrategyBlueGreen && service.TargetGroupArn == "" {
+		console.ErrorExit(errors.New("blue-green deployments require a service behind a load balancer"), "Could not deploy service %s", operation.ServiceName)
+	}
 
-	if operation.Image == "" {
-		var tag string
+	switch {
+	case operation.ImageArchive != "":
+		operation.Image, operation.RequestedImage = pushImageArchive(operation.ServiceName, operation.ImageArchive, operation.RepositoryOptions, operation.Revision, plan)
+	case operation.Image == "":
+		if err := validateBuildPlatform(service.RuntimePlatform, operation.Image); err != nil {
+			console.ErrorExit(err, "Could not deploy service %s", operation.ServiceName)
+		}
 
 		ecr := ECR.New(sess)
 		repositoryUri := ecr.GetRepositoryUri(operation.ServiceName)
-		repository := docker.Repository{Uri: repositoryUri}
-		username, password := ecr.GetUsernameAndPassword()
+		repository := docker.NewRepository(repositoryUri)
+		buildOptions := platformBuildOptions(operation.BuildOptions, service.RuntimePlatform, operation.Revision)
+
+		tag := operation.Revision.tag()
+		additionalTags := operation.Revision.additionalTags()
+		operation.Image = repository.UriFor(tag)
 
-		if git.IsCwdGitRepo() {
-			tag = git.GetShortSha()
-		} else {
-			tag = docker.GenerateTag()
+		if operation.PinDigest {
+			operation.Image = repository.UriForDigest(knownAfterApply)
+			operation.RequestedImage = repository.UriFor(tag)
 		}
 
-		repository.Login(username, password)
-		repository.Build(tag)
-		repository.Push(tag)
+		plan.do(
+			changeStep{
+				API:      "docker",
+				Action:   "BuildAndPush",
+				Resource: "image",
+				Name:     repository.UriFor(tag),
+				Changes:  append(buildChanges(buildOptions), tagChanges(additionalTags)...),
+			},
+			func() {
+				username, password := ecr.GetUsernameAndPassword()
 
-		operation.Image = repository.UriFor(tag)
+				repository.Login(username, password)
+				repository.Build(tag, buildOptions)
+				digest := repository.Push(tag, additionalTags...)
+
+				if operation.PinDigest {
+					operation.Image = repository.UriForDigest(digest)
+				}
+			},
+		)
+	case operation.PinDigest:
+		operation.Image, operation.RequestedImage = pinImage(operation.Image)
+	}
+
+	plan.do(
+		changeStep{
+			API:      "ecs",
+			Action:   "RegisterTaskDefinition",
+			Resource: "task definition",
+			Name:     operation.ServiceName,
+			ARN:      service.TaskDefinitionArn,
+			Changes:  diffField("image", container.Image, operation.Image),
+		},
+		func() {
+			taskDefinitionArn = ecs.UpdateTaskDefinitionImage(service.TaskDefinitionArn, container.Name, operation.Image, operation.RequestedImage, operation.Revision.commit())
+		},
+	)
+
+	if operation.Strategy == deployStrategyBlueGreen {
+		deployServiceBlueGreen(operation, service, taskDefinitionArn, plan)
+		return
+	}
+
+	since := time.Now()
+
+	plan.do(
+		changeStep{API: "ecs", Action: "UpdateService", Resource: "service", Name: operation.ServiceName},
+		func() { ecs.UpdateServiceTaskDefinition(operation.ServiceName, taskDefinitionArn) },
+	)
+
+	if plan.display(output) {
+		return
+	}
+
+	output.Info("Deployed %s to service %s", operation.Image, operation.ServiceName)
+
+	if operation.Wait {
+		waitForServiceDeployment(
+			operation.ServiceName,
+			taskDefinitionArn,
+			rollbackTaskDefinitionARN(operation.Rollback, service.TaskDefinitionArn),
+			since,
+			operation.Timeout,
+		)
+	}
+}
+
+// deployServiceBlueGreen shifts traffic to a registered task definition in steps through a shadow
+// service, or displays the steps it would take in a dry run.
+func deployServiceBlueGreen(operation *ServiceDeployOperation, service ECS.Service, taskDefinitionArn string, plan *changePlan) {
+	targetGroupName := fmt.Sprintf("%s-%s", clusterName, operation.ServiceName)
+
+	o := serviceBlueGreenDeployOperation{
+		ecs:                       ECS.New(sess, clusterName),
+		elbv2:                     ELBV2.New(sess),
+		greenTargetGroupName:      greenTargetGroupName(targetGroupName),
+		interval:                  deploymentPollingInterval,
+		output:                    output,
+		previousTaskDefinitionARN: service.TaskDefinitionArn,
+		serviceName:               operation.ServiceName,
+		stepInterval:              operation.StepInterval,
+		targetGroupARN:            service.TargetGroupArn,
+		targetGroupName:           targetGroupName,
+		taskDefinitionARN:         taskDefinitionArn,
+		timeout:                   operation.Timeout,
+		trafficSteps:              operation.TrafficSteps,
+	}
+
+	if plan.DryRun {
+		plan.Steps = append(plan.Steps, o.steps()...)
+		plan.display(output)
+		return
 	}
 
-	taskDefinitionArn := ecs.UpdateTaskDefinitionImage(service.TaskDefinitionArn, operation.Image)
-	ecs.UpdateServiceTaskDefinition(operation.ServiceName, taskDefinitionArn)
-	console.Info("Deployed %s to service %s", operation.Image, operation.ServiceName)
+	o.execute()
 }
@@ -0,0 +1,441 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+	ELBV2 "github.com/awslabs/fargatecli/elbv2"
+)
+
+const (
+	deployStrategyBlueGreen = "blue-green"
+	deployStrategyRolling   = "rolling"
+
+	defaultTrafficSteps = "10,50,100"
+	defaultStepInterval = time.Minute
+
+	blueGreenSuffix            = "-green"
+	targetGroupNameMaxLength   = 32
+	targetHealthStateHealthy   = "healthy"
+	targetHealthStateInitial   = "initial"
+	targetHealthStateUnhealthy = "unhealthy"
+)
+
+// errNoForwardRules is returned when no listener rules forward traffic to a service's target group.
+var errNoForwardRules = errors.New("no load balancer listener rules forward traffic to the service")
+
+// parseTrafficSteps parses a comma-separated list of percentages of traffic to shift to a new
+// deployment (e.g. 10,50,100). The percentages must increase and end at 100.
+func parseTrafficSteps(input string) ([]int64, error) {
+	var steps []int64
+
+	for _, field := range strings.Split(input, ",") {
+		step, err := strconv.ParseInt(strings.TrimSpace(strings.TrimSuffix(field, "%")), 10, 64)
+
+		if err != nil || step < 1 || step > 100 {
+			return nil, fmt.Errorf("Invalid traffic step %s [specify a percentage from 1 through 100]", field)
+		}
+
+		if len(steps) > 0 && step <= steps[len(steps)-1] {
+			return nil, fmt.Errorf("Invalid traffic steps %s [percentages must increase]", input)
+		}
+
+		steps = append(steps, step)
+	}
+
+	if steps[len(steps)-1] != 100 {
+		return nil, fmt.Errorf("Invalid traffic steps %s [the last step must be 100]", input)
+	}
+
+	return steps, nil
+}
+
+// greenTargetGroupName returns the name of the target group a blue/green deployment of a service
+// registers its new tasks with, truncating the name of the service's own target group to fit.
+func greenTargetGroupName(targetGroupName string) string {
+	if max := targetGroupNameMaxLength - len(blueGreenSuffix); len(targetGroupName) > max {
+		targetGroupName = strings.TrimRight(targetGroupName[:max], "-")
+	}
+
+	return targetGroupName + blueGreenSuffix
+}
+
+// serviceBlueGreenDeployOperation deploys a task definition to a service by starting it in a shadow
+// service registered with a second target group, shifting traffic to it in steps using weighted
+// forward actions, and finally deploying it to the service itself and shifting traffic back. If the
+// shadow service fails a health gate along the way, traffic is cut back to the service at once.
+type serviceBlueGreenDeployOperation struct {
+	ecs                       ECS.Client
+	elbv2                     ELBV2.Client
+	greenTargetGroupName      string
+	interval                  time.Duration
+	output                    Output
+	previousTaskDefinitionARN string
+	serviceName               string
+	stepInterval              time.Duration
+	targetGroupARN            string
+	targetGroupName           string
+	taskDefinitionARN         string
+	timeout                   time.Duration
+	trafficSteps              []int64
+}
+
+func (o serviceBlueGreenDeployOperation) shadowServiceName() string {
+	return o.serviceName + blueGreenSuffix
+}
+
+func (o serviceBlueGreenDeployOperation) execute() {
+	revision := ECS.TaskDefinitionRevision(o.taskDefinitionARN)
+
+	o.output.Debug("Describing listener rules [API=elbv2 Action=DescribeRules TargetGroup=%s]", o.targetGroupARN)
+	rules, err := o.elbv2.DescribeForwardRules(o.targetGroupARN)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not describe load balancer rules of service %s", o.serviceName)
+		return
+	}
+
+	if len(rules) == 0 {
+		o.output.Fatal(errNoForwardRules, "Could not deploy service %s", o.serviceName)
+		return
+	}
+
+	o.output.Debug("Creating target group [API=elbv2 Action=CreateTargetGroup Name=%s]", o.greenTargetGroupName)
+	greenTargetGroupARN, err := o.elbv2.CloneTargetGroup(o.targetGroupARN, o.greenTargetGroupName)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not create target group %s", o.greenTargetGroupName)
+		return
+	}
+
+	o.output.Debug("Creating service [API=ecs Action=CreateService Service=%s]", o.shadowServiceName())
+
+	if err := o.ecs.CreateShadowService(o.serviceName, o.shadowServiceName(), o.taskDefinitionARN, greenTargetGroupARN); err != nil {
+		o.destroyTargetGroup(greenTargetGroupARN)
+		o.output.Fatal(err, "Could not create service %s", o.shadowServiceName())
+		return
+	}
+
+	o.output.Info("Started revision %s of service %s as %s", revision, o.serviceName, o.shadowServiceName())
+
+	since := time.Now()
+
+	if err := o.waitForShadowService(greenTargetGroupARN, since); err != nil {
+		o.fail(err, rules, greenTargetGroupARN, false)
+		return
+	}
+
+	for _, step := range o.trafficSteps {
+		o.output.Info("Shifting %d%% of traffic to revision %s", step, revision)
+
+		if err := o.modifyRuleWeights(rules, o.weights(greenTargetGroupARN, step)); err != nil {
+			o.fail(err, rules, greenTargetGroupARN, false)
+			return
+		}
+
+		if err := o.gate(greenTargetGroupARN, since); err != nil {
+			o.fail(err, rules, greenTargetGroupARN, false)
+			return
+		}
+	}
+
+	o.output.Info("Deploying revision %s to service %s", revision, o.serviceName)
+
+	if err := o.promote(); err != nil {
+		o.fail(err, rules, greenTargetGroupARN, true)
+		return
+	}
+
+	o.output.Info("Shifting traffic back to service %s", o.serviceName)
+
+	if err := o.modifyRuleWeights(rules, o.weights(greenTargetGroupARN, 0)); err != nil {
+		o.output.Fatal(err, "Could not shift traffic back to service %s", o.serviceName)
+		return
+	}
+
+	o.removeShadowService(greenTargetGroupARN)
+	o.output.Info("Deployed revision %s to service %s", revision, o.serviceName)
+}
+
+// weights returns the weights which send the given percentage of traffic to the green target group
+// and the rest to the service's own target group. At zero percent, the rules forward only to the
+// service's own target group.
+func (o serviceBlueGreenDeployOperation) weights(greenTargetGroupARN string, percent int64) []ELBV2.TargetGroupWeight {
+	if percent == 0 {
+		return []ELBV2.TargetGroupWeight{ELBV2.TargetGroupWeight{ARN: o.targetGroupARN, Weight: 100}}
+	}
+
+	return []ELBV2.TargetGroupWeight{
+		ELBV2.TargetGroupWeight{ARN: o.targetGroupARN, Weight: 100 - percent},
+		ELBV2.TargetGroupWeight{ARN: greenTargetGroupARN, Weight: percent},
+	}
+}
+
+func (o serviceBlueGreenDeployOperation) modifyRuleWeights(rules []ELBV2.Rule, weights []ELBV2.TargetGroupWeight) error {
+	for _, rule := range rules {
+		if rule.IsDefault {
+			o.output.Debug("Modifying listener [API=elbv2 Action=ModifyListener ARN=%s]", rule.ListenerARN)
+		} else {
+			o.output.Debug("Modifying listener rule [API=elbv2 Action=ModifyRule ARN=%s]", rule.ARN)
+		}
+
+		if err := o.elbv2.ModifyRuleWeights(rule, weights); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// waitForShadowService waits for the shadow service to run all of its tasks and for them to pass
+// the target group's health checks before any traffic is shifted to it.
+func (o serviceBlueGreenDeployOperation) waitForShadowService(greenTargetGroupARN string, since time.Time) error {
+	err := serviceDeploymentWaitOperation{
+		ecs:               o.ecs,
+		interval:          o.interval,
+		output:            o.output,
+		serviceName:       o.shadowServiceName(),
+		since:             since,
+		taskDefinitionARN: o.taskDefinitionARN,
+		timeout:           o.timeout,
+	}.wait()
+
+	if err != nil {
+		return err
+	}
+
+	return o.waitForHealthyTargets(greenTargetGroupARN, since.Add(o.timeout))
+}
+
+// gate checks the health of the shadow service for the step interval after each traffic shift,
+// returning an error as soon as a target fails its health checks or a task stops.
+func (o serviceBlueGreenDeployOperation) gate(greenTargetGroupARN string, since time.Time) error {
+	deadline := time.Now().Add(o.stepInterval)
+
+	for {
+		if err := o.checkHealth(greenTargetGroupARN, since); err != nil {
+			return err
+		}
+
+		if !time.Now().Before(deadline) {
+			return nil
+		}
+
+		time.Sleep(o.interval)
+	}
+}
+
+func (o serviceBlueGreenDeployOperation) checkHealth(greenTargetGroupARN string, since time.Time) error {
+	o.output.Debug("Describing target health [API=elbv2 Action=DescribeTargetHealth TargetGroup=%s]", greenTargetGroupARN)
+	targetHealths, err := o.elbv2.DescribeTargetHealth(greenTargetGroupARN)
+
+	if err != nil {
+		return err
+	}
+
+	var healthy int
+
+	for _, targetHealth := range targetHealths {
+		switch targetHealth.State {
+		case targetHealthStateHealthy:
+			healthy++
+		case targetHealthStateUnhealthy:
+			return fmt.Errorf("target %s failed health checks: %s", targetHealth.ID, targetHealth.Description)
+		}
+	}
+
+	if healthy == 0 {
+		return fmt.Errorf("no healthy targets in target group %s", o.greenTargetGroupName)
+	}
+
+	o.output.Debug("Listing stopped tasks [API=ecs Action=ListTasks Service=%s]", o.shadowServiceName())
+	tasks, err := o.ecs.ListStoppedServiceTasks(o.shadowServiceName())
+
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if task.TaskDefinitionArn == o.taskDefinitionARN && !task.CreatedAt.Before(since) {
+			return fmt.Errorf("task %s stopped: %s", task.TaskId, task.StoppedReason)
+		}
+	}
+
+	return nil
+}
+
+// waitForHealthyTargets polls a target group until it has healthy targets and none which are still
+// being registered or failing health checks.
+func (o serviceBlueGreenDeployOperation) waitForHealthyTargets(targetGroupARN string, deadline time.Time) error {
+	for {
+		o.output.Debug("Describing target health [API=elbv2 Action=DescribeTargetHealth TargetGroup=%s]", targetGroupARN)
+		targetHealths, err := o.elbv2.DescribeTargetHealth(targetGroupARN)
+
+		if err != nil {
+			return err
+		}
+
+		if targetsHealthy(targetHealths) {
+			return nil
+		}
+
+		if !time.Now().Before(deadline) {
+			return errDeploymentTimeout
+		}
+
+		time.Sleep(o.interval)
+	}
+}
+
+// promote deploys the task definition to the service itself, waiting for its tasks to become stable
+// and healthy while the shadow service handles traffic.
+func (o serviceBlueGreenDeployOperation) promote() error {
+	since := time.Now()
+
+	o.output.Debug("Updating service [API=ecs Action=UpdateService TaskDefinition=%s]", o.taskDefinitionARN)
+
+	if err := o.ecs.DeployTaskDefinition(o.serviceName, o.taskDefinitionARN); err != nil {
+		return err
+	}
+
+	err := serviceDeploymentWaitOperation{
+		ecs:               o.ecs,
+		interval:          o.interval,
+		output:            o.output,
+		serviceName:       o.serviceName,
+		since:             since,
+		taskDefinitionARN: o.taskDefinitionARN,
+		timeout:           o.timeout,
+	}.wait()
+
+	if err != nil {
+		return err
+	}
+
+	return o.waitForHealthyTargets(o.targetGroupARN, since.Add(o.timeout))
+}
+
+// fail cuts all traffic back to the service, removes the shadow service and its target group, and
+// exits. If the service itself was being deployed to, it is rolled back to its previous task
+// definition.
+func (o serviceBlueGreenDeployOperation) fail(err error, rules []ELBV2.Rule, greenTargetGroupARN string, rollback bool) {
+	o.output.Warn("Deployment of service %s failed: %v", o.serviceName, err)
+	o.output.Info("Cutting traffic back to service %s", o.serviceName)
+
+	if cutbackErr := o.modifyRuleWeights(rules, o.weights(greenTargetGroupARN, 0)); cutbackErr != nil {
+		o.output.Fatal(cutbackErr, "Could not cut traffic back to service %s", o.serviceName)
+		return
+	}
+
+	if rollback {
+		revision := ECS.TaskDefinitionRevision(o.previousTaskDefinitionARN)
+
+		o.output.Info("Rolling back service %s to revision %s", o.serviceName, revision)
+		o.output.Debug("Updating service [API=ecs Action=UpdateService TaskDefinition=%s]", o.previousTaskDefinitionARN)
+
+		if rollbackErr := o.ecs.DeployTaskDefinition(o.serviceName, o.previousTaskDefinitionARN); rollbackErr != nil {
+			o.output.Warn("Could not roll back service %s: %v", o.serviceName, rollbackErr)
+		}
+	}
+
+	o.removeShadowService(greenTargetGroupARN)
+	o.output.Fatal(err, "Deployment of service %s failed and traffic was cut back", o.serviceName)
+}
+
+func (o serviceBlueGreenDeployOperation) removeShadowService(greenTargetGroupARN string) {
+	o.output.Debug("Deleting service [API=ecs Action=DeleteService Service=%s]", o.shadowServiceName())
+
+	if err := o.ecs.DeleteService(o.shadowServiceName()); err != nil {
+		o.output.Warn("Could not delete service %s: %v", o.shadowServiceName(), err)
+	}
+
+	o.destroyTargetGroup(greenTargetGroupARN)
+}
+
+func (o serviceBlueGreenDeployOperation) destroyTargetGroup(greenTargetGroupARN string) {
+	o.output.Debug("Deleting target group [API=elbv2 Action=DeleteTargetGroup Name=%s]", o.greenTargetGroupName)
+
+	if err := o.elbv2.DestroyTargetGroup(greenTargetGroupARN); err != nil {
+		o.output.Warn("Could not delete target group %s: %v", o.greenTargetGroupName, err)
+	}
+}
+
+// steps returns the changes a blue/green deployment makes for display in a dry run.
+func (o serviceBlueGreenDeployOperation) steps() []changeStep {
+	revision := knownAfterApply
+
+	if o.taskDefinitionARN != "" {
+		revision = ECS.TaskDefinitionRevision(o.taskDefinitionARN)
+	}
+
+	steps := []changeStep{
+		changeStep{
+			API:      "elbv2",
+			Action:   "CreateTargetGroup",
+			Resource: "target group",
+			Name:     o.greenTargetGroupName,
+			Changes:  []string{fmt.Sprintf("settings: copied from %s", o.targetGroupName)},
+		},
+		changeStep{
+			API:      "ecs",
+			Action:   "CreateService",
+			Resource: "service",
+			Name:     o.shadowServiceName(),
+			Changes: []string{
+				fmt.Sprintf("task definition revision: %s", revision),
+				fmt.Sprintf("target group: %s", o.greenTargetGroupName),
+			},
+		},
+	}
+
+	for _, step := range o.trafficSteps {
+		steps = append(steps, o.trafficStep(100-step, step))
+	}
+
+	return append(steps,
+		changeStep{
+			API:      "ecs",
+			Action:   "UpdateService",
+			Resource: "service",
+			Name:     o.serviceName,
+			Changes:  []string{fmt.Sprintf("task definition revision: %s", revision)},
+		},
+		o.trafficStep(100, 0),
+		changeStep{API: "ecs", Action: "DeleteService", Resource: "service", Name: o.shadowServiceName()},
+		changeStep{API: "elbv2", Action: "DeleteTargetGroup", Resource: "target group", Name: o.greenTargetGroupName},
+	)
+}
+
+func (o serviceBlueGreenDeployOperation) trafficStep(blue, green int64) changeStep {
+	return changeStep{
+		API:      "elbv2",
+		Action:   "ModifyRule",
+		Resource: "load balancer rules",
+		Name:     o.serviceName,
+		Changes: []string{
+			fmt.Sprintf("weight: %s %d%%", o.targetGroupName, blue),
+			fmt.Sprintf("weight: %s %d%%", o.greenTargetGroupName, green),
+		},
+	}
+}
+
+// targetsHealthy returns whether a target group has healthy targets and none which are still being
+// registered or failing health checks. Draining targets are ignored.
+func targetsHealthy(targetHealths ELBV2.TargetHealths) bool {
+	var healthy int
+
+	for _, targetHealth := range targetHealths {
+		switch targetHealth.State {
+		case targetHealthStateHealthy:
+			healthy++
+		case targetHealthStateInitial, targetHealthStateUnhealthy:
+			return false
+		}
+	}
+
+	return healthy > 0
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/awslabs/fargatecli/cmd/mock"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	ecsclient "github.com/awslabs/fargatecli/ecs/mock/client"
+	ELBV2 "github.com/awslabs/fargatecli/elbv2"
+	elbv2client "github.com/awslabs/fargatecli/elbv2/mock/client"
+	"github.com/golang/mock/gomock"
+)
+
+const (
+	blueTargetGroupARN  = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/fargate-web/73e2d6bc24d8a067"
+	greenTargetGroupARN = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/fargate-web-green/2453ed029918f21f"
+)
+
+func TestParseTrafficSteps(t *testing.T) {
+	var tests = []struct {
+		input string
+		steps []int64
+	}{
+		{"10,50,100", []int64{10, 50, 100}},
+		{"100", []int64{100}},
+		{"5%, 25%, 100%", []int64{5, 25, 100}},
+	}
+
+	for _, test := range tests {
+		steps, err := parseTrafficSteps(test.input)
+
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", test.input, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(test.steps, steps) {
+			t.Errorf("%s: expected %v, got %v", test.input, test.steps, steps)
+		}
+	}
+
+	for _, input := range []string{"", "10,50", "50,10,100", "10,10,100", "0,100", "10,150", "ten,100"} {
+		if _, err := parseTrafficSteps(input); err == nil {
+			t.Errorf("%s: expected error, got none", input)
+		}
+	}
+}
+
+func TestGreenTargetGroupName(t *testing.T) {
+	var tests = []struct {
+		targetGroupName string
+		expected        string
+	}{
+		{"fargate-web", "fargate-web-green"},
+		{"fargate-a-very-long-service-name", "fargate-a-very-long-servic-green"},
+		{"fargate-a-very-long-servi-ce-name", "fargate-a-very-long-servi-green"},
+	}
+
+	for _, test := range tests {
+		got := greenTargetGroupName(test.targetGroupName)
+
+		if got != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.targetGroupName, test.expected, got)
+		}
+
+		if len(got) > targetGroupNameMaxLength {
+			t.Errorf("%s: expected at most %d characters, got %d", test.targetGroupName, targetGroupNameMaxLength, len(got))
+		}
+	}
+}
+
+func TestTargetsHealthy(t *testing.T) {
+	var tests = []struct {
+		targetHealths ELBV2.TargetHealths
+		healthy       bool
+	}{
+		{ELBV2.TargetHealths{}, false},
+		{ELBV2.TargetHealths{ELBV2.TargetHealth{State: "healthy"}}, true},
+		{ELBV2.TargetHealths{ELBV2.TargetHealth{State: "healthy"}, ELBV2.TargetHealth{State: "draining"}}, true},
+		{ELBV2.TargetHealths{ELBV2.TargetHealth{State: "healthy"}, ELBV2.TargetHealth{State: "initial"}}, false},
+		{ELBV2.TargetHealths{ELBV2.TargetHealth{State: "healthy"}, ELBV2.TargetHealth{State: "unhealthy"}}, false},
+	}
+
+	for _, test := range tests {
+		if got := targetsHealthy(test.targetHealths); got != test.healthy {
+			t.Errorf("%+v: expected %t, got %t", test.targetHealths, test.healthy, got)
+		}
+	}
+}
+
+func TestServiceBlueGreenDeployOperation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSClient := ecsclient.NewMockClient(mockCtrl)
+	mockELBV2Client := elbv2client.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	rule := ELBV2.Rule{ARN: "ruleARN", ListenerARN: "listenerARN", TargetGroupARN: blueTargetGroupARN, Type: "HOST", Value: "web.example.com"}
+	healthy := ELBV2.TargetHealths{ELBV2.TargetHealth{ID: "10.0.0.1", State: "healthy"}}
+	stable := func(name string) ECS.Service {
+		return ECS.Service{
+			Name:         name,
+			DesiredCount: 1,
+			Deployments: []ECS.Deployment{
+				ECS.Deployment{Id: "4", Status: "PRIMARY", DesiredCount: 1, RunningCount: 1, TaskDefinitionArn: waitTaskDefinitionARN},
+			},
+		}
+	}
+	weights := func(blue, green int64) []ELBV2.TargetGroupWeight {
+		return []ELBV2.TargetGroupWeight{
+			ELBV2.TargetGroupWeight{ARN: blueTargetGroupARN, Weight: blue},
+			ELBV2.TargetGroupWeight{ARN: greenTargetGroupARN, Weight: green},
+		}
+	}
+
+	gomock.InOrder(
+		mockELBV2Client.EXPECT().DescribeForwardRules(blueTargetGroupARN).Return([]ELBV2.Rule{rule}, nil),
+		mockELBV2Client.EXPECT().CloneTargetGroup(blueTargetGroupARN, "fargate-web-green").Return(greenTargetGroupARN, nil),
+		mockECSClient.EXPECT().CreateShadowService("web", "web-green", waitTaskDefinitionARN, greenTargetGroupARN).Return(nil),
+		mockECSClient.EXPECT().DescribeServiceDeployments("web-green").Return(stable("web-green"), nil),
+		mockELBV2Client.EXPECT().DescribeTargetHealth(greenTargetGroupARN).Return(healthy, nil),
+
+		mockELBV2Client.EXPECT().ModifyRuleWeights(rule, weights(50, 50)).Return(nil),
+		mockELBV2Client.EXPECT().DescribeTargetHealth(greenTargetGroupARN).Return(healthy, nil),
+		mockECSClient.EXPECT().ListStoppedServiceTasks("web-green").Return([]ECS.Task{}, nil),
+
+		mockELBV2Client.EXPECT().ModifyRuleWeights(rule, weights(0, 100)).Return(nil),
+		mockELBV2Client.EXPECT().DescribeTargetHealth(greenTargetGroupARN).Return(healthy, nil),
+		mockECSClient.EXPECT().ListStoppedServiceTasks("web-green").Return([]ECS.Task{}, nil),
+
+		mockECSClient.EXPECT().DeployTaskDefinition("web", waitTaskDefinitionARN).Return(nil),
+		mockECSClient.EXPECT().DescribeServiceDeployments("web").Return(stable("web"), nil),
+		mockELBV2Client.EXPECT().DescribeTargetHealth(blueTargetGroupARN).Return(healthy, nil),
+
+		mockELBV2Client.EXPECT().ModifyRuleWeights(rule, []ELBV2.TargetGroupWeight{ELBV2.TargetGroupWeight{ARN: blueTargetGroupARN, Weight: 100}}).Return(nil),
+		mockECSClient.EXPECT().DeleteService("web-green").Return(nil),
+		mockELBV2Client.EXPECT().DestroyTargetGroup(greenTargetGroupARN).Return(nil),
+	)
+
+	serviceBlueGreenDeployOperation{
+		ecs:                       mockECSClient,
+		elbv2:                     mockELBV2Client,
+		greenTargetGroupName:      "fargate-web-green",
+		output:                    mockOutput,
+		previousTaskDefinitionARN: waitOldTaskDefinitionARN,
+		serviceName:               "web",
+		targetGroupARN:            blueTargetGroupARN,
+		targetGroupName:           "fargate-web",
+		taskDefinitionARN:         waitTaskDefinitionARN,
+		timeout:                   time.Minute,
+		trafficSteps:              []int64{50, 100},
+	}.execute()
+
+	if mockOutput.Exited {
+		t.Fatalf("expected no exit, got %v", mockOutput.FatalMsgs)
+	}
+
+	if expected, got := "Deployed revision 4 to service web", mockOutput.InfoMsgs[len(mockOutput.InfoMsgs)-1]; expected != got {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestServiceBlueGreenDeployOperationCutback(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSClient := ecsclient.NewMockClient(mockCtrl)
+	mockELBV2Client := elbv2client.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	rule := ELBV2.Rule{IsDefault: true, ListenerARN: "listenerARN", TargetGroupARN: blueTargetGroupARN, Type: "DEFAULT"}
+	stable := ECS.Service{
+		Name:         "web-green",
+		DesiredCount: 1,
+		Deployments: []ECS.Deployment{
+			ECS.Deployment{Id: "4", Status: "PRIMARY", DesiredCount: 1, RunningCount: 1, TaskDefinitionArn: waitTaskDefinitionARN},
+		},
+	}
+
+	gomock.InOrder(
+		mockELBV2Client.EXPECT().DescribeForwardRules(blueTargetGroupARN).Return([]ELBV2.Rule{rule}, nil),
+		mockELBV2Client.EXPECT().CloneTargetGroup(blueTargetGroupARN, "fargate-web-green").Return(greenTargetGroupARN, nil),
+		mockECSClient.EXPECT().CreateShadowService("web", "web-green", waitTaskDefinitionARN, greenTargetGroupARN).Return(nil),
+		mockECSClient.EXPECT().DescribeServiceDeployments("web-green").Return(stable, nil),
+		mockELBV2Client.EXPECT().DescribeTargetHealth(greenTargetGroupARN).Return(ELBV2.TargetHealths{ELBV2.TargetHealth{State: "healthy"}}, nil),
+
+		mockELBV2Client.EXPECT().ModifyRuleWeights(rule, gomock.Any()).Return(nil),
+		mockELBV2Client.EXPECT().DescribeTargetHealth(greenTargetGroupARN).Return(
+			ELBV2.TargetHealths{ELBV2.TargetHealth{ID: "10.0.0.1", State: "unhealthy", Description: "Health checks failed with these codes: [502]"}},
+			nil,
+		),
+
+		mockELBV2Client.EXPECT().ModifyRuleWeights(rule, []ELBV2.TargetGroupWeight{ELBV2.TargetGroupWeight{ARN: blueTargetGroupARN, Weight: 100}}).Return(nil),
+		mockECSClient.EXPECT().DeleteService("web-green").Return(nil),
+		mockELBV2Client.EXPECT().DestroyTargetGroup(greenTargetGroupARN).Return(nil),
+	)
+
+	serviceBlueGreenDeployOperation{
+		ecs:                       mockECSClient,
+		elbv2:                     mockELBV2Client,
+		greenTargetGroupName:      "fargate-web-green",
+		output:                    mockOutput,
+		previousTaskDefinitionARN: waitOldTaskDefinitionARN,
+		serviceName:               "web",
+		targetGroupARN:            blueTargetGroupARN,
+		targetGroupName:           "fargate-web",
+		taskDefinitionARN:         waitTaskDefinitionARN,
+		timeout:                   time.Minute,
+		trafficSteps:              []int64{10, 50, 100},
+	}.execute()
+
+	if !mockOutput.Exited {
+		t.Fatal("expected exit, got none")
+	}
+
+	if expected, got := "Deployment of service web failed and traffic was cut back", mockOutput.FatalMsgs[0].Msg; expected != got {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	if expected, got := "target 10.0.0.1 failed health checks: Health checks failed with these codes: [502]", mockOutput.FatalMsgs[0].Errors[0].Error(); expected != got {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestServiceBlueGreenDeployOperationSteps(t *testing.T) {
+	steps := serviceBlueGreenDeployOperation{
+		greenTargetGroupName: "fargate-web-green",
+		serviceName:          "web",
+		targetGroupName:      "fargate-web",
+		trafficSteps:         []int64{10, 100},
+	}.steps()
+
+	var actions []string
+
+	for _, step := range steps {
+		actions = append(actions, step.Action)
+	}
+
+	expected := []string{"CreateTargetGroup", "CreateService", "ModifyRule", "ModifyRule", "UpdateService", "ModifyRule", "DeleteService", "DeleteTargetGroup"}
+
+	if !reflect.DeepEqual(expected, actions) {
+		t.Fatalf("expected %v, got %v", expected, actions)
+	}
+
+	if expected := []string{"weight: fargate-web 90%", "weight: fargate-web-green 10%"}; !reflect.DeepEqual(expected, steps[2].Changes) {
+		t.Errorf("expected %v, got %v", expected, steps[2].Changes)
+	}
+
+	if expected := "task definition revision: (known after apply)"; steps[1].Changes[0] != expected {
+		t.Errorf("expected %q, got %q", expected, steps[1].Changes[0])
+	}
+}
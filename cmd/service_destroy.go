@@ -19,7 +19,8 @@ var serviceDestroyCmd = &cobra.Command{
 	Long: `Destroy service
 
 In order to destroy a service, it must first be scaled to 0 running tasks.`,
-	Args: cobra.ExactArgs(1),
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
 	Run: func(cmd *cobra.Command, args []string) {
 		operation := &ServiceDestroyOperation{
 			ServiceName: args[0],
@@ -43,40 +44,93 @@ func destroyService(operation *ServiceDestroyOperation) {
 		console.ErrorExit(err, "Cannot destroy service %s", operation.ServiceName)
 	}
 
+	plan := newChangePlan()
+
 	if service.TargetGroupArn != "" {
 		loadBalancerArn := elbv2.GetTargetGroupLoadBalancerArn(service.TargetGroupArn)
 		loadBalancer := elbv2.DescribeLoadBalancerByARN(loadBalancerArn)
 		listeners := elbv2.GetListeners(loadBalancerArn)
 
 		for _, listener := range listeners {
+			listener := listener
+
 			for _, rule := range elbv2.DescribeRules(listener.ARN) {
+				rule := rule
+
 				if rule.TargetGroupARN == service.TargetGroupArn {
 					if rule.IsDefault {
 						defaultTargetGroupName := fmt.Sprintf(defaultTargetGroupFormat, loadBalancer.Name)
 						defaultTargetGroupArn := elbv2.GetTargetGroupArn(defaultTargetGroupName)
 
 						if defaultTargetGroupArn == "" {
-							defaultTargetGroupArn, _ = elbv2.CreateTargetGroup(
-								ELBV2.CreateTargetGroupParameters{
+							plan.do(
+								changeStep{
+									API:      "elbv2",
+									Action:   "CreateTargetGroup",
+									Resource: "target group",
 									Name:     defaultTargetGroupName,
-									Port:     listeners[0].Port,
-									Protocol: listeners[0].Protocol,
-									VPCID:    loadBalancer.VPCID,
+								},
+								func() {
+									defaultTargetGroupArn, _ = elbv2.CreateTargetGroup(
+										ELBV2.CreateTargetGroupParameters{
+											Name:     defaultTargetGroupName,
+											Port:     listeners[0].Port,
+											Protocol: listeners[0].Protocol,
+											VPCID:    loadBalancer.VPCID,
+										},
+									)
 								},
 							)
 						}
 
-						elbv2.ModifyListenerDefaultAction(listener.ARN, defaultTargetGroupArn)
+						plan.do(
+							changeStep{
+								API:      "elbv2",
+								Action:   "ModifyListener",
+								Resource: "listener",
+								Name:     listener.String(),
+								ARN:      listener.ARN,
+								Changes:  []string{fmt.Sprintf("default action: %s-%s => %s", clusterName, operation.ServiceName, defaultTargetGroupName)},
+							},
+							func() { elbv2.ModifyListenerDefaultAction(listener.ARN, defaultTargetGroupArn) },
+						)
 					} else {
-						elbv2.DeleteRule(rule.ARN)
+						plan.do(
+							changeStep{
+								API:      "elbv2",
+								Action:   "DeleteRule",
+								Resource: "listener",
+								Name:     listener.String(),
+								ARN:      rule.ARN,
+								Changes:  []string{fmt.Sprintf("rule: remove %s", rule)},
+							},
+							func() { elbv2.DeleteRule(rule.ARN) },
+						)
 					}
 				}
 			}
 		}
 
-		elbv2.DeleteTargetGroupByArn(service.TargetGroupArn)
+		plan.do(
+			changeStep{
+				API:      "elbv2",
+				Action:   "DeleteTargetGroup",
+				Resource: "target group",
+				Name:     fmt.Sprintf("%s-%s", clusterName, operation.ServiceName),
+				ARN:      service.TargetGroupArn,
+			},
+			func() { elbv2.DeleteTargetGroupByArn(service.TargetGroupArn) },
+		)
+	}
+
+	plan.do(
+		changeStep{API: "ecs", Action: "DeleteService", Resource: "service", Name: operation.ServiceName},
+		func() { ecs.DestroyService(operation.ServiceName) },
+	)
+
+	if plan.display(output) {
+		return
 	}
 
-	ecs.DestroyService(operation.ServiceName)
-	console.Info("Destroyed service %s", operation.ServiceName)
+	output.Info("Destroyed service %s", operation.ServiceName)
 }
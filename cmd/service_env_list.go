@@ -1,13 +1,13 @@
 package cmd
 
 import (
-	"fmt"
 	ECS "github.com/awslabs/fargatecli/ecs"
 	"github.com/spf13/cobra"
 )
 
 type ServiceEnvListOperation struct {
 	ServiceName string
+	Container   string
 }
 
 var serviceEnvListCmd = &cobra.Command{
@@ -17,22 +17,30 @@ var serviceEnvListCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		operation := &ServiceEnvListOperation{
 			ServiceName: args[0],
+			Container:   flagServiceEnvListContainer,
 		}
 
 		serviceEnvList(operation)
 	},
 }
 
+var flagServiceEnvListContainer string
+
 func init() {
+	serviceEnvListCmd.Flags().StringVar(&flagServiceEnvListContainer, "container", "", "Name of the container to show environment variables of (default: the first container)")
+
 	serviceEnvCmd.AddCommand(serviceEnvListCmd)
 }
 
 func serviceEnvList(operation *ServiceEnvListOperation) {
 	ecs := ECS.New(sess, clusterName)
 	service := ecs.DescribeService(operation.ServiceName)
-	envVars := ecs.GetEnvVarsFromTaskDefinition(service.TaskDefinitionArn)
+	container := findServiceContainer(service, operation.Container)
+	envVars := ecs.GetEnvVarsFromTaskDefinition(service.TaskDefinitionArn, container.Name)
+
+	output.Document(envVars)
 
 	for _, envVar := range envVars {
-		fmt.Printf("%s=%s\n", envVar.Key, envVar.Value)
+		output.Say("%s=%s", 0, envVar.Key, envVar.Value)
 	}
 }
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/awslabs/fargatecli/console"
 	ECS "github.com/awslabs/fargatecli/ecs"
 	"github.com/spf13/cobra"
@@ -8,7 +10,11 @@ import (
 
 type ServiceEnvSetOperation struct {
 	ServiceName string
+	Container   string
 	EnvVars     []ECS.EnvVar
+	Rollback    bool
+	Timeout     time.Duration
+	Wait        bool
 }
 
 func (o *ServiceEnvSetOperation) Validate() {
@@ -21,7 +27,13 @@ func (o *ServiceEnvSetOperation) SetEnvVars(inputEnvVars []string) {
 	o.EnvVars = extractEnvVars(inputEnvVars)
 }
 
-var flagServiceEnvSetEnvVars []string
+var (
+	flagServiceEnvSetContainer string
+	flagServiceEnvSetEnvVars   []string
+	flagServiceEnvSetRollback  bool
+	flagServiceEnvSetTimeout   time.Duration
+	flagServiceEnvSetWait      bool
+)
 
 var serviceEnvSetCmd = &cobra.Command{
 	Use:   "set --env <key=value> [--env <key=value>] ...",
@@ -29,11 +41,28 @@ var serviceEnvSetCmd = &cobra.Command{
 	Long: `Set environment variables
 
 At least one environment variable must be specified via the --env flag. Specify
---env with a key=value parameter multiple times to add multiple variables.`,
-	Args: cobra.ExactArgs(1),
+--env with a key=value parameter multiple times to add multiple variables.
+
+Variables are set in the service's first container unless another container is
+named with the --container flag.
+
+Pass the --wait flag to wait for the resulting deployment to become stable. The
+command exits with a non-zero status if the deployment does not become stable
+within the time given by --timeout (default 10m) or if tasks from the new
+deployment repeatedly stop.
+
+If the deployment fails while waiting, the service is rolled back to the task
+definition it ran before the deployment. Pass --rollback=false to leave the
+service on the failed deployment.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
 	Run: func(cmd *cobra.Command, args []string) {
 		operation := &ServiceEnvSetOperation{
 			ServiceName: args[0],
+			Container:   flagServiceEnvSetContainer,
+			Rollback:    flagServiceEnvSetRollback,
+			Timeout:     flagServiceEnvSetTimeout,
+			Wait:        flagServiceEnvSetWait,
 		}
 
 		operation.SetEnvVars(flagServiceEnvSetEnvVars)
@@ -44,21 +73,61 @@ At least one environment variable must be specified via the --env flag. Specify
 
 func init() {
 	serviceEnvSetCmd.Flags().StringArrayVarP(&flagServiceEnvSetEnvVars, "env", "e", []string{}, "Environment variables to set [e.g. KEY=value]")
+	serviceEnvSetCmd.Flags().BoolVarP(&flagServiceEnvSetWait, "wait", "w", false, "Wait for the deployment to become stable")
+	serviceEnvSetCmd.Flags().DurationVar(&flagServiceEnvSetTimeout, "timeout", defaultDeploymentTimeout, "Time to wait for the deployment to become stable")
+	serviceEnvSetCmd.Flags().BoolVar(&flagServiceEnvSetRollback, "rollback", true, "Roll back to the previous task definition if the deployment fails while waiting")
+
+	serviceEnvSetCmd.Flags().StringVar(&flagServiceEnvSetContainer, "container", "", "Name of the container to set environment variables in (default: the first container)")
 
 	serviceEnvCmd.AddCommand(serviceEnvSetCmd)
 }
 
 func serviceEnvSet(operation *ServiceEnvSetOperation) {
+	var taskDefinitionArn string
+
 	ecs := ECS.New(sess, clusterName)
 	service := ecs.DescribeService(operation.ServiceName)
-	taskDefinitionArn := ecs.AddEnvVarsToTaskDefinition(service.TaskDefinitionArn, operation.EnvVars)
+	container := findServiceContainer(service, operation.Container)
+	plan := newChangePlan()
+
+	plan.do(
+		changeStep{
+			API:      "ecs",
+			Action:   "RegisterTaskDefinition",
+			Resource: "task definition",
+			Name:     operation.ServiceName,
+			ARN:      service.TaskDefinitionArn,
+			Changes:  envVarChanges(container.EnvVars, operation.EnvVars, nil),
+		},
+		func() {
+			taskDefinitionArn = ecs.AddEnvVarsToTaskDefinition(service.TaskDefinitionArn, container.Name, operation.EnvVars)
+		},
+	)
+
+	since := time.Now()
 
-	ecs.UpdateServiceTaskDefinition(operation.ServiceName, taskDefinitionArn)
+	plan.do(
+		changeStep{API: "ecs", Action: "UpdateService", Resource: "service", Name: operation.ServiceName},
+		func() { ecs.UpdateServiceTaskDefinition(operation.ServiceName, taskDefinitionArn) },
+	)
 
-	console.Info("Set %s environment variables:", operation.ServiceName)
+	if plan.display(output) {
+		return
+	}
+
+	output.Info("Set %s environment variables:", operation.ServiceName)
 
 	for _, envVar := range operation.EnvVars {
-		console.Info("- %s=%s", envVar.Key, envVar.Value)
+		output.Say("- %s=%s", 1, envVar.Key, envVar.Value)
 	}
 
+	if operation.Wait {
+		waitForServiceDeployment(
+			operation.ServiceName,
+			taskDefinitionArn,
+			rollbackTaskDefinitionARN(operation.Rollback, service.TaskDefinitionArn),
+			since,
+			operation.Timeout,
+		)
+	}
 }
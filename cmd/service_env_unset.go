@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"strings"
+	"time"
 
 	"github.com/awslabs/fargatecli/console"
 	ECS "github.com/awslabs/fargatecli/ecs"
@@ -10,7 +11,11 @@ import (
 
 type ServiceEnvUnsetOperation struct {
 	ServiceName string
+	Container   string
 	Keys        []string
+	Rollback    bool
+	Timeout     time.Duration
+	Wait        bool
 }
 
 func (o *ServiceEnvUnsetOperation) Validate() {
@@ -29,11 +34,25 @@ var serviceEnvUnsetCmd = &cobra.Command{
 	Long: `Unset environment variables
 
 Unsets the environment variable specified via the --key flag. Specify --key with
-a key name multiple times to unset multiple variables.`,
-	Args: cobra.ExactArgs(1),
+a key name multiple times to unset multiple variables.
+
+Pass the --wait flag to wait for the resulting deployment to become stable. The
+command exits with a non-zero status if the deployment does not become stable
+within the time given by --timeout (default 10m) or if tasks from the new
+deployment repeatedly stop.
+
+If the deployment fails while waiting, the service is rolled back to the task
+definition it ran before the deployment. Pass --rollback=false to leave the
+service on the failed deployment.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
 	Run: func(cmd *cobra.Command, args []string) {
 		operation := &ServiceEnvUnsetOperation{
 			ServiceName: args[0],
+			Container:   flagServiceEnvUnsetContainer,
+			Rollback:    flagServiceEnvUnsetRollback,
+			Timeout:     flagServiceEnvUnsetTimeout,
+			Wait:        flagServiceEnvUnsetWait,
 		}
 
 		operation.SetKeys(flagServiceEnvUnsetKeys)
@@ -42,24 +61,72 @@ a key name multiple times to unset multiple variables.`,
 	},
 }
 
-var flagServiceEnvUnsetKeys []string
+var (
+	flagServiceEnvUnsetContainer string
+	flagServiceEnvUnsetKeys      []string
+	flagServiceEnvUnsetRollback  bool
+	flagServiceEnvUnsetTimeout   time.Duration
+	flagServiceEnvUnsetWait      bool
+)
 
 func init() {
 	serviceEnvUnsetCmd.Flags().StringSliceVarP(&flagServiceEnvUnsetKeys, "key", "k", []string{}, "Environment variable keys to unset [e.g. KEY, NGINX_PORT]")
 
+	serviceEnvUnsetCmd.Flags().BoolVarP(&flagServiceEnvUnsetWait, "wait", "w", false, "Wait for the deployment to become stable")
+	serviceEnvUnsetCmd.Flags().DurationVar(&flagServiceEnvUnsetTimeout, "timeout", defaultDeploymentTimeout, "Time to wait for the deployment to become stable")
+	serviceEnvUnsetCmd.Flags().BoolVar(&flagServiceEnvUnsetRollback, "rollback", true, "Roll back to the previous task definition if the deployment fails while waiting")
+
+	serviceEnvUnsetCmd.Flags().StringVar(&flagServiceEnvUnsetContainer, "container", "", "Name of the container to unset environment variables in (default: the first container)")
+
 	serviceEnvCmd.AddCommand(serviceEnvUnsetCmd)
 }
 
 func serviceEnvUnset(operation *ServiceEnvUnsetOperation) {
+	var taskDefinitionArn string
+
 	ecs := ECS.New(sess, clusterName)
 	service := ecs.DescribeService(operation.ServiceName)
-	taskDefinitionArn := ecs.RemoveEnvVarsFromTaskDefinition(service.TaskDefinitionArn, operation.Keys)
+	container := findServiceContainer(service, operation.Container)
+	plan := newChangePlan()
 
-	ecs.UpdateServiceTaskDefinition(operation.ServiceName, taskDefinitionArn)
+	plan.do(
+		changeStep{
+			API:      "ecs",
+			Action:   "RegisterTaskDefinition",
+			Resource: "task definition",
+			Name:     operation.ServiceName,
+			ARN:      service.TaskDefinitionArn,
+			Changes:  envVarChanges(container.EnvVars, nil, operation.Keys),
+		},
+		func() {
+			taskDefinitionArn = ecs.RemoveEnvVarsFromTaskDefinition(service.TaskDefinitionArn, container.Name, operation.Keys)
+		},
+	)
 
-	console.Info("Unset %s environment variables:", operation.ServiceName)
+	since := time.Now()
+
+	plan.do(
+		changeStep{API: "ecs", Action: "UpdateService", Resource: "service", Name: operation.ServiceName},
+		func() { ecs.UpdateServiceTaskDefinition(operation.ServiceName, taskDefinitionArn) },
+	)
+
+	if plan.display(output) {
+		return
+	}
+
+	output.Info("Unset %s environment variables:", operation.ServiceName)
 
 	for _, key := range operation.Keys {
-		console.Info("- %s", key)
+		output.Say("- %s", 1, key)
+	}
+
+	if operation.Wait {
+		waitForServiceDeployment(
+			operation.ServiceName,
+			taskDefinitionArn,
+			rollbackTaskDefinitionARN(operation.Rollback, service.TaskDefinitionArn),
+			since,
+			operation.Timeout,
+		)
 	}
 }
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"github.com/awslabs/fargatecli/console"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	"github.com/spf13/cobra"
+)
+
+type ServiceExecOperation struct {
+	Command     string
+	Container   string
+	ServiceName string
+	TaskID      string
+}
+
+var (
+	flagServiceExecContainer string
+	flagServiceExecTask      string
+)
+
+var serviceExecCmd = &cobra.Command{
+	Use:   "exec <service-name> [--task <task-id>] [--container <name>] [-- <command>]",
+	Short: "Run a command in a running task of a service",
+	Long: `Run a command in a running task of a service
+
+Opens an interactive session running a command inside a container of one of
+the service's running tasks using ECS Exec. The command is given after -- and
+defaults to /bin/sh. The first running task is used unless a task is specified
+with the --task flag, and the task's first container is used unless another
+container is named with the --container flag.
+
+The service must have been created with the --enable-exec flag, which enables
+ECS Exec and grants its task role the Session Manager permissions ECS Exec
+requires. Services created without the flag must be created again to use this
+command.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if dash := cmd.ArgsLenAtDash(); dash > 1 || (dash < 0 && len(args) > 1) {
+			console.IssueExit("Specify the command to run after --")
+		}
+
+		operation := &ServiceExecOperation{
+			Command:     execCommand(args, cmd.ArgsLenAtDash()),
+			Container:   flagServiceExecContainer,
+			ServiceName: args[0],
+			TaskID:      flagServiceExecTask,
+		}
+
+		serviceExec(operation)
+	},
+}
+
+func init() {
+	serviceExecCmd.Flags().StringVarP(&flagServiceExecTask, "task", "t", "", "ID of the task to run the command in (default: the first running task)")
+	serviceExecCmd.Flags().StringVar(&flagServiceExecContainer, "container", "", "Name of the container to run the command in (default: the first container)")
+
+	serviceCmd.AddCommand(serviceExecCmd)
+}
+
+func serviceExec(operation *ServiceExecOperation) {
+	ecs := ECS.New(sess, clusterName)
+	task, err := findExecTask(ecs.DescribeTasksForService(operation.ServiceName), operation.TaskID)
+
+	if err != nil {
+		console.ErrorExit(err, "Could not find task in service %s", operation.ServiceName)
+	}
+
+	execInTask(task, operation.Container, operation.Command)
+}
@@ -2,13 +2,11 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"sort"
 	"strings"
-	"text/tabwriter"
 
 	ACM "github.com/awslabs/fargatecli/acm"
-	"github.com/awslabs/fargatecli/console"
+	AAS "github.com/awslabs/fargatecli/applicationautoscaling"
 	EC2 "github.com/awslabs/fargatecli/ec2"
 	ECS "github.com/awslabs/fargatecli/ecs"
 	ELBV2 "github.com/awslabs/fargatecli/elbv2"
@@ -18,23 +16,45 @@ import (
 const statusActive = "ACTIVE"
 
 type ServiceInfoOperation struct {
+	Container   string
 	ServiceName string
 }
 
+// serviceInfoDocument is the structured representation of a service including its load balancer,
+// autoscaling configuration, and running tasks.
+type serviceInfoDocument struct {
+	ECS.Service
+	Autoscaling  *serviceAutoscalingDocument `json:"autoscaling,omitempty"`
+	LoadBalancer *ELBV2.LoadBalancer         `json:"loadBalancer,omitempty"`
+	TargetGroup  *ELBV2.TargetGroupSettings  `json:"targetGroup,omitempty"`
+	TargetHealth ELBV2.TargetHealths         `json:"targetHealth,omitempty"`
+	Tasks        []taskDocument              `json:"tasks"`
+}
+
 var serviceInfoCmd = &cobra.Command{
 	Use:   "info <service-name>",
 	Short: "Inspect service",
 	Long: `Inspect service
 
 Show extended information for a service including load balancer configuration,
-active deployments, and environment variables.
+health check settings and the health of each target, active deployments,
+environment variables, the sources of secrets, sidecar containers, and
+autoscaling configuration and recent scaling activity.
 
 Deployments show active versions of your service that are running. Multiple
 deployments are shown if a service is transitioning due to a deployment or
-update to configuration such a CPU, memory, or environment variables.`,
+update to configuration such a CPU, memory, or environment variables. Each
+deployment shows the image it was deployed from, if the image was pinned, the
+digest it runs, and, if the image was built by fargate in a git repository, the
+commit it was built from.
+
+The image, environment variables, secrets, and container health check shown are
+those of the container named for the service, or of the first container if
+there is none of that name. Use --container to show those of another container.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		operation := &ServiceInfoOperation{
+			Container:   flagServiceInfoContainer,
 			ServiceName: args[0],
 		}
 
@@ -42,7 +62,11 @@ update to configuration such a CPU, memory, or environment variables.`,
 	},
 }
 
+var flagServiceInfoContainer string
+
 func init() {
+	serviceInfoCmd.Flags().StringVar(&flagServiceInfoContainer, "container", "", "Name of the container to show the image, environment variables, and secrets of (default: the container named for the service)")
+
 	serviceCmd.AddCommand(serviceInfoCmd)
 }
 
@@ -53,136 +77,312 @@ func getServiceInfo(operation *ServiceInfoOperation) {
 	ecs := ECS.New(sess, clusterName)
 	ec2 := EC2.New(sess)
 	elbv2 := ELBV2.New(sess)
-	service := ecs.DescribeService(operation.ServiceName)
+	service := ecs.DescribeServiceContainer(operation.ServiceName, operation.Container)
 	tasks := ecs.DescribeTasksForService(operation.ServiceName)
 
 	if service.Status != statusActive {
-		console.InfoExit("Service not found")
+		output.Info("Service not found")
+		return
 	}
 
-	console.KeyValue("Service Name", "%s\n", operation.ServiceName)
-	console.KeyValue("Status", "\n")
-	console.KeyValue("  Desired", "%d\n", service.DesiredCount)
-	console.KeyValue("  Running", "%d\n", service.RunningCount)
-	console.KeyValue("  Pending", "%d\n", service.PendingCount)
-	console.KeyValue("Image", "%s\n", service.Image)
-	console.KeyValue("Cpu", "%s\n", service.Cpu)
-	console.KeyValue("Memory", "%s\n", service.Memory)
+	if operation.Container != "" {
+		if _, err := selectContainer(service.Containers, operation.Container); err != nil {
+			output.Fatal(err, "Invalid container")
+			return
+		}
 
-	if service.TaskRole != "" {
-		console.KeyValue("Task Role", "%s\n", service.TaskRole)
+		// Tasks still running a previous task definition may not have the container.
+		var err error
+
+		if tasks, err = selectTaskContainers(tasks, operation.Container); err != nil {
+			output.Debug("Could not select container of every task of service %s: %v", operation.ServiceName, err)
+		}
 	}
 
-	console.KeyValue("Subnets", "%s\n", strings.Join(service.SubnetIds, ", "))
-	console.KeyValue("Security Groups", "%s\n", strings.Join(service.SecurityGroupIds, ", "))
+	document := serviceInfoDocument{Service: service}
 
 	if service.TargetGroupArn != "" {
 		if loadBalancerArn := elbv2.GetTargetGroupLoadBalancerArn(service.TargetGroupArn); loadBalancerArn != "" {
 			loadBalancer := elbv2.DescribeLoadBalancerByARN(loadBalancerArn)
-			listeners := elbv2.GetListeners(loadBalancerArn)
-
-			console.KeyValue("Load Balancer", "\n")
-			console.KeyValue("  Name", "%s\n", loadBalancer.Name)
-			console.KeyValue("  DNS Name", "%s\n", loadBalancer.DNSName)
-
-			if len(listeners) > 0 {
-				console.KeyValue("  Ports", "\n")
-			}
-
-			for _, listener := range listeners {
-				var ruleOutput []string
 
+			for _, listener := range elbv2.GetListeners(loadBalancerArn) {
 				rules := elbv2.DescribeRules(listener.ARN)
 
 				sort.Slice(rules, func(i, j int) bool { return rules[i].Priority > rules[j].Priority })
 
 				for _, rule := range rules {
 					if rule.TargetGroupARN == service.TargetGroupArn {
-						ruleOutput = append(ruleOutput, rule.String())
+						listener.Rules = append(listener.Rules, rule)
 					}
 				}
 
-				console.KeyValue("    "+listener.String(), "\n")
-				console.KeyValue("      Rules", "%s\n", strings.Join(ruleOutput, ", "))
-
-				if len(listener.CertificateARNs) > 0 {
-					certificateDomains := acm.ListCertificateDomainNames(listener.CertificateARNs)
-					console.KeyValue("      Certificates", "%s\n", strings.Join(certificateDomains, ", "))
-				}
+				loadBalancer.Listeners = append(loadBalancer.Listeners, listener)
 			}
+
+			document.LoadBalancer = &loadBalancer
 		}
 
-		if len(service.EnvVars) > 0 {
-			console.KeyValue("Environment Variables", "\n")
+		if settings, err := elbv2.DescribeTargetGroupSettings(service.TargetGroupArn); err == nil {
+			document.TargetGroup = &settings
+		} else {
+			output.Debug("Could not describe target group of service %s, skipping: %v", operation.ServiceName, err)
+		}
 
-			for _, envVar := range service.EnvVars {
-				fmt.Printf("   %s=%s\n", envVar.Key, envVar.Value)
-			}
+		if targetHealth, err := elbv2.DescribeTargetHealth(service.TargetGroupArn); err == nil {
+			document.TargetHealth = targetHealth
+		} else {
+			output.Debug("Could not describe target health of service %s, skipping: %v", operation.ServiceName, err)
+		}
+	}
+
+	// Autoscaling is left out when it cannot be described, such as when the caller lacks access to
+	// Application Auto Scaling, rather than failing to show the rest of the service.
+	if autoscaling, err := describeServiceAutoscaling(AAS.New(sess), AAS.ServiceResourceID(clusterName, operation.ServiceName)); err == nil {
+		document.Autoscaling = autoscaling
+	} else {
+		output.Debug("Could not describe autoscaling of service %s, skipping: %v", operation.ServiceName, err)
+	}
+
+	for _, task := range tasks {
+		if task.EniId != "" {
+			eniIds = append(eniIds, task.EniId)
 		}
 	}
 
 	if len(tasks) > 0 {
-		console.Header("Tasks")
+		document.Tasks = withTargetHealth(newTaskDocuments(tasks, ec2.DescribeNetworkInterfaces(eniIds)), document.TargetHealth)
+	}
+
+	output.Document(document)
+	output.KeyValue("Service Name", operation.ServiceName, 0)
+	output.KeyValue("Status", "", 0)
+	output.KeyValue("Desired", "%d", 1, service.DesiredCount)
+	output.KeyValue("Running", "%d", 1, service.RunningCount)
+	output.KeyValue("Pending", "%d", 1, service.PendingCount)
+	output.KeyValue("Image", service.Image, 0)
+	output.KeyValue("Cpu", service.Cpu, 0)
+	output.KeyValue("Memory", service.Memory, 0)
+	output.KeyValue("Platform", runtimePlatformString(service.RuntimePlatform), 0)
+	output.KeyValue("Ephemeral Storage", ephemeralStorageString(service.EphemeralStorage), 0)
+	output.KeyValue("Capacity Providers", "%s", 0, capacityProviderStrategyString(service.CapacityProviderStrategy))
+
+	if service.TaskRole != "" {
+		output.KeyValue("Task Role", service.TaskRole, 0)
+	}
+
+	output.KeyValue("Subnets", strings.Join(service.SubnetIds, ", "), 0)
+	output.KeyValue("Security Groups", strings.Join(service.SecurityGroupIds, ", "), 0)
+
+	if loadBalancer := document.LoadBalancer; loadBalancer != nil {
+		output.KeyValue("Load Balancer", "", 0)
+		output.KeyValue("Name", loadBalancer.Name, 1)
+		output.KeyValue("DNS Name", loadBalancer.DNSName, 1)
+
+		if len(loadBalancer.Listeners) > 0 {
+			output.KeyValue("Ports", "", 1)
+		}
+
+		for _, listener := range loadBalancer.Listeners {
+			var ruleOutput []string
 
-		for _, task := range tasks {
-			if task.EniId != "" {
-				eniIds = append(eniIds, task.EniId)
+			for _, rule := range listener.Rules {
+				ruleOutput = append(ruleOutput, rule.String())
+			}
+
+			output.KeyValue(listener.String(), "", 2)
+			output.KeyValue("Rules", strings.Join(ruleOutput, ", "), 3)
+
+			if len(listener.CertificateARNs) > 0 {
+				certificateDomains := acm.ListCertificateDomainNames(listener.CertificateARNs)
+				output.KeyValue("Certificates", strings.Join(certificateDomains, ", "), 3)
 			}
 		}
+	}
+
+	if targetGroup := document.TargetGroup; targetGroup != nil {
+		healthCheck := targetGroup.HealthCheck
 
-		enis := ec2.DescribeNetworkInterfaces(eniIds)
-		w := new(tabwriter.Writer)
+		output.KeyValue("Health Check", "", 0)
 
-		w.Init(os.Stdout, 0, 8, 1, '\t', 0)
-		fmt.Fprintln(w, "ID\tIMAGE\tSTATUS\tRUNNING\tIP\tCPU\tMEMORY\tDEPLOYMENT\t")
+		if healthCheck.Path != "" {
+			output.KeyValue("Path", healthCheck.Path, 1)
+		}
+
+		if healthCheck.Matcher != "" {
+			output.KeyValue("Matcher", healthCheck.Matcher, 1)
+		}
+
+		output.KeyValue("Interval", "%ds", 1, healthCheck.IntervalSeconds)
+		output.KeyValue("Healthy Threshold", "%d", 1, healthCheck.HealthyThreshold)
+		output.KeyValue("Unhealthy Threshold", "%d", 1, healthCheck.UnhealthyThreshold)
+		output.KeyValue("Deregistration Delay", "%ds", 1, targetGroup.DeregistrationDelay)
+	}
+
+	if healthCheck := service.HealthCheck; healthCheck != nil {
+		output.KeyValue("Container Health Check", "", 0)
+		output.KeyValue("Command", healthCheck.Command, 1)
+
+		if healthCheck.Interval != 0 {
+			output.KeyValue("Interval", "%ds", 1, healthCheck.Interval)
+		}
+
+		if healthCheck.Retries != 0 {
+			output.KeyValue("Retries", "%d", 1, healthCheck.Retries)
+		}
+
+		if healthCheck.StartPeriod != 0 {
+			output.KeyValue("Start Period", "%ds", 1, healthCheck.StartPeriod)
+		}
+	}
 
-		for _, t := range tasks {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+	if len(service.EnvVars) > 0 {
+		output.KeyValue("Environment Variables", "", 0)
+
+		for _, envVar := range service.EnvVars {
+			output.Say("%s=%s", 1, envVar.Key, envVar.Value)
+		}
+	}
+
+	if len(service.Secrets) > 0 {
+		output.KeyValue("Secrets", "", 0)
+
+		for _, secret := range service.Secrets {
+			output.Say("%s=%s", 1, secret.Key, secret.ValueFrom)
+		}
+	}
+
+	displaySidecars(output, service.Containers, 0)
+	displayVolumes(output, service.Volumes, service.Containers, 0)
+
+	if autoscaling := document.Autoscaling; autoscaling != nil {
+		output.KeyValue("Autoscaling", "", 0)
+		output.KeyValue("Min", "%d", 1, autoscaling.MinCapacity)
+		output.KeyValue("Max", "%d", 1, autoscaling.MaxCapacity)
+
+		if len(autoscaling.Policies) > 0 {
+			output.KeyValue("Policies", "", 1)
+
+			for _, policy := range autoscaling.Policies {
+				output.KeyValue(policy.Name, "%s %g", 2, policy.Metric, policy.TargetValue)
+			}
+		}
+
+		if len(autoscaling.ScheduledActions) > 0 {
+			output.KeyValue("Scheduled Actions", "", 1)
+
+			for _, action := range autoscaling.ScheduledActions {
+				output.KeyValue(action.Name, "%s min=%d max=%d", 2, action.Schedule, action.MinCapacity, action.MaxCapacity)
+			}
+		}
+	}
+
+	if len(document.Tasks) > 0 {
+		header := []string{"ID", "IMAGE", "STATUS", "RUNNING", "IP", "CPU", "MEMORY", "DEPLOYMENT"}
+
+		if service.TargetGroupArn != "" {
+			header = append(header, "HEALTH")
+		}
+
+		rows := [][]string{header}
+
+		for _, t := range document.Tasks {
+			row := []string{
 				t.TaskId,
 				t.Image,
 				Humanize(t.LastStatus),
-				t.RunningFor(),
-				enis[t.EniId].PublicIpAddress,
+				t.RunningFor().String(),
+				t.PublicIPAddress,
 				t.Cpu,
 				t.Memory,
 				t.DeploymentId,
-			)
+			}
+
+			if service.TargetGroupArn != "" {
+				row = append(row, t.HealthString())
+			}
+
+			rows = append(rows, row)
 		}
 
-		w.Flush()
+		output.LineBreak()
+		output.Table("Tasks", rows)
 	}
 
-	if len(service.Deployments) > 0 {
-		console.Header("Deployments")
+	if len(document.TargetHealth) > 0 {
+		rows := [][]string{
+			[]string{"TARGET", "PORT", "STATE", "DESCRIPTION"},
+		}
+
+		for _, target := range document.TargetHealth {
+			rows = append(rows, []string{target.ID, fmt.Sprintf("%d", target.Port), Humanize(target.State), target.Description})
+		}
+
+		output.LineBreak()
+		output.Table("Targets", rows)
+	}
 
-		w := new(tabwriter.Writer)
-		w.Init(os.Stdout, 0, 8, 1, '\t', 0)
-		fmt.Fprintln(w, "ID\tIMAGE\tSTATUS\tCREATED\tDESIRED\tRUNNING\tPENDING")
+	if len(service.Deployments) > 0 {
+		rows := [][]string{
+			[]string{"ID", "IMAGE", "DIGEST", "COMMIT", "STATUS", "CREATED", "DESIRED", "RUNNING", "PENDING"},
+		}
 
 		for _, d := range service.Deployments {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%d\n",
-				d.Id,
-				d.Image,
-				Humanize(d.Status),
-				d.CreatedAt,
-				d.DesiredCount,
-				d.RunningCount,
-				d.PendingCount,
+			var commit string
+
+			image := d.Image
+
+			if d.RequestedImage != "" {
+				image = d.RequestedImage
+			}
+
+			if d.Commit != nil {
+				commit = d.Commit.String()
+			}
+
+			rows = append(rows,
+				[]string{
+					d.Id,
+					image,
+					d.Digest,
+					commit,
+					Humanize(d.Status),
+					d.CreatedAt.String(),
+					fmt.Sprintf("%d", d.DesiredCount),
+					fmt.Sprintf("%d", d.RunningCount),
+					fmt.Sprintf("%d", d.PendingCount),
+				},
 			)
 		}
 
-		w.Flush()
+		output.LineBreak()
+		output.Table("Deployments", rows)
 	}
 
 	if len(service.Events) > 0 {
-		console.Header("Events")
+		var rows [][]string
 
 		for i, event := range service.Events {
-			fmt.Printf("[%s] %s\n", event.CreatedAt, event.Message)
+			rows = append(rows, []string{fmt.Sprintf("[%s] %s", event.CreatedAt, event.Message)})
 
 			if i == 10 && !verbose {
 				break
 			}
 		}
+
+		output.LineBreak()
+		output.Table("Events", rows)
+	}
+
+	if document.Autoscaling != nil && len(document.Autoscaling.Activities) > 0 {
+		rows := [][]string{
+			[]string{"STARTED", "STATUS", "DESCRIPTION"},
+		}
+
+		for _, activity := range document.Autoscaling.Activities {
+			rows = append(rows, []string{activity.StartTime.String(), activity.StatusCode, activity.Description})
+		}
+
+		output.LineBreak()
+		output.Table("Scaling Activities", rows)
 	}
 }
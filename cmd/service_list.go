@@ -2,15 +2,19 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"text/tabwriter"
 
-	"github.com/awslabs/fargatecli/console"
 	ECS "github.com/awslabs/fargatecli/ecs"
 	ELBV2 "github.com/awslabs/fargatecli/elbv2"
 	"github.com/spf13/cobra"
 )
 
+// serviceListDocument is the structured representation of a service and the name of the load
+// balancer it is attached to.
+type serviceListDocument struct {
+	ECS.Service
+	LoadBalancerName string `json:"loadBalancerName,omitempty"`
+}
+
 var serviceListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List services",
@@ -57,35 +61,42 @@ func listServices() {
 		}
 	}
 
-	if len(services) > 0 {
-		w := new(tabwriter.Writer)
-		w.Init(os.Stdout, 0, 8, 1, '\t', 0)
-		fmt.Fprintln(w, "NAME\tIMAGE\tCPU\tMEMORY\tLOAD BALANCER\tDESIRED\tRUNNING\tPENDING\t")
+	if len(services) == 0 {
+		output.Document([]serviceListDocument{})
+		output.Info("No services found")
+		return
+	}
 
-		for _, service := range services {
-			var loadBalancer string
+	documents := []serviceListDocument{}
+	rows := [][]string{
+		[]string{"NAME", "IMAGE", "CPU", "MEMORY", "LOAD BALANCER", "DESIRED", "RUNNING", "PENDING"},
+	}
 
-			if service.TargetGroupArn != "" {
-				tg := targetGroups[service.TargetGroupArn]
-				lb := loadBalancers[tg.LoadBalancerARN]
+	for _, service := range services {
+		var loadBalancer string
 
-				loadBalancer = lb.Name
-			}
+		if service.TargetGroupArn != "" {
+			tg := targetGroups[service.TargetGroupArn]
+			lb := loadBalancers[tg.LoadBalancerARN]
+
+			loadBalancer = lb.Name
+		}
 
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t\n",
+		documents = append(documents, serviceListDocument{Service: service, LoadBalancerName: loadBalancer})
+		rows = append(rows,
+			[]string{
 				service.Name,
 				service.Image,
 				service.Cpu,
 				service.Memory,
 				loadBalancer,
-				service.DesiredCount,
-				service.RunningCount,
-				service.PendingCount,
-			)
-		}
-
-		w.Flush()
-	} else {
-		console.Info("No services found")
+				fmt.Sprintf("%d", service.DesiredCount),
+				fmt.Sprintf("%d", service.RunningCount),
+				fmt.Sprintf("%d", service.PendingCount),
+			},
+		)
 	}
+
+	output.Document(documents)
+	output.Table("", rows)
 }
@@ -1,13 +1,9 @@
 package cmd
 
 import (
-	"fmt"
-	"os"
-	"text/tabwriter"
-
-	"github.com/awslabs/fargatecli/console"
 	EC2 "github.com/awslabs/fargatecli/ec2"
 	ECS "github.com/awslabs/fargatecli/ecs"
+	ELBV2 "github.com/awslabs/fargatecli/elbv2"
 	"github.com/spf13/cobra"
 )
 
@@ -18,7 +14,14 @@ type ServiceProcessListOperation struct {
 var servicePsCmd = &cobra.Command{
 	Use:   "ps <service-name>",
 	Short: "List running tasks for a service",
-	Args:  cobra.ExactArgs(1),
+	Long: `List running tasks for a service
+
+If the service uses a load balancer, the health of each task as determined by
+the load balancer's health checks is shown. Tasks which are not healthy include
+the reason code and description reported by the load balancer. If the service's
+container has a health check command, the container health of each task is
+shown.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		operation := &ServiceProcessListOperation{
 			ServiceName: args[0],
@@ -45,27 +48,64 @@ func getServiceProcessList(operation *ServiceProcessListOperation) {
 		}
 	}
 
-	if len(tasks) > 0 {
-		enis := ec2.DescribeNetworkInterfaces(eniIds)
-
-		w := new(tabwriter.Writer)
-		w.Init(os.Stdout, 0, 8, 1, '\t', 0)
-		fmt.Fprintln(w, "ID\tIMAGE\tSTATUS\tRUNNING\tIP\tCPU\tMEMORY\t")
-
-		for _, t := range tasks {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-				t.TaskId,
-				t.Image,
-				Humanize(t.LastStatus),
-				t.RunningFor(),
-				enis[t.EniId].PublicIpAddress,
-				t.Cpu,
-				t.Memory,
-			)
+	if len(tasks) == 0 {
+		output.Document([]taskDocument{})
+		output.Info("No tasks found")
+		return
+	}
+
+	documents := newTaskDocuments(tasks, ec2.DescribeNetworkInterfaces(eniIds))
+	service := ecs.DescribeService(operation.ServiceName)
+	header := []string{"ID", "IMAGE", "STATUS", "RUNNING", "IP", "CPU", "MEMORY"}
+
+	if service.TargetGroupArn != "" {
+		targetHealths, err := ELBV2.New(sess).DescribeTargetHealth(service.TargetGroupArn)
+
+		if err != nil {
+			output.Fatal(err, "Could not describe target health of service %s", operation.ServiceName)
+			return
+		}
+
+		documents = withTargetHealth(documents, targetHealths)
+		header = append(header, "HEALTH")
+	}
+
+	if hasContainerHealthChecks(documents) {
+		header = append(header, "CONTAINER HEALTH")
+	}
+
+	if hasCapacityProviders(documents) {
+		header = append(header, "PROVIDER")
+	}
+
+	rows := [][]string{header}
+
+	for _, t := range documents {
+		row := []string{
+			t.TaskId,
+			t.Image,
+			Humanize(t.LastStatus),
+			t.RunningFor().String(),
+			t.PublicIPAddress,
+			t.Cpu,
+			t.Memory,
+		}
+
+		if service.TargetGroupArn != "" {
+			row = append(row, t.HealthString())
 		}
 
-		w.Flush()
-	} else {
-		console.Info("No tasks found")
+		if hasContainerHealthChecks(documents) {
+			row = append(row, t.ContainerHealthString())
+		}
+
+		if hasCapacityProviders(documents) {
+			row = append(row, t.CapacityProviderName)
+		}
+
+		rows = append(rows, row)
 	}
+
+	output.Document(documents)
+	output.Table("", rows)
 }
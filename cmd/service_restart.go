@@ -1,15 +1,23 @@
 package cmd
 
 import (
-	"github.com/awslabs/fargatecli/console"
+	"time"
+
 	ECS "github.com/awslabs/fargatecli/ecs"
 	"github.com/spf13/cobra"
 )
 
 type ServiceRestartOperation struct {
 	ServiceName string
+	Timeout     time.Duration
+	Wait        bool
 }
 
+var (
+	flagServiceRestartTimeout time.Duration
+	flagServiceRestartWait    bool
+)
+
 var serviceRestartCmd = &cobra.Command{
 	Use:   "restart <service-name>",
 	Short: "Restart service",
@@ -17,11 +25,18 @@ var serviceRestartCmd = &cobra.Command{
 
 Creates a new set of tasks for the service and stops the previous tasks. This
 is useful if your service needs to reload data cached from an external source,
-for example.`,
+for example.
+
+Pass the --wait flag to wait for the new set of tasks to become stable. The
+command exits with a non-zero status if the deployment does not become stable
+within the time given by --timeout (default 10m) or if tasks from the new
+deployment repeatedly stop.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		operation := &ServiceRestartOperation{
 			ServiceName: args[0],
+			Timeout:     flagServiceRestartTimeout,
+			Wait:        flagServiceRestartWait,
 		}
 
 		restartService(operation)
@@ -29,12 +44,21 @@ for example.`,
 }
 
 func init() {
+	serviceRestartCmd.Flags().BoolVarP(&flagServiceRestartWait, "wait", "w", false, "Wait for the deployment to become stable")
+	serviceRestartCmd.Flags().DurationVar(&flagServiceRestartTimeout, "timeout", defaultDeploymentTimeout, "Time to wait for the deployment to become stable")
+
 	serviceCmd.AddCommand(serviceRestartCmd)
 }
 
 func restartService(operation *ServiceRestartOperation) {
 	ecs := ECS.New(sess, clusterName)
+	since := time.Now()
 
 	ecs.RestartService(operation.ServiceName)
-	console.Info("Restarted %s", operation.ServiceName)
+	output.Info("Restarted %s", operation.ServiceName)
+
+	if operation.Wait {
+		service := ecs.DescribeService(operation.ServiceName)
+		waitForServiceDeployment(operation.ServiceName, service.TaskDefinitionArn, "", since, operation.Timeout)
+	}
 }
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+	"github.com/spf13/cobra"
+)
+
+const serviceTaskDefinitionFamilyFormat = typeService + "_%s"
+
+type serviceRollbackDocument struct {
+	CurrentTaskDefinitionARN string   `json:"currentTaskDefinitionArn"`
+	TargetTaskDefinitionARN  string   `json:"targetTaskDefinitionArn"`
+	TaskDefinitionARNs       []string `json:"taskDefinitionArns"`
+}
+
+type serviceRollbackOperation struct {
+	dryRun      bool
+	ecs         ECS.Client
+	output      Output
+	revision    string
+	serviceName string
+	timeout     time.Duration
+	wait        bool
+}
+
+func (o serviceRollbackOperation) validate() error {
+	if o.revision == "" {
+		return nil
+	}
+
+	if n, err := strconv.ParseInt(o.revision, 10, 64); err != nil || n < 1 {
+		return fmt.Errorf("--to must be a task definition revision number, got %s", o.revision)
+	}
+
+	return nil
+}
+
+func (o serviceRollbackOperation) execute() {
+	o.output.Debug("Describing service [API=ecs Action=DescribeServices Service=%s]", o.serviceName)
+	service, err := o.ecs.DescribeServiceDeployments(o.serviceName)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not describe service %s", o.serviceName)
+		return
+	}
+
+	family := fmt.Sprintf(serviceTaskDefinitionFamilyFormat, o.serviceName)
+
+	o.output.Debug("Listing task definitions [API=ecs Action=ListTaskDefinitions Family=%s]", family)
+	taskDefinitionARNs, err := o.ecs.ListTaskDefinitionRevisions(family)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not list revisions of service %s", o.serviceName)
+		return
+	}
+
+	targetARN, err := o.target(service.TaskDefinitionArn, taskDefinitionARNs)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not roll back service %s", o.serviceName)
+		return
+	}
+
+	o.display(service.TaskDefinitionArn, targetARN, taskDefinitionARNs)
+
+	currentRevision := ECS.TaskDefinitionRevision(service.TaskDefinitionArn)
+	targetRevision := ECS.TaskDefinitionRevision(targetARN)
+
+	if o.dryRun {
+		plan := &changePlan{
+			DryRun: true,
+			Steps: []changeStep{
+				changeStep{
+					API:      "ecs",
+					Action:   "UpdateService",
+					Resource: "service",
+					Name:     o.serviceName,
+					ARN:      targetARN,
+					Changes:  diffField("revision", currentRevision, targetRevision),
+				},
+			},
+		}
+
+		plan.display(o.output)
+		return
+	}
+
+	since := time.Now()
+
+	o.output.Debug("Updating service [API=ecs Action=UpdateService TaskDefinition=%s]", targetARN)
+
+	if err := o.ecs.DeployTaskDefinition(o.serviceName, targetARN); err != nil {
+		o.output.Fatal(err, "Could not roll back service %s", o.serviceName)
+		return
+	}
+
+	o.output.Document(
+		serviceRollbackDocument{
+			CurrentTaskDefinitionARN: service.TaskDefinitionArn,
+			TargetTaskDefinitionARN:  targetARN,
+			TaskDefinitionARNs:       taskDefinitionARNs,
+		},
+	)
+	o.output.Info("Rolled back service %s from revision %s to revision %s", o.serviceName, currentRevision, targetRevision)
+
+	if o.wait {
+		serviceDeploymentWaitOperation{
+			ecs:               o.ecs,
+			interval:          deploymentPollingInterval,
+			output:            o.output,
+			serviceName:       o.serviceName,
+			since:             since,
+			taskDefinitionARN: targetARN,
+			timeout:           o.timeout,
+		}.execute()
+	}
+}
+
+// target returns the ARN of the task definition to roll back to: the requested revision if one was
+// given, otherwise the most recent revision prior to the current one.
+func (o serviceRollbackOperation) target(currentARN string, taskDefinitionARNs []string) (string, error) {
+	current, _ := strconv.ParseInt(ECS.TaskDefinitionRevision(currentARN), 10, 64)
+
+	for _, taskDefinitionARN := range taskDefinitionARNs {
+		revision := ECS.TaskDefinitionRevision(taskDefinitionARN)
+
+		if o.revision != "" {
+			if revision == o.revision {
+				if taskDefinitionARN == currentARN {
+					return "", fmt.Errorf("service is already running revision %s", revision)
+				}
+
+				return taskDefinitionARN, nil
+			}
+
+			continue
+		}
+
+		if n, _ := strconv.ParseInt(revision, 10, 64); n < current {
+			return taskDefinitionARN, nil
+		}
+	}
+
+	if o.revision != "" {
+		return "", fmt.Errorf("revision %s not found", o.revision)
+	}
+
+	return "", fmt.Errorf("no revision prior to %d found", current)
+}
+
+func (o serviceRollbackOperation) display(currentARN, targetARN string, taskDefinitionARNs []string) {
+	rows := [][]string{
+		[]string{"REVISION", "STATUS", "ARN"},
+	}
+
+	for _, taskDefinitionARN := range taskDefinitionARNs {
+		var status string
+
+		switch taskDefinitionARN {
+		case currentARN:
+			status = "current"
+		case targetARN:
+			status = "target"
+		}
+
+		rows = append(rows, []string{ECS.TaskDefinitionRevision(taskDefinitionARN), status, taskDefinitionARN})
+	}
+
+	o.output.Table("Revisions", rows)
+}
+
+var serviceRollbackCmd = &cobra.Command{
+	Use:   "rollback <service-name> [--to <revision>]",
+	Short: "Roll back service to a previous task definition",
+	Long: `Roll back service to a previous task definition
+
+Lists the revisions of the service's task definition and redeploys the service
+with a prior revision. By default the most recent revision before the one the
+service is currently running is used; pass the --to flag with a revision number
+to roll back to a specific revision.
+
+Pass the --wait flag to wait for the rollback to become stable. The command
+exits with a non-zero status if the deployment does not become stable within
+the time given by --timeout (default 10m) or if tasks from the new deployment
+repeatedly stop.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		operation := serviceRollbackOperation{
+			dryRun:      dryRun,
+			ecs:         ECS.New(sess, clusterName),
+			output:      output,
+			revision:    serviceRollbackFlags.to,
+			serviceName: args[0],
+			timeout:     serviceRollbackFlags.timeout,
+			wait:        serviceRollbackFlags.wait,
+		}
+
+		if err := operation.validate(); err != nil {
+			output.Fatal(err, "Invalid command line flags")
+			return
+		}
+
+		operation.execute()
+	},
+}
+
+var serviceRollbackFlags struct {
+	timeout time.Duration
+	to      string
+	wait    bool
+}
+
+func init() {
+	serviceRollbackCmd.Flags().StringVar(&serviceRollbackFlags.to, "to", "", "Revision of the task definition to roll back to (default: the previous revision)")
+	serviceRollbackCmd.Flags().BoolVarP(&serviceRollbackFlags.wait, "wait", "w", false, "Wait for the deployment to become stable")
+	serviceRollbackCmd.Flags().DurationVar(&serviceRollbackFlags.timeout, "timeout", defaultDeploymentTimeout, "Time to wait for the deployment to become stable")
+
+	serviceCmd.AddCommand(serviceRollbackCmd)
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/awslabs/fargatecli/cmd/mock"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	ecsclient "github.com/awslabs/fargatecli/ecs/mock/client"
+	"github.com/golang/mock/gomock"
+)
+
+var rollbackTaskDefinitionARNs = []string{
+	"arn:aws:ecs:us-east-1:123456789012:task-definition/service_web:5",
+	"arn:aws:ecs:us-east-1:123456789012:task-definition/service_web:4",
+	"arn:aws:ecs:us-east-1:123456789012:task-definition/service_web:2",
+}
+
+func TestServiceRollbackOperationValidate(t *testing.T) {
+	var tests = []struct {
+		revision string
+		valid    bool
+	}{
+		{"", true},
+		{"3", true},
+		{"0", false},
+		{"web:3", false},
+	}
+
+	for _, test := range tests {
+		err := serviceRollbackOperation{revision: test.revision}.validate()
+
+		if valid := err == nil; valid != test.valid {
+			t.Errorf("expected revision %q valid == %t, got %v", test.revision, test.valid, err)
+		}
+	}
+}
+
+func TestServiceRollbackOperationTarget(t *testing.T) {
+	var tests = []struct {
+		current  string
+		revision string
+		target   string
+		err      bool
+	}{
+		{rollbackTaskDefinitionARNs[0], "", rollbackTaskDefinitionARNs[1], false},
+		{rollbackTaskDefinitionARNs[1], "", rollbackTaskDefinitionARNs[2], false},
+		{rollbackTaskDefinitionARNs[2], "", "", true},
+		{rollbackTaskDefinitionARNs[0], "2", rollbackTaskDefinitionARNs[2], false},
+		{rollbackTaskDefinitionARNs[2], "5", rollbackTaskDefinitionARNs[0], false},
+		{rollbackTaskDefinitionARNs[0], "5", "", true},
+		{rollbackTaskDefinitionARNs[0], "3", "", true},
+	}
+
+	for _, test := range tests {
+		target, err := serviceRollbackOperation{revision: test.revision}.target(test.current, rollbackTaskDefinitionARNs)
+
+		if (err != nil) != test.err {
+			t.Errorf("current %s revision %q: expected error == %t, got %v", test.current, test.revision, test.err, err)
+		}
+
+		if target != test.target {
+			t.Errorf("current %s revision %q: expected target %s, got %s", test.current, test.revision, test.target, target)
+		}
+	}
+}
+
+func TestServiceRollbackOperation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().DescribeServiceDeployments("web").Return(ECS.Service{TaskDefinitionArn: rollbackTaskDefinitionARNs[0]}, nil)
+	mockClient.EXPECT().ListTaskDefinitionRevisions("service_web").Return(rollbackTaskDefinitionARNs, nil)
+	mockClient.EXPECT().DeployTaskDefinition("web", rollbackTaskDefinitionARNs[1]).Return(nil)
+
+	serviceRollbackOperation{ecs: mockClient, output: mockOutput, serviceName: "web"}.execute()
+
+	if len(mockOutput.FatalMsgs) > 0 {
+		t.Fatalf("expected no fatal messages, got %v", mockOutput.FatalMsgs)
+	}
+
+	if len(mockOutput.Tables) != 1 || len(mockOutput.Tables[0].Rows) != 4 {
+		t.Errorf("expected revisions table with 4 rows, got %v", mockOutput.Tables)
+	}
+
+	if expected, got := "Rolled back service web from revision 5 to revision 4", mockOutput.InfoMsgs[0]; expected != got {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestServiceRollbackOperationJSONOutput(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+
+	mockClient.EXPECT().DescribeServiceDeployments("web").Return(ECS.Service{TaskDefinitionArn: rollbackTaskDefinitionARNs[0]}, nil)
+	mockClient.EXPECT().ListTaskDefinitionRevisions("service_web").Return(rollbackTaskDefinitionARNs, nil)
+	mockClient.EXPECT().DeployTaskDefinition("web", rollbackTaskDefinitionARNs[1]).Return(nil)
+
+	stdout, stderr := captureOutput(t, func() {
+		serviceRollbackOperation{
+			ecs:         mockClient,
+			output:      StructuredOutput{Format: outputFormatJSON, Test: true},
+			serviceName: "web",
+		}.execute()
+	})
+
+	var document serviceRollbackDocument
+
+	if err := json.Unmarshal(stdout, &document); err != nil {
+		t.Fatalf("expected standard output to be a JSON document, got %q: %v", stdout, err)
+	}
+
+	if document.TargetTaskDefinitionARN != rollbackTaskDefinitionARNs[1] {
+		t.Errorf("expected target %s, got %s", rollbackTaskDefinitionARNs[1], document.TargetTaskDefinitionARN)
+	}
+
+	if expected := "[i] Rolled back service web from revision 5 to revision 4\n"; string(stderr) != expected {
+		t.Errorf("expected standard error %q, got %q", expected, stderr)
+	}
+}
+
+func TestServiceRollbackOperationDryRun(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().DescribeServiceDeployments("web").Return(ECS.Service{TaskDefinitionArn: rollbackTaskDefinitionARNs[0]}, nil)
+	mockClient.EXPECT().ListTaskDefinitionRevisions("service_web").Return(rollbackTaskDefinitionARNs, nil)
+
+	serviceRollbackOperation{dryRun: true, ecs: mockClient, output: mockOutput, revision: "2", serviceName: "web"}.execute()
+
+	if len(mockOutput.Documents) != 1 {
+		t.Fatalf("expected 1 document, got %d", len(mockOutput.Documents))
+	}
+
+	plan := mockOutput.Documents[0].(*changePlan)
+
+	if expected, got := "revision: 5 => 2", plan.Steps[0].Changes[0]; expected != got {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestServiceRollbackOperationDeployError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().DescribeServiceDeployments("web").Return(ECS.Service{TaskDefinitionArn: rollbackTaskDefinitionARNs[0]}, nil)
+	mockClient.EXPECT().ListTaskDefinitionRevisions("service_web").Return(rollbackTaskDefinitionARNs, nil)
+	mockClient.EXPECT().DeployTaskDefinition("web", rollbackTaskDefinitionARNs[1]).Return(errors.New("boom"))
+
+	serviceRollbackOperation{ecs: mockClient, output: mockOutput, serviceName: "web"}.execute()
+
+	if len(mockOutput.FatalMsgs) != 1 {
+		t.Fatalf("expected 1 fatal message, got %d", len(mockOutput.FatalMsgs))
+	}
+
+	if expected, got := "Could not roll back service web", mockOutput.FatalMsgs[0].Msg; expected != got {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestServiceRollbackOperationNoPriorRevision(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().DescribeServiceDeployments("web").Return(ECS.Service{TaskDefinitionArn: rollbackTaskDefinitionARNs[2]}, nil)
+	mockClient.EXPECT().ListTaskDefinitionRevisions("service_web").Return(rollbackTaskDefinitionARNs, nil)
+
+	serviceRollbackOperation{ecs: mockClient, output: mockOutput, serviceName: "web"}.execute()
+
+	if len(mockOutput.FatalMsgs) != 1 {
+		t.Fatalf("expected 1 fatal message, got %d", len(mockOutput.FatalMsgs))
+	}
+}
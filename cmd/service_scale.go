@@ -53,7 +53,8 @@ var serviceScaleCmd = &cobra.Command{
 Changes the number of desired tasks to be run in a service by the given scale
 expression. A scale expression can either be an absolute number or a delta
 specified with a sign such as +5 or -2.`,
-	Args: cobra.ExactArgs(2),
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
 	Run: func(cmd *cobra.Command, args []string) {
 		operation := &ScaleServiceOperation{
 			ServiceName: args[0],
@@ -71,7 +72,26 @@ func init() {
 
 func scaleService(operation *ScaleServiceOperation) {
 	ecs := ECS.New(sess, clusterName)
+	plan := newChangePlan()
 
-	ecs.SetDesiredCount(operation.ServiceName, operation.DesiredCount)
-	console.Info("Scaled service %s to %d", operation.ServiceName, operation.DesiredCount)
+	plan.do(
+		changeStep{
+			API:      "ecs",
+			Action:   "UpdateService",
+			Resource: "service",
+			Name:     operation.ServiceName,
+			Changes: diffField(
+				"num",
+				strconv.FormatInt(ecs.GetDesiredCount(operation.ServiceName), 10),
+				strconv.FormatInt(operation.DesiredCount, 10),
+			),
+		},
+		func() { ecs.SetDesiredCount(operation.ServiceName, operation.DesiredCount) },
+	)
+
+	if plan.display(output) {
+		return
+	}
+
+	output.Info("Scaled service %s to %d", operation.ServiceName, operation.DesiredCount)
 }
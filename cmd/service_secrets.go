@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var serviceSecretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Manage secrets",
+	Long: `Manage secrets
+
+Secrets are environment variables whose values are read from AWS Systems
+Manager Parameter Store parameters or AWS Secrets Manager secrets when the
+service's containers start. Only the name or ARN of each parameter or secret is
+stored in the service's task definition.`,
+}
+
+func init() {
+	serviceCmd.AddCommand(serviceSecretsCmd)
+}
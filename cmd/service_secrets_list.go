@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	ECS "github.com/awslabs/fargatecli/ecs"
+	"github.com/spf13/cobra"
+)
+
+type ServiceSecretsListOperation struct {
+	ServiceName string
+	Container   string
+}
+
+var serviceSecretsListCmd = &cobra.Command{
+	Use:   "list <service-name>",
+	Short: "Show secrets",
+	Long: `Show secrets
+
+Lists the key of each secret along with the name or ARN of the parameter or
+secret its value is read from. Secret values themselves are not displayed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		operation := &ServiceSecretsListOperation{
+			ServiceName: args[0],
+			Container:   flagServiceSecretsListContainer,
+		}
+
+		serviceSecretsList(operation)
+	},
+}
+
+var flagServiceSecretsListContainer string
+
+func init() {
+	serviceSecretsListCmd.Flags().StringVar(&flagServiceSecretsListContainer, "container", "", "Name of the container to show secrets of (default: the first container)")
+
+	serviceSecretsCmd.AddCommand(serviceSecretsListCmd)
+}
+
+func serviceSecretsList(operation *ServiceSecretsListOperation) {
+	ecs := ECS.New(sess, clusterName)
+	service := ecs.DescribeService(operation.ServiceName)
+	container := findServiceContainer(service, operation.Container)
+	secrets := ecs.GetSecretsFromTaskDefinition(service.TaskDefinitionArn, container.Name)
+
+	output.Document(secrets)
+
+	for _, secret := range secrets {
+		output.Say("%s=%s", 0, secret.Key, secret.ValueFrom)
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/awslabs/fargatecli/console"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	IAM "github.com/awslabs/fargatecli/iam"
+	"github.com/spf13/cobra"
+)
+
+type ServiceSecretsSetOperation struct {
+	ServiceName string
+	Container   string
+	KMSKeys     []string
+	Secrets     []ECS.Secret
+	Rollback    bool
+	Timeout     time.Duration
+	Wait        bool
+}
+
+func (o *ServiceSecretsSetOperation) Validate() {
+	if len(o.Secrets) == 0 {
+		console.IssueExit("No secrets specified")
+	}
+}
+
+func (o *ServiceSecretsSetOperation) SetSecrets(inputSecrets []string) {
+	o.Secrets = extractSecrets(inputSecrets)
+}
+
+// SetKMSKeys sets the customer managed KMS keys the task execution role is allowed to decrypt
+// secrets with.
+func (o *ServiceSecretsSetOperation) SetKMSKeys(inputKMSKeys []string) {
+	if err := validateKMSKeys(inputKMSKeys); err != nil {
+		console.ErrorExit(err, "Invalid KMS key")
+	}
+
+	o.KMSKeys = inputKMSKeys
+}
+
+var (
+	flagServiceSecretsSetContainer string
+	flagServiceSecretsSetKMSKeys   []string
+	flagServiceSecretsSetRollback  bool
+	flagServiceSecretsSetSecrets   []string
+	flagServiceSecretsSetTimeout   time.Duration
+	flagServiceSecretsSetWait      bool
+)
+
+var serviceSecretsSetCmd = &cobra.Command{
+	Use:   "set <service-name> --secret <key=parameter-name|arn> [--secret <key=parameter-name|arn>] ...",
+	Short: "Set secrets",
+	Long: `Set secrets
+
+At least one secret must be specified via the --secret flag. Specify --secret
+with a KEY=parameter-name or KEY=arn parameter multiple times to add multiple
+secrets. Values may be the name of an SSM parameter in the current region or the
+ARN of an SSM parameter or Secrets Manager secret. Setting a secret that already
+exists replaces its source.
+
+The service's task execution role is granted access to read the referenced
+parameters and secrets. Parameters and secrets encrypted with a customer managed
+KMS key also require kms:Decrypt permission on the key, which is granted by
+passing the --kms-key flag with the key's ARN.
+
+Pass the --wait flag to wait for the resulting deployment to become stable. The
+command exits with a non-zero status if the deployment does not become stable
+within the time given by --timeout (default 10m) or if tasks from the new
+deployment repeatedly stop.
+
+If the deployment fails while waiting, the service is rolled back to the task
+definition it ran before the deployment. Pass --rollback=false to leave the
+service on the failed deployment.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		operation := &ServiceSecretsSetOperation{
+			ServiceName: args[0],
+			Container:   flagServiceSecretsSetContainer,
+			Rollback:    flagServiceSecretsSetRollback,
+			Timeout:     flagServiceSecretsSetTimeout,
+			Wait:        flagServiceSecretsSetWait,
+		}
+
+		operation.SetSecrets(flagServiceSecretsSetSecrets)
+		operation.SetKMSKeys(flagServiceSecretsSetKMSKeys)
+		operation.Validate()
+		serviceSecretsSet(operation)
+	},
+}
+
+func init() {
+	serviceSecretsSetCmd.Flags().StringArrayVarP(&flagServiceSecretsSetSecrets, "secret", "s", []string{}, "Secrets to set [e.g. KEY=parameter-name, KEY=arn]")
+	serviceSecretsSetCmd.Flags().StringSliceVar(&flagServiceSecretsSetKMSKeys, "kms-key", []string{}, "ARN of a customer managed KMS key the task execution role may decrypt secrets with (can be specified multiple times)")
+	serviceSecretsSetCmd.Flags().BoolVarP(&flagServiceSecretsSetWait, "wait", "w", false, "Wait for the deployment to become stable")
+	serviceSecretsSetCmd.Flags().DurationVar(&flagServiceSecretsSetTimeout, "timeout", defaultDeploymentTimeout, "Time to wait for the deployment to become stable")
+	serviceSecretsSetCmd.Flags().BoolVar(&flagServiceSecretsSetRollback, "rollback", true, "Roll back to the previous task definition if the deployment fails while waiting")
+
+	serviceSecretsSetCmd.Flags().StringVar(&flagServiceSecretsSetContainer, "container", "", "Name of the container to set secrets in (default: the first container)")
+
+	serviceSecretsCmd.AddCommand(serviceSecretsSetCmd)
+}
+
+func serviceSecretsSet(operation *ServiceSecretsSetOperation) {
+	var taskDefinitionArn string
+
+	ecs := ECS.New(sess, clusterName)
+	service := ecs.DescribeService(operation.ServiceName)
+	container := findServiceContainer(service, operation.Container)
+	executionRoleArn := aws.StringValue(ecs.DescribeTaskDefinition(service.TaskDefinitionArn).ExecutionRoleArn)
+	plan := newChangePlan()
+
+	if executionRoleArn == "" {
+		console.IssueExit("Service %s has no task execution role with which to read secrets", operation.ServiceName)
+	}
+
+	plan.do(
+		changeStep{
+			API:      "iam",
+			Action:   "PutRolePolicy",
+			Resource: "role",
+			Name:     IAM.RoleName(executionRoleArn),
+			ARN:      executionRoleArn,
+			Changes:  append(secretChanges(container.Secrets, operation.Secrets, nil), kmsKeyChanges(operation.KMSKeys)...),
+		},
+		func() { grantSecretsAccess(executionRoleArn, operation.Secrets, operation.KMSKeys) },
+	)
+
+	plan.do(
+		changeStep{
+			API:      "ecs",
+			Action:   "RegisterTaskDefinition",
+			Resource: "task definition",
+			Name:     operation.ServiceName,
+			ARN:      service.TaskDefinitionArn,
+			Changes:  secretChanges(container.Secrets, operation.Secrets, nil),
+		},
+		func() {
+			taskDefinitionArn = ecs.AddSecretsToTaskDefinition(service.TaskDefinitionArn, container.Name, operation.Secrets)
+		},
+	)
+
+	since := time.Now()
+
+	plan.do(
+		changeStep{API: "ecs", Action: "UpdateService", Resource: "service", Name: operation.ServiceName},
+		func() { ecs.UpdateServiceTaskDefinition(operation.ServiceName, taskDefinitionArn) },
+	)
+
+	if plan.display(output) {
+		return
+	}
+
+	output.Info("Set %s secrets:", operation.ServiceName)
+
+	for _, secret := range operation.Secrets {
+		output.Say("- %s=%s", 1, secret.Key, secret.ValueFrom)
+	}
+
+	if operation.Wait {
+		waitForServiceDeployment(
+			operation.ServiceName,
+			taskDefinitionArn,
+			rollbackTaskDefinitionARN(operation.Rollback, service.TaskDefinitionArn),
+			since,
+			operation.Timeout,
+		)
+	}
+}
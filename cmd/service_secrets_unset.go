@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/awslabs/fargatecli/console"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	"github.com/spf13/cobra"
+)
+
+type ServiceSecretsUnsetOperation struct {
+	ServiceName string
+	Container   string
+	Keys        []string
+	Rollback    bool
+	Timeout     time.Duration
+	Wait        bool
+}
+
+func (o *ServiceSecretsUnsetOperation) Validate() {
+	if len(o.Keys) == 0 {
+		console.IssueExit("No keys specified")
+	}
+}
+
+func (o *ServiceSecretsUnsetOperation) SetKeys(keys []string) {
+	o.Keys = Map(keys, strings.ToUpper)
+}
+
+var serviceSecretsUnsetCmd = &cobra.Command{
+	Use:   "unset <service-name> --key <key-name> [--key <key-name>] ...",
+	Short: "Unset secrets",
+	Long: `Unset secrets
+
+Unsets the secret specified via the --key flag. Specify --key with a key name
+multiple times to unset multiple secrets. The task execution role's access to
+the underlying parameters and secrets is left in place.
+
+Pass the --wait flag to wait for the resulting deployment to become stable. The
+command exits with a non-zero status if the deployment does not become stable
+within the time given by --timeout (default 10m) or if tasks from the new
+deployment repeatedly stop.
+
+If the deployment fails while waiting, the service is rolled back to the task
+definition it ran before the deployment. Pass --rollback=false to leave the
+service on the failed deployment.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		operation := &ServiceSecretsUnsetOperation{
+			ServiceName: args[0],
+			Container:   flagServiceSecretsUnsetContainer,
+			Rollback:    flagServiceSecretsUnsetRollback,
+			Timeout:     flagServiceSecretsUnsetTimeout,
+			Wait:        flagServiceSecretsUnsetWait,
+		}
+
+		operation.SetKeys(flagServiceSecretsUnsetKeys)
+		operation.Validate()
+		serviceSecretsUnset(operation)
+	},
+}
+
+var (
+	flagServiceSecretsUnsetContainer string
+	flagServiceSecretsUnsetKeys      []string
+	flagServiceSecretsUnsetRollback  bool
+	flagServiceSecretsUnsetTimeout   time.Duration
+	flagServiceSecretsUnsetWait      bool
+)
+
+func init() {
+	serviceSecretsUnsetCmd.Flags().StringSliceVarP(&flagServiceSecretsUnsetKeys, "key", "k", []string{}, "Secret keys to unset [e.g. KEY, DB_PASSWORD]")
+
+	serviceSecretsUnsetCmd.Flags().BoolVarP(&flagServiceSecretsUnsetWait, "wait", "w", false, "Wait for the deployment to become stable")
+	serviceSecretsUnsetCmd.Flags().DurationVar(&flagServiceSecretsUnsetTimeout, "timeout", defaultDeploymentTimeout, "Time to wait for the deployment to become stable")
+	serviceSecretsUnsetCmd.Flags().BoolVar(&flagServiceSecretsUnsetRollback, "rollback", true, "Roll back to the previous task definition if the deployment fails while waiting")
+
+	serviceSecretsUnsetCmd.Flags().StringVar(&flagServiceSecretsUnsetContainer, "container", "", "Name of the container to unset secrets in (default: the first container)")
+
+	serviceSecretsCmd.AddCommand(serviceSecretsUnsetCmd)
+}
+
+func serviceSecretsUnset(operation *ServiceSecretsUnsetOperation) {
+	var taskDefinitionArn string
+
+	ecs := ECS.New(sess, clusterName)
+	service := ecs.DescribeService(operation.ServiceName)
+	container := findServiceContainer(service, operation.Container)
+	plan := newChangePlan()
+
+	plan.do(
+		changeStep{
+			API:      "ecs",
+			Action:   "RegisterTaskDefinition",
+			Resource: "task definition",
+			Name:     operation.ServiceName,
+			ARN:      service.TaskDefinitionArn,
+			Changes:  secretChanges(container.Secrets, nil, operation.Keys),
+		},
+		func() {
+			taskDefinitionArn = ecs.RemoveSecretsFromTaskDefinition(service.TaskDefinitionArn, container.Name, operation.Keys)
+		},
+	)
+
+	since := time.Now()
+
+	plan.do(
+		changeStep{API: "ecs", Action: "UpdateService", Resource: "service", Name: operation.ServiceName},
+		func() { ecs.UpdateServiceTaskDefinition(operation.ServiceName, taskDefinitionArn) },
+	)
+
+	if plan.display(output) {
+		return
+	}
+
+	output.Info("Unset %s secrets:", operation.ServiceName)
+
+	for _, key := range operation.Keys {
+		output.Say("- %s", 1, key)
+	}
+
+	if operation.Wait {
+		waitForServiceDeployment(
+			operation.ServiceName,
+			taskDefinitionArn,
+			rollbackTaskDefinitionARN(operation.Rollback, service.TaskDefinitionArn),
+			since,
+			operation.Timeout,
+		)
+	}
+}
@@ -2,27 +2,238 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/awslabs/fargatecli/console"
 	ECS "github.com/awslabs/fargatecli/ecs"
+	ELBV2 "github.com/awslabs/fargatecli/elbv2"
 	"github.com/spf13/cobra"
 )
 
 type ServiceUpdateOperation struct {
-	ServiceName string
-	Cpu         string
-	Memory      string
-	Service     ECS.Service
+	ServiceName              string
+	CapacityProviderStrategy ECS.CapacityProviderStrategy
+	Container                string
+	ContainerHealthCheck     ECS.HealthCheck
+	Cpu                      string
+	DeregistrationDelay      *int64
+	EphemeralStorage         int64
+	HealthCheck              ELBV2.HealthCheck
+	Memory                   string
+	MountPoints              []ECS.MountPoint
+	RemoveMountPoints        []string
+	RemoveVolumes            []string
+	Service                  ECS.Service
+	Rollback                 bool
+	RuntimePlatform          ECS.RuntimePlatform
+	Timeout                  time.Duration
+	Volumes                  []ECS.Volume
+	Wait                     bool
+
+	container       ECS.Container
+	mountPointExprs []string
+}
+
+// UpdatesCpuAndMemory returns whether the update changes the CPU or memory of the service's tasks.
+func (o *ServiceUpdateOperation) UpdatesCpuAndMemory() bool {
+	return o.Cpu != "" || o.Memory != ""
+}
+
+// UpdatesRuntimePlatform returns whether the update changes the ephemeral storage or the platform of
+// the service's tasks.
+func (o *ServiceUpdateOperation) UpdatesRuntimePlatform() bool {
+	return o.EphemeralStorage != 0 || !o.RuntimePlatform.IsEmpty()
+}
+
+// UpdatesCapacityProviderStrategy returns whether the update changes the capacity providers the
+// service's tasks are run on.
+func (o *ServiceUpdateOperation) UpdatesCapacityProviderStrategy() bool {
+	return len(o.CapacityProviderStrategy) > 0
+}
+
+// UpdatesContainerHealthCheck returns whether the update changes the health check of the service's
+// container. The interval alone only changes the container health check if the container has one.
+func (o *ServiceUpdateOperation) UpdatesContainerHealthCheck() bool {
+	c := o.ContainerHealthCheck
+
+	return c.Command != "" || c.Retries != 0 || c.StartPeriod != 0 || (c.Interval != 0 && o.container.HealthCheck != nil)
+}
+
+// UpdatesVolumes returns whether the update adds or removes volumes or mount points.
+func (o *ServiceUpdateOperation) UpdatesVolumes() bool {
+	return len(o.Volumes) > 0 || len(o.mountPointExprs) > 0 || len(o.MountPoints) > 0 || len(o.RemoveVolumes) > 0 || len(o.RemoveMountPoints) > 0
+}
+
+// UpdatesTaskDefinition returns whether the update registers a new revision of the service's task
+// definition.
+func (o *ServiceUpdateOperation) UpdatesTaskDefinition() bool {
+	return o.UpdatesCpuAndMemory() || o.UpdatesRuntimePlatform() || o.UpdatesContainerHealthCheck() || o.UpdatesVolumes()
+}
+
+// SetRuntimePlatform parses the platform and operating system family to run the service's tasks on.
+func (o *ServiceUpdateOperation) SetRuntimePlatform(inputPlatform, inputOSFamily string) {
+	var msgs []string
+
+	runtimePlatform, errs := inflateRuntimePlatform(inputPlatform, inputOSFamily)
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid platform")
+	}
+
+	o.RuntimePlatform = runtimePlatform
+}
+
+// SetVolumes parses the volumes to add to the service. Mount points are parsed once the service's
+// existing volumes are known.
+func (o *ServiceUpdateOperation) SetVolumes(inputVolumes, inputMountPoints []string) {
+	var msgs []string
+
+	volumes, errs := inflateVolumes(inputVolumes)
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid volume")
+	}
+
+	o.Volumes = volumes
+	o.mountPointExprs = inputMountPoints
+}
+
+// validateVolumes checks that removed volumes and mount points exist and that mount points refer to
+// a volume the service will have after the update.
+func (o *ServiceUpdateOperation) validateVolumes() {
+	var (
+		msgs    []string
+		volumes []ECS.Volume
+	)
+
+	existing := make(map[string]bool)
+	removed := make(map[string]bool)
+	paths := make(map[string]bool)
+
+	for _, volume := range o.Service.Volumes {
+		existing[volume.Name] = true
+	}
+
+	for _, name := range o.RemoveVolumes {
+		if !existing[name] {
+			msgs = append(msgs, fmt.Sprintf("volume %s not found in service %s", name, o.ServiceName))
+		}
+
+		removed[name] = true
+	}
+
+	for _, mountPoint := range o.container.MountPoints {
+		paths[mountPoint.ContainerPath] = true
+	}
+
+	for _, path := range o.RemoveMountPoints {
+		if !paths[path] {
+			msgs = append(msgs, fmt.Sprintf("no volume is mounted at %s in container %s", path, o.container.Name))
+		}
+	}
+
+	for _, volume := range o.Service.Volumes {
+		if !removed[volume.Name] {
+			volumes = append(volumes, volume)
+		}
+	}
+
+	mountPoints, errs := inflateMountPoints(o.mountPointExprs, append(volumes, o.Volumes...))
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid volume")
+	}
+
+	o.MountPoints = mountPoints
+}
+
+// UpdatesTargetGroup returns whether the update changes the health check or deregistration settings
+// of the service's target group. The interval alone only changes the target group if the service has
+// a load balancer.
+func (o *ServiceUpdateOperation) UpdatesTargetGroup() bool {
+	return o.updatesTargetGroupOnlySettings() || (o.HealthCheck.IntervalSeconds != 0 && o.Service.TargetGroupArn != "")
+}
+
+func (o *ServiceUpdateOperation) updatesTargetGroupOnlySettings() bool {
+	return o.DeregistrationDelay != nil || o.HealthCheck != (ELBV2.HealthCheck{IntervalSeconds: o.HealthCheck.IntervalSeconds})
 }
 
 func (o *ServiceUpdateOperation) Validate() {
 	ecs := ECS.New(sess, clusterName)
 
-	if o.Cpu == "" && o.Memory == "" {
-		console.ErrorExit(fmt.Errorf("--cpu and/or --memory must be supplied"), "Invalid command line arguments")
+	if !o.UpdatesCpuAndMemory() && !o.UpdatesRuntimePlatform() && !o.UpdatesCapacityProviderStrategy() && !o.UpdatesVolumes() && o.ContainerHealthCheck == (ECS.HealthCheck{}) && o.HealthCheck.IsEmpty() && o.DeregistrationDelay == nil {
+		console.ErrorExit(fmt.Errorf("--cpu, --memory, --ephemeral-storage, --platform, --os-family, --capacity-provider-strategy, a volume flag, or a health check flag must be supplied"), "Invalid command line arguments")
 	}
 
 	o.Service = ecs.DescribeService(o.ServiceName)
+	o.container = findServiceContainer(o.Service, o.Container)
+
+	if o.UpdatesVolumes() {
+		o.validateVolumes()
+	}
+
+	if o.updatesTargetGroupOnlySettings() && o.Service.TargetGroupArn == "" {
+		console.ErrorExit(fmt.Errorf("service %s has no load balancer", o.ServiceName), "Invalid command line arguments")
+	}
+
+	if o.HealthCheck.IntervalSeconds != 0 && !o.UpdatesTargetGroup() && !o.UpdatesContainerHealthCheck() {
+		console.ErrorExit(fmt.Errorf("service %s has no load balancer or container health check", o.ServiceName), "Invalid command line arguments")
+	}
+
+	if o.ContainerHealthCheck.Command == "" && o.container.HealthCheck == nil && o.UpdatesContainerHealthCheck() {
+		console.IssueExit("Setting container health check retries or start period requires --health-check-command")
+	}
+
+	if err := validateHealthCheck(o.HealthCheck, o.DeregistrationDelay, ""); err != nil {
+		console.ErrorExit(err, "Invalid health check")
+	}
+
+	if err := validateContainerHealthCheck(o.ContainerHealthCheck); err != nil {
+		console.ErrorExit(err, "Invalid health check")
+	}
+
+	if err := validateEphemeralStorage(o.EphemeralStorage); err != nil {
+		console.ErrorExit(err, "Invalid settings")
+	}
+
+	if o.UpdatesRuntimePlatform() {
+		if o.EphemeralStorage == 0 {
+			o.EphemeralStorage = o.Service.EphemeralStorage
+		}
+
+		o.RuntimePlatform = o.Service.RuntimePlatform.Merge(o.RuntimePlatform)
+	}
+
+	if o.UpdatesCpuAndMemory() || o.UpdatesRuntimePlatform() {
+		cpu := o.Cpu
+		runtimePlatform := o.Service.RuntimePlatform.Merge(o.RuntimePlatform)
+
+		if cpu == "" {
+			cpu = o.Service.Cpu
+		}
+
+		if err := validateRuntimePlatform(runtimePlatform, cpu); err != nil {
+			console.ErrorExit(err, "Invalid settings: %s CPU units on %s", cpu, runtimePlatformString(runtimePlatform))
+		}
+	}
+
+	if !o.UpdatesCpuAndMemory() {
+		return
+	}
+
 	cpu, memory := ecs.GetCpuAndMemoryFromTaskDefinition(o.Service.TaskDefinitionArn)
 
 	if o.Cpu == "" {
@@ -41,12 +252,26 @@ func (o *ServiceUpdateOperation) Validate() {
 }
 
 var (
-	flagServiceUpdateCpu    string
-	flagServiceUpdateMemory string
+	flagServiceUpdateCapacityProviderStrategy string
+	flagServiceUpdateContainer                string
+	flagServiceUpdateContainerHealth          containerHealthCheckFlagValues
+	flagServiceUpdateCpu                      string
+	flagServiceUpdateEphemeralStorage         int64
+	flagServiceUpdateHealthCheck              healthCheckFlagValues
+	flagServiceUpdateMemory                   string
+	flagServiceUpdateMountPoints              []string
+	flagServiceUpdateOSFamily                 string
+	flagServiceUpdatePlatform                 string
+	flagServiceUpdateRemoveMounts             []string
+	flagServiceUpdateRemoveVolumes            []string
+	flagServiceUpdateRollback                 bool
+	flagServiceUpdateTimeout                  time.Duration
+	flagServiceUpdateVolumes                  []string
+	flagServiceUpdateWait                     bool
 )
 
 var serviceUpdateCmd = &cobra.Command{
-	Use:   "update <service-name> --cpu <cpu-units> | --memory <MiB>",
+	Use:   "update <service-name> --cpu <cpu-units> | --memory <MiB> | --platform <os/arch> | --capacity-provider-strategy <strategy> | --volume <volume-expression> | --health-check-path <path> ...",
 	Short: "Update service configuration",
 	Long: `Update service configuration
 
@@ -55,21 +280,97 @@ using the --cpu and --memory flags. Every 1024 CPU units is equivilent to a
 single vCPU. AWS Fargate only supports certain combinations of CPU and memory
 configurations:
 
-| CPU (CPU Units) | Memory (MiB)                          |
-| --------------- | ------------------------------------- |
-| 256             | 512, 1024, or 2048                    |
-| 512             | 1024 through 4096 in 1GiB increments  |
-| 1024            | 2048 through 8192 in 1GiB increments  |
-| 2048            | 4096 through 16384 in 1GiB increments |
-| 4096            | 8192 through 30720 in 1GiB increments |
+` + cpuAndMemoryTable() + `
+The ephemeral storage of the service's tasks can be changed to between 21 and
+200 GiB with the --ephemeral-storage flag.
 
-At least one of --cpu or --memory must be specified.`,
-	Args: cobra.ExactArgs(1),
+The platform the service's tasks run on can be changed with the --platform flag,
+such as linux/arm64 to run on AWS Graviton (ARM64) processors, and the
+operating system family with the --os-family flag. The service's image is not
+rebuilt, so it must already support the new platform; run fargate service
+deploy to deploy an image built for it. Changing the platform, operating system
+family, or ephemeral storage deploys a new task definition.
+
+The health checks the load balancer performs on the service's tasks can be
+changed with the --health-check-path, --health-check-interval,
+--healthy-threshold, --unhealthy-threshold, and --health-check-matcher flags,
+and the time given to in-flight requests to complete before a stopping task is
+deregistered with the --deregistration-delay flag. These settings are changed
+on the service's target group and do not require a deployment.
+
+The health check command run inside the service's containers can be set or
+changed with the --health-check-command, --health-check-retries, and
+--health-check-start-period flags. The health check of the first container is
+changed unless another container is named with the --container flag. The --health-check-interval flag changes the
+interval of the load balancer's health checks if the service has a load
+balancer, and of the container health check if the container has one.
+Changing the container health check deploys a new task definition.
+
+Amazon EFS volumes can be added with the --volume flag and mounted with the
+--mount flag, which take the same expressions as they do for fargate service
+create. Volumes are mounted into the first container unless another container
+is named with the --container flag. Pass --remove-volume with the name of a
+volume to remove it and unmount it from every container, or --remove-mount
+with a path in the container to unmount the volume mounted there. Adding a
+volume with the name of an existing volume replaces it. Changing volumes
+deploys a new task definition.
+
+The capacity providers the service's tasks are run on can be changed by passing
+the --capacity-provider-strategy flag with a comma separated list of capacity
+providers, FARGATE or FARGATE_SPOT, each followed by an optional base and
+weight, such as FARGATE_SPOT:weight=3,FARGATE:base=1,weight=1. Changing the
+capacity provider strategy deploys the service's tasks again.
+
+At least one of --cpu, --memory, --ephemeral-storage, --platform, --os-family,
+--capacity-provider-strategy, a volume flag, or a health check flag must be
+specified.
+
+Pass the --wait flag to wait for the deployment to become stable, displaying
+the progress of the deployment and new service events along the way. The
+command exits with a non-zero status if the deployment does not become stable
+within the time given by --timeout (default 10m) or if tasks from the new
+deployment repeatedly stop.
+
+If the deployment fails while waiting, the service is rolled back to the task
+definition it ran before the deployment. Pass --rollback=false to leave the
+service on the failed deployment.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
 	Run: func(cmd *cobra.Command, args []string) {
 		operation := &ServiceUpdateOperation{
 			ServiceName: args[0],
-			Cpu:         flagServiceUpdateCpu,
-			Memory:      flagServiceUpdateMemory,
+			Container:   flagServiceUpdateContainer,
+			ContainerHealthCheck: flagServiceUpdateContainerHealth.healthCheck(
+				flagServiceUpdateHealthCheck.healthCheck.IntervalSeconds,
+			),
+			Cpu:                 flagServiceUpdateCpu,
+			DeregistrationDelay: flagServiceUpdateHealthCheck.deregistrationDelayValue(cmd.Flags()),
+			EphemeralStorage:    flagServiceUpdateEphemeralStorage,
+			HealthCheck:         flagServiceUpdateHealthCheck.healthCheck,
+			Memory:              flagServiceUpdateMemory,
+			RemoveMountPoints:   flagServiceUpdateRemoveMounts,
+			RemoveVolumes:       flagServiceUpdateRemoveVolumes,
+			Rollback:            flagServiceUpdateRollback,
+			Timeout:             flagServiceUpdateTimeout,
+			Wait:                flagServiceUpdateWait,
+		}
+
+		if len(flagServiceUpdateVolumes) > 0 || len(flagServiceUpdateMountPoints) > 0 {
+			operation.SetVolumes(flagServiceUpdateVolumes, flagServiceUpdateMountPoints)
+		}
+
+		if flagServiceUpdatePlatform != "" || flagServiceUpdateOSFamily != "" {
+			operation.SetRuntimePlatform(flagServiceUpdatePlatform, flagServiceUpdateOSFamily)
+		}
+
+		if flagServiceUpdateCapacityProviderStrategy != "" {
+			strategy, err := inflateCapacityProviderStrategy(flagServiceUpdateCapacityProviderStrategy)
+
+			if err != nil {
+				console.ErrorExit(err, "Invalid capacity provider strategy")
+			}
+
+			operation.CapacityProviderStrategy = strategy
 		}
 
 		operation.Validate()
@@ -83,17 +384,199 @@ func init() {
 
 	serviceUpdateCmd.Flags().StringVarP(&flagServiceUpdateCpu, "cpu", "c", "", "Amount of cpu units to allocate for each task")
 	serviceUpdateCmd.Flags().StringVarP(&flagServiceUpdateMemory, "memory", "m", "", "Amount of MiB to allocate for each task")
+	serviceUpdateCmd.Flags().Int64Var(&flagServiceUpdateEphemeralStorage, "ephemeral-storage", 0, "Amount of GiB of ephemeral storage to allocate for each task [21 - 200]")
+	serviceUpdateCmd.Flags().StringVar(&flagServiceUpdatePlatform, "platform", "", "Platform to run tasks on [e.g. linux/amd64, linux/arm64]")
+	serviceUpdateCmd.Flags().StringVar(&flagServiceUpdateOSFamily, "os-family", "", "Operating system family to run tasks on [e.g. LINUX, WINDOWS_SERVER_2019_CORE]")
+	serviceUpdateCmd.Flags().StringVar(&flagServiceUpdateCapacityProviderStrategy, "capacity-provider-strategy", "", "Capacity providers to run tasks on [e.g. FARGATE_SPOT:weight=3,FARGATE:base=1,weight=1]")
+	addHealthCheckFlags(serviceUpdateCmd.Flags(), &flagServiceUpdateHealthCheck)
+	addContainerHealthCheckFlags(serviceUpdateCmd.Flags(), &flagServiceUpdateContainerHealth)
+	serviceUpdateCmd.Flags().StringArrayVar(&flagServiceUpdateVolumes, "volume", []string{}, "EFS volume to add [e.g. uploads=efs:fs-12345678:/uploads,access-point=fsap-12345678,readonly] (can be specified multiple times)")
+	serviceUpdateCmd.Flags().StringArrayVar(&flagServiceUpdateMountPoints, "mount", []string{}, "Volume to mount in the container [e.g. uploads:/var/uploads] (can be specified multiple times)")
+	serviceUpdateCmd.Flags().StringSliceVar(&flagServiceUpdateRemoveVolumes, "remove-volume", []string{}, "Name of a volume to remove (can be specified multiple times)")
+	serviceUpdateCmd.Flags().StringSliceVar(&flagServiceUpdateRemoveMounts, "remove-mount", []string{}, "Path in the container of a mount to remove (can be specified multiple times)")
+	serviceUpdateCmd.Flags().StringVar(&flagServiceUpdateContainer, "container", "", "Name of the container to update the health check or mounts of (default: the first container)")
+	serviceUpdateCmd.Flags().BoolVarP(&flagServiceUpdateWait, "wait", "w", false, "Wait for the deployment to become stable")
+	serviceUpdateCmd.Flags().DurationVar(&flagServiceUpdateTimeout, "timeout", defaultDeploymentTimeout, "Time to wait for the deployment to become stable")
+	serviceUpdateCmd.Flags().BoolVar(&flagServiceUpdateRollback, "rollback", true, "Roll back to the previous task definition if the deployment fails while waiting")
 }
 
 func updateService(operation *ServiceUpdateOperation) {
+	var newTaskDefinitionArn string
+
 	ecs := ECS.New(sess, clusterName)
+	plan := newChangePlan()
 
-	newTaskDefinitionArn := ecs.UpdateTaskDefinitionCpuAndMemory(
-		operation.Service.TaskDefinitionArn,
-		operation.Cpu,
-		operation.Memory,
-	)
+	if operation.UpdatesTargetGroup() {
+		elbv2 := ELBV2.New(sess)
+		settings, err := elbv2.DescribeTargetGroupSettings(operation.Service.TargetGroupArn)
 
-	ecs.UpdateServiceTaskDefinition(operation.ServiceName, newTaskDefinitionArn)
-	console.Info("Updated service %s to %s CPU units / %s MiB", operation.ServiceName, operation.Cpu, operation.Memory)
+		if err != nil {
+			console.ErrorExit(err, "Could not describe target group of service %s", operation.ServiceName)
+		}
+
+		plan.do(
+			changeStep{
+				API:      "elbv2",
+				Action:   "ModifyTargetGroup",
+				Resource: "target group",
+				Name:     operation.ServiceName,
+				ARN:      operation.Service.TargetGroupArn,
+				Changes:  healthCheckChanges(&settings, operation.HealthCheck, operation.DeregistrationDelay),
+			},
+			func() {
+				err := elbv2.ModifyTargetGroup(
+					ELBV2.ModifyTargetGroupParameters{
+						ARN:                 operation.Service.TargetGroupArn,
+						DeregistrationDelay: operation.DeregistrationDelay,
+						HealthCheck:         operation.HealthCheck,
+					},
+				)
+
+				if err != nil {
+					console.ErrorExit(err, "Could not update health check of service %s", operation.ServiceName)
+				}
+			},
+		)
+
+		if !operation.UpdatesTaskDefinition() && !operation.UpdatesCapacityProviderStrategy() {
+			if plan.display(output) {
+				return
+			}
+
+			output.Info("Updated health check of service %s", operation.ServiceName)
+			return
+		}
+	}
+
+	if operation.UpdatesTaskDefinition() {
+		update := ECS.TaskDefinitionUpdate{
+			Cpu:              operation.Cpu,
+			EphemeralStorage: operation.EphemeralStorage,
+			Memory:           operation.Memory,
+			RuntimePlatform:  operation.RuntimePlatform,
+		}
+
+		var changes []string
+
+		if operation.UpdatesCpuAndMemory() {
+			changes = append(
+				diffField("cpu", operation.Service.Cpu, operation.Cpu),
+				diffField("memory", operation.Service.Memory, operation.Memory)...,
+			)
+		}
+
+		if operation.UpdatesRuntimePlatform() {
+			changes = append(changes, diffField("ephemeral storage", ephemeralStorageString(operation.Service.EphemeralStorage), ephemeralStorageString(operation.EphemeralStorage))...)
+			changes = append(changes, diffField("platform", runtimePlatformString(operation.Service.RuntimePlatform), runtimePlatformString(operation.RuntimePlatform))...)
+		}
+
+		if operation.UpdatesContainerHealthCheck() {
+			update.Container = operation.container.Name
+			update.HealthCheck = &operation.ContainerHealthCheck
+			changes = append(changes, containerHealthCheckChanges(operation.container.HealthCheck, operation.ContainerHealthCheck)...)
+		}
+
+		if operation.UpdatesVolumes() {
+			update.Container = operation.container.Name
+			update.MountPoints = operation.MountPoints
+			update.RemoveMountPoints = operation.RemoveMountPoints
+			update.RemoveVolumes = operation.RemoveVolumes
+			update.Volumes = operation.Volumes
+			changes = append(changes, volumeChanges(operation.Volumes, operation.MountPoints, operation.RemoveVolumes, operation.RemoveMountPoints)...)
+		}
+
+		plan.do(
+			changeStep{
+				API:      "ecs",
+				Action:   "RegisterTaskDefinition",
+				Resource: "task definition",
+				Name:     operation.ServiceName,
+				ARN:      operation.Service.TaskDefinitionArn,
+				Changes:  changes,
+			},
+			func() {
+				newTaskDefinitionArn = ecs.UpdateTaskDefinition(operation.Service.TaskDefinitionArn, update)
+			},
+		)
+	}
+
+	since := time.Now()
+
+	if operation.UpdatesCapacityProviderStrategy() {
+		plan.do(
+			changeStep{
+				API:      "ecs",
+				Action:   "PutClusterCapacityProviders",
+				Resource: "cluster",
+				Name:     clusterName,
+				Changes:  []string{"capacity providers: FARGATE, FARGATE_SPOT"},
+			},
+			func() {
+				if _, err := ecs.CreateCluster(); err != nil {
+					console.ErrorExit(err, "Could not attach capacity providers to cluster %s", clusterName)
+				}
+			},
+		)
+
+		plan.do(
+			changeStep{
+				API:      "ecs",
+				Action:   "UpdateService",
+				Resource: "service",
+				Name:     operation.ServiceName,
+				Changes: diffField(
+					"capacity provider strategy",
+					capacityProviderStrategyString(operation.Service.CapacityProviderStrategy),
+					operation.CapacityProviderStrategy.String(),
+				),
+			},
+			func() {
+				err := ecs.UpdateServiceCapacityProviderStrategy(
+					operation.ServiceName,
+					newTaskDefinitionArn,
+					operation.CapacityProviderStrategy,
+				)
+
+				if err != nil {
+					console.ErrorExit(err, "Could not update capacity provider strategy of service %s", operation.ServiceName)
+				}
+			},
+		)
+	} else {
+		plan.do(
+			changeStep{API: "ecs", Action: "UpdateService", Resource: "service", Name: operation.ServiceName},
+			func() { ecs.UpdateServiceTaskDefinition(operation.ServiceName, newTaskDefinitionArn) },
+		)
+	}
+
+	if plan.display(output) {
+		return
+	}
+
+	switch {
+	case operation.UpdatesCpuAndMemory():
+		output.Info("Updated service %s to %s CPU units / %s MiB", operation.ServiceName, operation.Cpu, operation.Memory)
+	case operation.UpdatesRuntimePlatform():
+		output.Info("Updated service %s to %s with %s of ephemeral storage", operation.ServiceName, runtimePlatformString(operation.RuntimePlatform), ephemeralStorageString(operation.EphemeralStorage))
+	case operation.UpdatesVolumes():
+		output.Info("Updated volumes of service %s", operation.ServiceName)
+	case operation.UpdatesCapacityProviderStrategy():
+		output.Info("Updated service %s to capacity provider strategy %s", operation.ServiceName, operation.CapacityProviderStrategy)
+	default:
+		output.Info("Updated health check of service %s", operation.ServiceName)
+	}
+
+	if newTaskDefinitionArn == "" {
+		newTaskDefinitionArn = operation.Service.TaskDefinitionArn
+	}
+
+	if operation.Wait {
+		waitForServiceDeployment(
+			operation.ServiceName,
+			newTaskDefinitionArn,
+			rollbackTaskDefinitionARN(operation.Rollback, operation.Service.TaskDefinitionArn),
+			since,
+			operation.Timeout,
+		)
+	}
 }
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+const (
+	defaultDeploymentTimeout  = 10 * time.Minute
+	deploymentPollingInterval = 5 * time.Second
+
+	minStoppedTaskThreshold = 3
+	maxStoppedTaskThreshold = 200
+)
+
+// errDeploymentTimeout is returned when a deployment does not become stable within its timeout.
+var errDeploymentTimeout = fmt.Errorf("timed out waiting for deployment to become stable")
+
+type serviceDeploymentWaitOperation struct {
+	ecs                       ECS.Client
+	interval                  time.Duration
+	output                    Output
+	previousTaskDefinitionARN string
+	serviceName               string
+	since                     time.Time
+	taskDefinitionARN         string
+	timeout                   time.Duration
+}
+
+// execute waits for the deployment to become stable. If it does not and the task definition the
+// service previously ran is known, the service is rolled back to it.
+func (o serviceDeploymentWaitOperation) execute() {
+	err := o.wait()
+
+	if err == nil {
+		return
+	}
+
+	if o.previousTaskDefinitionARN == "" || o.previousTaskDefinitionARN == o.taskDefinitionARN {
+		o.output.Fatal(err, "Deployment of service %s failed", o.serviceName)
+		return
+	}
+
+	revision := ECS.TaskDefinitionRevision(o.previousTaskDefinitionARN)
+
+	o.output.Warn("Deployment of service %s failed: %v", o.serviceName, err)
+	o.output.Info("Rolling back service %s to revision %s", o.serviceName, revision)
+	o.output.Debug("Updating service [API=ecs Action=UpdateService TaskDefinition=%s]", o.previousTaskDefinitionARN)
+
+	if rollbackErr := o.ecs.DeployTaskDefinition(o.serviceName, o.previousTaskDefinitionARN); rollbackErr != nil {
+		o.output.Fatal(rollbackErr, "Could not roll back service %s", o.serviceName)
+		return
+	}
+
+	o.output.Fatal(err, "Deployment of service %s failed and was rolled back to revision %s", o.serviceName, revision)
+}
+
+// wait polls a service until its deployment of the operation's task definition is the only
+// deployment and is running all of its desired tasks. Service events and stopped tasks seen along
+// the way are displayed. An error is returned if the deployment does not become stable before the
+// timeout or if tasks from the deployment repeatedly stop.
+func (o serviceDeploymentWaitOperation) wait() error {
+	var lastStatus string
+
+	deadline := o.since.Add(o.timeout)
+	lastEventAt := o.since
+	stoppedTasks := make(map[string]bool)
+
+	o.output.Info("Waiting for deployment of service %s to become stable", o.serviceName)
+
+	for {
+		o.output.Debug("Describing service [API=ecs Action=DescribeServices Service=%s]", o.serviceName)
+		service, err := o.ecs.DescribeServiceDeployments(o.serviceName)
+
+		if err != nil {
+			return err
+		}
+
+		lastEventAt = o.displayEvents(service.Events, lastEventAt)
+
+		if status := deploymentStatus(service.Deployments); status != lastStatus {
+			o.output.Say("%s", 1, status)
+			lastStatus = status
+		}
+
+		if o.isStable(service.Deployments) {
+			o.output.Info("Deployment of service %s is stable", o.serviceName)
+			return nil
+		}
+
+		o.output.Debug("Listing stopped tasks [API=ecs Action=ListTasks Service=%s]", o.serviceName)
+		tasks, err := o.ecs.ListStoppedServiceTasks(o.serviceName)
+
+		if err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			if task.TaskDefinitionArn != o.taskDefinitionARN || task.CreatedAt.Before(o.since) || stoppedTasks[task.TaskId] {
+				continue
+			}
+
+			stoppedTasks[task.TaskId] = true
+			o.output.Warn("Task %s stopped: %s", task.TaskId, task.StoppedReason)
+		}
+
+		if threshold := stoppedTaskThreshold(service.DesiredCount); len(stoppedTasks) >= threshold {
+			return fmt.Errorf("%d tasks from the deployment stopped (threshold %d)", len(stoppedTasks), threshold)
+		}
+
+		if !time.Now().Before(deadline) {
+			return errDeploymentTimeout
+		}
+
+		time.Sleep(o.interval)
+	}
+}
+
+func (o serviceDeploymentWaitOperation) isStable(deployments []ECS.Deployment) bool {
+	if len(deployments) != 1 {
+		return false
+	}
+
+	d := deployments[0]
+
+	return d.IsPrimary() && d.TaskDefinitionArn == o.taskDefinitionARN && d.IsComplete()
+}
+
+func (o serviceDeploymentWaitOperation) displayEvents(events []ECS.Event, since time.Time) time.Time {
+	var newEvents []ECS.Event
+
+	for _, event := range events {
+		if event.CreatedAt.After(since) {
+			newEvents = append(newEvents, event)
+		}
+	}
+
+	sort.Slice(newEvents, func(i, j int) bool {
+		return newEvents[i].CreatedAt.Before(newEvents[j].CreatedAt)
+	})
+
+	for _, event := range newEvents {
+		o.output.Say("%s %s", 1, event.CreatedAt.Format(time.Kitchen), event.Message)
+		since = event.CreatedAt
+	}
+
+	return since
+}
+
+// deploymentStatus returns a summary of the task counts of a service's PRIMARY and ACTIVE
+// deployments, identified by the revision of the task definition they run.
+func deploymentStatus(deployments []ECS.Deployment) string {
+	var primary, active []string
+
+	for _, d := range deployments {
+		if d.IsPrimary() {
+			primary = append(primary,
+				fmt.Sprintf("revision %s %d/%d running, %d pending", ECS.TaskDefinitionRevision(d.TaskDefinitionArn), d.RunningCount, d.DesiredCount, d.PendingCount),
+			)
+		} else {
+			active = append(active, fmt.Sprintf("revision %s %d running", ECS.TaskDefinitionRevision(d.TaskDefinitionArn), d.RunningCount))
+		}
+	}
+
+	if len(active) == 0 {
+		active = []string{"none"}
+	}
+
+	return fmt.Sprintf("PRIMARY: %s | ACTIVE: %s", strings.Join(primary, ", "), strings.Join(active, ", "))
+}
+
+// stoppedTaskThreshold returns the number of stopped tasks after which a deployment is considered
+// failed: half of the desired count, bounded between 3 and 200.
+func stoppedTaskThreshold(desiredCount int64) int {
+	threshold := int(desiredCount / 2)
+
+	if threshold < minStoppedTaskThreshold {
+		return minStoppedTaskThreshold
+	}
+
+	if threshold > maxStoppedTaskThreshold {
+		return maxStoppedTaskThreshold
+	}
+
+	return threshold
+}
+
+// waitForServiceDeployment waits for a service's deployment of the given task definition to become
+// stable, exiting if it does not. If a previous task definition is given, the service is rolled back
+// to it before exiting.
+func waitForServiceDeployment(serviceName, taskDefinitionARN, previousTaskDefinitionARN string, since time.Time, timeout time.Duration) {
+	serviceDeploymentWaitOperation{
+		ecs:                       ECS.New(sess, clusterName),
+		interval:                  deploymentPollingInterval,
+		output:                    output,
+		previousTaskDefinitionARN: previousTaskDefinitionARN,
+		serviceName:               serviceName,
+		since:                     since,
+		taskDefinitionARN:         taskDefinitionARN,
+		timeout:                   timeout,
+	}.execute()
+}
+
+// rollbackTaskDefinitionARN returns the task definition to roll back to if rollback is enabled.
+func rollbackTaskDefinitionARN(rollback bool, taskDefinitionARN string) string {
+	if rollback {
+		return taskDefinitionARN
+	}
+
+	return ""
+}
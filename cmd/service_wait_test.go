@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/awslabs/fargatecli/cmd/mock"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	ecsclient "github.com/awslabs/fargatecli/ecs/mock/client"
+	"github.com/golang/mock/gomock"
+)
+
+const (
+	waitTaskDefinitionARN    = "arn:aws:ecs:us-east-1:123456789012:task-definition/fargate_service_web:4"
+	waitOldTaskDefinitionARN = "arn:aws:ecs:us-east-1:123456789012:task-definition/fargate_service_web:3"
+)
+
+func TestServiceDeploymentWaitOperation(t *testing.T) {
+	since := time.Now()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	inProgress := ECS.Service{
+		DesiredCount: 2,
+		Deployments: []ECS.Deployment{
+			ECS.Deployment{Id: "4", Status: "PRIMARY", DesiredCount: 2, RunningCount: 1, PendingCount: 1, TaskDefinitionArn: waitTaskDefinitionARN},
+			ECS.Deployment{Id: "3", Status: "ACTIVE", DesiredCount: 0, RunningCount: 1, TaskDefinitionArn: waitOldTaskDefinitionARN},
+		},
+		Events: []ECS.Event{
+			ECS.Event{CreatedAt: since.Add(2 * time.Second), Message: "(service web) has started 1 tasks"},
+			ECS.Event{CreatedAt: since.Add(-time.Minute), Message: "(service web) has reached a steady state."},
+		},
+	}
+	stable := ECS.Service{
+		DesiredCount: 2,
+		Deployments: []ECS.Deployment{
+			ECS.Deployment{Id: "4", Status: "PRIMARY", DesiredCount: 2, RunningCount: 2, TaskDefinitionArn: waitTaskDefinitionARN},
+		},
+		Events: []ECS.Event{
+			ECS.Event{CreatedAt: since.Add(3 * time.Second), Message: "(service web) has reached a steady state."},
+			ECS.Event{CreatedAt: since.Add(2 * time.Second), Message: "(service web) has started 1 tasks"},
+		},
+	}
+
+	gomock.InOrder(
+		mockClient.EXPECT().DescribeServiceDeployments("web").Return(inProgress, nil),
+		mockClient.EXPECT().ListStoppedServiceTasks("web").Return([]ECS.Task{}, nil),
+		mockClient.EXPECT().DescribeServiceDeployments("web").Return(stable, nil),
+	)
+
+	operation := serviceDeploymentWaitOperation{
+		ecs:               mockClient,
+		output:            mockOutput,
+		serviceName:       "web",
+		since:             since,
+		taskDefinitionARN: waitTaskDefinitionARN,
+		timeout:           time.Minute,
+	}
+
+	if err := operation.wait(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []string{
+		since.Add(2*time.Second).Format(time.Kitchen) + " (service web) has started 1 tasks",
+		"PRIMARY: revision 4 1/2 running, 1 pending | ACTIVE: revision 3 1 running",
+		since.Add(3*time.Second).Format(time.Kitchen) + " (service web) has reached a steady state.",
+		"PRIMARY: revision 4 2/2 running, 0 pending | ACTIVE: none",
+	}
+
+	if len(mockOutput.SayMsgs) != len(expected) {
+		t.Fatalf("expected %d messages, got %d: %v", len(expected), len(mockOutput.SayMsgs), mockOutput.SayMsgs)
+	}
+
+	for i, msg := range expected {
+		if mockOutput.SayMsgs[i] != msg {
+			t.Errorf("expected message %q, got %q", msg, mockOutput.SayMsgs[i])
+		}
+	}
+
+	if expected, got := "Deployment of service web is stable", mockOutput.InfoMsgs[len(mockOutput.InfoMsgs)-1]; expected != got {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestServiceDeploymentWaitOperationStoppedTasks(t *testing.T) {
+	since := time.Now()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	service := ECS.Service{
+		DesiredCount: 1,
+		Deployments: []ECS.Deployment{
+			ECS.Deployment{Id: "4", Status: "PRIMARY", DesiredCount: 1, TaskDefinitionArn: waitTaskDefinitionARN},
+			ECS.Deployment{Id: "3", Status: "ACTIVE", DesiredCount: 0, RunningCount: 1, TaskDefinitionArn: waitOldTaskDefinitionARN},
+		},
+	}
+	tasks := []ECS.Task{
+		ECS.Task{TaskId: "1", CreatedAt: since.Add(time.Second), TaskDefinitionArn: waitTaskDefinitionARN, StoppedReason: "Essential container in task exited"},
+		ECS.Task{TaskId: "2", CreatedAt: since.Add(time.Second), TaskDefinitionArn: waitTaskDefinitionARN, StoppedReason: "Essential container in task exited"},
+		ECS.Task{TaskId: "3", CreatedAt: since.Add(time.Second), TaskDefinitionArn: waitOldTaskDefinitionARN, StoppedReason: "Scaling activity initiated by deployment"},
+		ECS.Task{TaskId: "4", CreatedAt: since.Add(-time.Minute), TaskDefinitionArn: waitTaskDefinitionARN, StoppedReason: "Task failed ELB health checks"},
+		ECS.Task{TaskId: "5", CreatedAt: since.Add(time.Second), TaskDefinitionArn: waitTaskDefinitionARN, StoppedReason: "Task failed ELB health checks"},
+	}
+
+	mockClient.EXPECT().DescribeServiceDeployments("web").Return(service, nil)
+	mockClient.EXPECT().ListStoppedServiceTasks("web").Return(tasks, nil)
+
+	operation := serviceDeploymentWaitOperation{
+		ecs:               mockClient,
+		output:            mockOutput,
+		serviceName:       "web",
+		since:             since,
+		taskDefinitionARN: waitTaskDefinitionARN,
+		timeout:           time.Minute,
+	}
+
+	err := operation.wait()
+
+	if err == nil {
+		t.Fatalf("expected error, got none")
+	}
+
+	if expected := "3 tasks from the deployment stopped (threshold 3)"; err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err)
+	}
+
+	if len(mockOutput.WarnMsgs) != 3 {
+		t.Errorf("expected 3 warnings, got %d: %v", len(mockOutput.WarnMsgs), mockOutput.WarnMsgs)
+	}
+}
+
+func TestServiceDeploymentWaitOperationTimeout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	service := ECS.Service{
+		DesiredCount: 1,
+		Deployments: []ECS.Deployment{
+			ECS.Deployment{Id: "4", Status: "PRIMARY", DesiredCount: 1, PendingCount: 1, TaskDefinitionArn: waitTaskDefinitionARN},
+		},
+	}
+
+	mockClient.EXPECT().DescribeServiceDeployments("web").Return(service, nil)
+	mockClient.EXPECT().ListStoppedServiceTasks("web").Return([]ECS.Task{}, nil)
+
+	operation := serviceDeploymentWaitOperation{
+		ecs:               mockClient,
+		output:            mockOutput,
+		serviceName:       "web",
+		since:             time.Now().Add(-time.Minute),
+		taskDefinitionARN: waitTaskDefinitionARN,
+		timeout:           time.Second,
+	}
+
+	if err := operation.wait(); err != errDeploymentTimeout {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+}
+
+func TestServiceDeploymentWaitOperationError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().DescribeServiceDeployments("web").Return(ECS.Service{}, errors.New("boom"))
+
+	operation := serviceDeploymentWaitOperation{
+		ecs:         mockClient,
+		output:      mockOutput,
+		serviceName: "web",
+		since:       time.Now(),
+		timeout:     time.Minute,
+	}
+
+	if err := operation.wait(); err == nil || err.Error() != "boom" {
+		t.Errorf("expected error boom, got %v", err)
+	}
+}
+
+func TestServiceDeploymentWaitOperationRollback(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().DescribeServiceDeployments("web").Return(ECS.Service{}, errors.New("boom"))
+	mockClient.EXPECT().DeployTaskDefinition("web", waitOldTaskDefinitionARN).Return(nil)
+
+	serviceDeploymentWaitOperation{
+		ecs:                       mockClient,
+		output:                    mockOutput,
+		previousTaskDefinitionARN: waitOldTaskDefinitionARN,
+		serviceName:               "web",
+		since:                     time.Now(),
+		taskDefinitionARN:         waitTaskDefinitionARN,
+		timeout:                   time.Minute,
+	}.execute()
+
+	if len(mockOutput.FatalMsgs) != 1 {
+		t.Fatalf("expected 1 fatal message, got %d", len(mockOutput.FatalMsgs))
+	}
+
+	if expected, got := "Deployment of service web failed and was rolled back to revision 3", mockOutput.FatalMsgs[0].Msg; expected != got {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestServiceDeploymentWaitOperationNoRollback(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().DescribeServiceDeployments("web").Return(ECS.Service{}, errors.New("boom"))
+
+	serviceDeploymentWaitOperation{
+		ecs:               mockClient,
+		output:            mockOutput,
+		serviceName:       "web",
+		since:             time.Now(),
+		taskDefinitionARN: waitTaskDefinitionARN,
+		timeout:           time.Minute,
+	}.execute()
+
+	if len(mockOutput.FatalMsgs) != 1 {
+		t.Fatalf("expected 1 fatal message, got %d", len(mockOutput.FatalMsgs))
+	}
+
+	if expected, got := "Deployment of service web failed", mockOutput.FatalMsgs[0].Msg; expected != got {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestStoppedTaskThreshold(t *testing.T) {
+	var tests = []struct {
+		desiredCount int64
+		threshold    int
+	}{
+		{0, 3},
+		{1, 3},
+		{10, 5},
+		{1000, 200},
+	}
+
+	for _, test := range tests {
+		if got := stoppedTaskThreshold(test.desiredCount); got != test.threshold {
+			t.Errorf("expected threshold %d for desired count %d, got %d", test.threshold, test.desiredCount, got)
+		}
+	}
+}
+
+func TestDeploymentStatus(t *testing.T) {
+	deployments := []ECS.Deployment{
+		ECS.Deployment{Id: "ecs-svc/1234567890123456789", Status: "PRIMARY", DesiredCount: 2, RunningCount: 1, PendingCount: 1, TaskDefinitionArn: waitTaskDefinitionARN},
+		ECS.Deployment{Id: "ecs-svc/9876543210987654321", Status: "ACTIVE", RunningCount: 1, TaskDefinitionArn: waitOldTaskDefinitionARN},
+	}
+
+	if expected, got := "PRIMARY: revision 4 1/2 running, 1 pending | ACTIVE: revision 3 1 running", deploymentStatus(deployments); expected != got {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
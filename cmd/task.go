@@ -1,11 +1,109 @@
 package cmd
 
 import (
+	"fmt"
+
+	EC2 "github.com/awslabs/fargatecli/ec2"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	ELBV2 "github.com/awslabs/fargatecli/elbv2"
 	"github.com/spf13/cobra"
 )
 
 const taskLogGroupFormat = "/fargate/task/%s"
 
+// taskDocument is the structured representation of a task along with the details of its network
+// interface and, for tasks registered with a load balancer, their health.
+type taskDocument struct {
+	ECS.Task
+	Health           *ELBV2.TargetHealth `json:"health,omitempty"`
+	PrivateIPAddress string              `json:"privateIpAddress"`
+	PublicIPAddress  string              `json:"publicIpAddress"`
+	SecurityGroupIDs []string            `json:"securityGroupIds"`
+}
+
+// HealthString returns the health of the task as seen by the load balancer, including the reason a
+// task is not healthy.
+func (d taskDocument) HealthString() string {
+	if d.Health == nil {
+		return ""
+	}
+
+	if d.Health.Reason == "" {
+		return Humanize(d.Health.State)
+	}
+
+	return fmt.Sprintf("%s (%s: %s)", Humanize(d.Health.State), d.Health.Reason, d.Health.Description)
+}
+
+func newTaskDocuments(tasks []ECS.Task, enis map[string]EC2.Eni) []taskDocument {
+	var documents []taskDocument
+
+	for _, task := range tasks {
+		eni := enis[task.EniId]
+
+		documents = append(documents,
+			taskDocument{
+				Task:             task,
+				PrivateIPAddress: eni.PrivateIpAddress,
+				PublicIPAddress:  eni.PublicIpAddress,
+				SecurityGroupIDs: eni.SecurityGroupIds,
+			},
+		)
+	}
+
+	return documents
+}
+
+// ContainerHealthString returns the health of the task's container as determined by its health check
+// command, or nothing if the container has no health check.
+func (d taskDocument) ContainerHealthString() string {
+	if d.HealthCheck == nil {
+		return ""
+	}
+
+	return Humanize(d.HealthStatus)
+}
+
+// hasContainerHealthChecks returns whether any of the tasks run a container health check.
+func hasContainerHealthChecks(documents []taskDocument) bool {
+	for _, document := range documents {
+		if document.HealthCheck != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasCapacityProviders returns whether any of the tasks were placed by a capacity provider.
+func hasCapacityProviders(documents []taskDocument) bool {
+	for _, document := range documents {
+		if document.CapacityProviderName != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withTargetHealth sets the health of each task registered with a target group, joining targets to
+// tasks by the private IP address of the task's network interface.
+func withTargetHealth(documents []taskDocument, targetHealths ELBV2.TargetHealths) []taskDocument {
+	healths := make(map[string]ELBV2.TargetHealth)
+
+	for _, targetHealth := range targetHealths {
+		healths[targetHealth.ID] = targetHealth
+	}
+
+	for i, document := range documents {
+		if targetHealth, ok := healths[document.PrivateIPAddress]; ok && document.PrivateIPAddress != "" {
+			documents[i].Health = &targetHealth
+		}
+	}
+
+	return documents
+}
+
 var taskCmd = &cobra.Command{
 	Use:   "task",
 	Short: "Manage tasks",
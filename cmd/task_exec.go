@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"github.com/awslabs/fargatecli/console"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	"github.com/spf13/cobra"
+)
+
+type TaskExecOperation struct {
+	Command       string
+	Container     string
+	TaskGroupName string
+	TaskID        string
+}
+
+var (
+	flagTaskExecContainer string
+	flagTaskExecTask      string
+)
+
+var taskExecCmd = &cobra.Command{
+	Use:   "exec <task-group-name> [--task <task-id>] [--container <name>] [-- <command>]",
+	Short: "Run a command in a running task",
+	Long: `Run a command in a running task
+
+Opens an interactive session running a command inside a container of a running
+task using ECS Exec. The command is given after -- and defaults to /bin/sh.
+The first running task in the task group is used unless a task is specified
+with the --task flag, and the task's first container is used unless another
+container is named with the --container flag.
+
+The task must have been run with the --enable-exec flag, which enables ECS
+Exec and grants its task role the Session Manager permissions ECS Exec
+requires. Tasks run without the flag must be run again to use this command.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if dash := cmd.ArgsLenAtDash(); dash > 1 || (dash < 0 && len(args) > 1) {
+			console.IssueExit("Specify the command to run after --")
+		}
+
+		operation := &TaskExecOperation{
+			Command:       execCommand(args, cmd.ArgsLenAtDash()),
+			Container:     flagTaskExecContainer,
+			TaskGroupName: args[0],
+			TaskID:        flagTaskExecTask,
+		}
+
+		taskExec(operation)
+	},
+}
+
+func init() {
+	taskExecCmd.Flags().StringVarP(&flagTaskExecTask, "task", "t", "", "ID of the task to run the command in (default: the first running task)")
+	taskExecCmd.Flags().StringVar(&flagTaskExecContainer, "container", "", "Name of the container to run the command in (default: the first container)")
+
+	taskCmd.AddCommand(taskExecCmd)
+}
+
+func taskExec(operation *TaskExecOperation) {
+	ecs := ECS.New(sess, clusterName)
+	task, err := findExecTask(ecs.DescribeTasksForTaskGroup(operation.TaskGroupName), operation.TaskID)
+
+	if err != nil {
+		console.ErrorExit(err, "Could not find task in task group %s", operation.TaskGroupName)
+	}
+
+	execInTask(task, operation.Container, operation.Command)
+}
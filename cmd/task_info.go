@@ -3,19 +3,24 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/awslabs/fargatecli/console"
 	EC2 "github.com/awslabs/fargatecli/ec2"
 	ECS "github.com/awslabs/fargatecli/ecs"
+	EB "github.com/awslabs/fargatecli/eventbridge"
 	"github.com/spf13/cobra"
 )
 
 type TaskInfoOperation struct {
+	Container     string
 	TaskGroupName string
 	TaskIds       []string
 }
 
-var flagTaskInfoTasks []string
+var (
+	flagTaskInfoContainer string
+	flagTaskInfoTasks     []string
+)
 
 var taskInfoCmd = &cobra.Command{
 	Use:   "info <task group name>",
@@ -26,10 +31,16 @@ Shows extended information for each running task within a task group or for
 specific tasks specified with the --task flag. Information includes environment
 variables which could differ between tasks in a task group. To inspect multiple
 specific tasks within a task group specific --task with a task ID multiple
-times.`,
+times. If the task group is run on a schedule, its schedule and next runs are
+also shown.
+
+The image, environment variables, and secrets shown are those of the container
+named for the task group, or of the first container if there is none of that
+name. Use --container to show those of another container.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		operation := &TaskInfoOperation{
+			Container:     flagTaskInfoContainer,
 			TaskGroupName: args[0],
 			TaskIds:       flagTaskInfoTasks,
 		}
@@ -41,6 +52,7 @@ times.`,
 func init() {
 	taskCmd.AddCommand(taskInfoCmd)
 
+	taskInfoCmd.Flags().StringVar(&flagTaskInfoContainer, "container", "", "Name of the container to show the image, environment variables, and secrets of (default: the container named for the task group)")
 	taskInfoCmd.Flags().StringSliceVarP(&flagTaskInfoTasks, "task", "t", []string{}, "Get info for specific task instances (can be specified multiple times)")
 }
 
@@ -50,6 +62,7 @@ func getTaskInfo(operation *TaskInfoOperation) {
 
 	ecs := ECS.New(sess, clusterName)
 	ec2 := EC2.New(sess)
+	eventbridge := EB.New(sess)
 
 	if len(operation.TaskIds) > 0 {
 		tasks = ecs.DescribeTasks(operation.TaskIds)
@@ -57,8 +70,38 @@ func getTaskInfo(operation *TaskInfoOperation) {
 		tasks = ecs.DescribeTasksForTaskGroup(operation.TaskGroupName)
 	}
 
+	if operation.Container != "" {
+		var err error
+
+		if tasks, err = selectTaskContainers(tasks, operation.Container); err != nil {
+			output.Fatal(err, "Invalid container")
+			return
+		}
+	}
+
+	output.Debug("Listing scheduled tasks [API=events Action=ListRules]")
+	scheduledTasks, err := eventbridge.ListScheduledTasks(clusterName)
+
+	if err != nil {
+		output.Warn("Could not list scheduled tasks, skipping next run times: %v", err)
+		scheduledTasks = EB.ScheduledTasks{}
+	}
+
+	scheduledTask, err := findScheduledTask(scheduledTasks, operation.TaskGroupName)
+	scheduled := err == nil
+
 	if len(tasks) == 0 {
-		console.InfoExit("No tasks found")
+		output.Document([]taskDocument{})
+
+		if !scheduled {
+			output.Info("No tasks found")
+			return
+		}
+
+		output.KeyValue("Task Group Name", operation.TaskGroupName, 0)
+		displayTaskSchedule(output, scheduledTask, time.Now(), 0)
+		output.KeyValue("Task Instances", "%d", 0, 0)
+		return
 	}
 
 	for _, task := range tasks {
@@ -67,35 +110,78 @@ func getTaskInfo(operation *TaskInfoOperation) {
 		}
 	}
 
-	enis := ec2.DescribeNetworkInterfaces(eniIds)
+	documents := newTaskDocuments(tasks, ec2.DescribeNetworkInterfaces(eniIds))
 
-	console.KeyValue("Task Group Name", "%s\n", operation.TaskGroupName)
-	console.KeyValue("Task Instances", "%d\n", len(tasks))
+	output.Document(documents)
+	output.KeyValue("Task Group Name", operation.TaskGroupName, 0)
 
-	for _, task := range tasks {
-		eni := enis[task.EniId]
+	if scheduled {
+		displayTaskSchedule(output, scheduledTask, time.Now(), 0)
+	}
 
-		console.KeyValue("  "+task.TaskId, "\n")
-		console.KeyValue("    Image", "%s\n", task.Image)
-		console.KeyValue("    Status", "%s\n", Humanize(task.LastStatus))
-		console.KeyValue("    Started At", "%s\n", task.CreatedAt)
-		console.KeyValue("    IP", "%s\n", eni.PublicIpAddress)
-		console.KeyValue("    CPU", "%s\n", task.Cpu)
-		console.KeyValue("    Memory", "%s\n", task.Memory)
+	output.KeyValue("Task Instances", "%d", 0, len(tasks))
+
+	for _, task := range documents {
+		output.KeyValue(task.TaskId, "", 1)
+		output.KeyValue("Image", task.Image, 2)
+		output.KeyValue("Status", Humanize(task.LastStatus), 2)
+		output.KeyValue("Started At", "%s", 2, task.CreatedAt)
+		output.KeyValue("IP", task.PublicIPAddress, 2)
+		output.KeyValue("CPU", task.Cpu, 2)
+		output.KeyValue("Memory", task.Memory, 2)
+		output.KeyValue("Platform", runtimePlatformString(task.RuntimePlatform), 2)
+		output.KeyValue("Ephemeral Storage", ephemeralStorageString(task.EphemeralStorage), 2)
 
 		if task.TaskRole != "" {
-			console.KeyValue("    Task Role", "%s\n", task.TaskRole)
+			output.KeyValue("Task Role", task.TaskRole, 2)
 		}
 
-		console.KeyValue("    Subnet", "%s\n", task.SubnetId)
-		console.KeyValue("    Security Groups", "%s\n", strings.Join(eni.SecurityGroupIds, ", "))
+		output.KeyValue("Subnet", task.SubnetId, 2)
+		output.KeyValue("Security Groups", strings.Join(task.SecurityGroupIDs, ", "), 2)
 
 		if len(task.EnvVars) > 0 {
-			console.KeyValue("    Environment Variables", "\n")
+			output.KeyValue("Environment Variables", "", 2)
 
 			for _, envVar := range task.EnvVars {
-				fmt.Printf("      %s=%s\n", envVar.Key, envVar.Value)
+				output.Say("%s=%s", 3, envVar.Key, envVar.Value)
+			}
+		}
+
+		if len(task.Secrets) > 0 {
+			output.KeyValue("Secrets", "", 2)
+
+			for _, secret := range task.Secrets {
+				output.Say("%s=%s", 3, secret.Key, secret.ValueFrom)
 			}
 		}
+
+		displaySidecars(output, task.Containers, 2)
+		displayVolumes(output, task.Volumes, task.Containers, 2)
 	}
 }
+
+// selectTaskContainers returns the tasks with their image, environment variables, secrets, and
+// health check taken from the named container. These are left empty for tasks whose task definition
+// has no container of that name, and an error is returned for the first of them.
+func selectTaskContainers(tasks []ECS.Task, name string) ([]ECS.Task, error) {
+	var (
+		firstErr error
+		selected []ECS.Task
+	)
+
+	for _, task := range tasks {
+		container, err := selectContainer(task.Containers, name)
+
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("task %s: %v", task.TaskId, err)
+		}
+
+		task.Image = container.Image
+		task.EnvVars = container.EnvVars
+		task.Secrets = container.Secrets
+		task.HealthCheck = container.HealthCheck
+		selected = append(selected, task)
+	}
+
+	return selected, firstErr
+}
@@ -2,10 +2,7 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"text/tabwriter"
 
-	"github.com/awslabs/fargatecli/console"
 	ECS "github.com/awslabs/fargatecli/ecs"
 	"github.com/spf13/cobra"
 )
@@ -27,19 +24,19 @@ func listTaskGroups() {
 	taskGroups := ecs.ListTaskGroups()
 
 	if len(taskGroups) == 0 {
-		console.InfoExit("No tasks running")
+		output.Document([]*ECS.TaskGroup{})
+		output.Info("No tasks running")
+		return
 	}
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 0, 8, 1, '\t', 0)
-	fmt.Fprintln(w, "NAME\tINSTANCES")
+	rows := [][]string{
+		[]string{"NAME", "INSTANCES"},
+	}
 
 	for _, taskGroup := range taskGroups {
-		fmt.Fprintf(w, "%s\t%d\n",
-			taskGroup.TaskGroupName,
-			taskGroup.Instances,
-		)
+		rows = append(rows, []string{taskGroup.TaskGroupName, fmt.Sprintf("%d", taskGroup.Instances)})
 	}
 
-	w.Flush()
+	output.Document(taskGroups)
+	output.Table("", rows)
 }
@@ -1,11 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-	"os"
-	"text/tabwriter"
-
-	"github.com/awslabs/fargatecli/console"
 	EC2 "github.com/awslabs/fargatecli/ec2"
 	ECS "github.com/awslabs/fargatecli/ecs"
 	"github.com/spf13/cobra"
@@ -18,7 +13,11 @@ type TaskProcessListOperation struct {
 var taskPsCmd = &cobra.Command{
 	Use:   "ps <task name>",
 	Short: "List running tasks",
-	Args:  cobra.ExactArgs(1),
+	Long: `List running tasks
+
+If the tasks were run with a health check command, the container health of
+each task is shown.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		operation := &TaskProcessListOperation{
 			TaskName: args[0],
@@ -46,26 +45,46 @@ func getTaskProcessList(operation *TaskProcessListOperation) {
 	}
 
 	if len(tasks) == 0 {
-		console.InfoExit("No tasks found")
+		output.Document([]taskDocument{})
+		output.Info("No tasks found")
+		return
+	}
+
+	documents := newTaskDocuments(tasks, ec2.DescribeNetworkInterfaces(eniIds))
+	header := []string{"ID", "IMAGE", "STATUS", "RUNNING", "IP", "CPU", "MEMORY"}
+
+	if hasContainerHealthChecks(documents) {
+		header = append(header, "HEALTH")
 	}
 
-	enis := ec2.DescribeNetworkInterfaces(eniIds)
+	if hasCapacityProviders(documents) {
+		header = append(header, "PROVIDER")
+	}
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 0, 8, 1, '\t', 0)
-	fmt.Fprintln(w, "ID\tIMAGE\tSTATUS\tRUNNING\tIP\tCPU\tMEMORY\t")
+	rows := [][]string{header}
 
-	for _, t := range tasks {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+	for _, t := range documents {
+		row := []string{
 			t.TaskId,
 			t.Image,
 			Humanize(t.LastStatus),
-			t.RunningFor(),
-			enis[t.EniId].PublicIpAddress,
+			t.RunningFor().String(),
+			t.PublicIPAddress,
 			t.Cpu,
 			t.Memory,
-		)
+		}
+
+		if hasContainerHealthChecks(documents) {
+			row = append(row, t.ContainerHealthString())
+		}
+
+		if hasCapacityProviders(documents) {
+			row = append(row, t.CapacityProviderName)
+		}
+
+		rows = append(rows, row)
 	}
 
-	w.Flush()
+	output.Document(documents)
+	output.Table("", rows)
 }
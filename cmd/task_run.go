@@ -1,30 +1,53 @@
 package cmd
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	CWL "github.com/awslabs/fargatecli/cloudwatchlogs"
 	"github.com/awslabs/fargatecli/console"
 	"github.com/awslabs/fargatecli/docker"
 	EC2 "github.com/awslabs/fargatecli/ec2"
 	ECR "github.com/awslabs/fargatecli/ecr"
 	ECS "github.com/awslabs/fargatecli/ecs"
-	"github.com/awslabs/fargatecli/git"
 	IAM "github.com/awslabs/fargatecli/iam"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 const typeTask string = "task"
 
 type TaskRunOperation struct {
-	Cpu              string
-	EnvVars          []ECS.EnvVar
-	Image            string
-	Memory           string
-	Num              int64
-	SecurityGroupIds []string
-	SubnetIds        []string
-	TaskName         string
-	TaskRole         string
-	TaskCommand      []string
+	BuildOptions      docker.BuildOptions
+	Cpu               string
+	EnableExec        bool
+	EnvVars           []ECS.EnvVar
+	EphemeralStorage  int64
+	Follow            bool
+	HealthCheck       *ECS.HealthCheck
+	Image             string
+	ImageArchive      string
+	KMSKeys           []string
+	Memory            string
+	MountPoints       []ECS.MountPoint
+	Num               int64
+	PinDigest         bool
+	RepositoryOptions ECR.RepositoryOptions
+	RequestedImage    string
+	Revision          gitRevision
+	RuntimePlatform   ECS.RuntimePlatform
+	Secrets           []ECS.Secret
+	SecurityGroupIds  []string
+	Sidecars          []ECS.Container
+	Spot              bool
+	SubnetIds         []string
+	TaskName          string
+	TaskRole          string
+	TaskCommand       []string
+	Timeout           time.Duration
+	Volumes           []ECS.Volume
+	Wait              bool
 }
 
 func (o *TaskRunOperation) Validate() {
@@ -34,25 +57,294 @@ func (o *TaskRunOperation) Validate() {
 		console.ErrorExit(err, "Invalid settings: %s CPU units / %s MiB", o.Cpu, o.Memory)
 	}
 
+	if err := validateEphemeralStorage(o.EphemeralStorage); err != nil {
+		console.ErrorExit(err, "Invalid settings")
+	}
+
+	if err := validateRuntimePlatform(o.RuntimePlatform, o.Cpu); err != nil {
+		console.ErrorExit(err, "Invalid settings: %s CPU units on %s", o.Cpu, runtimePlatformString(o.RuntimePlatform))
+	}
+
+	if o.ImageArchive == "" {
+		if err := validateBuildPlatform(o.RuntimePlatform, o.Image); err != nil {
+			console.ErrorExit(err, "Invalid settings")
+		}
+	}
+
 	if o.Num < 1 {
 		console.ErrorExit(err, "Invalid number of tasks: %d, num must be > 1", o.Num)
 	}
+
+	if !o.Wait && (o.Follow || o.Timeout != 0) {
+		console.IssueExit("--follow and --timeout require --wait")
+	}
+
+	if o.Timeout < 0 {
+		console.IssueExit("Invalid timeout: %s, timeout must be >= 0", o.Timeout)
+	}
 }
 
 func (o *TaskRunOperation) SetEnvVars(inputEnvVars []string) {
 	o.EnvVars = extractEnvVars(inputEnvVars)
 }
 
+func (o *TaskRunOperation) SetSecrets(inputSecrets []string) {
+	o.Secrets = extractSecrets(inputSecrets)
+}
+
+// SetKMSKeys sets the customer managed KMS keys the task execution role is allowed to decrypt
+// secrets with.
+func (o *TaskRunOperation) SetKMSKeys(inputKMSKeys []string) {
+	if err := validateKMSKeys(inputKMSKeys); err != nil {
+		console.ErrorExit(err, "Invalid KMS key")
+	}
+
+	o.KMSKeys = inputKMSKeys
+}
+
+func (o *TaskRunOperation) SetSidecars(inputSidecars []string) {
+	var msgs []string
+
+	sidecars, errs := inflateSidecars(o.TaskName, inputSidecars)
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid sidecar")
+	}
+
+	o.Sidecars = sidecars
+}
+
+func (o *TaskRunOperation) SetVolumes(inputVolumes, inputMountPoints []string) {
+	var msgs []string
+
+	volumes, errs := inflateVolumes(inputVolumes)
+	mountPoints, mountErrs := inflateMountPoints(inputMountPoints, volumes)
+
+	for _, err := range append(errs, mountErrs...) {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid volume")
+	}
+
+	o.Volumes = volumes
+	o.MountPoints = mountPoints
+}
+
+func (o *TaskRunOperation) SetRuntimePlatform(inputPlatform, inputOSFamily string) {
+	var msgs []string
+
+	runtimePlatform, errs := inflateRuntimePlatform(inputPlatform, inputOSFamily)
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid platform")
+	}
+
+	o.RuntimePlatform = runtimePlatform
+}
+
+// SetRepositoryOptions sets the options to create the image repository with if it does not exist.
+func (o *TaskRunOperation) SetRepositoryOptions(values repositoryFlagValues) {
+	var msgs []string
+
+	options, errs := values.repositoryOptions()
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid repository options")
+	}
+
+	o.RepositoryOptions = options
+}
+
+// SetRevision sets the git revision of the current working directory to tag and label the image
+// built or pushed from it with.
+func (o *TaskRunOperation) SetRevision(values gitFlagValues) {
+	revision, err := newGitRevision(values)
+
+	if err != nil {
+		console.ErrorExit(err, "Invalid git working tree")
+	}
+
+	o.Revision = revision
+}
+
+// SetBuildOptions sets the options to build the image with when no image is given.
+func (o *TaskRunOperation) SetBuildOptions(values buildFlagValues) {
+	var msgs []string
+
+	options, errs := values.buildOptions(o.Image)
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid build options")
+	}
+
+	o.BuildOptions = options
+}
+
+// SetImageArchive sets the image archive to push and run instead of building an image.
+func (o *TaskRunOperation) SetImageArchive(path string, build buildFlagValues) {
+	var msgs []string
+
+	for _, err := range validateImageArchive(path, o.Image, build) {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) > 0 {
+		console.ErrorExit(fmt.Errorf(strings.Join(msgs, ", ")), "Invalid image archive")
+	}
+
+	o.ImageArchive = path
+}
+
+func (o *TaskRunOperation) SetHealthCheck(healthCheck ECS.HealthCheck) {
+	if healthCheck.Command == "" {
+		console.IssueExit("Setting a health check interval, retries, or start period requires --health-check-command")
+	}
+
+	if err := validateContainerHealthCheck(healthCheck); err != nil {
+		console.ErrorExit(err, "Invalid health check")
+	}
+
+	o.HealthCheck = &healthCheck
+}
+
+// taskRunFlagValues holds the settings of the tasks and task definition fargate task run and
+// fargate task schedule register, given on the command line.
+type taskRunFlagValues struct {
+	build            buildFlagValues
+	cpu              string
+	enableExec       bool
+	envVars          []string
+	ephemeralStorage int64
+	git              gitFlagValues
+	healthCheck      containerHealthCheckFlagValues
+	healthInterval   int64
+	image            string
+	imageArchive     string
+	kmsKeys          []string
+	memory           string
+	mountPoints      []string
+	num              int64
+	osFamily         string
+	pinDigest        bool
+	platform         string
+	repository       repositoryFlagValues
+	secrets          []string
+	securityGroupIds []string
+	sidecars         []string
+	spot             bool
+	subnetIds        []string
+	taskCommand      []string
+	taskRole         string
+	volumes          []string
+}
+
+// addTaskRunFlags adds the flags which configure the tasks and task definition of a task group to a
+// flag set.
+func addTaskRunFlags(flags *pflag.FlagSet, values *taskRunFlagValues) {
+	flags.Int64VarP(&values.num, "num", "n", 1, "Number of task instances to run")
+	flags.StringSliceVarP(&values.envVars, "env", "e", []string{}, "Environment variables to set [e.g. KEY=value] (can be specified multiple times)")
+	flags.StringSliceVar(&values.secrets, "secret", []string{}, "Secrets to set from SSM parameters or Secrets Manager secrets [e.g. KEY=parameter-name, KEY=arn] (can be specified multiple times)")
+	flags.StringSliceVar(&values.kmsKeys, "kms-key", []string{}, "ARN of a customer managed KMS key the task execution role may decrypt secrets with (can be specified multiple times)")
+	flags.StringVarP(&values.cpu, "cpu", "c", "256", "Amount of cpu units to allocate for each task")
+	flags.StringVarP(&values.image, "image", "i", "", "Docker image to run; if omitted Fargate will build an image from the Dockerfile in the current directory")
+	flags.StringVarP(&values.memory, "memory", "m", "512", "Amount of MiB to allocate for each task")
+	flags.Int64Var(&values.ephemeralStorage, "ephemeral-storage", 0, "Amount of GiB of ephemeral storage to allocate for each task [21 - 200] (default 20)")
+	flags.StringVar(&values.imageArchive, "image-archive", "", "Image archive written by docker save or in OCI image layout to push and run instead of building an image")
+	flags.BoolVar(&values.pinDigest, "pin-digest", true, "Run images in Amazon ECR by the digest their tag refers to rather than by the tag")
+	addBuildFlags(flags, &values.build)
+	addRepositoryFlags(flags, &values.repository)
+	addGitFlags(flags, &values.git)
+	flags.StringVar(&values.platform, "platform", "", "Platform to run the tasks on and build the image for [e.g. linux/amd64, linux/arm64]")
+	flags.StringVar(&values.osFamily, "os-family", "", "Operating system family to run the tasks on [e.g. LINUX, WINDOWS_SERVER_2019_CORE]")
+	addContainerHealthCheckFlags(flags, &values.healthCheck)
+	flags.Int64Var(&values.healthInterval, "health-check-interval", 0, "Seconds between health checks of the container (5-300)")
+	flags.StringArrayVar(&values.sidecars, "sidecar", []string{}, "Sidecar container to run alongside the task's container [e.g. name=proxy,image=envoyproxy/envoy,port=9901] (can be specified multiple times)")
+	flags.StringArrayVar(&values.volumes, "volume", []string{}, "EFS volume to define [e.g. uploads=efs:fs-12345678:/uploads,access-point=fsap-12345678,readonly] (can be specified multiple times)")
+	flags.StringArrayVar(&values.mountPoints, "mount", []string{}, "Volume to mount in the task's container [e.g. uploads:/var/uploads] (can be specified multiple times)")
+	flags.StringSliceVar(&values.securityGroupIds, "security-group-id", []string{}, "ID of a security group to apply to the task (can be specified multiple times)")
+	flags.StringSliceVar(&values.subnetIds, "subnet-id", []string{}, "ID of a subnet in which to place the task (can be specified multiple times)")
+	flags.StringVarP(&values.taskRole, "task-role", "", "", "Name or ARN of an IAM role that the tasks can assume")
+	flags.StringSliceVar(&values.taskCommand, "task-command", []string{}, "Command to run inside container instead of the one specified in the docker image")
+	flags.BoolVar(&values.spot, "spot", false, "Run the tasks on Fargate Spot")
+	flags.BoolVar(&values.enableExec, "enable-exec", false, "Enable ECS Exec so that commands can be run in the tasks with fargate task exec")
+}
+
+// operation returns the operation to run a task group with the settings given on the command line,
+// exiting if any are invalid.
+func (v taskRunFlagValues) operation(taskName string) *TaskRunOperation {
+	operation := &TaskRunOperation{
+		Cpu:              v.cpu,
+		EnableExec:       v.enableExec,
+		EphemeralStorage: v.ephemeralStorage,
+		Image:            v.image,
+		Memory:           v.memory,
+		Num:              v.num,
+		PinDigest:        v.pinDigest,
+		SecurityGroupIds: v.securityGroupIds,
+		Spot:             v.spot,
+		SubnetIds:        v.subnetIds,
+		TaskName:         taskName,
+		TaskRole:         v.taskRole,
+		TaskCommand:      v.taskCommand,
+	}
+
+	operation.SetEnvVars(v.envVars)
+	operation.SetSecrets(v.secrets)
+	operation.SetKMSKeys(v.kmsKeys)
+
+	if len(v.sidecars) > 0 {
+		operation.SetSidecars(v.sidecars)
+	}
+
+	if len(v.volumes) > 0 || len(v.mountPoints) > 0 {
+		operation.SetVolumes(v.volumes, v.mountPoints)
+	}
+
+	if v.platform != "" || v.osFamily != "" {
+		operation.SetRuntimePlatform(v.platform, v.osFamily)
+	}
+
+	operation.SetBuildOptions(v.build)
+	operation.SetRepositoryOptions(v.repository)
+
+	if operation.Image == "" {
+		operation.SetRevision(v.git)
+	}
+
+	if v.imageArchive != "" {
+		operation.SetImageArchive(v.imageArchive, v.build)
+	}
+
+	if !v.healthCheck.isEmpty() || v.healthInterval != 0 {
+		operation.SetHealthCheck(v.healthCheck.healthCheck(v.healthInterval))
+	}
+
+	return operation
+}
+
 var (
-	flagTaskRunNum              int64
-	flagTaskRunCpu              string
-	flagTaskRunEnvVars          []string
-	flagTaskRunImage            string
-	flagTaskRunMemory           string
-	flagTaskRunSecurityGroupIds []string
-	flagTaskRunSubnetIds        []string
-	flagTaskRunTaskRole         string
-	flagTaskRunTaskCommand      []string
+	flagTaskRun        taskRunFlagValues
+	flagTaskRunFollow  bool
+	flagTaskRunTimeout time.Duration
+	flagTaskRunWait    bool
 )
 
 var taskRunCmd = &cobra.Command{
@@ -73,17 +365,22 @@ respectively using the --cpu and --memory flags. Every 1024 CPU units is
 equivilent to a single vCPU. AWS Fargate only supports certain combinations of
 CPU and memory configurations:
 
-| CPU (CPU Units) | Memory (MiB)                          |
-| --------------- | ------------------------------------- |
-| 256             | 512, 1024, or 2048                    |
-| 512             | 1024 through 4096 in 1GiB increments  |
-| 1024            | 2048 through 8192 in 1GiB increments  |
-| 2048            | 4096 through 16384 in 1GiB increments |
-| 4096            | 8192 through 30720 in 1GiB increments |
-
+` + cpuAndMemoryTable() + `
 If not specified, fargate will launch minimally sized tasks at 0.25 vCPU (256
 CPU units) and 0.5GB (512 MiB) of memory.
 
+Tasks are given 20 GiB of ephemeral storage for their containers' writable
+layers and bind mounts. Up to 200 GiB can be allocated with the
+--ephemeral-storage flag.
+
+Tasks run on Linux on x86_64 (amd64) CPUs unless a platform is specified with
+the --platform flag in the form of os/arch. Pass --platform linux/arm64 to run
+tasks on AWS Graviton (ARM64) processors. When fargate builds the image, it is
+built for the platform using docker buildx. Windows tasks are run by passing
+the --os-family flag with WINDOWS_SERVER_2019_FULL, WINDOWS_SERVER_2019_CORE,
+WINDOWS_SERVER_2022_FULL, or WINDOWS_SERVER_2022_CORE. Windows tasks require at
+least 1024 CPU units and an image passed with the --image flag.
+
 The Docker container image to use in the task can be optionally specified via
 the --image flag. If not specified, fargate will build a new Docker container
 image from the current working directory and push it to Amazon ECR in a
@@ -91,9 +388,82 @@ repository named for the task group. If the current working directory is a git
 repository, the container image will be tagged with the short ref of the HEAD
 commit. If not, a timestamp in the format of YYYYMMDDHHMMSS will be used.
 
+Images are built from the Dockerfile in the current working directory. Use
+--dockerfile and --context to build from another Dockerfile or directory,
+--target to build a stage of a multi-stage Dockerfile, and --build-arg to pass
+build-time variables. --cache-from imports a build cache from an image or cache
+source and --cache-to exports the build cache using docker buildx. Images are
+labelled with the git commit SHA, branch, author, and message they were built
+from and the time they were built.
+
+If the git working tree has uncommitted changes, the image tag is suffixed with
+-dirty-<timestamp> so it is never mistaken for an image of the committed code.
+Use --refuse-dirty to refuse to build from a working tree with uncommitted
+changes instead. Use --tag-git-refs to also tag the image with the current
+branch and any git tags pointing at HEAD. The commit is recorded as tags on the
+task definition, and service info shows the commit each deployment runs.
+
+Images are built with docker, podman, or buildah, using the first one installed
+unless another is passed with the --builder flag. Images are pushed to Amazon
+ECR by fargate itself, authenticating with a token from ECR, so docker login and
+docker push are not used.
+
+Images built elsewhere, such as in an earlier CI job, can be run by passing the
+--image-archive flag with the path to an archive written by docker save or an
+OCI image layout tarball. The image is pushed to the task group's Amazon ECR
+repository, which is created if needed, and run by its digest.
+
+Images in Amazon ECR, whether built by fargate or passed with --image, are run
+by the sha256 digest their tag refers to when the task definition is
+registered. Pass --pin-digest=false to run images by tag instead.
+
+Repositories created by fargate can be given a lifecycle policy which expires
+old images by passing the --lifecycle-policy flag with the path to a JSON
+policy document, and can scan images for vulnerabilities as they are pushed
+with --scan-on-push. Neither flag changes a repository which already exists.
+Images are listed by fargate image list and removed by fargate image prune.
+
 Environment variables can be specified via the --env flag. Specify --env with a
 key=value parameter multiple times to add multiple variables.
 
+Secrets can be specified via the --secret flag. Secrets are set as environment
+variables in the container whose values are read from AWS Systems Manager
+Parameter Store or AWS Secrets Manager when the container starts, so that their
+values are not stored in the task definition. Specify --secret with a
+KEY=parameter-name or KEY=arn parameter multiple times to add multiple secrets.
+The task execution role is granted access to read the referenced parameters and
+secrets. Parameters and secrets encrypted with a customer managed KMS key also
+require kms:Decrypt permission on the key, which is granted by passing the
+--kms-key flag with the key's ARN.
+
+A health check command can be run inside the task's container by passing the
+--health-check-command flag with a shell command which exits with a non-zero
+status when the container is unhealthy. The time between checks can be set
+with the --health-check-interval flag, the number of consecutive failures
+before the container is unhealthy with the --health-check-retries flag, and the
+time to allow the container to start before failures are counted with the
+--health-check-start-period flag. The health of each task is shown by fargate
+task ps.
+
+Sidecar containers can be run alongside the task's container by passing the
+--sidecar flag with a comma separated list of key=value pairs, such as
+name=proxy,image=envoyproxy/envoy,port=9901. The name and image keys are
+required. The port, essential (default true), env=KEY=value, and
+depends-on=container[:START|COMPLETE|SUCCESS|HEALTHY] keys are optional, and
+env and depends-on can be given more than once. The task's own container is
+named for the task group. Specify --sidecar multiple times to add multiple
+sidecars.
+
+Amazon EFS file systems can be mounted into the task's container to share files
+between tasks and keep them after tasks stop. Define a volume by passing the
+--volume flag with an expression of NAME=efs:FILE-SYSTEM-ID[:/path], optionally
+followed by ,access-point=ACCESS-POINT-ID to mount through an EFS access point,
+which enables encryption in transit, or ,readonly to mount the volume
+read-only. Mount a volume into the container by passing the --mount flag with
+NAME:/container/path. Both flags can be specified multiple times. The file
+system's mount targets must allow NFS traffic (TCP port 2049) from the task's
+security groups.
+
 Security groups can optionally be specified for the task by passing the
 --security-group-id flag with a security group ID. To add multiple security
 groups, pass --security-group-id with a security group ID multiple times. If
@@ -108,26 +478,36 @@ A task role can be optionally specified via the --task-role flag by providing
 eith a full IAM role ARN or the name of an IAM role. The tasks will be able to
 assume this role.
 
+Commands can be run in the tasks with fargate task exec when ECS Exec is
+enabled by passing the --enable-exec flag. The task role is then granted the
+Session Manager permissions ECS Exec requires, and tasks run without a task
+role assume the fargateTaskRole role, which fargate creates.
+
 The default command of the docker image can be overridden using the
 --task-command flag, where the value is a string of comma seperated values
 representing the command. These values will be placed into an array as per
-the requirements of the docker CMD syntax`,
+the requirements of the docker CMD syntax
+
+Tasks can be run on Fargate Spot, which runs interruptible tasks at a discount,
+by passing the --spot flag. Fargate Spot tasks can be stopped with two minutes
+notice when AWS needs the capacity back.
+
+By default, fargate task run returns once the tasks have been started. Pass
+the --wait flag to wait for the tasks to stop instead, after which the exit
+code and stopped reason of each container is shown and fargate exits with the
+exit code of the task's container. If more than one task is run, the first
+non-zero exit code is used. The logs of the tasks can be followed while waiting
+with the --follow flag. The --timeout flag sets how long to wait before the
+tasks are stopped, such as 30m; by default there is no limit. Tasks which were
+interrupted by Fargate Spot are reported as such.`,
 
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		operation := &TaskRunOperation{
-			Cpu:              flagTaskRunCpu,
-			Image:            flagTaskRunImage,
-			Memory:           flagTaskRunMemory,
-			Num:              flagTaskRunNum,
-			SecurityGroupIds: flagTaskRunSecurityGroupIds,
-			SubnetIds:        flagTaskRunSubnetIds,
-			TaskName:         args[0],
-			TaskRole:         flagTaskRunTaskRole,
-			TaskCommand:      flagTaskRunTaskCommand,
-		}
+		operation := flagTaskRun.operation(args[0])
+		operation.Follow = flagTaskRunFollow
+		operation.Timeout = flagTaskRunTimeout
+		operation.Wait = flagTaskRunWait
 
-		operation.SetEnvVars(flagTaskRunEnvVars)
 		operation.Validate()
 
 		runTask(operation)
@@ -135,25 +515,69 @@ the requirements of the docker CMD syntax`,
 }
 
 func init() {
-	taskRunCmd.Flags().Int64VarP(&flagTaskRunNum, "num", "n", 1, "Number of task instances to run")
-	taskRunCmd.Flags().StringSliceVarP(&flagTaskRunEnvVars, "env", "e", []string{}, "Environment variables to set [e.g. KEY=value] (can be specified multiple times)")
-	taskRunCmd.Flags().StringVarP(&flagTaskRunCpu, "cpu", "c", "256", "Amount of cpu units to allocate for each task")
-	taskRunCmd.Flags().StringVarP(&flagTaskRunImage, "image", "i", "", "Docker image to run; if omitted Fargate will build an image from the Dockerfile in the current directory")
-	taskRunCmd.Flags().StringVarP(&flagTaskRunMemory, "memory", "m", "512", "Amount of MiB to allocate for each task")
-	taskRunCmd.Flags().StringSliceVar(&flagTaskRunSecurityGroupIds, "security-group-id", []string{}, "ID of a security group to apply to the task (can be specified multiple times)")
-	taskRunCmd.Flags().StringSliceVar(&flagTaskRunSubnetIds, "subnet-id", []string{}, "ID of a subnet in which to place the task (can be specified multiple times)")
-	taskRunCmd.Flags().StringVarP(&flagTaskRunTaskRole, "task-role", "", "", "Name or ARN of an IAM role that the tasks can assume")
-	taskRunCmd.Flags().StringSliceVar(&flagTaskRunTaskCommand, "task-command", []string{}, "Command to run inside container instead of the one specified in the docker image")
+	addTaskRunFlags(taskRunCmd.Flags(), &flagTaskRun)
+	taskRunCmd.Flags().BoolVarP(&flagTaskRunWait, "wait", "w", false, "Wait for the tasks to stop and exit with the exit code of the task's container")
+	taskRunCmd.Flags().BoolVarP(&flagTaskRunFollow, "follow", "f", false, "Print the logs of the tasks while waiting (requires --wait)")
+	taskRunCmd.Flags().DurationVar(&flagTaskRunTimeout, "timeout", 0, "Time to wait before stopping the tasks [e.g. 30m] (requires --wait)")
 	taskCmd.AddCommand(taskRunCmd)
 }
 
 func runTask(operation *TaskRunOperation) {
+	ecs := ECS.New(sess, clusterName)
+	taskDefinitionArn, logGroupName := registerTaskDefinition(operation)
+
+	var strategy ECS.CapacityProviderStrategy
+
+	if operation.Spot {
+		if _, err := ecs.CreateCluster(); err != nil {
+			console.ErrorExit(err, "Could not attach capacity providers to cluster %s", clusterName)
+		}
+
+		strategy = spotCapacityProviderStrategy
+	}
+
+	since := time.Now()
+	taskIDs := ecs.RunTask(
+		&ECS.RunTaskInput{
+			CapacityProviderStrategy: strategy,
+			ClusterName:              clusterName,
+			Count:                    operation.Num,
+			EnableExecuteCommand:     operation.EnableExec,
+			TaskName:                 operation.TaskName,
+			TaskDefinitionArn:        taskDefinitionArn,
+			SubnetIds:                operation.SubnetIds,
+			SecurityGroupIds:         operation.SecurityGroupIds,
+		},
+	)
+
+	output.Info("Running task %s", operation.TaskName)
+
+	if !operation.Wait {
+		return
+	}
+
+	if exitCode := waitForTasks(operation.TaskName, taskIDs, logGroupName, operation.Follow, since, operation.Timeout); exitCode != 0 {
+		console.Exit(exitCode)
+	}
+}
+
+// registerTaskDefinition creates the roles, log group, and, unless an image is given, the container
+// image a task needs and registers its task definition, returning the task definition's ARN and the
+// name of its log group. The operation's security groups and subnets default to those of the default
+// VPC.
+func registerTaskDefinition(operation *TaskRunOperation) (string, string) {
 	cwl := CWL.New(sess)
 	ec2 := EC2.New(sess)
 	ecr := ECR.New(sess)
 	ecs := ECS.New(sess, clusterName)
 	iam := IAM.New(sess)
 	ecsTaskExecutionRoleArn := iam.CreateEcsTaskExecutionRole()
+	grantSecretsAccess(ecsTaskExecutionRoleArn, operation.Secrets, operation.KMSKeys)
+
+	if operation.EnableExec {
+		operation.TaskRole = execTaskRole(operation.TaskRole)
+	}
+
 	logGroupName := cwl.CreateLogGroup(taskLogGroupFormat, operation.TaskName)
 
 	if len(operation.SecurityGroupIds) == 0 {
@@ -165,57 +589,61 @@ func runTask(operation *TaskRunOperation) {
 		operation.SubnetIds, _ = ec2.GetDefaultSubnetIDs()
 	}
 
-	if operation.Image == "" {
-		var repositoryUri, tag string
+	switch {
+	case operation.ImageArchive != "":
+		operation.Image, operation.RequestedImage = pushImageArchive(operation.TaskName, operation.ImageArchive, operation.RepositoryOptions, operation.Revision, newChangePlan())
+	case operation.Image == "":
+		var repositoryUri string
 
 		if ecr.IsRepositoryCreated(operation.TaskName) {
 			repositoryUri = ecr.GetRepositoryUri(operation.TaskName)
 		} else {
-			repositoryUri = ecr.CreateRepository(operation.TaskName)
+			repositoryUri = ecr.CreateRepository(operation.TaskName, operation.RepositoryOptions)
 		}
 
-		if git.IsCwdGitRepo() {
-			tag = git.GetShortSha()
-		} else {
-			tag = docker.GenerateTag()
-		}
+		tag := operation.Revision.tag()
 
 		repository := docker.NewRepository(repositoryUri)
 		username, password := ecr.GetUsernameAndPassword()
 
 		repository.Login(username, password)
-		repository.Build(tag)
-		repository.Push(tag)
+		repository.Build(tag, platformBuildOptions(operation.BuildOptions, operation.RuntimePlatform, operation.Revision))
+		digest := repository.Push(tag, operation.Revision.additionalTags()...)
 
 		operation.Image = repository.UriFor(tag)
+
+		if operation.PinDigest {
+			operation.Image = repository.UriForDigest(digest)
+			operation.RequestedImage = repository.UriFor(tag)
+		}
+	case operation.PinDigest:
+		operation.Image, operation.RequestedImage = pinImage(operation.Image)
 	}
 
 	taskDefinitionArn := ecs.CreateTaskDefinition(
 		&ECS.CreateTaskDefinitionInput{
+			Commit:           operation.Revision.commit(),
 			Cpu:              operation.Cpu,
 			EnvVars:          operation.EnvVars,
+			EphemeralStorage: operation.EphemeralStorage,
 			ExecutionRoleArn: ecsTaskExecutionRoleArn,
+			HealthCheck:      operation.HealthCheck,
 			Image:            operation.Image,
 			LogGroupName:     logGroupName,
 			LogRegion:        region,
 			Memory:           operation.Memory,
+			MountPoints:      operation.MountPoints,
 			Name:             operation.TaskName,
+			RequestedImage:   operation.RequestedImage,
+			RuntimePlatform:  operation.RuntimePlatform,
+			Secrets:          operation.Secrets,
+			Sidecars:         operation.Sidecars,
 			Type:             typeTask,
 			TaskRole:         operation.TaskRole,
 			TaskCommand:      operation.TaskCommand,
+			Volumes:          operation.Volumes,
 		},
 	)
 
-	ecs.RunTask(
-		&ECS.RunTaskInput{
-			ClusterName:       clusterName,
-			Count:             operation.Num,
-			TaskName:          operation.TaskName,
-			TaskDefinitionArn: taskDefinitionArn,
-			SubnetIds:         operation.SubnetIds,
-			SecurityGroupIds:  operation.SecurityGroupIds,
-		},
-	)
-
-	console.Info("Running task %s", operation.TaskName)
+	return taskDefinitionArn, logGroupName
 }
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/awslabs/fargatecli/console"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	EB "github.com/awslabs/fargatecli/eventbridge"
+	IAM "github.com/awslabs/fargatecli/iam"
+	"github.com/spf13/cobra"
+)
+
+const nextRunsCount = 3
+
+var errScheduledTaskNotFound = errors.New("scheduled task not found")
+
+type TaskScheduleOperation struct {
+	TaskRunOperation
+	Schedule string
+}
+
+func (o *TaskScheduleOperation) SetSchedule(cron, rate string) {
+	var err error
+
+	switch {
+	case cron != "" && rate != "":
+		console.IssueExit("--cron and --rate cannot both be specified")
+	case cron != "":
+		o.Schedule, err = EB.CronExpression(cron)
+	case rate != "":
+		o.Schedule, err = EB.RateExpression(rate)
+	default:
+		console.IssueExit("A schedule must be specified with --cron or --rate")
+	}
+
+	if err != nil {
+		console.ErrorExit(err, "Invalid schedule")
+	}
+}
+
+// findScheduledTask returns the scheduled task of a task group from the scheduled tasks of a cluster.
+func findScheduledTask(scheduledTasks EB.ScheduledTasks, taskName string) (EB.ScheduledTask, error) {
+	for _, scheduledTask := range scheduledTasks {
+		if scheduledTask.Name == taskName {
+			return scheduledTask, nil
+		}
+	}
+
+	return EB.ScheduledTask{}, errScheduledTaskNotFound
+}
+
+// nextRuns returns the next times a schedule will run, or nothing if they cannot be determined, such
+// as for rate expressions.
+func nextRuns(schedule string, now time.Time, count int) []string {
+	var runs []string
+
+	times, _ := EB.NextRuns(schedule, now, count)
+
+	for _, t := range times {
+		runs = append(runs, t.Format(timeFormatWithZone))
+	}
+
+	return runs
+}
+
+// displayTaskSchedule displays when and how a task group is scheduled to run.
+func displayTaskSchedule(o Output, scheduledTask EB.ScheduledTask, now time.Time, indent int) {
+	o.KeyValue("Schedule", scheduledTask.Schedule, indent)
+	o.KeyValue("State", Humanize(scheduledTask.State), indent+1)
+	o.KeyValue("Revision", ECS.TaskDefinitionRevision(scheduledTask.TaskDefinitionARN), indent+1)
+	o.KeyValue("Tasks Per Run", "%d", indent+1, scheduledTask.TaskCount)
+
+	if runs := nextRuns(scheduledTask.Schedule, now, nextRunsCount); len(runs) > 0 {
+		o.KeyValue("Next Runs", "", indent+1)
+
+		for _, run := range runs {
+			o.Say("%s", indent+2, run)
+		}
+	}
+}
+
+var (
+	flagTaskSchedule     taskRunFlagValues
+	flagTaskScheduleCron string
+	flagTaskScheduleRate string
+)
+
+var taskScheduleCmd = &cobra.Command{
+	Use:   "schedule <task name>",
+	Short: "Run tasks on a schedule",
+	Long: `Run tasks on a schedule
+
+Registers a task definition in the same way as fargate task run and creates an
+Amazon EventBridge rule which runs it on a schedule. Scheduling a task group
+which is already scheduled replaces its schedule and task definition.
+
+The schedule is given either as a cron expression via the --cron flag or as a
+rate via the --rate flag. Cron expressions have six fields in UTC: minutes,
+hours, day-of-month, month, day-of-week, and year, one of day-of-month or
+day-of-week must be ?, e.g. "0 3 * * ? *" to run at 3:00 AM every day. Rates are
+a number followed by minutes, hours, or days, e.g. "5 minutes" or "1 day".
+
+The task definition and tasks are configured by the same flags as fargate task
+run, such as --cpu, --memory, --image, --env, --secret, --sidecar, --volume,
+--platform, --spot, and the flags which build the image, except for --wait,
+--follow, and --timeout. EventBridge runs the tasks using the ecsEventsRole
+role, which fargate creates if it does not exist.
+
+Scheduled tasks are listed by fargate task schedule list and removed by fargate
+task schedule remove. The schedule and next runs of a task group are shown by
+fargate task info.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		operation := &TaskScheduleOperation{TaskRunOperation: *flagTaskSchedule.operation(args[0])}
+
+		operation.SetSchedule(flagTaskScheduleCron, flagTaskScheduleRate)
+		operation.Validate()
+
+		scheduleTask(operation)
+	},
+}
+
+func init() {
+	taskScheduleCmd.Flags().StringVar(&flagTaskScheduleCron, "cron", "", "Cron expression in UTC [e.g. \"0 3 * * ? *\"]")
+	taskScheduleCmd.Flags().StringVar(&flagTaskScheduleRate, "rate", "", "Rate at which to run the task [e.g. \"5 minutes\"]")
+	addTaskRunFlags(taskScheduleCmd.Flags(), &flagTaskSchedule)
+	taskCmd.AddCommand(taskScheduleCmd)
+}
+
+func scheduleTask(operation *TaskScheduleOperation) {
+	ecs := ECS.New(sess, clusterName)
+	eventbridge := EB.New(sess)
+	iam := IAM.New(sess)
+
+	taskDefinitionArn, _ := registerTaskDefinition(&operation.TaskRunOperation)
+	ecsEventsRoleArn, err := iam.CreateEcsEventsRole()
+
+	if err != nil {
+		console.ErrorExit(err, "Could not create EventBridge role")
+	}
+
+	clusterArn, err := ecs.CreateCluster()
+
+	if err != nil {
+		console.ErrorExit(err, "Could not find cluster %s", clusterName)
+	}
+
+	err = eventbridge.PutScheduledTask(
+		EB.PutScheduledTaskParameters{
+			ClusterARN:           clusterArn,
+			ClusterName:          clusterName,
+			EnableExecuteCommand: operation.EnableExec,
+			Name:                 operation.TaskName,
+			RoleARN:              ecsEventsRoleArn,
+			Schedule:             operation.Schedule,
+			SecurityGroupIDs:     operation.SecurityGroupIds,
+			Spot:                 operation.Spot,
+			SubnetIDs:            operation.SubnetIds,
+			TaskCount:            operation.Num,
+			TaskDefinitionARN:    taskDefinitionArn,
+		},
+	)
+
+	if err != nil {
+		console.ErrorExit(err, "Could not schedule task %s", operation.TaskName)
+	}
+
+	output.Info("Scheduled task %s to run on %s", operation.TaskName, operation.Schedule)
+
+	if runs := nextRuns(operation.Schedule, time.Now(), nextRunsCount); len(runs) > 0 {
+		output.Info("Next run at %s", runs[0])
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+	EB "github.com/awslabs/fargatecli/eventbridge"
+	"github.com/spf13/cobra"
+)
+
+type taskScheduleListOperation struct {
+	clusterName string
+	eventbridge EB.Client
+	now         time.Time
+	output      Output
+}
+
+func (o taskScheduleListOperation) execute() {
+	o.output.Debug("Listing scheduled tasks [API=events Action=ListRules]")
+	scheduledTasks, err := o.eventbridge.ListScheduledTasks(o.clusterName)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not list scheduled tasks")
+		return
+	}
+
+	sort.Slice(scheduledTasks, func(i, j int) bool {
+		return scheduledTasks[i].Name < scheduledTasks[j].Name
+	})
+
+	o.output.Document(scheduledTasks)
+
+	if len(scheduledTasks) == 0 {
+		o.output.Info("No scheduled tasks found")
+		return
+	}
+
+	rows := [][]string{
+		[]string{"NAME", "SCHEDULE", "STATE", "NEXT RUN", "REVISION", "INSTANCES"},
+	}
+
+	for _, scheduledTask := range scheduledTasks {
+		nextRun := ""
+
+		if runs := nextRuns(scheduledTask.Schedule, o.now, 1); len(runs) > 0 {
+			nextRun = runs[0]
+		}
+
+		rows = append(rows,
+			[]string{
+				scheduledTask.Name,
+				scheduledTask.Schedule,
+				Titleize(scheduledTask.State),
+				nextRun,
+				ECS.TaskDefinitionRevision(scheduledTask.TaskDefinitionARN),
+				fmt.Sprintf("%d", scheduledTask.TaskCount),
+			},
+		)
+	}
+
+	o.output.Table("", rows)
+}
+
+var taskScheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled tasks",
+	Long: `List scheduled tasks
+
+Lists the task groups scheduled to run in the cluster, along with their
+schedule and, for cron expressions, when they will next run.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		taskScheduleListOperation{
+			clusterName: clusterName,
+			eventbridge: EB.New(sess),
+			now:         time.Now(),
+			output:      output,
+		}.execute()
+	},
+}
+
+func init() {
+	taskScheduleCmd.AddCommand(taskScheduleListCmd)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/awslabs/fargatecli/cmd/mock"
+	EB "github.com/awslabs/fargatecli/eventbridge"
+	ebclient "github.com/awslabs/fargatecli/eventbridge/mock/client"
+	"github.com/golang/mock/gomock"
+)
+
+func TestTaskScheduleListOperation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ebclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().ListScheduledTasks("fargate").Return(
+		EB.ScheduledTasks{
+			EB.ScheduledTask{Name: "nightly", Schedule: "cron(0 3 * * ? *)", State: "ENABLED", TaskCount: 1, TaskDefinitionARN: scheduleTaskDefinitionARN},
+			EB.ScheduledTask{Name: "hourly", Schedule: "rate(1 hour)", State: "DISABLED", TaskCount: 2, TaskDefinitionARN: scheduleTaskDefinitionARN},
+		},
+		nil,
+	)
+
+	taskScheduleListOperation{
+		clusterName: "fargate",
+		eventbridge: mockClient,
+		now:         time.Date(2020, time.January, 31, 3, 0, 0, 0, time.UTC),
+		output:      mockOutput,
+	}.execute()
+
+	if len(mockOutput.Tables) != 1 {
+		t.Fatalf("expected 1 table, got %d", len(mockOutput.Tables))
+	}
+
+	expected := [][]string{
+		[]string{"NAME", "SCHEDULE", "STATE", "NEXT RUN", "REVISION", "INSTANCES"},
+		[]string{"hourly", "rate(1 hour)", "Disabled", "", "3", "2"},
+		[]string{"nightly", "cron(0 3 * * ? *)", "Enabled", "2020-02-01 03:00:00 UTC", "3", "1"},
+	}
+
+	if !reflect.DeepEqual(expected, mockOutput.Tables[0].Rows) {
+		t.Errorf("expected rows %v, got %v", expected, mockOutput.Tables[0].Rows)
+	}
+}
+
+func TestTaskScheduleListOperationNoneFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ebclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().ListScheduledTasks("fargate").Return(EB.ScheduledTasks{}, nil)
+
+	taskScheduleListOperation{clusterName: "fargate", eventbridge: mockClient, output: mockOutput}.execute()
+
+	if len(mockOutput.InfoMsgs) != 1 || mockOutput.InfoMsgs[0] != "No scheduled tasks found" {
+		t.Errorf("expected no scheduled tasks message, got %v", mockOutput.InfoMsgs)
+	}
+}
+
+func TestTaskScheduleListOperationError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ebclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().ListScheduledTasks("fargate").Return(EB.ScheduledTasks{}, errors.New("boom"))
+
+	taskScheduleListOperation{clusterName: "fargate", eventbridge: mockClient, output: mockOutput}.execute()
+
+	if !mockOutput.Exited {
+		t.Errorf("expected fatal exit")
+	}
+}
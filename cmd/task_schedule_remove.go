@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	EB "github.com/awslabs/fargatecli/eventbridge"
+	"github.com/spf13/cobra"
+)
+
+type taskScheduleRemoveOperation struct {
+	clusterName string
+	dryRun      bool
+	eventbridge EB.Client
+	output      Output
+	taskName    string
+}
+
+func (o taskScheduleRemoveOperation) execute() {
+	o.output.Debug("Listing scheduled tasks [API=events Action=ListRules]")
+	scheduledTasks, err := o.eventbridge.ListScheduledTasks(o.clusterName)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not remove schedule of task %s", o.taskName)
+		return
+	}
+
+	scheduledTask, err := findScheduledTask(scheduledTasks, o.taskName)
+
+	if err != nil {
+		o.output.Fatal(err, "Could not remove schedule of task %s", o.taskName)
+		return
+	}
+
+	step := changeStep{
+		API:      "events",
+		Action:   "DeleteRule",
+		Resource: "rule",
+		Name:     scheduledTask.RuleName,
+		Changes:  []string{"schedule: " + scheduledTask.Schedule},
+	}
+
+	if o.dryRun {
+		plan := &changePlan{DryRun: true, Steps: []changeStep{step}}
+		plan.display(o.output)
+		return
+	}
+
+	o.output.Debug("%s", step.String())
+
+	if err := o.eventbridge.DeleteScheduledTask(o.clusterName, o.taskName); err != nil {
+		o.output.Fatal(err, "Could not remove schedule of task %s", o.taskName)
+		return
+	}
+
+	o.output.Info("Removed schedule of task %s", o.taskName)
+}
+
+var taskScheduleRemoveCmd = &cobra.Command{
+	Use:   "remove <task name>",
+	Short: "Remove the schedule of a task group",
+	Long: `Remove the schedule of a task group
+
+Deletes the EventBridge rule which runs the task group. Tasks which are already
+running are not stopped.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		taskScheduleRemoveOperation{
+			clusterName: clusterName,
+			dryRun:      dryRun,
+			eventbridge: EB.New(sess),
+			output:      output,
+			taskName:    args[0],
+		}.execute()
+	},
+}
+
+func init() {
+	taskScheduleCmd.AddCommand(taskScheduleRemoveCmd)
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/awslabs/fargatecli/cmd/mock"
+	EB "github.com/awslabs/fargatecli/eventbridge"
+	ebclient "github.com/awslabs/fargatecli/eventbridge/mock/client"
+	"github.com/golang/mock/gomock"
+)
+
+var removeScheduledTasks = EB.ScheduledTasks{
+	EB.ScheduledTask{Name: "nightly", RuleName: "fargate-fargate-nightly", Schedule: "cron(0 3 * * ? *)"},
+}
+
+func TestTaskScheduleRemoveOperation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ebclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	gomock.InOrder(
+		mockClient.EXPECT().ListScheduledTasks("fargate").Return(removeScheduledTasks, nil),
+		mockClient.EXPECT().DeleteScheduledTask("fargate", "nightly").Return(nil),
+	)
+
+	taskScheduleRemoveOperation{
+		clusterName: "fargate",
+		eventbridge: mockClient,
+		output:      mockOutput,
+		taskName:    "nightly",
+	}.execute()
+
+	if len(mockOutput.InfoMsgs) != 1 || mockOutput.InfoMsgs[0] != "Removed schedule of task nightly" {
+		t.Errorf("expected removed message, got %v", mockOutput.InfoMsgs)
+	}
+}
+
+func TestTaskScheduleRemoveOperationDryRun(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ebclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().ListScheduledTasks("fargate").Return(removeScheduledTasks, nil)
+
+	taskScheduleRemoveOperation{
+		clusterName: "fargate",
+		dryRun:      true,
+		eventbridge: mockClient,
+		output:      mockOutput,
+		taskName:    "nightly",
+	}.execute()
+
+	if len(mockOutput.Documents) != 1 {
+		t.Fatalf("expected 1 document, got %d", len(mockOutput.Documents))
+	}
+
+	plan := mockOutput.Documents[0].(*changePlan)
+
+	if len(plan.Steps) != 1 || plan.Steps[0].Action != "DeleteRule" || plan.Steps[0].Name != "fargate-fargate-nightly" {
+		t.Errorf("expected DeleteRule step, got %+v", plan.Steps)
+	}
+}
+
+func TestTaskScheduleRemoveOperationNotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ebclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().ListScheduledTasks("fargate").Return(removeScheduledTasks, nil)
+
+	taskScheduleRemoveOperation{
+		clusterName: "fargate",
+		eventbridge: mockClient,
+		output:      mockOutput,
+		taskName:    "weekly",
+	}.execute()
+
+	if !mockOutput.Exited {
+		t.Fatalf("expected fatal exit")
+	}
+
+	if len(mockOutput.FatalMsgs[0].Errors) != 1 || mockOutput.FatalMsgs[0].Errors[0] != errScheduledTaskNotFound {
+		t.Errorf("expected errScheduledTaskNotFound, got %v", mockOutput.FatalMsgs)
+	}
+}
+
+func TestTaskScheduleRemoveOperationError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ebclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	gomock.InOrder(
+		mockClient.EXPECT().ListScheduledTasks("fargate").Return(removeScheduledTasks, nil),
+		mockClient.EXPECT().DeleteScheduledTask("fargate", "nightly").Return(errors.New("boom")),
+	)
+
+	taskScheduleRemoveOperation{
+		clusterName: "fargate",
+		eventbridge: mockClient,
+		output:      mockOutput,
+		taskName:    "nightly",
+	}.execute()
+
+	if !mockOutput.Exited {
+		t.Errorf("expected fatal exit")
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/awslabs/fargatecli/cmd/mock"
+	EB "github.com/awslabs/fargatecli/eventbridge"
+	"github.com/spf13/pflag"
+)
+
+const scheduleTaskDefinitionARN = "arn:aws:ecs:us-east-1:123456789012:task-definition/fargate_task_nightly:3"
+
+func TestFindScheduledTask(t *testing.T) {
+	scheduledTasks := EB.ScheduledTasks{
+		EB.ScheduledTask{Name: "hourly"},
+		EB.ScheduledTask{Name: "nightly"},
+	}
+
+	scheduledTask, err := findScheduledTask(scheduledTasks, "nightly")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if scheduledTask.Name != "nightly" {
+		t.Errorf("expected nightly, got %s", scheduledTask.Name)
+	}
+
+	if _, err := findScheduledTask(scheduledTasks, "weekly"); err != errScheduledTaskNotFound {
+		t.Errorf("expected errScheduledTaskNotFound, got %v", err)
+	}
+}
+
+func TestNextRuns(t *testing.T) {
+	now := time.Date(2020, time.January, 31, 3, 0, 0, 0, time.UTC)
+
+	runs := nextRuns("cron(0 3 * * ? *)", now, 2)
+
+	if expected := []string{"2020-02-01 03:00:00 UTC", "2020-02-02 03:00:00 UTC"}; len(runs) != 2 || runs[0] != expected[0] || runs[1] != expected[1] {
+		t.Errorf("expected %v, got %v", expected, runs)
+	}
+
+	if runs := nextRuns("rate(5 minutes)", now, 2); len(runs) != 0 {
+		t.Errorf("expected no runs for rate expression, got %v", runs)
+	}
+}
+
+func TestDisplayTaskSchedule(t *testing.T) {
+	now := time.Date(2020, time.January, 31, 3, 0, 0, 0, time.UTC)
+	scheduledTask := EB.ScheduledTask{
+		Name:              "nightly",
+		Schedule:          "cron(0 3 * * ? *)",
+		State:             "ENABLED",
+		TaskCount:         2,
+		TaskDefinitionARN: scheduleTaskDefinitionARN,
+	}
+
+	mockOutput := &mock.Output{}
+
+	displayTaskSchedule(mockOutput, scheduledTask, now, 0)
+
+	if expected, got := "cron(0 3 * * ? *)", mockOutput.KeyValueMsgs["Schedule"]; expected != got {
+		t.Errorf("expected schedule %s, got %s", expected, got)
+	}
+
+	if expected, got := "3", mockOutput.KeyValueMsgs["Revision"]; expected != got {
+		t.Errorf("expected revision %s, got %s", expected, got)
+	}
+
+	if _, ok := mockOutput.KeyValueMsgs["Next Runs"]; !ok {
+		t.Errorf("expected next runs to be displayed")
+	}
+
+	if expected := "2020-02-01 03:00:00 UTC"; len(mockOutput.SayMsgs) != 3 || mockOutput.SayMsgs[0] != expected {
+		t.Errorf("expected 3 next runs starting with %s, got %v", expected, mockOutput.SayMsgs)
+	}
+
+	mockOutput = &mock.Output{}
+	scheduledTask.Schedule = "rate(1 hour)"
+
+	displayTaskSchedule(mockOutput, scheduledTask, now, 0)
+
+	if _, ok := mockOutput.KeyValueMsgs["Next Runs"]; ok {
+		t.Errorf("expected next runs not to be displayed for a rate expression")
+	}
+}
+
+func TestTaskScheduleFlagsMatchTaskRun(t *testing.T) {
+	excluded := map[string]bool{"follow": true, "timeout": true, "wait": true}
+
+	taskRunCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if excluded[flag.Name] {
+			return
+		}
+
+		if taskScheduleCmd.Flags().Lookup(flag.Name) == nil {
+			t.Errorf("expected task schedule to accept --%s", flag.Name)
+		}
+	})
+}
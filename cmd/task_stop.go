@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"github.com/awslabs/fargatecli/console"
 	ECS "github.com/awslabs/fargatecli/ecs"
 	"github.com/spf13/cobra"
 )
@@ -64,8 +63,8 @@ func stopTasks(operation *TaskStopOperation) {
 	}
 
 	if taskCount == 1 {
-		console.Info("Stopped %d task", taskCount)
+		output.Info("Stopped %d task", taskCount)
 	} else {
-		console.Info("Stopped %d tasks", taskCount)
+		output.Info("Stopped %d tasks", taskCount)
 	}
 }
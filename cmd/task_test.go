@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"testing"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+	ELBV2 "github.com/awslabs/fargatecli/elbv2"
+)
+
+func TestWithTargetHealth(t *testing.T) {
+	documents := []taskDocument{
+		taskDocument{Task: ECS.Task{TaskId: "1"}, PrivateIPAddress: "10.0.0.1"},
+		taskDocument{Task: ECS.Task{TaskId: "2"}, PrivateIPAddress: "10.0.0.2"},
+		taskDocument{Task: ECS.Task{TaskId: "3"}},
+	}
+	targetHealths := ELBV2.TargetHealths{
+		ELBV2.TargetHealth{ID: "10.0.0.2", Port: 80, State: "unhealthy", Reason: "Target.Timeout", Description: "Request timed out"},
+		ELBV2.TargetHealth{ID: "10.0.0.1", Port: 80, State: "healthy"},
+	}
+
+	documents = withTargetHealth(documents, targetHealths)
+
+	var tests = []struct {
+		health string
+	}{
+		{"healthy"},
+		{"unhealthy (Target.Timeout: Request timed out)"},
+		{""},
+	}
+
+	for i, test := range tests {
+		if health := documents[i].HealthString(); health != test.health {
+			t.Errorf("task %s: expected health %q, got %q", documents[i].TaskId, test.health, health)
+		}
+	}
+}
+
+func TestContainerHealthString(t *testing.T) {
+	documents := []taskDocument{
+		taskDocument{Task: ECS.Task{TaskId: "1", HealthStatus: "UNKNOWN"}},
+		taskDocument{Task: ECS.Task{TaskId: "2", HealthStatus: "UNHEALTHY", HealthCheck: &ECS.HealthCheck{Command: "pgrep server"}}},
+	}
+
+	if !hasContainerHealthChecks(documents) {
+		t.Errorf("expected container health checks, got none")
+	}
+
+	if hasContainerHealthChecks(documents[:1]) {
+		t.Errorf("expected no container health checks")
+	}
+
+	if health := documents[0].ContainerHealthString(); health != "" {
+		t.Errorf("expected no container health, got %q", health)
+	}
+
+	if health := documents[1].ContainerHealthString(); health != "unhealthy" {
+		t.Errorf("expected container health unhealthy, got %q", health)
+	}
+}
+
+func TestHasCapacityProviders(t *testing.T) {
+	documents := []taskDocument{
+		taskDocument{Task: ECS.Task{TaskId: "1"}},
+		taskDocument{Task: ECS.Task{TaskId: "2", CapacityProviderName: "FARGATE_SPOT"}},
+	}
+
+	if !hasCapacityProviders(documents) {
+		t.Errorf("expected capacity providers, got none")
+	}
+
+	if hasCapacityProviders(documents[:1]) {
+		t.Errorf("expected no capacity providers")
+	}
+}
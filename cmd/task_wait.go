@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+const (
+	taskPollingInterval = 2 * time.Second
+	taskStatusStopped   = "STOPPED"
+	taskTimeoutReason   = "Stopped by fargate after exceeding timeout"
+
+	taskStopCodeSpotInterruption   = "SpotInterruption"
+	taskStoppedReasonSpotInterrupt = "Spot Task was interrupted"
+)
+
+type taskWaitOperation struct {
+	ecs      ECS.Client
+	interval time.Duration
+	logs     func()
+	output   Output
+	since    time.Time
+	taskIDs  []string
+	taskName string
+	timeout  time.Duration
+}
+
+// execute waits for the operation's tasks to stop, displays the exit code of each of their
+// containers, and returns the exit code of the task's own container. If more than one task was run,
+// the first non-zero exit code is returned. A container which stopped without an exit code, such as
+// one which could not be started, is treated as having failed, as is a task interrupted by Fargate
+// Spot.
+func (o taskWaitOperation) execute() int {
+	tasks, timedOut, err := o.wait()
+
+	if err != nil {
+		o.output.Fatal(err, "Could not wait for task %s", o.taskName)
+		return 1
+	}
+
+	exitCode := 0
+
+	for _, task := range tasks {
+		o.output.KeyValue("Task", task.TaskId, 0)
+
+		if task.StoppedReason != "" {
+			o.output.KeyValue("Stopped Reason", "%s", 1, task.StoppedReason)
+		}
+
+		code := o.displayContainerStatuses(task.ContainerStatuses)
+
+		if isSpotInterruption(task) {
+			o.output.Warn("Task %s was interrupted by Fargate Spot", task.TaskId)
+
+			if code == 0 {
+				code = 1
+			}
+		}
+
+		if exitCode == 0 {
+			exitCode = code
+		}
+	}
+
+	if timedOut && exitCode == 0 {
+		exitCode = 1
+	}
+
+	return exitCode
+}
+
+// wait polls the operation's tasks until all of them have stopped, displaying changes to their
+// status along the way. If the timeout is set and exceeded, the tasks are stopped and waited on
+// until they have.
+func (o taskWaitOperation) wait() ([]ECS.Task, bool, error) {
+	var timedOut bool
+
+	deadline := o.since.Add(o.timeout)
+	lastStatuses := make(map[string]string)
+
+	o.output.Info("Waiting for task %s to stop", o.taskName)
+
+	for {
+		if o.logs != nil {
+			o.logs()
+		}
+
+		o.output.Debug("Describing tasks [API=ecs Action=DescribeTasks Tasks=%v]", o.taskIDs)
+		tasks, err := o.ecs.DescribeTaskStatuses(o.taskIDs)
+
+		if err != nil {
+			return tasks, timedOut, err
+		}
+
+		stopped := len(tasks) > 0
+
+		for _, task := range tasks {
+			if task.LastStatus != lastStatuses[task.TaskId] {
+				o.output.Say("Task %s is %s", 1, task.TaskId, Humanize(task.LastStatus))
+				lastStatuses[task.TaskId] = task.LastStatus
+			}
+
+			if task.LastStatus != taskStatusStopped {
+				stopped = false
+			}
+		}
+
+		if stopped {
+			if o.logs != nil {
+				o.logs()
+			}
+
+			return tasks, timedOut, nil
+		}
+
+		if o.timeout > 0 && !timedOut && !time.Now().Before(deadline) {
+			o.output.Warn("Task %s did not stop within %s, stopping", o.taskName, o.timeout)
+			o.output.Debug("Stopping tasks [API=ecs Action=StopTask Tasks=%v]", o.taskIDs)
+
+			if err := o.ecs.StopTasksWithReason(o.taskIDs, taskTimeoutReason); err != nil {
+				return tasks, timedOut, err
+			}
+
+			timedOut = true
+		}
+
+		time.Sleep(o.interval)
+	}
+}
+
+// displayContainerStatuses displays the exit code of each container in a stopped task and returns
+// the exit code of the task's own container.
+func (o taskWaitOperation) displayContainerStatuses(statuses []ECS.ContainerStatus) int {
+	exitCode := 1
+
+	for i, status := range statuses {
+		exit := "none"
+
+		if status.ExitCode != nil {
+			exit = fmt.Sprintf("%d", *status.ExitCode)
+		}
+
+		if status.Reason != "" {
+			o.output.KeyValue(status.Name, "exit code %s (%s)", 1, exit, status.Reason)
+		} else {
+			o.output.KeyValue(status.Name, "exit code %s", 1, exit)
+		}
+
+		if status.Name == o.taskName || (i == 0 && !hasContainerStatus(statuses, o.taskName)) {
+			if status.ExitCode != nil {
+				exitCode = int(*status.ExitCode)
+			}
+		}
+	}
+
+	return exitCode
+}
+
+// isSpotInterruption returns whether a stopped task was stopped because Fargate Spot reclaimed its
+// capacity.
+func isSpotInterruption(task ECS.Task) bool {
+	return task.StopCode == taskStopCodeSpotInterruption || strings.Contains(task.StoppedReason, taskStoppedReasonSpotInterrupt)
+}
+
+func hasContainerStatus(statuses []ECS.ContainerStatus, name string) bool {
+	for _, status := range statuses {
+		if status.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// waitForTasks waits for tasks to stop, optionally following their logs, and returns the exit code
+// of the task's container.
+func waitForTasks(taskName string, taskIDs []string, logGroupName string, follow bool, since time.Time, timeout time.Duration) int {
+	var logs func()
+
+	if follow {
+		operation := &GetLogsOperation{
+			LogGroupName: logGroupName,
+			Namespace:    taskName,
+			StartTime:    since,
+		}
+
+		operation.AddTasks(taskIDs)
+
+		logs = func() {
+			getLogs(operation)
+
+			if newStartTime := time.Now().Add(-10 * time.Second); newStartTime.After(operation.StartTime) {
+				operation.StartTime = newStartTime
+			}
+		}
+	}
+
+	return taskWaitOperation{
+		ecs:      ECS.New(sess, clusterName),
+		interval: taskPollingInterval,
+		logs:     logs,
+		output:   output,
+		since:    since,
+		taskIDs:  taskIDs,
+		taskName: taskName,
+		timeout:  timeout,
+	}.execute()
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/awslabs/fargatecli/cmd/mock"
+	ECS "github.com/awslabs/fargatecli/ecs"
+	ecsclient "github.com/awslabs/fargatecli/ecs/mock/client"
+	"github.com/golang/mock/gomock"
+)
+
+func TestTaskWaitOperation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+	logs := 0
+
+	gomock.InOrder(
+		mockClient.EXPECT().DescribeTaskStatuses([]string{"1"}).Return(
+			[]ECS.Task{ECS.Task{TaskId: "1", LastStatus: "PENDING"}}, nil,
+		),
+		mockClient.EXPECT().DescribeTaskStatuses([]string{"1"}).Return(
+			[]ECS.Task{ECS.Task{TaskId: "1", LastStatus: "RUNNING"}}, nil,
+		),
+		mockClient.EXPECT().DescribeTaskStatuses([]string{"1"}).Return(
+			[]ECS.Task{
+				ECS.Task{
+					TaskId:        "1",
+					LastStatus:    "STOPPED",
+					StoppedReason: "Essential container in task exited",
+					ContainerStatuses: []ECS.ContainerStatus{
+						ECS.ContainerStatus{Name: "migrate", ExitCode: aws.Int64(3)},
+						ECS.ContainerStatus{Name: "proxy", ExitCode: aws.Int64(0)},
+					},
+				},
+			}, nil,
+		),
+	)
+
+	operation := taskWaitOperation{
+		ecs:      mockClient,
+		logs:     func() { logs++ },
+		output:   mockOutput,
+		since:    time.Now(),
+		taskIDs:  []string{"1"},
+		taskName: "migrate",
+	}
+
+	if exitCode := operation.execute(); exitCode != 3 {
+		t.Errorf("expected exit code 3, got %d", exitCode)
+	}
+
+	expected := []string{"Task 1 is pending", "Task 1 is running", "Task 1 is stopped"}
+
+	if len(mockOutput.SayMsgs) != len(expected) {
+		t.Fatalf("expected %d messages, got %d: %v", len(expected), len(mockOutput.SayMsgs), mockOutput.SayMsgs)
+	}
+
+	for i, msg := range expected {
+		if mockOutput.SayMsgs[i] != msg {
+			t.Errorf("expected message %q, got %q", msg, mockOutput.SayMsgs[i])
+		}
+	}
+
+	if logs != 4 {
+		t.Errorf("expected logs to be fetched 4 times, got %d", logs)
+	}
+
+	if len(mockOutput.FatalMsgs) > 0 {
+		t.Errorf("expected no fatal messages, got %v", mockOutput.FatalMsgs)
+	}
+}
+
+func TestTaskWaitOperationTimeout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	gomock.InOrder(
+		mockClient.EXPECT().DescribeTaskStatuses([]string{"1"}).Return(
+			[]ECS.Task{ECS.Task{TaskId: "1", LastStatus: "RUNNING"}}, nil,
+		),
+		mockClient.EXPECT().StopTasksWithReason([]string{"1"}, taskTimeoutReason).Return(nil),
+		mockClient.EXPECT().DescribeTaskStatuses([]string{"1"}).Return(
+			[]ECS.Task{
+				ECS.Task{
+					TaskId:            "1",
+					LastStatus:        "STOPPED",
+					ContainerStatuses: []ECS.ContainerStatus{ECS.ContainerStatus{Name: "migrate", ExitCode: aws.Int64(0)}},
+				},
+			}, nil,
+		),
+	)
+
+	operation := taskWaitOperation{
+		ecs:      mockClient,
+		output:   mockOutput,
+		since:    time.Now().Add(-time.Minute),
+		taskIDs:  []string{"1"},
+		taskName: "migrate",
+		timeout:  time.Second,
+	}
+
+	if exitCode := operation.execute(); exitCode != 1 {
+		t.Errorf("expected exit code 1, got %d", exitCode)
+	}
+
+	if len(mockOutput.WarnMsgs) != 1 || mockOutput.WarnMsgs[0] != "Task migrate did not stop within 1s, stopping" {
+		t.Errorf("expected timeout warning, got %v", mockOutput.WarnMsgs)
+	}
+}
+
+func TestTaskWaitOperationError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().DescribeTaskStatuses([]string{"1"}).Return([]ECS.Task{}, errors.New("boom"))
+
+	operation := taskWaitOperation{
+		ecs:      mockClient,
+		output:   mockOutput,
+		since:    time.Now(),
+		taskIDs:  []string{"1"},
+		taskName: "migrate",
+	}
+
+	if exitCode := operation.execute(); exitCode != 1 {
+		t.Errorf("expected exit code 1, got %d", exitCode)
+	}
+
+	if !mockOutput.Exited {
+		t.Errorf("expected fatal exit")
+	}
+}
+
+func TestTaskWaitOperationSpotInterruption(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockClient := ecsclient.NewMockClient(mockCtrl)
+	mockOutput := &mock.Output{}
+
+	mockClient.EXPECT().DescribeTaskStatuses([]string{"1"}).Return(
+		[]ECS.Task{
+			ECS.Task{
+				TaskId:        "1",
+				LastStatus:    "STOPPED",
+				StopCode:      "SpotInterruption",
+				StoppedReason: "Your Spot Task was interrupted.",
+				ContainerStatuses: []ECS.ContainerStatus{
+					ECS.ContainerStatus{Name: "migrate", ExitCode: aws.Int64(0)},
+				},
+			},
+		}, nil,
+	)
+
+	operation := taskWaitOperation{
+		ecs:      mockClient,
+		output:   mockOutput,
+		since:    time.Now(),
+		taskIDs:  []string{"1"},
+		taskName: "migrate",
+	}
+
+	if exitCode := operation.execute(); exitCode != 1 {
+		t.Errorf("expected exit code 1, got %d", exitCode)
+	}
+
+	if len(mockOutput.WarnMsgs) != 1 {
+		t.Fatalf("expected 1 warning, got %v", mockOutput.WarnMsgs)
+	}
+
+	if expected := "Task 1 was interrupted by Fargate Spot"; mockOutput.WarnMsgs[0] != expected {
+		t.Errorf("expected warning %q, got %q", expected, mockOutput.WarnMsgs[0])
+	}
+}
+
+func TestTaskWaitDisplayContainerStatuses(t *testing.T) {
+	var tests = []struct {
+		statuses []ECS.ContainerStatus
+		exitCode int
+	}{
+		{[]ECS.ContainerStatus{ECS.ContainerStatus{Name: "migrate", ExitCode: aws.Int64(0)}}, 0},
+		{[]ECS.ContainerStatus{ECS.ContainerStatus{Name: "migrate", Reason: "CannotPullContainerError"}}, 1},
+		{[]ECS.ContainerStatus{ECS.ContainerStatus{Name: "proxy", ExitCode: aws.Int64(137)}, ECS.ContainerStatus{Name: "migrate", ExitCode: aws.Int64(0)}}, 0},
+		{[]ECS.ContainerStatus{ECS.ContainerStatus{Name: "other", ExitCode: aws.Int64(2)}}, 2},
+		{[]ECS.ContainerStatus{}, 1},
+	}
+
+	for _, test := range tests {
+		operation := taskWaitOperation{output: &mock.Output{}, taskName: "migrate"}
+
+		if exitCode := operation.displayContainerStatuses(test.statuses); exitCode != test.exitCode {
+			t.Errorf("expected exit code %d for %+v, got %d", test.exitCode, test.statuses, exitCode)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+const (
+	volumeTypeEFS               = "efs"
+	validAccessPointIDPattern   = `\Afsap-[0-9a-f]+\z`
+	validEFSFileSystemIDPattern = `\Afs-[0-9a-f]+\z`
+)
+
+var (
+	validAccessPointID   = regexp.MustCompile(validAccessPointIDPattern)
+	validEFSFileSystemID = regexp.MustCompile(validEFSFileSystemIDPattern)
+)
+
+// inflateVolumes parses volume expressions and checks that the volumes' names are unique.
+func inflateVolumes(volumeExprs []string) ([]ECS.Volume, []error) {
+	var (
+		errs    []error
+		volumes []ECS.Volume
+	)
+
+	names := make(map[string]bool)
+
+	for _, volumeExpr := range volumeExprs {
+		volume, volumeErrs := inflateVolume(volumeExpr)
+		errs = append(errs, volumeErrs...)
+
+		if names[volume.Name] {
+			errs = append(errs, fmt.Errorf("Duplicate volume name %s", volume.Name))
+		}
+
+		names[volume.Name] = true
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, errs
+}
+
+// inflateVolume parses a volume expression in the form
+// NAME=efs:FILE-SYSTEM-ID[:/path][,access-point=ACCESS-POINT-ID][,readonly].
+func inflateVolume(volumeExpr string) (ECS.Volume, []error) {
+	var (
+		errs   []error
+		volume ECS.Volume
+	)
+
+	options := strings.Split(volumeExpr, ",")
+	splitVolume := strings.SplitN(options[0], "=", 2)
+
+	if len(splitVolume) != 2 {
+		return volume, []error{fmt.Errorf("volume %s must be in the form of name=efs:file-system-id[:/path]", options[0])}
+	}
+
+	volume.Name = splitVolume[0]
+	location := strings.SplitN(splitVolume[1], ":", 3)
+
+	if !validContainerName.MatchString(volume.Name) {
+		errs = append(errs, fmt.Errorf("Invalid volume name %q [must be letters, numbers, hyphens, and underscores]", volume.Name))
+	}
+
+	if strings.ToLower(location[0]) != volumeTypeEFS || len(location) < 2 {
+		errs = append(errs, fmt.Errorf("Invalid volume %s [must be efs:file-system-id[:/path]]", splitVolume[1]))
+		return volume, errs
+	}
+
+	volume.FileSystemID = location[1]
+
+	if !validEFSFileSystemID.MatchString(volume.FileSystemID) {
+		errs = append(errs, fmt.Errorf("Invalid EFS file system ID %s [e.g. fs-12345678]", volume.FileSystemID))
+	}
+
+	if len(location) == 3 {
+		volume.RootDirectory = location[2]
+
+		if !strings.HasPrefix(volume.RootDirectory, "/") {
+			errs = append(errs, fmt.Errorf("Invalid path %s of volume %s [must be absolute]", volume.RootDirectory, volume.Name))
+		}
+	}
+
+	for _, option := range options[1:] {
+		splitOption := strings.SplitN(option, "=", 2)
+
+		switch key := strings.ToLower(strings.TrimSpace(splitOption[0])); {
+		case key == "readonly" && len(splitOption) == 1:
+			volume.ReadOnly = true
+		case key == "access-point" && len(splitOption) == 2:
+			volume.AccessPointID = splitOption[1]
+
+			if !validAccessPointID.MatchString(volume.AccessPointID) {
+				errs = append(errs, fmt.Errorf("Invalid EFS access point ID %s [e.g. fsap-12345678]", volume.AccessPointID))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("Invalid volume option %s [must be access-point=ID or readonly]", option))
+		}
+	}
+
+	// The access point sets the directory which is mounted.
+	if volume.AccessPointID != "" && volume.RootDirectory != "" && volume.RootDirectory != "/" {
+		errs = append(errs, fmt.Errorf("Volume %s cannot have both a path and an access point", volume.Name))
+	}
+
+	if volume.RootDirectory == "/" {
+		volume.RootDirectory = ""
+	}
+
+	return volume, errs
+}
+
+// inflateMountPoints parses mount point expressions in the form NAME:/path and checks that each
+// mounts one of the given volumes at a unique path.
+func inflateMountPoints(mountExprs []string, volumes []ECS.Volume) ([]ECS.MountPoint, []error) {
+	var (
+		errs        []error
+		mountPoints []ECS.MountPoint
+	)
+
+	names := make(map[string]bool)
+	paths := make(map[string]bool)
+
+	for _, volume := range volumes {
+		names[volume.Name] = true
+	}
+
+	for _, mountExpr := range mountExprs {
+		splitMount := strings.SplitN(mountExpr, ":", 2)
+
+		if len(splitMount) != 2 {
+			errs = append(errs, fmt.Errorf("mount %s must be in the form of volume:/path", mountExpr))
+			continue
+		}
+
+		mountPoint := ECS.MountPoint{ContainerPath: splitMount[1], Volume: splitMount[0]}
+
+		if !names[mountPoint.Volume] {
+			errs = append(errs, fmt.Errorf("Mount %s refers to unknown volume %s", mountExpr, mountPoint.Volume))
+		}
+
+		if !strings.HasPrefix(mountPoint.ContainerPath, "/") {
+			errs = append(errs, fmt.Errorf("Invalid mount path %s [must be absolute]", mountPoint.ContainerPath))
+		}
+
+		if paths[mountPoint.ContainerPath] {
+			errs = append(errs, fmt.Errorf("Duplicate mount path %s", mountPoint.ContainerPath))
+		}
+
+		paths[mountPoint.ContainerPath] = true
+		mountPoints = append(mountPoints, mountPoint)
+	}
+
+	return mountPoints, errs
+}
+
+// volumeChanges describes the volumes and mount points added to and removed from a task definition.
+func volumeChanges(volumes []ECS.Volume, mountPoints []ECS.MountPoint, removeVolumes, removeMountPoints []string) (changes []string) {
+	for _, volume := range volumes {
+		changes = append(changes, fmt.Sprintf("volume: add %s (%s)", volume.Name, volume))
+	}
+
+	for _, name := range removeVolumes {
+		changes = append(changes, fmt.Sprintf("volume: remove %s", name))
+	}
+
+	for _, mountPoint := range mountPoints {
+		changes = append(changes, fmt.Sprintf("mount: add %s", mountPoint))
+	}
+
+	for _, path := range removeMountPoints {
+		changes = append(changes, fmt.Sprintf("mount: remove %s", path))
+	}
+
+	return
+}
+
+// displayVolumes shows the volumes of a task definition and where each is mounted.
+func displayVolumes(o Output, volumes []ECS.Volume, containers []ECS.Container, indent int) {
+	if len(volumes) == 0 {
+		return
+	}
+
+	o.KeyValue("Volumes", "", indent)
+
+	for _, volume := range volumes {
+		o.KeyValue(volume.Name, "", indent+1)
+		o.KeyValue("File System", volume.String(), indent+2)
+
+		if volume.AccessPointID != "" {
+			o.KeyValue("Access Point", volume.AccessPointID, indent+2)
+		}
+
+		var mounts []string
+
+		for _, container := range containers {
+			for _, mountPoint := range container.MountPoints {
+				if mountPoint.Volume != volume.Name {
+					continue
+				}
+
+				mount := fmt.Sprintf("%s:%s", container.Name, mountPoint.ContainerPath)
+
+				if mountPoint.ReadOnly {
+					mount += " (read-only)"
+				}
+
+				mounts = append(mounts, mount)
+			}
+		}
+
+		if len(mounts) > 0 {
+			o.KeyValue("Mounts", strings.Join(mounts, ", "), indent+2)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/awslabs/fargatecli/cmd/mock"
+	ECS "github.com/awslabs/fargatecli/ecs"
+)
+
+func TestInflateVolume(t *testing.T) {
+	var tests = []struct {
+		input  string
+		volume ECS.Volume
+	}{
+		{"uploads=efs:fs-1234", ECS.Volume{FileSystemID: "fs-1234", Name: "uploads"}},
+		{"uploads=efs:fs-1234:/", ECS.Volume{FileSystemID: "fs-1234", Name: "uploads"}},
+		{"uploads=EFS:fs-1234:/uploads,readonly", ECS.Volume{FileSystemID: "fs-1234", Name: "uploads", ReadOnly: true, RootDirectory: "/uploads"}},
+		{"cache=efs:fs-1234,access-point=fsap-5678", ECS.Volume{AccessPointID: "fsap-5678", FileSystemID: "fs-1234", Name: "cache"}},
+	}
+
+	for _, test := range tests {
+		volume, errs := inflateVolume(test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("%s: expected no errors, got %v", test.input, errs)
+			continue
+		}
+
+		if !reflect.DeepEqual(test.volume, volume) {
+			t.Errorf("%s: expected %+v, got %+v", test.input, test.volume, volume)
+		}
+	}
+}
+
+func TestInflateVolumeErrors(t *testing.T) {
+	for _, input := range []string{
+		"uploads",
+		"uploads=efs",
+		"uploads=nfs:fs-1234",
+		"uploads=efs:1234",
+		"uploads=efs:fs-1234:uploads",
+		"uploads=efs:fs-1234,access-point=1234",
+		"uploads=efs:fs-1234:/uploads,access-point=fsap-5678",
+		"uploads=efs:fs-1234,readonly=true",
+		"uploads=efs:fs-1234,encrypted",
+		"up/loads=efs:fs-1234",
+	} {
+		if _, errs := inflateVolume(input); len(errs) == 0 {
+			t.Errorf("%s: expected errors, got none", input)
+		}
+	}
+}
+
+func TestInflateVolumesDuplicateNames(t *testing.T) {
+	_, errs := inflateVolumes([]string{"uploads=efs:fs-1234", "uploads=efs:fs-5678"})
+
+	if len(errs) != 1 {
+		t.Errorf("expected 1 error, got %v", errs)
+	}
+}
+
+func TestInflateMountPoints(t *testing.T) {
+	volumes := []ECS.Volume{ECS.Volume{FileSystemID: "fs-1234", Name: "uploads"}}
+	mountPoints, errs := inflateMountPoints([]string{"uploads:/var/uploads", "uploads:/srv/uploads"}, volumes)
+
+	if len(errs) > 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	expected := []ECS.MountPoint{
+		ECS.MountPoint{ContainerPath: "/var/uploads", Volume: "uploads"},
+		ECS.MountPoint{ContainerPath: "/srv/uploads", Volume: "uploads"},
+	}
+
+	if !reflect.DeepEqual(expected, mountPoints) {
+		t.Errorf("expected %+v, got %+v", expected, mountPoints)
+	}
+}
+
+func TestInflateMountPointsErrors(t *testing.T) {
+	volumes := []ECS.Volume{ECS.Volume{FileSystemID: "fs-1234", Name: "uploads"}}
+
+	for _, input := range [][]string{
+		[]string{"uploads"},
+		[]string{"cache:/var/cache"},
+		[]string{"uploads:var/uploads"},
+		[]string{"uploads:/var/uploads", "uploads:/var/uploads"},
+	} {
+		if _, errs := inflateMountPoints(input, volumes); len(errs) == 0 {
+			t.Errorf("%v: expected errors, got none", input)
+		}
+	}
+}
+
+func TestVolumeChanges(t *testing.T) {
+	changes := volumeChanges(
+		[]ECS.Volume{ECS.Volume{FileSystemID: "fs-1234", Name: "uploads", RootDirectory: "/uploads"}},
+		[]ECS.MountPoint{ECS.MountPoint{ContainerPath: "/var/uploads", Volume: "uploads"}},
+		[]string{"cache"},
+		[]string{"/var/cache"},
+	)
+	expected := []string{
+		"volume: add uploads (fs-1234:/uploads)",
+		"volume: remove cache",
+		"mount: add uploads:/var/uploads",
+		"mount: remove /var/cache",
+	}
+
+	if !reflect.DeepEqual(expected, changes) {
+		t.Errorf("expected %v, got %v", expected, changes)
+	}
+}
+
+func TestDisplayVolumes(t *testing.T) {
+	mockOutput := &mock.Output{}
+	volumes := []ECS.Volume{
+		ECS.Volume{AccessPointID: "fsap-5678", FileSystemID: "fs-1234", Name: "uploads"},
+	}
+	containers := []ECS.Container{
+		ECS.Container{Name: "web", MountPoints: []ECS.MountPoint{ECS.MountPoint{ContainerPath: "/var/uploads", Volume: "uploads"}}},
+		ECS.Container{Name: "backup", MountPoints: []ECS.MountPoint{ECS.MountPoint{ContainerPath: "/backup", ReadOnly: true, Volume: "uploads"}}},
+	}
+
+	displayVolumes(mockOutput, volumes, containers, 0)
+
+	expected := map[string]string{
+		"Volumes":      "",
+		"uploads":      "",
+		"File System":  "fs-1234:/",
+		"Access Point": "fsap-5678",
+		"Mounts":       "web:/var/uploads, backup:/backup (read-only)",
+	}
+
+	for key, value := range expected {
+		if got, ok := mockOutput.KeyValueMsgs[key]; !ok || got != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, got)
+		}
+	}
+}
@@ -0,0 +1,405 @@
+package docker
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	mediaTypeDockerConfig   = "application/vnd.docker.container.image.v1+json"
+	mediaTypeDockerLayer    = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+
+	archiveManifestFile = "manifest.json"
+	ociIndexFile        = "index.json"
+	ociLayoutFile       = "oci-layout"
+)
+
+var (
+	digestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+	gzipMagic     = []byte{0x1f, 0x8b}
+)
+
+// blob is the content of an image's config or one of its layers, stored in a file until it is pushed.
+type blob struct {
+	Digest    string
+	MediaType string
+	Path      string
+	Size      int64
+}
+
+// descriptor refers to a blob from a manifest.
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Size      int64  `json:"size"`
+	Digest    string `json:"digest"`
+}
+
+type manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Config        descriptor   `json:"config"`
+	Layers        []descriptor `json:"layers"`
+}
+
+// ociIndex is the index.json file of an OCI image layout.
+type ociIndex struct {
+	Manifests []descriptor `json:"manifests"`
+}
+
+// archiveManifest is an entry of the manifest.json file of an archive written by docker save.
+type archiveManifest struct {
+	Config   string   `json:"Config"`
+	Layers   []string `json:"Layers"`
+	RepoTags []string `json:"RepoTags"`
+}
+
+// image is an image read from an archive, extracted to a temporary directory with its layers
+// compressed so that it can be pushed. Images read from an OCI image layout keep their own manifest
+// so that their digest does not change. The directory is removed on Close.
+type image struct {
+	Config blob
+	Layers []blob
+
+	dir               string
+	manifestContents  []byte
+	manifestMediaType string
+}
+
+// manifest returns the image manifest referring to the image's config and layers and its media type.
+func (i image) manifest() ([]byte, string, error) {
+	if i.manifestContents != nil {
+		return i.manifestContents, i.manifestMediaType, nil
+	}
+
+	m := manifest{
+		SchemaVersion: 2,
+		MediaType:     mediaTypeDockerManifest,
+		Config:        i.Config.descriptor(),
+	}
+
+	for _, layer := range i.Layers {
+		m.Layers = append(m.Layers, layer.descriptor())
+	}
+
+	contents, err := json.Marshal(m)
+
+	return contents, mediaTypeDockerManifest, err
+}
+
+// Close removes the files the image was extracted to.
+func (i image) Close() error {
+	return os.RemoveAll(i.dir)
+}
+
+func (b blob) descriptor() descriptor {
+	return descriptor{MediaType: b.MediaType, Size: b.Size, Digest: b.Digest}
+}
+
+// readArchive reads an image from an archive in the format written by docker save or from an OCI
+// image layout archive. Uncompressed layers of docker save archives are compressed with gzip as they
+// would be by docker push.
+func readArchive(path string) (image, error) {
+	dir, err := ioutil.TempDir("", "fargate-image")
+
+	if err != nil {
+		return image{}, err
+	}
+
+	img := image{dir: dir}
+
+	if err := extractArchive(path, dir); err != nil {
+		img.Close()
+		return image{}, err
+	}
+
+	switch {
+	case fileExists(filepath.Join(dir, archiveManifestFile)):
+		err = img.readDockerArchive()
+	case fileExists(filepath.Join(dir, ociLayoutFile)):
+		err = img.readOCILayout()
+	default:
+		err = fmt.Errorf("%s is neither a docker save archive nor an OCI image layout", path)
+	}
+
+	if err != nil {
+		img.Close()
+		return image{}, err
+	}
+
+	return img, nil
+}
+
+func (i *image) readDockerArchive() error {
+	contents, err := ioutil.ReadFile(filepath.Join(i.dir, archiveManifestFile))
+
+	if err != nil {
+		return fmt.Errorf("could not read %s from image archive: %v", archiveManifestFile, err)
+	}
+
+	var manifests []archiveManifest
+
+	if err := json.Unmarshal(contents, &manifests); err != nil {
+		return fmt.Errorf("could not parse %s from image archive: %v", archiveManifestFile, err)
+	}
+
+	if len(manifests) != 1 {
+		return fmt.Errorf("image archive must contain exactly one image, found %d", len(manifests))
+	}
+
+	if i.Config, err = newBlob(filepath.Join(i.dir, manifests[0].Config), mediaTypeDockerConfig); err != nil {
+		return err
+	}
+
+	for n, layer := range manifests[0].Layers {
+		path, err := compressLayer(filepath.Join(i.dir, layer), filepath.Join(i.dir, fmt.Sprintf("layer-%d.tar.gz", n)))
+
+		if err != nil {
+			return err
+		}
+
+		b, err := newBlob(path, mediaTypeDockerLayer)
+
+		if err != nil {
+			return err
+		}
+
+		i.Layers = append(i.Layers, b)
+	}
+
+	return nil
+}
+
+func (i *image) readOCILayout() error {
+	contents, err := ioutil.ReadFile(filepath.Join(i.dir, ociIndexFile))
+
+	if err != nil {
+		return fmt.Errorf("could not read %s from image archive: %v", ociIndexFile, err)
+	}
+
+	var index ociIndex
+
+	if err := json.Unmarshal(contents, &index); err != nil {
+		return fmt.Errorf("could not parse %s from image archive: %v", ociIndexFile, err)
+	}
+
+	if len(index.Manifests) != 1 {
+		return fmt.Errorf("image archive must contain exactly one image, found %d", len(index.Manifests))
+	}
+
+	d := index.Manifests[0]
+
+	if d.MediaType != mediaTypeOCIManifest && d.MediaType != mediaTypeDockerManifest {
+		return fmt.Errorf("image archive contains a manifest of type %s; only single-platform images are supported", d.MediaType)
+	}
+
+	b, err := i.layoutBlob(d)
+
+	if err != nil {
+		return err
+	}
+
+	if i.manifestContents, err = ioutil.ReadFile(b.Path); err != nil {
+		return err
+	}
+
+	var m manifest
+
+	if err := json.Unmarshal(i.manifestContents, &m); err != nil {
+		return fmt.Errorf("could not parse manifest %s from image archive: %v", d.Digest, err)
+	}
+
+	i.manifestMediaType = d.MediaType
+
+	if i.Config, err = i.layoutBlob(m.Config); err != nil {
+		return err
+	}
+
+	for _, layer := range m.Layers {
+		b, err := i.layoutBlob(layer)
+
+		if err != nil {
+			return err
+		}
+
+		i.Layers = append(i.Layers, b)
+	}
+
+	return nil
+}
+
+// layoutBlob returns the blob of an OCI image layout a descriptor refers to, checking its digest.
+func (i image) layoutBlob(d descriptor) (blob, error) {
+	if !digestPattern.MatchString(d.Digest) {
+		return blob{}, fmt.Errorf("invalid digest %s in image archive", d.Digest)
+	}
+
+	b, err := newBlob(filepath.Join(i.dir, "blobs", "sha256", strings.TrimPrefix(d.Digest, "sha256:")), d.MediaType)
+
+	if err != nil {
+		return blob{}, err
+	}
+
+	if b.Digest != d.Digest {
+		return blob{}, fmt.Errorf("blob %s in image archive does not match its digest", d.Digest)
+	}
+
+	return b, nil
+}
+
+// newBlob returns a blob of the content of a file, calculating its digest and size.
+func newBlob(path, mediaType string) (blob, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return blob{}, err
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+
+	if err != nil {
+		return blob{}, err
+	}
+
+	return blob{
+		Digest:    fmt.Sprintf("sha256:%x", hash.Sum(nil)),
+		MediaType: mediaType,
+		Path:      path,
+		Size:      size,
+	}, nil
+}
+
+// compressLayer compresses a layer with gzip, returning the path of the compressed layer. Layers
+// which are already compressed are returned as they are.
+func compressLayer(path, compressedPath string) (string, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	if magic, err := reader.Peek(len(gzipMagic)); err == nil && bytes.Equal(magic, gzipMagic) {
+		return path, nil
+	}
+
+	compressed, err := os.Create(compressedPath)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer compressed.Close()
+
+	writer := gzip.NewWriter(compressed)
+
+	if _, err := io.Copy(writer, reader); err != nil {
+		return "", err
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	return compressedPath, compressed.Close()
+}
+
+// extractArchive extracts the files, directories, and symbolic links of a tar archive to a directory.
+// Entries which would be extracted outside of the directory are rejected.
+func extractArchive(path, dir string) error {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	reader := tar.NewReader(file)
+
+	for {
+		header, err := reader.Next()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("could not read image archive %s: %v", path, err)
+		}
+
+		target, err := archivePath(dir, header.Name)
+
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0700)
+		case tar.TypeReg:
+			err = extractFile(reader, target)
+		case tar.TypeSymlink:
+			if _, err = archivePath(dir, filepath.Join(filepath.Dir(header.Name), header.Linkname)); err == nil {
+				err = os.Symlink(header.Linkname, target)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func extractFile(reader io.Reader, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+
+	file, err := os.Create(target)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
+
+func archivePath(dir, name string) (string, error) {
+	cleanName := filepath.Clean(filepath.FromSlash(name))
+
+	if filepath.IsAbs(cleanName) || cleanName == ".." || strings.HasPrefix(cleanName, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("image archive entry %s is outside of the archive", name)
+	}
+
+	return filepath.Join(dir, cleanName), nil
+}
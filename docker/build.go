@@ -0,0 +1,117 @@
+package docker
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	defaultBuildContext = "."
+
+	// LabelAuthor is the label of the author of the git commit an image was built from.
+	LabelAuthor = "com.github.awslabs.fargatecli.git.author"
+
+	// LabelBranch is the label of the git branch an image was built from.
+	LabelBranch = "com.github.awslabs.fargatecli.git.branch"
+
+	// LabelCreated is the label of the time an image was built.
+	LabelCreated = "org.opencontainers.image.created"
+
+	// LabelMessage is the label of the message of the git commit an image was built from.
+	LabelMessage = "com.github.awslabs.fargatecli.git.message"
+
+	// LabelRevision is the label of the git commit SHA an image was built from.
+	LabelRevision = "org.opencontainers.image.revision"
+)
+
+// BuildOptions configure how an image is built and by which builder. Images are built for the
+// platform the builder runs on unless a platform in the form of os/arch (e.g. linux/arm64) is given.
+// When built by docker, images built for another platform or exporting their build cache are built
+// with docker buildx.
+type BuildOptions struct {
+	Args       []string
+	Builder    string
+	CacheFrom  []string
+	CacheTo    []string
+	Context    string
+	Dockerfile string
+	Labels     map[string]string
+	Platform   string
+	Target     string
+}
+
+// NewLabels returns the labels recording the git commit SHA, branch, author, and message an image was
+// built from, if known, and the time it was built.
+func NewLabels(sha, branch, author, message string, createdAt time.Time) map[string]string {
+	labels := map[string]string{
+		LabelCreated: createdAt.UTC().Format(time.RFC3339),
+	}
+
+	for label, value := range map[string]string{
+		LabelAuthor:   author,
+		LabelBranch:   branch,
+		LabelMessage:  message,
+		LabelRevision: sha,
+	} {
+		if value != "" {
+			labels[label] = value
+		}
+	}
+
+	return labels
+}
+
+// UsesBuildx returns whether the image must be built with docker buildx when built by docker.
+func (o BuildOptions) UsesBuildx() bool {
+	return o.Platform != "" || len(o.CacheTo) > 0
+}
+
+// flags returns the flags common to each builder to build an image with the given name.
+func (o BuildOptions) flags(name string) []string {
+	var flags []string
+
+	if o.Platform != "" {
+		flags = append(flags, "--platform", o.Platform)
+	}
+
+	if o.Dockerfile != "" {
+		flags = append(flags, "--file", o.Dockerfile)
+	}
+
+	if o.Target != "" {
+		flags = append(flags, "--target", o.Target)
+	}
+
+	for _, arg := range o.Args {
+		flags = append(flags, "--build-arg", arg)
+	}
+
+	for _, cacheFrom := range o.CacheFrom {
+		flags = append(flags, "--cache-from", cacheFrom)
+	}
+
+	for _, cacheTo := range o.CacheTo {
+		flags = append(flags, "--cache-to", cacheTo)
+	}
+
+	var keys []string
+
+	for key := range o.Labels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		flags = append(flags, "--label", fmt.Sprintf("%s=%s", key, o.Labels[key]))
+	}
+
+	context := o.Context
+
+	if context == "" {
+		context = defaultBuildContext
+	}
+
+	return append(flags, "--tag", name, context)
+}
@@ -0,0 +1,86 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testImage = "123456789012.dkr.ecr.us-east-1.amazonaws.com/web:abc1234"
+
+func TestDockerBuilderBuildArgs(t *testing.T) {
+	if expected, got := []string{"build", "--tag", testImage, "."}, (dockerBuilder{}).buildArgs(testImage, BuildOptions{}); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	options := BuildOptions{
+		Args:       []string{"VERSION=1.2.3", "NPM_TOKEN"},
+		CacheFrom:  []string{"type=registry,ref=example/web:cache"},
+		Context:    "app",
+		Dockerfile: "app/Dockerfile.prod",
+		Labels:     map[string]string{LabelRevision: "abc1234", LabelBranch: "main"},
+		Target:     "release",
+	}
+	expected := []string{
+		"build",
+		"--file", "app/Dockerfile.prod",
+		"--target", "release",
+		"--build-arg", "VERSION=1.2.3",
+		"--build-arg", "NPM_TOKEN",
+		"--cache-from", "type=registry,ref=example/web:cache",
+		"--label", LabelBranch + "=main",
+		"--label", LabelRevision + "=abc1234",
+		"--tag", testImage,
+		"app",
+	}
+
+	if got := (dockerBuilder{}).buildArgs(testImage, options); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestDockerBuilderBuildArgsBuildx(t *testing.T) {
+	var tests = []struct {
+		options  BuildOptions
+		expected []string
+	}{
+		{
+			BuildOptions{Platform: "linux/arm64"},
+			[]string{"buildx", "build", "--load", "--platform", "linux/arm64", "--tag", testImage, "."},
+		},
+		{
+			BuildOptions{CacheTo: []string{"type=inline"}},
+			[]string{"buildx", "build", "--load", "--cache-to", "type=inline", "--tag", testImage, "."},
+		},
+	}
+
+	for _, test := range tests {
+		if !test.options.UsesBuildx() {
+			t.Errorf("%+v: expected buildx", test.options)
+		}
+
+		if got := (dockerBuilder{}).buildArgs(testImage, test.options); !reflect.DeepEqual(test.expected, got) {
+			t.Errorf("expected %v, got %v", test.expected, got)
+		}
+	}
+}
+
+func TestNewLabels(t *testing.T) {
+	createdAt := time.Date(2020, 4, 1, 12, 30, 0, 0, time.UTC)
+
+	expected := map[string]string{
+		LabelAuthor:   "Jane Doe <jane@example.com>",
+		LabelBranch:   "main",
+		LabelCreated:  "2020-04-01T12:30:00Z",
+		LabelMessage:  "Add labels",
+		LabelRevision: "0123456789abcdef0123456789abcdef01234567",
+	}
+
+	if got := NewLabels("0123456789abcdef0123456789abcdef01234567", "main", "Jane Doe <jane@example.com>", "Add labels", createdAt); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if got := NewLabels("", "", "", "", createdAt); len(got) != 1 {
+		t.Errorf("expected only the created label, got %v", got)
+	}
+}
@@ -0,0 +1,156 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/awslabs/fargatecli/console"
+)
+
+const (
+	BuilderBuildah = "buildah"
+	BuilderDocker  = "docker"
+	BuilderPodman  = "podman"
+)
+
+// Builders are the names of the supported builders in the order they are looked for when none is
+// given.
+var Builders = []string{BuilderDocker, BuilderPodman, BuilderBuildah}
+
+// errNoBuilder is returned when none of the supported builders are installed.
+var errNoBuilder = fmt.Errorf("could not find an image builder [install %s]", strings.Join(Builders, ", "))
+
+// Builder builds images from a Dockerfile and saves them to an image archive in the format of docker
+// save from which they are pushed.
+type Builder interface {
+	Build(image string, options BuildOptions) error
+	Name() string
+	Save(image, path string) error
+}
+
+// NewBuilder returns the builder with the given name. If no name is given, the first supported
+// builder installed is returned.
+func NewBuilder(name string) (Builder, error) {
+	if name == "" {
+		return detectBuilder(exec.LookPath)
+	}
+
+	switch name {
+	case BuilderDocker:
+		return dockerBuilder{}, nil
+	case BuilderPodman:
+		return podmanBuilder{}, nil
+	case BuilderBuildah:
+		return buildahBuilder{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown builder %s [specify %s]", name, strings.Join(Builders, ", "))
+}
+
+func detectBuilder(lookPath func(string) (string, error)) (Builder, error) {
+	for _, name := range Builders {
+		if _, err := lookPath(name); err == nil {
+			return NewBuilder(name)
+		}
+	}
+
+	return nil, errNoBuilder
+}
+
+// dockerBuilder builds images with docker, using docker buildx for builds which need it.
+type dockerBuilder struct{}
+
+func (dockerBuilder) Name() string {
+	return BuilderDocker
+}
+
+func (b dockerBuilder) Build(image string, options BuildOptions) error {
+	return run(b.Name(), b.buildArgs(image, options))
+}
+
+func (b dockerBuilder) Save(image, path string) error {
+	return run(b.Name(), b.saveArgs(image, path))
+}
+
+func (dockerBuilder) buildArgs(image string, options BuildOptions) []string {
+	if options.UsesBuildx() {
+		return append([]string{"buildx", "build", "--load"}, options.flags(image)...)
+	}
+
+	return append([]string{"build"}, options.flags(image)...)
+}
+
+func (dockerBuilder) saveArgs(image, path string) []string {
+	return []string{"save", "--output", path, image}
+}
+
+// podmanBuilder builds images with podman, which builds for other platforms and exports build caches
+// without buildx.
+type podmanBuilder struct{}
+
+func (podmanBuilder) Name() string {
+	return BuilderPodman
+}
+
+func (b podmanBuilder) Build(image string, options BuildOptions) error {
+	return run(b.Name(), b.buildArgs(image, options))
+}
+
+func (b podmanBuilder) Save(image, path string) error {
+	return run(b.Name(), b.saveArgs(image, path))
+}
+
+func (podmanBuilder) buildArgs(image string, options BuildOptions) []string {
+	return append([]string{"build"}, options.flags(image)...)
+}
+
+func (podmanBuilder) saveArgs(image, path string) []string {
+	return []string{"save", "--format", "docker-archive", "--output", path, image}
+}
+
+// buildahBuilder builds images with buildah, which needs no daemon.
+type buildahBuilder struct{}
+
+func (buildahBuilder) Name() string {
+	return BuilderBuildah
+}
+
+func (b buildahBuilder) Build(image string, options BuildOptions) error {
+	return run(b.Name(), b.buildArgs(image, options))
+}
+
+func (b buildahBuilder) Save(image, path string) error {
+	return run(b.Name(), b.saveArgs(image, path))
+}
+
+func (buildahBuilder) buildArgs(image string, options BuildOptions) []string {
+	return append([]string{"bud"}, options.flags(image)...)
+}
+
+func (buildahBuilder) saveArgs(image, path string) []string {
+	return []string{"push", image, "docker-archive:" + path}
+}
+
+func run(name string, args []string) error {
+	console.Shell("%s %s", name, strings.Join(args, " "))
+
+	cmd := exec.Command(name, args...)
+
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("%s %s exited with status %d", name, args[0], exitErr.ExitCode())
+		}
+
+		return err
+	}
+
+	return nil
+}
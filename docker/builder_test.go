@@ -0,0 +1,106 @@
+package docker
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewBuilder(t *testing.T) {
+	for _, name := range Builders {
+		builder, err := NewBuilder(name)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if builder.Name() != name {
+			t.Errorf("expected builder %s, got %s", name, builder.Name())
+		}
+	}
+}
+
+func TestNewBuilderUnknown(t *testing.T) {
+	if _, err := NewBuilder("kaniko"); err == nil {
+		t.Errorf("expected error, got none")
+	} else if expected := "unknown builder kaniko [specify docker, podman, buildah]"; err.Error() != expected {
+		t.Errorf("expected error %s, got %v", expected, err)
+	}
+}
+
+func TestDetectBuilder(t *testing.T) {
+	lookPath := func(installed ...string) func(string) (string, error) {
+		return func(file string) (string, error) {
+			for _, name := range installed {
+				if name == file {
+					return "/usr/bin/" + file, nil
+				}
+			}
+
+			return "", errors.New("executable file not found in $PATH")
+		}
+	}
+
+	var tests = []struct {
+		installed []string
+		expected  string
+	}{
+		{[]string{"buildah", "podman", "docker"}, BuilderDocker},
+		{[]string{"buildah", "podman"}, BuilderPodman},
+		{[]string{"buildah"}, BuilderBuildah},
+	}
+
+	for _, test := range tests {
+		builder, err := detectBuilder(lookPath(test.installed...))
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if builder.Name() != test.expected {
+			t.Errorf("%v: expected builder %s, got %s", test.installed, test.expected, builder.Name())
+		}
+	}
+
+	if _, err := detectBuilder(lookPath()); err != errNoBuilder {
+		t.Errorf("expected error %v, got %v", errNoBuilder, err)
+	}
+}
+
+func TestPodmanBuilderArgs(t *testing.T) {
+	options := BuildOptions{CacheTo: []string{"type=inline"}, Platform: "linux/arm64"}
+	expected := []string{"build", "--platform", "linux/arm64", "--cache-to", "type=inline", "--tag", testImage, "."}
+
+	if got := (podmanBuilder{}).buildArgs(testImage, options); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	expected = []string{"save", "--format", "docker-archive", "--output", "/tmp/image.tar", testImage}
+
+	if got := (podmanBuilder{}).saveArgs(testImage, "/tmp/image.tar"); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestBuildahBuilderArgs(t *testing.T) {
+	options := BuildOptions{Dockerfile: "Dockerfile.prod"}
+	expected := []string{"bud", "--file", "Dockerfile.prod", "--tag", testImage, "."}
+
+	if got := (buildahBuilder{}).buildArgs(testImage, options); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	expected = []string{"push", testImage, "docker-archive:/tmp/image.tar"}
+
+	if got := (buildahBuilder{}).saveArgs(testImage, "/tmp/image.tar"); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestDockerBuilderSaveArgs(t *testing.T) {
+	expected := []string{"save", "--output", "/tmp/image.tar", testImage}
+
+	if got := (dockerBuilder{}).saveArgs(testImage, "/tmp/image.tar"); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -1,8 +1,9 @@
 package docker
 
 import (
+	"io/ioutil"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"time"
 
 	"github.com/awslabs/fargatecli/console"
@@ -14,8 +15,14 @@ func GenerateTag() string {
 	return time.Now().UTC().Format(timestampFormat)
 }
 
+// Repository is an image repository. Images are built by a Builder and pushed with a registry client
+// which authenticates with the repository's credentials directly.
 type Repository struct {
 	Uri string
+
+	builder  Builder
+	password string
+	username string
 }
 
 func NewRepository(repositoryUri string) Repository {
@@ -24,62 +31,114 @@ func NewRepository(repositoryUri string) Repository {
 	}
 }
 
+// Login sets the credentials images are pushed to the repository with. Credentials are sent to the
+// registry with each request rather than being passed to a docker login command.
 func (repository *Repository) Login(username, password string) {
-	console.Debug("Logging into Docker repository [%s]", repository.Uri)
-	console.Shell("docker login --username %s --password ******* %s", username, repository.Uri)
+	console.Debug("Using credentials for repository [%s]", repository.Uri)
+
+	repository.username = username
+	repository.password = password
+}
 
-	cmd := exec.Command("docker", "login", "--username", username, "--password", password, repository.Uri)
+// Build builds an image from a Dockerfile with the given options and tags it in the repository.
+func (repository *Repository) Build(tag string, options BuildOptions) {
+	builder, err := NewBuilder(options.Builder)
 
-	if console.Verbose {
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+	if err != nil {
+		console.ErrorExit(err, "Couldn't build image [%s]", repository.UriFor(tag))
 	}
 
-	if err := cmd.Start(); err != nil {
-		console.ErrorExit(err, "Couldn't login to Docker repository [%s]", repository.Uri)
-	}
+	console.Debug("Building image [%s] with %s", repository.UriFor(tag), builder.Name())
 
-	if err := cmd.Wait(); err != nil {
-		console.IssueExit("Couldn't login to Docker repository [%s]", repository.Uri)
+	if err := builder.Build(repository.UriFor(tag), options); err != nil {
+		console.ErrorExit(err, "Couldn't build image [%s]", repository.UriFor(tag))
 	}
+
+	repository.builder = builder
 }
 
-func (repository *Repository) Build(tag string) {
-	console.Debug("Building Docker image [%s]", repository.UriFor(tag))
-	console.Shell("docker build --rm=false --tag %s .", repository.UriFor(tag))
+// Push saves the image built with the given tag to an archive and pushes it to the repository,
+// along with any additional tags, returning the digest of the pushed image.
+func (repository *Repository) Push(tag string, additionalTags ...string) string {
+	if repository.builder == nil {
+		console.IssueExit("Couldn't push image [%s]: image has not been built", repository.UriFor(tag))
+	}
 
-	cmd := exec.Command("docker", "build", "--tag", repository.Uri+":"+tag, ".")
+	dir, err := ioutil.TempDir("", "fargate-push")
 
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	if err != nil {
+		console.ErrorExit(err, "Couldn't push image [%s]", repository.UriFor(tag))
+	}
 
-	if err := cmd.Start(); err != nil {
-		console.ErrorExit(err, "Couldn't build Docker image [%s]", repository.UriFor(tag))
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "image.tar")
+
+	if err := repository.builder.Save(repository.UriFor(tag), path); err != nil {
+		console.ErrorExit(err, "Couldn't save image [%s]", repository.UriFor(tag))
 	}
 
-	if err := cmd.Wait(); err != nil {
-		console.IssueExit("Couldn't build Docker image [%s]", repository.Uri)
+	digest, err := repository.pushArchive(path, tag, additionalTags)
+
+	if err != nil {
+		console.ErrorExit(err, "Couldn't push image [%s]", repository.UriFor(tag))
 	}
+
+	return digest
 }
 
-func (repository *Repository) Push(tag string) {
-	console.Debug("Pushing Docker image [%s]", repository.UriFor(tag))
-	console.Shell("docker push %s .", repository.UriFor(tag))
+// PushArchive pushes the image in a docker save or OCI image layout archive to the repository,
+// tagging it with the given tag and any additional tags, and returns the digest of the pushed image.
+func (repository *Repository) PushArchive(path, tag string, additionalTags ...string) string {
+	digest, err := repository.pushArchive(path, tag, additionalTags)
 
-	cmd := exec.Command("docker", "push", repository.UriFor(tag))
+	if err != nil {
+		console.ErrorExit(err, "Couldn't push image archive [%s] to [%s]", path, repository.UriFor(tag))
+	}
 
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	return digest
+}
 
-	if err := cmd.Start(); err != nil {
-		console.ErrorExit(err, "Couldn't push Docker image [%s]", repository.UriFor(tag))
+// pushArchive pushes the image in an archive to the repository, tagging it
+// with the given tags, and returns the digest of its manifest.
+func (repository *Repository) pushArchive(path, tag string, additionalTags []string) (string, error) {
+	img, err := readArchive(path)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer img.Close()
+
+	host, name := splitRepositoryUri(repository.Uri)
+
+	registry := NewRegistry(host, repository.username, repository.password)
+
+	console.Debug("Pushing image [%s]", repository.UriFor(tag))
+
+	digest, err := registry.Push(name, tag, img)
+
+	if err != nil {
+		return "", err
 	}
 
-	if err := cmd.Wait(); err != nil {
-		console.IssueExit("Couldn't push Docker image [%s]", repository.UriFor(tag))
+	for _, additionalTag := range additionalTags {
+		console.Debug("Tagging image [%s]", repository.UriFor(additionalTag))
+
+		if err := registry.Tag(name, additionalTag, img); err != nil {
+			return "", err
+		}
 	}
+
+	return digest, nil
 }
 
 func (repository *Repository) UriFor(tag string) string {
 	return repository.Uri + ":" + tag
 }
+
+// UriForDigest returns the URI of an image in the repository by its digest, which, unlike a tag,
+// always refers to the same image.
+func (repository *Repository) UriForDigest(digest string) string {
+	return repository.Uri + "@" + digest
+}
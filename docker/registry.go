@@ -0,0 +1,239 @@
+package docker
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Registry is a client for the OCI distribution API of an image registry, such as Amazon ECR, which
+// authenticates with a username and password sent in each request rather than through docker login.
+type Registry struct {
+	Host string
+
+	client   *http.Client
+	password string
+	username string
+}
+
+// NewRegistry returns a client for the registry at the given host, e.g. the host of an ECR repository
+// URI, authenticating with the username and password returned by ECR.GetUsernameAndPassword.
+func NewRegistry(host, username, password string) Registry {
+	return Registry{
+		Host:     host,
+		client:   http.DefaultClient,
+		password: password,
+		username: username,
+	}
+}
+
+// Push pushes an image's config, layers, and manifest to a repository of the registry, tagging it
+// with the given reference. Blobs already in the repository are not pushed again. The digest of the
+// pushed manifest is returned.
+func (r Registry) Push(name, reference string, img image) (string, error) {
+	for _, b := range append([]blob{img.Config}, img.Layers...) {
+		if err := r.pushBlob(name, b); err != nil {
+			return "", err
+		}
+	}
+
+	m, mediaType, err := img.manifest()
+
+	if err != nil {
+		return "", err
+	}
+
+	return r.putManifest(name, reference, m, mediaType)
+}
+
+// Tag tags an image which has already been pushed to a repository of the registry with another
+// reference by pushing its manifest again.
+func (r Registry) Tag(name, reference string, img image) error {
+	m, mediaType, err := img.manifest()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = r.putManifest(name, reference, m, mediaType)
+
+	return err
+}
+
+func (r Registry) pushBlob(name string, b blob) error {
+	exists, err := r.blobExists(name, b.Digest)
+
+	if err != nil || exists {
+		return err
+	}
+
+	resp, err := r.do(http.MethodPost, r.url("/v2/%s/blobs/uploads/", name), nil, 0, "")
+
+	if err != nil {
+		return err
+	}
+
+	if err := checkStatus(resp, http.StatusAccepted); err != nil {
+		return err
+	}
+
+	location, err := r.location(resp)
+
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(b.Path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	resp, err = r.do(http.MethodPatch, location, file, b.Size, "application/octet-stream")
+
+	if err != nil {
+		return err
+	}
+
+	if err := checkStatus(resp, http.StatusAccepted); err != nil {
+		return err
+	}
+
+	if location, err = r.location(resp); err != nil {
+		return err
+	}
+
+	query := location.Query()
+	query.Set("digest", b.Digest)
+	location.RawQuery = query.Encode()
+
+	resp, err = r.do(http.MethodPut, location, nil, 0, "")
+
+	if err != nil {
+		return err
+	}
+
+	return checkStatus(resp, http.StatusCreated)
+}
+
+func (r Registry) blobExists(name, digest string) (bool, error) {
+	resp, err := r.do(http.MethodHead, r.url("/v2/%s/blobs/%s", name, digest), nil, 0, "")
+
+	if err != nil {
+		return false, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return false, nil
+	}
+
+	return true, checkStatus(resp, http.StatusOK)
+}
+
+func (r Registry) putManifest(name, reference string, m []byte, mediaType string) (string, error) {
+	resp, err := r.do(
+		http.MethodPut,
+		r.url("/v2/%s/manifests/%s", name, reference),
+		bytes.NewReader(m),
+		int64(len(m)),
+		mediaType,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+
+	if err := checkStatus(resp, http.StatusCreated); err != nil {
+		return "", err
+	}
+
+	if digest == "" {
+		digest = fmt.Sprintf("sha256:%x", sha256.Sum256(m))
+	}
+
+	return digest, nil
+}
+
+func (r Registry) do(method string, u *url.URL, body io.Reader, size int64, contentType string) (*http.Response, error) {
+	req, err := http.NewRequest(method, u.String(), body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.SetBasicAuth(r.username, r.password)
+	req.ContentLength = size
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	return r.client.Do(req)
+}
+
+// url returns the URL of a path on the registry. Registries on the local host are reached over
+// plain HTTP as they commonly are when run for development.
+func (r Registry) url(format string, a ...interface{}) *url.URL {
+	u := &url.URL{Scheme: "https", Host: r.Host, Path: fmt.Sprintf(format, a...)}
+
+	if host := strings.Split(r.Host, ":")[0]; host == "localhost" || host == "127.0.0.1" {
+		u.Scheme = "http"
+	}
+
+	return u
+}
+
+// location returns the URL of an upload given by a response's Location header, which may be
+// relative to the registry.
+func (r Registry) location(resp *http.Response) (*url.URL, error) {
+	location := resp.Header.Get("Location")
+
+	if location == "" {
+		return nil, fmt.Errorf("%s %s: response has no Location header", resp.Request.Method, resp.Request.URL.Path)
+	}
+
+	return r.url("/").Parse(location)
+}
+
+// checkStatus closes a response's body, returning an error including the body if the response does
+// not have the expected status.
+func checkStatus(resp *http.Response, status int) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode == status {
+		return nil
+	}
+
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	return fmt.Errorf(
+		"%s %s: unexpected status %s: %s",
+		resp.Request.Method,
+		resp.Request.URL.Path,
+		resp.Status,
+		strings.TrimSpace(string(body)),
+	)
+}
+
+// splitRepositoryUri splits a repository URI into the host of its registry and the name of the
+// repository.
+func splitRepositoryUri(uri string) (string, string) {
+	parts := strings.SplitN(uri, "/", 2)
+
+	if len(parts) != 2 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
@@ -0,0 +1,374 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testRegistry is a stand-in for an image registry which stores blobs and manifests in memory.
+type testRegistry struct {
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	mu        sync.Mutex
+	uploads   map[string][]byte
+	pushed    int
+}
+
+func newTestRegistry() *testRegistry {
+	return &testRegistry{
+		blobs:     make(map[string][]byte),
+		manifests: make(map[string][]byte),
+		uploads:   make(map[string][]byte),
+	}
+}
+
+func (r *testRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if username, password, ok := req.BasicAuth(); !ok || username != "AWS" || password != "token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/web/")
+
+	switch {
+	case req.Method == http.MethodHead && strings.HasPrefix(path, "blobs/sha256:"):
+		if _, ok := r.blobs[strings.TrimPrefix(path, "blobs/")]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case req.Method == http.MethodPost && path == "blobs/uploads/":
+		id := fmt.Sprintf("%d", len(r.uploads))
+		r.uploads[id] = nil
+
+		w.Header().Set("Location", "/v2/web/blobs/uploads/"+id+"?state=started")
+		w.WriteHeader(http.StatusAccepted)
+	case req.Method == http.MethodPatch && strings.HasPrefix(path, "blobs/uploads/"):
+		id := strings.TrimPrefix(path, "blobs/uploads/")
+		r.uploads[id], _ = ioutil.ReadAll(req.Body)
+
+		w.Header().Set("Location", req.URL.String())
+		w.WriteHeader(http.StatusAccepted)
+	case req.Method == http.MethodPut && strings.HasPrefix(path, "blobs/uploads/"):
+		id := strings.TrimPrefix(path, "blobs/uploads/")
+		digest := req.URL.Query().Get("digest")
+
+		if req.URL.Query().Get("state") != "started" || digest != fmt.Sprintf("sha256:%x", sha256.Sum256(r.uploads[id])) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		r.blobs[digest] = r.uploads[id]
+		r.pushed++
+
+		w.WriteHeader(http.StatusCreated)
+	case req.Method == http.MethodPut && strings.HasPrefix(path, "manifests/"):
+		if contentType := req.Header.Get("Content-Type"); contentType != mediaTypeDockerManifest && contentType != mediaTypeOCIManifest {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		m, _ := ioutil.ReadAll(req.Body)
+		r.manifests[strings.TrimPrefix(path, "manifests/")] = m
+
+		w.Header().Set("Docker-Content-Digest", fmt.Sprintf("sha256:%x", sha256.Sum256(m)))
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"code":"UNSUPPORTED"}]}`)
+	}
+}
+
+// writeTestArchive writes an archive in the format written by docker save with one uncompressed and
+// one compressed layer.
+func writeTestArchive(t *testing.T, dir string) string {
+	var compressed bytes.Buffer
+
+	writer := gzip.NewWriter(&compressed)
+	writer.Write([]byte("compressed layer"))
+	writer.Close()
+
+	manifest, _ := json.Marshal(
+		[]archiveManifest{
+			{
+				Config:   "config.json",
+				Layers:   []string{"a/layer.tar", "b/layer.tar"},
+				RepoTags: []string{"web:latest"},
+			},
+		},
+	)
+
+	files := []struct {
+		name     string
+		contents []byte
+	}{
+		{"config.json", []byte(`{"architecture":"amd64","os":"linux"}`)},
+		{"a/layer.tar", []byte("uncompressed layer")},
+		{"b/layer.tar", compressed.Bytes()},
+		{"manifest.json", manifest},
+	}
+
+	path := filepath.Join(dir, "image.tar")
+	file, err := os.Create(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	tw := tar.NewWriter(file)
+
+	for _, f := range files {
+		tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.contents)), Typeflag: tar.TypeReg})
+		tw.Write(f.contents)
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestRepositoryPushArchive(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "fargate-test")
+	defer os.RemoveAll(dir)
+
+	registry := newTestRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	repository := NewRepository(strings.TrimPrefix(server.URL, "http://") + "/web")
+	repository.Login("AWS", "token")
+
+	digest, err := repository.pushArchive(writeTestArchive(t, dir), "abc1234", nil)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if registry.pushed != 3 {
+		t.Errorf("expected 3 blobs pushed, got %d", registry.pushed)
+	}
+
+	m, ok := registry.manifests["abc1234"]
+
+	if !ok {
+		t.Fatalf("expected manifest tagged abc1234, got none")
+	}
+
+	if expected := fmt.Sprintf("sha256:%x", sha256.Sum256(m)); digest != expected {
+		t.Errorf("expected digest %s, got %s", expected, digest)
+	}
+
+	var pushed manifest
+
+	if err := json.Unmarshal(m, &pushed); err != nil {
+		t.Fatalf("expected valid manifest, got %v", err)
+	}
+
+	if pushed.SchemaVersion != 2 || pushed.MediaType != mediaTypeDockerManifest {
+		t.Errorf("expected schema 2 manifest, got %d %s", pushed.SchemaVersion, pushed.MediaType)
+	}
+
+	if pushed.Config.MediaType != mediaTypeDockerConfig {
+		t.Errorf("expected config media type %s, got %s", mediaTypeDockerConfig, pushed.Config.MediaType)
+	}
+
+	if len(pushed.Layers) != 2 {
+		t.Fatalf("expected 2 layers, got %d", len(pushed.Layers))
+	}
+
+	for n, expected := range []string{"uncompressed layer", "compressed layer"} {
+		layer := pushed.Layers[n]
+		contents := registry.blobs[layer.Digest]
+
+		if layer.MediaType != mediaTypeDockerLayer {
+			t.Errorf("expected layer media type %s, got %s", mediaTypeDockerLayer, layer.MediaType)
+		}
+
+		if int64(len(contents)) != layer.Size {
+			t.Errorf("expected layer size %d, got %d", len(contents), layer.Size)
+		}
+
+		reader, err := gzip.NewReader(bytes.NewReader(contents))
+
+		if err != nil {
+			t.Fatalf("expected compressed layer, got %v", err)
+		}
+
+		if got, _ := ioutil.ReadAll(reader); string(got) != expected {
+			t.Errorf("expected layer %q, got %q", expected, got)
+		}
+	}
+
+	if _, err := repository.pushArchive(writeTestArchive(t, dir), "def5678", nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if registry.pushed != 3 {
+		t.Errorf("expected existing blobs to be skipped, got %d blobs pushed", registry.pushed)
+	}
+}
+
+// writeTestOCILayout writes an OCI image layout archive with one layer, returning its path and the
+// digest of its manifest.
+func writeTestOCILayout(t *testing.T, dir string) (string, string) {
+	var compressed bytes.Buffer
+
+	writer := gzip.NewWriter(&compressed)
+	writer.Write([]byte("layer"))
+	writer.Close()
+
+	config := []byte(`{"architecture":"arm64","os":"linux"}`)
+	layer := compressed.Bytes()
+	m, _ := json.Marshal(
+		manifest{
+			SchemaVersion: 2,
+			MediaType:     mediaTypeOCIManifest,
+			Config:        testDescriptor("application/vnd.oci.image.config.v1+json", config),
+			Layers:        []descriptor{testDescriptor("application/vnd.oci.image.layer.v1.tar+gzip", layer)},
+		},
+	)
+	manifestDescriptor := testDescriptor(mediaTypeOCIManifest, m)
+	index, _ := json.Marshal(ociIndex{Manifests: []descriptor{manifestDescriptor}})
+
+	files := map[string][]byte{
+		"oci-layout": []byte(`{"imageLayoutVersion":"1.0.0"}`),
+		"index.json": index,
+	}
+
+	for _, contents := range [][]byte{config, layer, m} {
+		files[fmt.Sprintf("blobs/sha256/%x", sha256.Sum256(contents))] = contents
+	}
+
+	path := filepath.Join(dir, "oci.tar")
+	file, err := os.Create(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	tw := tar.NewWriter(file)
+
+	for name, contents := range files {
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(contents)), Typeflag: tar.TypeReg})
+		tw.Write(contents)
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return path, manifestDescriptor.Digest
+}
+
+func testDescriptor(mediaType string, contents []byte) descriptor {
+	return descriptor{
+		MediaType: mediaType,
+		Size:      int64(len(contents)),
+		Digest:    fmt.Sprintf("sha256:%x", sha256.Sum256(contents)),
+	}
+}
+
+func TestRepositoryPushArchiveOCILayout(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "fargate-test")
+	defer os.RemoveAll(dir)
+
+	registry := newTestRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	repository := NewRepository(strings.TrimPrefix(server.URL, "http://") + "/web")
+	repository.Login("AWS", "token")
+
+	path, expected := writeTestOCILayout(t, dir)
+	digest, err := repository.pushArchive(path, "abc1234", []string{"main", "v1.0.0"})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if digest != expected {
+		t.Errorf("expected digest %s, got %s", expected, digest)
+	}
+
+	if registry.pushed != 2 {
+		t.Errorf("expected 2 blobs pushed, got %d", registry.pushed)
+	}
+
+	for _, tag := range []string{"abc1234", "main", "v1.0.0"} {
+		if m, ok := registry.manifests[tag]; !ok {
+			t.Errorf("expected manifest tagged %s, got none", tag)
+		} else if digest := fmt.Sprintf("sha256:%x", sha256.Sum256(m)); digest != expected {
+			t.Errorf("expected manifest tagged %s to have digest %s, got %s", tag, expected, digest)
+		}
+	}
+
+	if expected := repository.Uri + "@" + expected; repository.UriForDigest(digest) != expected {
+		t.Errorf("expected %s, got %s", expected, repository.UriForDigest(digest))
+	}
+}
+
+func TestRepositoryPushArchiveUnauthorized(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "fargate-test")
+	defer os.RemoveAll(dir)
+
+	server := httptest.NewServer(newTestRegistry())
+	defer server.Close()
+
+	repository := NewRepository(strings.TrimPrefix(server.URL, "http://") + "/web")
+	repository.Login("AWS", "expired")
+
+	_, err := repository.pushArchive(writeTestArchive(t, dir), "abc1234", nil)
+
+	if err == nil {
+		t.Fatalf("expected error, got none")
+	}
+
+	if !strings.Contains(err.Error(), "401 Unauthorized") {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+}
+
+func TestReadArchiveOutsideEntry(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "fargate-test")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "image.tar")
+	file, _ := os.Create(path)
+	tw := tar.NewWriter(file)
+	tw.WriteHeader(&tar.Header{Name: "../escape", Mode: 0644, Typeflag: tar.TypeReg})
+	tw.Close()
+	file.Close()
+
+	if _, err := readArchive(path); err == nil {
+		t.Errorf("expected error, got none")
+	} else if expected := "image archive entry ../escape is outside of the archive"; err.Error() != expected {
+		t.Errorf("expected error %s, got %v", expected, err)
+	}
+}
+
+func TestSplitRepositoryUri(t *testing.T) {
+	host, name := splitRepositoryUri("123456789012.dkr.ecr.us-east-1.amazonaws.com/team/web")
+
+	if host != "123456789012.dkr.ecr.us-east-1.amazonaws.com" || name != "team/web" {
+		t.Errorf("expected host and name, got %s and %s", host, name)
+	}
+}
@@ -7,6 +7,7 @@ import (
 )
 
 type Eni struct {
+	PrivateIpAddress string
 	PublicIpAddress  string
 	EniId            string
 	SecurityGroupIds []string
@@ -32,15 +33,17 @@ func (ec2 SDKClient) DescribeNetworkInterfaces(eniIds []string) map[string]Eni {
 			securityGroupIds = append(securityGroupIds, group.GroupId)
 		}
 
-		if e.Association != nil {
-			eni := Eni{
-				EniId:            aws.StringValue(e.NetworkInterfaceId),
-				PublicIpAddress:  aws.StringValue(e.Association.PublicIp),
-				SecurityGroupIds: aws.StringValueSlice(securityGroupIds),
-			}
+		eni := Eni{
+			EniId:            aws.StringValue(e.NetworkInterfaceId),
+			PrivateIpAddress: aws.StringValue(e.PrivateIpAddress),
+			SecurityGroupIds: aws.StringValueSlice(securityGroupIds),
+		}
 
-			enis[eni.EniId] = eni
+		if e.Association != nil {
+			eni.PublicIpAddress = aws.StringValue(e.Association.PublicIp)
 		}
+
+		enis[eni.EniId] = eni
 	}
 
 	return enis
@@ -0,0 +1,52 @@
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/awslabs/fargatecli/ec2/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+func TestDescribeNetworkInterfaces(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	input := &awsec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: aws.StringSlice([]string{"eni-1", "eni-2"}),
+	}
+	output := &awsec2.DescribeNetworkInterfacesOutput{
+		NetworkInterfaces: []*awsec2.NetworkInterface{
+			&awsec2.NetworkInterface{
+				Association:        &awsec2.NetworkInterfaceAssociation{PublicIp: aws.String("54.0.0.1")},
+				Groups:             []*awsec2.GroupIdentifier{&awsec2.GroupIdentifier{GroupId: aws.String("sg-1")}},
+				NetworkInterfaceId: aws.String("eni-1"),
+				PrivateIpAddress:   aws.String("10.0.0.1"),
+			},
+			&awsec2.NetworkInterface{
+				NetworkInterfaceId: aws.String("eni-2"),
+				PrivateIpAddress:   aws.String("10.0.0.2"),
+			},
+		},
+	}
+
+	mockEC2Client := sdk.NewMockEC2API(mockCtrl)
+	ec2 := SDKClient{client: mockEC2Client}
+
+	mockEC2Client.EXPECT().DescribeNetworkInterfaces(input).Return(output, nil)
+
+	enis := ec2.DescribeNetworkInterfaces([]string{"eni-1", "eni-2"})
+
+	if len(enis) != 2 {
+		t.Fatalf("expected 2 network interfaces, got %d", len(enis))
+	}
+
+	if enis["eni-1"].PublicIpAddress != "54.0.0.1" || enis["eni-1"].PrivateIpAddress != "10.0.0.1" {
+		t.Errorf("expected eni-1 with addresses 54.0.0.1 and 10.0.0.1, got %+v", enis["eni-1"])
+	}
+
+	if enis["eni-2"].PublicIpAddress != "" || enis["eni-2"].PrivateIpAddress != "10.0.0.2" {
+		t.Errorf("expected eni-2 with only private address 10.0.0.2, got %+v", enis["eni-2"])
+	}
+}
@@ -0,0 +1,174 @@
+package ecr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecr "github.com/aws/aws-sdk-go/service/ecr"
+)
+
+const (
+	defaultImageTag = "latest"
+
+	// batchDeleteImageLimit is the largest number of images BatchDeleteImage deletes in one call.
+	batchDeleteImageLimit = 100
+)
+
+var imageUriPattern = regexp.MustCompile(
+	`^((\d{12})\.dkr\.ecr(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?/([a-z0-9._/-]+))(?::([\w][\w.-]{0,127}))?(?:@(sha256:[a-f0-9]{64}))?$`,
+)
+
+// ImageUri is the URI of an image in an Amazon ECR repository. Repository is the URI of the repository
+// and RepositoryName its name.
+type ImageUri struct {
+	Digest         string
+	Region         string
+	RegistryID     string
+	Repository     string
+	RepositoryName string
+	Tag            string
+}
+
+// ParseImageUri parses the URI of an image in an Amazon ECR repository, returning false if the image
+// is not in ECR. Images referred to by neither tag nor digest are given the latest tag.
+func ParseImageUri(image string) (ImageUri, bool) {
+	matches := imageUriPattern.FindStringSubmatch(image)
+
+	if matches == nil {
+		return ImageUri{}, false
+	}
+
+	uri := ImageUri{
+		Digest:         matches[6],
+		Region:         matches[3],
+		RegistryID:     matches[2],
+		Repository:     matches[1],
+		RepositoryName: matches[4],
+		Tag:            matches[5],
+	}
+
+	if uri.Tag == "" && uri.Digest == "" {
+		uri.Tag = defaultImageTag
+	}
+
+	return uri, true
+}
+
+// DescribeImageDigest returns the digest of the image a tag in a repository currently refers to.
+func (ecr *ECR) DescribeImageDigest(registryID, repositoryName, tag string) (string, error) {
+	resp, err := ecr.svc.DescribeImages(
+		&awsecr.DescribeImagesInput{
+			ImageIds:       []*awsecr.ImageIdentifier{&awsecr.ImageIdentifier{ImageTag: aws.String(tag)}},
+			RegistryId:     aws.String(registryID),
+			RepositoryName: aws.String(repositoryName),
+		},
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.ImageDetails) != 1 {
+		return "", fmt.Errorf("could not find image %s:%s", repositoryName, tag)
+	}
+
+	return aws.StringValue(resp.ImageDetails[0].ImageDigest), nil
+}
+
+// Image is an image in an Amazon ECR repository. ScanFindings counts the findings of the image's
+// latest scan by severity, if it has been scanned.
+type Image struct {
+	Digest       string           `json:"digest"`
+	PushedAt     time.Time        `json:"pushedAt"`
+	ScanFindings map[string]int64 `json:"scanFindings,omitempty"`
+	ScanStatus   string           `json:"scanStatus,omitempty"`
+	SizeInBytes  int64            `json:"sizeInBytes"`
+	Tags         []string         `json:"tags"`
+}
+
+// Images is a collection of images.
+type Images []Image
+
+// ListImages returns the images in a repository, tagged or not.
+func (ecr ECR) ListImages(repositoryName string) (Images, error) {
+	var images Images
+
+	handler := func(resp *awsecr.DescribeImagesOutput, lastPage bool) bool {
+		for _, detail := range resp.ImageDetails {
+			image := Image{
+				Digest:      aws.StringValue(detail.ImageDigest),
+				PushedAt:    aws.TimeValue(detail.ImagePushedAt),
+				SizeInBytes: aws.Int64Value(detail.ImageSizeInBytes),
+				Tags:        aws.StringValueSlice(detail.ImageTags),
+			}
+
+			if detail.ImageScanStatus != nil {
+				image.ScanStatus = aws.StringValue(detail.ImageScanStatus.Status)
+			}
+
+			if detail.ImageScanFindingsSummary != nil {
+				image.ScanFindings = aws.Int64ValueMap(detail.ImageScanFindingsSummary.FindingSeverityCounts)
+			}
+
+			images = append(images, image)
+		}
+
+		return true
+	}
+
+	err := ecr.svc.DescribeImagesPages(
+		&awsecr.DescribeImagesInput{RepositoryName: aws.String(repositoryName)},
+		handler,
+	)
+
+	return images, err
+}
+
+// DeleteImages deletes the images with the given digests from a repository, along with their tags.
+func (ecr ECR) DeleteImages(repositoryName string, digests []string) error {
+	var failures []string
+
+	for start := 0; start < len(digests); start += batchDeleteImageLimit {
+		var imageIds []*awsecr.ImageIdentifier
+
+		end := start + batchDeleteImageLimit
+
+		if end > len(digests) {
+			end = len(digests)
+		}
+
+		for _, digest := range digests[start:end] {
+			imageIds = append(imageIds, &awsecr.ImageIdentifier{ImageDigest: aws.String(digest)})
+		}
+
+		resp, err := ecr.svc.BatchDeleteImage(
+			&awsecr.BatchDeleteImageInput{
+				ImageIds:       imageIds,
+				RepositoryName: aws.String(repositoryName),
+			},
+		)
+
+		if err != nil {
+			return err
+		}
+
+		for _, failure := range resp.Failures {
+			var digest string
+
+			if failure.ImageId != nil {
+				digest = aws.StringValue(failure.ImageId.ImageDigest)
+			}
+
+			failures = append(failures, fmt.Sprintf("%s: %s", digest, aws.StringValue(failure.FailureReason)))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("could not delete images: %s", strings.Join(failures, ", "))
+	}
+
+	return nil
+}
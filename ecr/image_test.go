@@ -0,0 +1,209 @@
+package ecr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecr "github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/awslabs/fargatecli/ecr/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestParseImageUri(t *testing.T) {
+	var tests = []struct {
+		image    string
+		expected ImageUri
+	}{
+		{
+			"123456789012.dkr.ecr.us-east-1.amazonaws.com/web:abc1234",
+			ImageUri{
+				Region:         "us-east-1",
+				RegistryID:     "123456789012",
+				Repository:     "123456789012.dkr.ecr.us-east-1.amazonaws.com/web",
+				RepositoryName: "web",
+				Tag:            "abc1234",
+			},
+		},
+		{
+			"123456789012.dkr.ecr.eu-west-1.amazonaws.com/team/web",
+			ImageUri{
+				Region:         "eu-west-1",
+				RegistryID:     "123456789012",
+				Repository:     "123456789012.dkr.ecr.eu-west-1.amazonaws.com/team/web",
+				RepositoryName: "team/web",
+				Tag:            "latest",
+			},
+		},
+		{
+			"123456789012.dkr.ecr.us-east-1.amazonaws.com/web@" + testDigest,
+			ImageUri{
+				Digest:         testDigest,
+				Region:         "us-east-1",
+				RegistryID:     "123456789012",
+				Repository:     "123456789012.dkr.ecr.us-east-1.amazonaws.com/web",
+				RepositoryName: "web",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		uri, ok := ParseImageUri(test.image)
+
+		if !ok {
+			t.Errorf("%s: expected ECR image", test.image)
+		}
+
+		if uri != test.expected {
+			t.Errorf("%s: expected %+v, got %+v", test.image, test.expected, uri)
+		}
+	}
+}
+
+func TestParseImageUriNotECR(t *testing.T) {
+	for _, image := range []string{"nginx:latest", "quay.io/prometheus/prometheus:v2.17.1", "public.ecr.aws/nginx/nginx:latest"} {
+		if _, ok := ParseImageUri(image); ok {
+			t.Errorf("%s: expected image not in ECR", image)
+		}
+	}
+}
+
+func TestListImages(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECRAPI := sdk.NewMockECRAPI(mockCtrl)
+	ecr := ECR{svc: mockECRAPI}
+	pushedAt := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	mockECRAPI.EXPECT().DescribeImagesPages(
+		&awsecr.DescribeImagesInput{RepositoryName: aws.String("web")},
+		gomock.Any(),
+	).Do(
+		func(input *awsecr.DescribeImagesInput, fn func(*awsecr.DescribeImagesOutput, bool) bool) {
+			fn(
+				&awsecr.DescribeImagesOutput{
+					ImageDetails: []*awsecr.ImageDetail{
+						&awsecr.ImageDetail{
+							ImageDigest:      aws.String(testDigest),
+							ImagePushedAt:    aws.Time(pushedAt),
+							ImageSizeInBytes: aws.Int64(1024),
+							ImageTags:        aws.StringSlice([]string{"abc1234", "latest"}),
+							ImageScanStatus:  &awsecr.ImageScanStatus{Status: aws.String(awsecr.ScanStatusComplete)},
+							ImageScanFindingsSummary: &awsecr.ImageScanFindingsSummary{
+								FindingSeverityCounts: aws.Int64Map(map[string]int64{"HIGH": 2, "LOW": 5}),
+							},
+						},
+					},
+				},
+				false,
+			)
+			fn(
+				&awsecr.DescribeImagesOutput{
+					ImageDetails: []*awsecr.ImageDetail{
+						&awsecr.ImageDetail{
+							ImageDigest:   aws.String("sha256:def"),
+							ImagePushedAt: aws.Time(pushedAt.Add(-time.Hour)),
+						},
+					},
+				},
+				true,
+			)
+		},
+	).Return(nil)
+
+	images, err := ecr.ListImages("web")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := Images{
+		Image{
+			Digest:       testDigest,
+			PushedAt:     pushedAt,
+			ScanFindings: map[string]int64{"HIGH": 2, "LOW": 5},
+			ScanStatus:   "COMPLETE",
+			SizeInBytes:  1024,
+			Tags:         []string{"abc1234", "latest"},
+		},
+		Image{
+			Digest:   "sha256:def",
+			PushedAt: pushedAt.Add(-time.Hour),
+			Tags:     []string{},
+		},
+	}
+
+	if !reflect.DeepEqual(images, expected) {
+		t.Errorf("expected images %+v, got %+v", expected, images)
+	}
+}
+
+func TestDeleteImages(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECRAPI := sdk.NewMockECRAPI(mockCtrl)
+	ecr := ECR{svc: mockECRAPI}
+
+	var digests []string
+
+	for i := 0; i < 101; i++ {
+		digests = append(digests, fmt.Sprintf("sha256:%064x", i))
+	}
+
+	gomock.InOrder(
+		mockECRAPI.EXPECT().BatchDeleteImage(gomock.Any()).Do(
+			func(input *awsecr.BatchDeleteImageInput) {
+				if len(input.ImageIds) != 100 {
+					t.Errorf("expected 100 images in first batch, got %d", len(input.ImageIds))
+				}
+			},
+		).Return(&awsecr.BatchDeleteImageOutput{}, nil),
+		mockECRAPI.EXPECT().BatchDeleteImage(
+			&awsecr.BatchDeleteImageInput{
+				ImageIds:       []*awsecr.ImageIdentifier{&awsecr.ImageIdentifier{ImageDigest: aws.String(digests[100])}},
+				RepositoryName: aws.String("web"),
+			},
+		).Return(&awsecr.BatchDeleteImageOutput{}, nil),
+	)
+
+	if err := ecr.DeleteImages("web", digests); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestDeleteImagesFailure(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECRAPI := sdk.NewMockECRAPI(mockCtrl)
+	ecr := ECR{svc: mockECRAPI}
+
+	mockECRAPI.EXPECT().BatchDeleteImage(gomock.Any()).Return(
+		&awsecr.BatchDeleteImageOutput{
+			Failures: []*awsecr.ImageFailure{
+				&awsecr.ImageFailure{
+					FailureReason: aws.String("Requested image not found"),
+					ImageId:       &awsecr.ImageIdentifier{ImageDigest: aws.String(testDigest)},
+				},
+			},
+		},
+		nil,
+	)
+
+	err := ecr.DeleteImages("web", []string{testDigest})
+
+	if err == nil {
+		t.Fatalf("expected error, got none")
+	}
+
+	if !strings.Contains(err.Error(), testDigest+": Requested image not found") {
+		t.Errorf("expected failure reason in error, got %v", err)
+	}
+}
@@ -1,14 +1,27 @@
 package ecr
 
+//go:generate mockgen -package client -destination=mock/client/client.go github.com/awslabs/fargatecli/ecr Client
+//go:generate mockgen -package sdk -source ../vendor/github.com/aws/aws-sdk-go/service/ecr/ecriface/interface.go -destination=mock/sdk/ecriface.go github.com/aws/aws-sdk-go/service/ecr/ecriface ECRAPI
+
 import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
 )
 
+// Client represents a method for accessing Amazon ECR.
+type Client interface {
+	DeleteImages(string, []string) error
+	DescribeRepository(string) (Repository, error)
+	ListImages(string) (Images, error)
+}
+
+// ECR implements access to Amazon ECR via the AWS SDK.
 type ECR struct {
-	svc *ecr.ECR
+	svc ecriface.ECRAPI
 }
 
+// New returns an ECR client configured with the given session.
 func New(sess *session.Session) ECR {
 	return ECR{
 		svc: ecr.New(sess),
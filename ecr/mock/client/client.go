@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/awslabs/fargatecli/ecr (interfaces: Client)
+
+// Package client is a generated GoMock package.
+package client
+
+import (
+	ecr "github.com/awslabs/fargatecli/ecr"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockClient is a mock of Client interface
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// DeleteImages mocks base method
+func (m *MockClient) DeleteImages(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImages indicates an expected call of DeleteImages
+func (mr *MockClientMockRecorder) DeleteImages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImages", reflect.TypeOf((*MockClient)(nil).DeleteImages), arg0, arg1)
+}
+
+// DescribeRepository mocks base method
+func (m *MockClient) DescribeRepository(arg0 string) (ecr.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeRepository", arg0)
+	ret0, _ := ret[0].(ecr.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeRepository indicates an expected call of DescribeRepository
+func (mr *MockClientMockRecorder) DescribeRepository(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeRepository", reflect.TypeOf((*MockClient)(nil).DescribeRepository), arg0)
+}
+
+// ListImages mocks base method
+func (m *MockClient) ListImages(arg0 string) (ecr.Images, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImages", arg0)
+	ret0, _ := ret[0].(ecr.Images)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImages indicates an expected call of ListImages
+func (mr *MockClientMockRecorder) ListImages(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImages", reflect.TypeOf((*MockClient)(nil).ListImages), arg0)
+}
@@ -2,6 +2,7 @@ package ecr
 
 import (
 	"encoding/base64"
+	"fmt"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -10,11 +11,28 @@ import (
 	"github.com/awslabs/fargatecli/console"
 )
 
-func (ecr *ECR) CreateRepository(repositoryName string) string {
+// Repository is an Amazon ECR repository.
+type Repository struct {
+	Name       string `json:"name"`
+	ScanOnPush bool   `json:"scanOnPush"`
+	URI        string `json:"uri"`
+}
+
+// RepositoryOptions configure a repository when it is created. LifecyclePolicy is the JSON text of a
+// lifecycle policy to expire images with.
+type RepositoryOptions struct {
+	LifecyclePolicy string
+	ScanOnPush      bool
+}
+
+func (ecr *ECR) CreateRepository(repositoryName string, options RepositoryOptions) string {
 	console.Debug("Creating Amazon ECR repository")
 
 	resp, err := ecr.svc.CreateRepository(
 		&awsecr.CreateRepositoryInput{
+			ImageScanningConfiguration: &awsecr.ImageScanningConfiguration{
+				ScanOnPush: aws.Bool(options.ScanOnPush),
+			},
 			RepositoryName: aws.String(repositoryName),
 		},
 	)
@@ -24,9 +42,53 @@ func (ecr *ECR) CreateRepository(repositoryName string) string {
 	}
 
 	console.Debug("Created Amazon ECR repository [%s]", *resp.Repository.RepositoryName)
+
+	if options.LifecyclePolicy != "" {
+		_, err := ecr.svc.PutLifecyclePolicy(
+			&awsecr.PutLifecyclePolicyInput{
+				LifecyclePolicyText: aws.String(options.LifecyclePolicy),
+				RepositoryName:      aws.String(repositoryName),
+			},
+		)
+
+		if err != nil {
+			console.ErrorExit(err, "Couldn't put lifecycle policy on Amazon ECR repository")
+		}
+
+		console.Debug("Put lifecycle policy on Amazon ECR repository [%s]", repositoryName)
+	}
+
 	return aws.StringValue(resp.Repository.RepositoryUri)
 }
 
+// DescribeRepository returns the repository with the given name.
+func (ecr ECR) DescribeRepository(repositoryName string) (Repository, error) {
+	resp, err := ecr.svc.DescribeRepositories(
+		&awsecr.DescribeRepositoriesInput{
+			RepositoryNames: aws.StringSlice([]string{repositoryName}),
+		},
+	)
+
+	if err != nil {
+		return Repository{}, err
+	}
+
+	if len(resp.Repositories) != 1 {
+		return Repository{}, fmt.Errorf("could not find repository %s", repositoryName)
+	}
+
+	repository := Repository{
+		Name: aws.StringValue(resp.Repositories[0].RepositoryName),
+		URI:  aws.StringValue(resp.Repositories[0].RepositoryUri),
+	}
+
+	if config := resp.Repositories[0].ImageScanningConfiguration; config != nil {
+		repository.ScanOnPush = aws.BoolValue(config.ScanOnPush)
+	}
+
+	return repository, nil
+}
+
 func (ecr *ECR) IsRepositoryCreated(repositoryName string) bool {
 	resp, err := ecr.svc.DescribeRepositories(
 		&awsecr.DescribeRepositoriesInput{
@@ -0,0 +1,85 @@
+package ecr
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecr "github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/awslabs/fargatecli/ecr/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCreateRepository(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECRAPI := sdk.NewMockECRAPI(mockCtrl)
+	ecr := ECR{svc: mockECRAPI}
+	policy := `{"rules":[]}`
+
+	mockECRAPI.EXPECT().CreateRepository(
+		&awsecr.CreateRepositoryInput{
+			ImageScanningConfiguration: &awsecr.ImageScanningConfiguration{ScanOnPush: aws.Bool(true)},
+			RepositoryName:             aws.String("web"),
+		},
+	).Return(
+		&awsecr.CreateRepositoryOutput{
+			Repository: &awsecr.Repository{
+				RepositoryName: aws.String("web"),
+				RepositoryUri:  aws.String("123456789012.dkr.ecr.us-east-1.amazonaws.com/web"),
+			},
+		},
+		nil,
+	)
+	mockECRAPI.EXPECT().PutLifecyclePolicy(
+		&awsecr.PutLifecyclePolicyInput{
+			LifecyclePolicyText: aws.String(policy),
+			RepositoryName:      aws.String("web"),
+		},
+	).Return(&awsecr.PutLifecyclePolicyOutput{}, nil)
+
+	uri := ecr.CreateRepository("web", RepositoryOptions{LifecyclePolicy: policy, ScanOnPush: true})
+
+	if expected := "123456789012.dkr.ecr.us-east-1.amazonaws.com/web"; uri != expected {
+		t.Errorf("expected %s, got %s", expected, uri)
+	}
+}
+
+func TestDescribeRepository(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECRAPI := sdk.NewMockECRAPI(mockCtrl)
+	ecr := ECR{svc: mockECRAPI}
+
+	mockECRAPI.EXPECT().DescribeRepositories(
+		&awsecr.DescribeRepositoriesInput{RepositoryNames: aws.StringSlice([]string{"web"})},
+	).Return(
+		&awsecr.DescribeRepositoriesOutput{
+			Repositories: []*awsecr.Repository{
+				&awsecr.Repository{
+					ImageScanningConfiguration: &awsecr.ImageScanningConfiguration{ScanOnPush: aws.Bool(true)},
+					RepositoryName:             aws.String("web"),
+					RepositoryUri:              aws.String("123456789012.dkr.ecr.us-east-1.amazonaws.com/web"),
+				},
+			},
+		},
+		nil,
+	)
+
+	repository, err := ecr.DescribeRepository("web")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := Repository{
+		Name:       "web",
+		ScanOnPush: true,
+		URI:        "123456789012.dkr.ecr.us-east-1.amazonaws.com/web",
+	}
+
+	if repository != expected {
+		t.Errorf("expected %+v, got %+v", expected, repository)
+	}
+}
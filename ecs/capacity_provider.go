@@ -0,0 +1,107 @@
+package ecs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+)
+
+// Capacity providers available to Fargate tasks in every cluster.
+const (
+	CapacityProviderFargate     = "FARGATE"
+	CapacityProviderFargateSpot = "FARGATE_SPOT"
+)
+
+// CapacityProviderStrategyItem sets how many tasks are placed on a capacity provider. Base tasks are
+// placed on the provider first, after which tasks are placed in proportion to the weights of the
+// providers in a strategy.
+type CapacityProviderStrategyItem struct {
+	Base             int64  `json:"base,omitempty"`
+	CapacityProvider string `json:"capacityProvider"`
+	Weight           int64  `json:"weight"`
+}
+
+// CapacityProviderStrategy is the set of capacity providers tasks are placed on.
+type CapacityProviderStrategy []CapacityProviderStrategyItem
+
+func (i CapacityProviderStrategyItem) String() string {
+	if i.Base > 0 {
+		return fmt.Sprintf("%s:base=%d,weight=%d", i.CapacityProvider, i.Base, i.Weight)
+	}
+
+	return fmt.Sprintf("%s:weight=%d", i.CapacityProvider, i.Weight)
+}
+
+func (s CapacityProviderStrategy) String() string {
+	var items []string
+
+	for _, item := range s {
+		items = append(items, item.String())
+	}
+
+	return strings.Join(items, ",")
+}
+
+// UsesFargate returns whether all of the strategy's capacity providers run Fargate tasks.
+func (s CapacityProviderStrategy) UsesFargate() bool {
+	for _, item := range s {
+		if item.CapacityProvider != CapacityProviderFargate && item.CapacityProvider != CapacityProviderFargateSpot {
+			return false
+		}
+	}
+
+	return len(s) > 0
+}
+
+func (s CapacityProviderStrategy) sdk() []*awsecs.CapacityProviderStrategyItem {
+	var items []*awsecs.CapacityProviderStrategyItem
+
+	for _, item := range s {
+		items = append(items,
+			&awsecs.CapacityProviderStrategyItem{
+				Base:             aws.Int64(item.Base),
+				CapacityProvider: aws.String(item.CapacityProvider),
+				Weight:           aws.Int64(item.Weight),
+			},
+		)
+	}
+
+	return items
+}
+
+func newCapacityProviderStrategy(items []*awsecs.CapacityProviderStrategyItem) CapacityProviderStrategy {
+	var strategy CapacityProviderStrategy
+
+	for _, item := range items {
+		strategy = append(strategy,
+			CapacityProviderStrategyItem{
+				Base:             aws.Int64Value(item.Base),
+				CapacityProvider: aws.StringValue(item.CapacityProvider),
+				Weight:           aws.Int64Value(item.Weight),
+			},
+		)
+	}
+
+	return strategy
+}
+
+// UpdateServiceCapacityProviderStrategy starts a new deployment of a service which places its tasks
+// using the given strategy, and optionally runs a new task definition.
+func (ecs ECS) UpdateServiceCapacityProviderStrategy(serviceName, taskDefinitionArn string, strategy CapacityProviderStrategy) error {
+	input := &awsecs.UpdateServiceInput{
+		CapacityProviderStrategy: strategy.sdk(),
+		Cluster:                  aws.String(ecs.ClusterName),
+		ForceNewDeployment:       aws.Bool(true),
+		Service:                  aws.String(serviceName),
+	}
+
+	if taskDefinitionArn != "" {
+		input.TaskDefinition = aws.String(taskDefinitionArn)
+	}
+
+	_, err := ecs.svc.UpdateService(input)
+
+	return err
+}
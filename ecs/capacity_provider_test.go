@@ -0,0 +1,131 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/awslabs/fargatecli/ecs/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCapacityProviderStrategyString(t *testing.T) {
+	strategy := CapacityProviderStrategy{
+		CapacityProviderStrategyItem{CapacityProvider: "FARGATE_SPOT", Weight: 3},
+		CapacityProviderStrategyItem{CapacityProvider: "FARGATE", Base: 1, Weight: 1},
+	}
+
+	if expected, got := "FARGATE_SPOT:weight=3,FARGATE:base=1,weight=1", strategy.String(); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestCapacityProviderStrategyUsesFargate(t *testing.T) {
+	var tests = []struct {
+		strategy CapacityProviderStrategy
+		expected bool
+	}{
+		{CapacityProviderStrategy{CapacityProviderStrategyItem{CapacityProvider: "FARGATE_SPOT"}}, true},
+		{CapacityProviderStrategy{CapacityProviderStrategyItem{CapacityProvider: "FARGATE"}, CapacityProviderStrategyItem{CapacityProvider: "FARGATE_SPOT"}}, true},
+		{CapacityProviderStrategy{CapacityProviderStrategyItem{CapacityProvider: "ec2-asg"}}, false},
+		{CapacityProviderStrategy{}, false},
+	}
+
+	for _, test := range tests {
+		if got := test.strategy.UsesFargate(); got != test.expected {
+			t.Errorf("expected %t for %s, got %t", test.expected, test.strategy, got)
+		}
+	}
+}
+
+func TestUpdateServiceCapacityProviderStrategy(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().UpdateService(
+		&awsecs.UpdateServiceInput{
+			CapacityProviderStrategy: []*awsecs.CapacityProviderStrategyItem{
+				&awsecs.CapacityProviderStrategyItem{Base: aws.Int64(0), CapacityProvider: aws.String("FARGATE_SPOT"), Weight: aws.Int64(1)},
+			},
+			Cluster:            aws.String("fargate"),
+			ForceNewDeployment: aws.Bool(true),
+			Service:            aws.String("web"),
+		},
+	).Return(&awsecs.UpdateServiceOutput{}, nil)
+
+	err := ecs.UpdateServiceCapacityProviderStrategy("web", "",
+		CapacityProviderStrategy{CapacityProviderStrategyItem{CapacityProvider: "FARGATE_SPOT", Weight: 1}},
+	)
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestCreateClusterAttachesCapacityProviders(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+	clusterARN := "arn:aws:ecs:us-east-1:123456789012:cluster/fargate"
+
+	gomock.InOrder(
+		mockECSAPI.EXPECT().CreateCluster(
+			&awsecs.CreateClusterInput{
+				CapacityProviders: aws.StringSlice([]string{"FARGATE", "FARGATE_SPOT"}),
+				ClusterName:       aws.String("fargate"),
+			},
+		).Return(
+			&awsecs.CreateClusterOutput{
+				Cluster: &awsecs.Cluster{
+					ClusterArn:        aws.String(clusterARN),
+					CapacityProviders: aws.StringSlice([]string{"FARGATE"}),
+				},
+			},
+			nil,
+		),
+		mockECSAPI.EXPECT().PutClusterCapacityProviders(
+			&awsecs.PutClusterCapacityProvidersInput{
+				CapacityProviders:               aws.StringSlice([]string{"FARGATE", "FARGATE_SPOT"}),
+				Cluster:                         aws.String("fargate"),
+				DefaultCapacityProviderStrategy: []*awsecs.CapacityProviderStrategyItem{},
+			},
+		).Return(&awsecs.PutClusterCapacityProvidersOutput{}, nil),
+	)
+
+	arn, err := ecs.CreateCluster()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if arn != clusterARN {
+		t.Errorf("expected %s, got %s", clusterARN, arn)
+	}
+}
+
+func TestCreateClusterWithCapacityProviders(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().CreateCluster(gomock.Any()).Return(
+		&awsecs.CreateClusterOutput{
+			Cluster: &awsecs.Cluster{
+				ClusterArn:        aws.String("arn:aws:ecs:us-east-1:123456789012:cluster/fargate"),
+				CapacityProviders: aws.StringSlice([]string{"FARGATE_SPOT", "FARGATE"}),
+			},
+		},
+		nil,
+	)
+
+	if _, err := ecs.CreateCluster(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
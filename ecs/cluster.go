@@ -5,9 +5,14 @@ import (
 	awsecs "github.com/aws/aws-sdk-go/service/ecs"
 )
 
+var fargateCapacityProviders = []string{CapacityProviderFargate, CapacityProviderFargateSpot}
+
+// CreateCluster creates the cluster if it does not exist and attaches the Fargate capacity providers
+// to it if they are not already attached, returning the cluster's ARN.
 func (ecs *ECS) CreateCluster() (string, error) {
 	input := &awsecs.CreateClusterInput{
-		ClusterName: aws.String(ecs.ClusterName),
+		CapacityProviders: aws.StringSlice(fargateCapacityProviders),
+		ClusterName:       aws.String(ecs.ClusterName),
 	}
 
 	resp, err := ecs.svc.CreateCluster(input)
@@ -15,5 +20,41 @@ func (ecs *ECS) CreateCluster() (string, error) {
 		return "", err
 	}
 
+	attached := make(map[string]bool)
+	capacityProviders := resp.Cluster.CapacityProviders
+
+	for _, capacityProvider := range capacityProviders {
+		attached[aws.StringValue(capacityProvider)] = true
+	}
+
+	var missing bool
+
+	for _, capacityProvider := range fargateCapacityProviders {
+		if !attached[capacityProvider] {
+			capacityProviders = append(capacityProviders, aws.String(capacityProvider))
+			missing = true
+		}
+	}
+
+	if missing {
+		defaultStrategy := resp.Cluster.DefaultCapacityProviderStrategy
+
+		if defaultStrategy == nil {
+			defaultStrategy = []*awsecs.CapacityProviderStrategyItem{}
+		}
+
+		_, err := ecs.svc.PutClusterCapacityProviders(
+			&awsecs.PutClusterCapacityProvidersInput{
+				CapacityProviders:               capacityProviders,
+				Cluster:                         aws.String(ecs.ClusterName),
+				DefaultCapacityProviderStrategy: defaultStrategy,
+			},
+		)
+
+		if err != nil {
+			return "", err
+		}
+	}
+
 	return aws.StringValue(resp.Cluster.ClusterArn), nil
 }
@@ -0,0 +1,126 @@
+package ecs
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+)
+
+const (
+	// TagCommitAuthor is the task definition tag of the author of the git commit its image was built
+	// from.
+	TagCommitAuthor = "fargatecli:git:author"
+
+	// TagCommitBranch is the task definition tag of the git branch its image was built from.
+	TagCommitBranch = "fargatecli:git:branch"
+
+	// TagCommitMessage is the task definition tag of the subject line of the message of the git
+	// commit its image was built from.
+	TagCommitMessage = "fargatecli:git:message"
+
+	// TagCommitSHA is the task definition tag of the SHA of the git commit its image was built from.
+	TagCommitSHA = "fargatecli:git:sha"
+
+	maxTagValueLength = 256
+	shortSHALength    = 7
+)
+
+// tagValueInvalidCharacters matches the characters which are not allowed in the values of tags.
+var tagValueInvalidCharacters = regexp.MustCompile(`[^\p{L}\p{Z}\p{N}_.:/=+\-@]+`)
+
+// Commit is the git commit the image of a task definition was built from, recorded in the task
+// definition's tags. Characters which are not allowed in tags are removed from the author and
+// message, and only the subject line of the message is kept.
+type Commit struct {
+	Author  string `json:"author,omitempty"`
+	Branch  string `json:"branch,omitempty"`
+	Message string `json:"message,omitempty"`
+	SHA     string `json:"sha"`
+}
+
+// ShortSHA returns the abbreviated SHA of the commit.
+func (c Commit) ShortSHA() string {
+	if len(c.SHA) > shortSHALength {
+		return c.SHA[:shortSHALength]
+	}
+
+	return c.SHA
+}
+
+// String returns the abbreviated SHA and subject line of the commit.
+func (c Commit) String() string {
+	if c.Message == "" {
+		return c.ShortSHA()
+	}
+
+	return c.ShortSHA() + " " + c.Message
+}
+
+// newCommit returns the commit recorded in a task definition's tags, or nil if none is recorded.
+func newCommit(tags []*awsecs.Tag) *Commit {
+	var commit Commit
+
+	for _, tag := range tags {
+		switch aws.StringValue(tag.Key) {
+		case TagCommitAuthor:
+			commit.Author = aws.StringValue(tag.Value)
+		case TagCommitBranch:
+			commit.Branch = aws.StringValue(tag.Value)
+		case TagCommitMessage:
+			commit.Message = aws.StringValue(tag.Value)
+		case TagCommitSHA:
+			commit.SHA = aws.StringValue(tag.Value)
+		}
+	}
+
+	if commit.SHA == "" {
+		return nil
+	}
+
+	return &commit
+}
+
+// commitTags returns a task definition's tags recording the given commit in place of any commit
+// they recorded before. If commit is nil, the tags no longer record a commit.
+func commitTags(tags []*awsecs.Tag, commit *Commit) []*awsecs.Tag {
+	var updated []*awsecs.Tag
+
+	for _, tag := range tags {
+		if !strings.HasPrefix(aws.StringValue(tag.Key), "fargatecli:git:") {
+			updated = append(updated, tag)
+		}
+	}
+
+	if commit == nil {
+		return updated
+	}
+
+	subject := strings.SplitN(commit.Message, "\n", 2)[0]
+
+	for _, tag := range []struct{ key, value string }{
+		{TagCommitSHA, commit.SHA},
+		{TagCommitAuthor, commit.Author},
+		{TagCommitBranch, commit.Branch},
+		{TagCommitMessage, subject},
+	} {
+		if value := tagValue(tag.value); value != "" {
+			updated = append(updated, &awsecs.Tag{Key: aws.String(tag.key), Value: aws.String(value)})
+		}
+	}
+
+	return updated
+}
+
+// tagValue returns a string with the characters which are not allowed in the values of tags removed,
+// truncated to the maximum length of a tag value.
+func tagValue(s string) string {
+	value := []rune(strings.TrimSpace(tagValueInvalidCharacters.ReplaceAllString(s, "")))
+
+	if len(value) > maxTagValueLength {
+		value = value[:maxTagValueLength]
+	}
+
+	return strings.TrimSpace(string(value))
+}
@@ -0,0 +1,66 @@
+package ecs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+)
+
+func TestNewCommit(t *testing.T) {
+	if commit := newCommit([]*awsecs.Tag{&awsecs.Tag{Key: aws.String("team"), Value: aws.String("web")}}); commit != nil {
+		t.Errorf("expected no commit, got %+v", commit)
+	}
+
+	commit := newCommit(
+		[]*awsecs.Tag{
+			&awsecs.Tag{Key: aws.String(TagCommitSHA), Value: aws.String("0123456789abcdef0123456789abcdef01234567")},
+			&awsecs.Tag{Key: aws.String(TagCommitMessage), Value: aws.String("Add labels")},
+		},
+	)
+
+	if commit == nil {
+		t.Fatalf("expected commit, got none")
+	}
+
+	if expected := "0123456 Add labels"; commit.String() != expected {
+		t.Errorf("expected %s, got %s", expected, commit.String())
+	}
+}
+
+func TestCommitTagsRemovesCommit(t *testing.T) {
+	tags := []*awsecs.Tag{
+		&awsecs.Tag{Key: aws.String("team"), Value: aws.String("web")},
+		&awsecs.Tag{Key: aws.String(TagCommitSHA), Value: aws.String("0123456789abcdef0123456789abcdef01234567")},
+	}
+
+	updated := commitTags(tags, nil)
+
+	if len(updated) != 1 || aws.StringValue(updated[0].Key) != "team" {
+		t.Errorf("expected only the team tag, got %v", updated)
+	}
+
+	if updated := commitTags(nil, nil); updated != nil {
+		t.Errorf("expected no tags, got %v", updated)
+	}
+}
+
+func TestTagValue(t *testing.T) {
+	var tests = []struct {
+		value    string
+		expected string
+	}{
+		{"Jane Doe <jane@example.com>", "Jane Doe jane@example.com"},
+		{"Fix login (#123)!", "Fix login 123"},
+		{"feature/labels", "feature/labels"},
+		{"Ünïcödé tëxt", "Ünïcödé tëxt"},
+		{strings.Repeat("é", 300), strings.Repeat("é", 256)},
+	}
+
+	for _, test := range tests {
+		if got := tagValue(test.value); got != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.value, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package ecs
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/awslabs/fargatecli/console"
+)
+
+// Container is a container defined in a task definition. The first container of a task definition
+// created by fargate, named for the service or task group, runs the task's image; any further
+// containers are sidecars.
+type Container struct {
+	DependsOn   []ContainerDependency `json:"dependsOn,omitempty"`
+	EnvVars     []EnvVar              `json:"envVars"`
+	Essential   bool                  `json:"essential"`
+	HealthCheck *HealthCheck          `json:"healthCheck,omitempty"`
+	Image       string                `json:"image"`
+	MountPoints []MountPoint          `json:"mountPoints,omitempty"`
+	Name        string                `json:"name"`
+	Port        int64                 `json:"port,omitempty"`
+	Secrets     []Secret              `json:"secrets"`
+}
+
+// ContainerDependency is a condition of another container which must be met before a container
+// starts.
+type ContainerDependency struct {
+	Condition string `json:"condition"`
+	Container string `json:"container"`
+}
+
+// String returns the dependency in the form container:CONDITION.
+func (d ContainerDependency) String() string {
+	return fmt.Sprintf("%s:%s", d.Container, d.Condition)
+}
+
+// definition returns the container definition of a sidecar container which logs to the given log
+// configuration.
+func (c Container) definition(logConfiguration *awsecs.LogConfiguration) *awsecs.ContainerDefinition {
+	var dependsOn []*awsecs.ContainerDependency
+
+	for _, dependency := range c.DependsOn {
+		dependsOn = append(dependsOn,
+			&awsecs.ContainerDependency{
+				Condition:     aws.String(dependency.Condition),
+				ContainerName: aws.String(dependency.Container),
+			},
+		)
+	}
+
+	input := &CreateTaskDefinitionInput{EnvVars: c.EnvVars, Secrets: c.Secrets}
+	containerDefinition := &awsecs.ContainerDefinition{
+		DependsOn:        dependsOn,
+		Environment:      input.Environment(),
+		Essential:        aws.Bool(c.Essential),
+		HealthCheck:      containerHealthCheck(nil, c.HealthCheck),
+		Image:            aws.String(c.Image),
+		LogConfiguration: logConfiguration,
+		Name:             aws.String(c.Name),
+		Secrets:          input.ContainerSecrets(),
+	}
+
+	if c.Port != 0 {
+		containerDefinition.SetPortMappings(
+			[]*awsecs.PortMapping{
+				&awsecs.PortMapping{
+					ContainerPort: aws.Int64(c.Port),
+				},
+			},
+		)
+	}
+
+	return containerDefinition
+}
+
+func newContainers(containerDefinitions []*awsecs.ContainerDefinition) []Container {
+	var containers []Container
+
+	for _, containerDefinition := range containerDefinitions {
+		container := Container{
+			Essential:   aws.BoolValue(containerDefinition.Essential),
+			HealthCheck: newHealthCheck(containerDefinition.HealthCheck),
+			Image:       aws.StringValue(containerDefinition.Image),
+			MountPoints: newMountPoints(containerDefinition.MountPoints),
+			Name:        aws.StringValue(containerDefinition.Name),
+			Secrets:     newSecrets(containerDefinition.Secrets),
+		}
+
+		for _, keyValuePair := range containerDefinition.Environment {
+			container.EnvVars = append(container.EnvVars,
+				EnvVar{
+					Key:   aws.StringValue(keyValuePair.Name),
+					Value: aws.StringValue(keyValuePair.Value),
+				},
+			)
+		}
+
+		for _, dependency := range containerDefinition.DependsOn {
+			container.DependsOn = append(container.DependsOn,
+				ContainerDependency{
+					Condition: aws.StringValue(dependency.Condition),
+					Container: aws.StringValue(dependency.ContainerName),
+				},
+			)
+		}
+
+		if len(containerDefinition.PortMappings) > 0 {
+			container.Port = aws.Int64Value(containerDefinition.PortMappings[0].ContainerPort)
+		}
+
+		containers = append(containers, container)
+	}
+
+	return containers
+}
+
+// FindContainer returns the named container, or the first container if no name is given.
+func FindContainer(containers []Container, name string) (Container, bool) {
+	for i, container := range containers {
+		if (name == "" && i == 0) || container.Name == name {
+			return container, true
+		}
+	}
+
+	return Container{}, false
+}
+
+// containerDefinition returns the definition of the named container in a task definition, or of the
+// first container if no name is given.
+func containerDefinition(taskDefinition *awsecs.TaskDefinition, name string) *awsecs.ContainerDefinition {
+	for i, containerDefinition := range taskDefinition.ContainerDefinitions {
+		if (name == "" && i == 0) || aws.StringValue(containerDefinition.Name) == name {
+			return containerDefinition
+		}
+	}
+
+	console.ErrorExit(
+		fmt.Errorf("container %s not found", name),
+		"Could not find container in task definition %s", aws.StringValue(taskDefinition.Family),
+	)
+
+	return nil
+}
+
+// mainContainerDefinition returns the definition of the container running a service or task group's
+// own image, which fargate names for the service or task group. The first container is returned for
+// task definitions without a container of that name, and nil for those without containers.
+func mainContainerDefinition(taskDefinition *awsecs.TaskDefinition, name string) *awsecs.ContainerDefinition {
+	if len(taskDefinition.ContainerDefinitions) == 0 {
+		return nil
+	}
+
+	for _, containerDefinition := range taskDefinition.ContainerDefinitions {
+		if aws.StringValue(containerDefinition.Name) == name {
+			return containerDefinition
+		}
+	}
+
+	return containerDefinition(taskDefinition, "")
+}
+
+// selectContainerDefinition returns the definition of the named container in a task definition, or
+// nil if it has none of that name. The main container is returned if no name is given.
+func selectContainerDefinition(taskDefinition *awsecs.TaskDefinition, mainName, name string) *awsecs.ContainerDefinition {
+	if name == "" {
+		return mainContainerDefinition(taskDefinition, mainName)
+	}
+
+	for _, containerDefinition := range taskDefinition.ContainerDefinitions {
+		if aws.StringValue(containerDefinition.Name) == name {
+			return containerDefinition
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+)
+
+func TestContainerDefinition(t *testing.T) {
+	logConfiguration := &awsecs.LogConfiguration{LogDriver: aws.String(awsecs.LogDriverAwslogs)}
+	sidecar := Container{
+		DependsOn: []ContainerDependency{ContainerDependency{Condition: "HEALTHY", Container: "web"}},
+		EnvVars:   []EnvVar{EnvVar{Key: "LOG_LEVEL", Value: "info"}},
+		Essential: false,
+		Image:     "envoyproxy/envoy",
+		Name:      "proxy",
+		Port:      9901,
+	}
+
+	definition := sidecar.definition(logConfiguration)
+
+	if definition.LogConfiguration != logConfiguration {
+		t.Errorf("expected sidecar to use the task's log configuration")
+	}
+
+	if expected, got := []Container{sidecar}, newContainers([]*awsecs.ContainerDefinition{definition}); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestContainerDefinitionWithoutPort(t *testing.T) {
+	definition := Container{Essential: true, Image: "busybox", Name: "init"}.definition(nil)
+
+	if len(definition.PortMappings) != 0 {
+		t.Errorf("expected no port mappings, got %v", definition.PortMappings)
+	}
+
+	if !aws.BoolValue(definition.Essential) {
+		t.Errorf("expected container to be essential")
+	}
+}
+
+func TestFindContainer(t *testing.T) {
+	containers := []Container{Container{Name: "web"}, Container{Name: "proxy"}}
+
+	var tests = []struct {
+		name     string
+		expected string
+		ok       bool
+	}{
+		{"", "web", true},
+		{"web", "web", true},
+		{"proxy", "proxy", true},
+		{"missing", "", false},
+	}
+
+	for _, test := range tests {
+		container, ok := FindContainer(containers, test.name)
+
+		if ok != test.ok {
+			t.Errorf("expected found %t for %q, got %t", test.ok, test.name, ok)
+		}
+
+		if container.Name != test.expected {
+			t.Errorf("expected container %q for %q, got %q", test.expected, test.name, container.Name)
+		}
+	}
+
+	if _, ok := FindContainer(nil, ""); ok {
+		t.Errorf("expected no container in an empty task definition")
+	}
+}
+
+func TestMainContainerDefinition(t *testing.T) {
+	taskDefinition := &awsecs.TaskDefinition{
+		ContainerDefinitions: []*awsecs.ContainerDefinition{
+			&awsecs.ContainerDefinition{Name: aws.String("proxy")},
+			&awsecs.ContainerDefinition{Name: aws.String("web")},
+		},
+	}
+
+	var tests = []struct {
+		name     string
+		expected string
+	}{
+		{"web", "web"},
+		{"proxy", "proxy"},
+		{"missing", "proxy"},
+	}
+
+	for _, test := range tests {
+		if got := aws.StringValue(mainContainerDefinition(taskDefinition, test.name).Name); got != test.expected {
+			t.Errorf("expected container %q for %q, got %q", test.expected, test.name, got)
+		}
+	}
+
+	if mainContainerDefinition(&awsecs.TaskDefinition{}, "web") != nil {
+		t.Errorf("expected no container in an empty task definition")
+	}
+}
+
+func TestTaskContainerName(t *testing.T) {
+	var tests = []struct {
+		task     *awsecs.Task
+		expected string
+	}{
+		{&awsecs.Task{StartedBy: aws.String("fargate:worker"), Group: aws.String("family:fargate_task_worker")}, "worker"},
+		{&awsecs.Task{StartedBy: aws.String("ecs-svc/1234567890123456789"), Group: aws.String("service:web")}, "web"},
+	}
+
+	for _, test := range tests {
+		if got := taskContainerName(test.task); got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
+
+func TestSelectContainerDefinition(t *testing.T) {
+	taskDefinition := &awsecs.TaskDefinition{
+		ContainerDefinitions: []*awsecs.ContainerDefinition{
+			&awsecs.ContainerDefinition{Name: aws.String("proxy")},
+			&awsecs.ContainerDefinition{Name: aws.String("web")},
+		},
+	}
+
+	if got := aws.StringValue(selectContainerDefinition(taskDefinition, "web", "").Name); got != "web" {
+		t.Errorf("expected the main container web, got %q", got)
+	}
+
+	if got := aws.StringValue(selectContainerDefinition(taskDefinition, "web", "proxy").Name); got != "proxy" {
+		t.Errorf("expected the selected container proxy, got %q", got)
+	}
+
+	if selectContainerDefinition(taskDefinition, "web", "missing") != nil {
+		t.Errorf("expected no container for an unknown name")
+	}
+}
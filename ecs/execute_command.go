@@ -0,0 +1,43 @@
+package ecs
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+)
+
+// ExecuteCommandSession is a Session Manager session opened by ECS Exec to run a command in a
+// container. The session's data channel is opened by connecting to StreamURL with TokenValue.
+type ExecuteCommandSession struct {
+	SessionID  string `json:"sessionId"`
+	StreamURL  string `json:"streamUrl"`
+	TokenValue string `json:"tokenValue"`
+}
+
+// ExecuteCommand opens an interactive ECS Exec session running a command in a container of a task.
+func (ecs *ECS) ExecuteCommand(taskID, container, command string) (ExecuteCommandSession, error) {
+	resp, err := ecs.svc.ExecuteCommand(
+		&awsecs.ExecuteCommandInput{
+			Cluster:     aws.String(ecs.ClusterName),
+			Command:     aws.String(command),
+			Container:   aws.String(container),
+			Interactive: aws.Bool(true),
+			Task:        aws.String(taskID),
+		},
+	)
+
+	if err != nil {
+		return ExecuteCommandSession{}, err
+	}
+
+	if resp.Session == nil {
+		return ExecuteCommandSession{}, errors.New("ECS did not return a session")
+	}
+
+	return ExecuteCommandSession{
+		SessionID:  aws.StringValue(resp.Session.SessionId),
+		StreamURL:  aws.StringValue(resp.Session.StreamUrl),
+		TokenValue: aws.StringValue(resp.Session.TokenValue),
+	}, nil
+}
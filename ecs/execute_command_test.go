@@ -0,0 +1,101 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/awslabs/fargatecli/ecs/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+func TestExecuteCommand(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().ExecuteCommand(
+		&awsecs.ExecuteCommandInput{
+			Cluster:     aws.String("fargate"),
+			Command:     aws.String("/bin/sh"),
+			Container:   aws.String("web"),
+			Interactive: aws.Bool(true),
+			Task:        aws.String("1b8ae0f6"),
+		},
+	).Return(
+		&awsecs.ExecuteCommandOutput{
+			Session: &awsecs.Session{
+				SessionId:  aws.String("ecs-execute-command-0123"),
+				StreamUrl:  aws.String("wss://ssmmessages.us-east-1.amazonaws.com/v1/data-channel/ecs-execute-command-0123"),
+				TokenValue: aws.String("token"),
+			},
+		},
+		nil,
+	)
+
+	session, err := ecs.ExecuteCommand("1b8ae0f6", "web", "/bin/sh")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := ExecuteCommandSession{
+		SessionID:  "ecs-execute-command-0123",
+		StreamURL:  "wss://ssmmessages.us-east-1.amazonaws.com/v1/data-channel/ecs-execute-command-0123",
+		TokenValue: "token",
+	}
+
+	if session != expected {
+		t.Errorf("expected %+v, got %+v", expected, session)
+	}
+}
+
+func TestExecuteCommandWithoutSession(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().ExecuteCommand(gomock.Any()).Return(&awsecs.ExecuteCommandOutput{}, nil)
+
+	if _, err := ecs.ExecuteCommand("1b8ae0f6", "web", "/bin/sh"); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+func TestRunTaskEnablesExecuteCommand(t *testing.T) {
+	for _, enableExecuteCommand := range []bool{true, false} {
+		mockCtrl := gomock.NewController(t)
+		mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+		ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+		mockECSAPI.EXPECT().RunTask(gomock.Any()).DoAndReturn(
+			func(input *awsecs.RunTaskInput) (*awsecs.RunTaskOutput, error) {
+				if got := aws.BoolValue(input.EnableExecuteCommand); got != enableExecuteCommand {
+					t.Errorf("expected RunTask to set execute command enabled to %t, got %t", enableExecuteCommand, got)
+				}
+
+				if expected, got := int64(1), aws.Int64Value(input.Count); expected != got {
+					t.Errorf("expected count %d, got %d", expected, got)
+				}
+
+				return &awsecs.RunTaskOutput{}, nil
+			},
+		)
+
+		ecs.RunTask(
+			&RunTaskInput{
+				ClusterName:          "fargate",
+				Count:                1,
+				EnableExecuteCommand: enableExecuteCommand,
+				TaskDefinitionArn:    "arn:aws:ecs:us-east-1:123456789012:task-definition/task_web:1",
+				TaskName:             "web",
+			},
+		)
+
+		mockCtrl.Finish()
+	}
+}
@@ -1,15 +1,34 @@
 package ecs
 
+//go:generate mockgen -package client -destination=mock/client/client.go github.com/awslabs/fargatecli/ecs Client
+//go:generate mockgen -package sdk -source ../vendor/github.com/aws/aws-sdk-go/service/ecs/ecsiface/interface.go -destination=mock/sdk/ecsiface.go github.com/aws/aws-sdk-go/service/ecs/ecsiface ECSAPI
+
 import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
 )
 
+// Client represents a method for accessing Amazon ECS.
+type Client interface {
+	CreateShadowService(string, string, string, string) error
+	DeleteService(string) error
+	DeployTaskDefinition(string, string) error
+	DescribeServiceDeployments(string) (Service, error)
+	DescribeTaskStatuses([]string) ([]Task, error)
+	ListActiveTaskDefinitionImages() ([]string, error)
+	ListStoppedServiceTasks(string) ([]Task, error)
+	ListTaskDefinitionRevisions(string) ([]string, error)
+	StopTasksWithReason([]string, string) error
+}
+
+// ECS implements access to Amazon ECS via the AWS SDK.
 type ECS struct {
-	svc         *ecs.ECS
+	svc         ecsiface.ECSAPI
 	ClusterName string
 }
 
+// New returns an ECS client configured with the given session and cluster name.
 func New(sess *session.Session, clusterName string) ECS {
 	return ECS{
 		ClusterName: clusterName,
@@ -0,0 +1,165 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/awslabs/fargatecli/ecs (interfaces: Client)
+
+// Package client is a generated GoMock package.
+package client
+
+import (
+	ecs "github.com/awslabs/fargatecli/ecs"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockClient is a mock of Client interface
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// CreateShadowService mocks base method
+func (m *MockClient) CreateShadowService(arg0, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShadowService", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShadowService indicates an expected call of CreateShadowService
+func (mr *MockClientMockRecorder) CreateShadowService(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShadowService", reflect.TypeOf((*MockClient)(nil).CreateShadowService), arg0, arg1, arg2, arg3)
+}
+
+// DeleteService mocks base method
+func (m *MockClient) DeleteService(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteService", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteService indicates an expected call of DeleteService
+func (mr *MockClientMockRecorder) DeleteService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockClient)(nil).DeleteService), arg0)
+}
+
+// DeployTaskDefinition mocks base method
+func (m *MockClient) DeployTaskDefinition(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeployTaskDefinition", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeployTaskDefinition indicates an expected call of DeployTaskDefinition
+func (mr *MockClientMockRecorder) DeployTaskDefinition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployTaskDefinition", reflect.TypeOf((*MockClient)(nil).DeployTaskDefinition), arg0, arg1)
+}
+
+// DescribeServiceDeployments mocks base method
+func (m *MockClient) DescribeServiceDeployments(arg0 string) (ecs.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeServiceDeployments", arg0)
+	ret0, _ := ret[0].(ecs.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeServiceDeployments indicates an expected call of DescribeServiceDeployments
+func (mr *MockClientMockRecorder) DescribeServiceDeployments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeServiceDeployments", reflect.TypeOf((*MockClient)(nil).DescribeServiceDeployments), arg0)
+}
+
+// DescribeTaskStatuses mocks base method
+func (m *MockClient) DescribeTaskStatuses(arg0 []string) ([]ecs.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTaskStatuses", arg0)
+	ret0, _ := ret[0].([]ecs.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTaskStatuses indicates an expected call of DescribeTaskStatuses
+func (mr *MockClientMockRecorder) DescribeTaskStatuses(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTaskStatuses", reflect.TypeOf((*MockClient)(nil).DescribeTaskStatuses), arg0)
+}
+
+// ListActiveTaskDefinitionImages mocks base method
+func (m *MockClient) ListActiveTaskDefinitionImages() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveTaskDefinitionImages")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveTaskDefinitionImages indicates an expected call of ListActiveTaskDefinitionImages
+func (mr *MockClientMockRecorder) ListActiveTaskDefinitionImages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveTaskDefinitionImages", reflect.TypeOf((*MockClient)(nil).ListActiveTaskDefinitionImages))
+}
+
+// ListStoppedServiceTasks mocks base method
+func (m *MockClient) ListStoppedServiceTasks(arg0 string) ([]ecs.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStoppedServiceTasks", arg0)
+	ret0, _ := ret[0].([]ecs.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStoppedServiceTasks indicates an expected call of ListStoppedServiceTasks
+func (mr *MockClientMockRecorder) ListStoppedServiceTasks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStoppedServiceTasks", reflect.TypeOf((*MockClient)(nil).ListStoppedServiceTasks), arg0)
+}
+
+// ListTaskDefinitionRevisions mocks base method
+func (m *MockClient) ListTaskDefinitionRevisions(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskDefinitionRevisions", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskDefinitionRevisions indicates an expected call of ListTaskDefinitionRevisions
+func (mr *MockClientMockRecorder) ListTaskDefinitionRevisions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskDefinitionRevisions", reflect.TypeOf((*MockClient)(nil).ListTaskDefinitionRevisions), arg0)
+}
+
+// StopTasksWithReason mocks base method
+func (m *MockClient) StopTasksWithReason(arg0 []string, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTasksWithReason", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopTasksWithReason indicates an expected call of StopTasksWithReason
+func (mr *MockClientMockRecorder) StopTasksWithReason(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTasksWithReason", reflect.TypeOf((*MockClient)(nil).StopTasksWithReason), arg0, arg1)
+}
@@ -0,0 +1,116 @@
+package ecs
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+)
+
+const (
+	CPUArchitectureARM64                       = awsecs.CPUArchitectureArm64
+	CPUArchitectureX86_64                      = awsecs.CPUArchitectureX8664
+	OperatingSystemFamilyLinux                 = awsecs.OSFamilyLinux
+	OperatingSystemFamilyWindowsServer2019Core = awsecs.OSFamilyWindowsServer2019Core
+	OperatingSystemFamilyWindowsServer2019Full = awsecs.OSFamilyWindowsServer2019Full
+	OperatingSystemFamilyWindowsServer2022Core = awsecs.OSFamilyWindowsServer2022Core
+	OperatingSystemFamilyWindowsServer2022Full = awsecs.OSFamilyWindowsServer2022Full
+
+	operatingSystemFamilyWindowsPrefix = "WINDOWS_"
+)
+
+// RuntimePlatform is the operating system family and CPU architecture a task runs on. Empty values
+// use the Fargate defaults of LINUX and X86_64.
+type RuntimePlatform struct {
+	CPUArchitecture       string `json:"cpuArchitecture,omitempty"`
+	OperatingSystemFamily string `json:"operatingSystemFamily,omitempty"`
+}
+
+// IsEmpty returns whether the runtime platform uses the Fargate defaults.
+func (p RuntimePlatform) IsEmpty() bool {
+	return p == RuntimePlatform{}
+}
+
+// IsWindows returns whether the runtime platform is a version of Windows Server.
+func (p RuntimePlatform) IsWindows() bool {
+	return strings.HasPrefix(p.OperatingSystemFamily, operatingSystemFamilyWindowsPrefix)
+}
+
+// Merge returns the runtime platform resulting from replacing values with the non-empty values of
+// another runtime platform.
+func (p RuntimePlatform) Merge(update RuntimePlatform) RuntimePlatform {
+	if update.CPUArchitecture != "" {
+		p.CPUArchitecture = update.CPUArchitecture
+	}
+
+	if update.OperatingSystemFamily != "" {
+		p.OperatingSystemFamily = update.OperatingSystemFamily
+	}
+
+	return p
+}
+
+func (p RuntimePlatform) sdk() *awsecs.RuntimePlatform {
+	if p.IsEmpty() {
+		return nil
+	}
+
+	runtimePlatform := &awsecs.RuntimePlatform{}
+
+	if p.CPUArchitecture != "" {
+		runtimePlatform.CpuArchitecture = aws.String(p.CPUArchitecture)
+	}
+
+	if p.OperatingSystemFamily != "" {
+		runtimePlatform.OperatingSystemFamily = aws.String(p.OperatingSystemFamily)
+	}
+
+	return runtimePlatform
+}
+
+func newRuntimePlatform(runtimePlatform *awsecs.RuntimePlatform) RuntimePlatform {
+	if runtimePlatform == nil {
+		return RuntimePlatform{}
+	}
+
+	return RuntimePlatform{
+		CPUArchitecture:       aws.StringValue(runtimePlatform.CpuArchitecture),
+		OperatingSystemFamily: aws.StringValue(runtimePlatform.OperatingSystemFamily),
+	}
+}
+
+// ephemeralStorageDefinition returns the ephemeral storage of a task definition, or nil to use the
+// Fargate default.
+func ephemeralStorageDefinition(sizeInGiB int64) *awsecs.EphemeralStorage {
+	if sizeInGiB == 0 {
+		return nil
+	}
+
+	return &awsecs.EphemeralStorage{SizeInGiB: aws.Int64(sizeInGiB)}
+}
+
+// updateRuntimeParameters applies the non-zero ephemeral storage and runtime platform values of an
+// update to a task definition.
+func updateRuntimeParameters(taskDefinition *awsecs.TaskDefinition, ephemeralStorageGiB int64, runtimePlatform RuntimePlatform) {
+	if ephemeralStorageGiB != 0 {
+		taskDefinition.EphemeralStorage = ephemeralStorageDefinition(ephemeralStorageGiB)
+	}
+
+	if !runtimePlatform.IsEmpty() {
+		taskDefinition.RuntimePlatform = newRuntimePlatform(taskDefinition.RuntimePlatform).Merge(runtimePlatform).sdk()
+	}
+}
+
+// GetEphemeralStorageAndRuntimePlatformFromTaskDefinition returns the ephemeral storage in GiB and
+// the runtime platform of a task definition. Zero values mean the Fargate defaults are used.
+func (ecs *ECS) GetEphemeralStorageAndRuntimePlatformFromTaskDefinition(taskDefinitionArn string) (int64, RuntimePlatform) {
+	taskDefinition := ecs.DescribeTaskDefinition(taskDefinitionArn)
+
+	var ephemeralStorage int64
+
+	if taskDefinition.EphemeralStorage != nil {
+		ephemeralStorage = aws.Int64Value(taskDefinition.EphemeralStorage.SizeInGiB)
+	}
+
+	return ephemeralStorage, newRuntimePlatform(taskDefinition.RuntimePlatform)
+}
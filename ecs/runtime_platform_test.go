@@ -0,0 +1,146 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/awslabs/fargatecli/ecs/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCreateTaskDefinitionRuntimePlatform(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().RegisterTaskDefinition(gomock.Any()).DoAndReturn(
+		func(input *awsecs.RegisterTaskDefinitionInput) (*awsecs.RegisterTaskDefinitionOutput, error) {
+			if input.EphemeralStorage == nil || aws.Int64Value(input.EphemeralStorage.SizeInGiB) != 50 {
+				t.Errorf("expected ephemeral storage of 50 GiB, got %v", input.EphemeralStorage)
+			}
+
+			if expected, got := (RuntimePlatform{CPUArchitecture: "ARM64", OperatingSystemFamily: "LINUX"}), newRuntimePlatform(input.RuntimePlatform); expected != got {
+				t.Errorf("expected runtime platform %+v, got %+v", expected, got)
+			}
+
+			return &awsecs.RegisterTaskDefinitionOutput{
+				TaskDefinition: &awsecs.TaskDefinition{
+					TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/task_arm:1"),
+				},
+			}, nil
+		},
+	)
+
+	ecs.CreateTaskDefinition(
+		&CreateTaskDefinitionInput{
+			Cpu:              "256",
+			EphemeralStorage: 50,
+			Image:            "nginx:latest",
+			Memory:           "512",
+			Name:             "arm",
+			RuntimePlatform:  RuntimePlatform{CPUArchitecture: CPUArchitectureARM64, OperatingSystemFamily: OperatingSystemFamilyLinux},
+			Type:             "task",
+		},
+	)
+}
+
+func TestCreateTaskDefinitionWithoutRuntimePlatform(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().RegisterTaskDefinition(gomock.Any()).DoAndReturn(
+		func(input *awsecs.RegisterTaskDefinitionInput) (*awsecs.RegisterTaskDefinitionOutput, error) {
+			if input.EphemeralStorage != nil || input.RuntimePlatform != nil {
+				t.Errorf("expected no ephemeral storage or runtime platform, got %v and %v", input.EphemeralStorage, input.RuntimePlatform)
+			}
+
+			return &awsecs.RegisterTaskDefinitionOutput{
+				TaskDefinition: &awsecs.TaskDefinition{
+					TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/task_web:1"),
+				},
+			}, nil
+		},
+	)
+
+	ecs.CreateTaskDefinition(&CreateTaskDefinitionInput{Cpu: "256", Image: "nginx:latest", Memory: "512", Name: "web", Type: "task"})
+}
+
+func TestUpdateTaskDefinitionRuntimePlatform(t *testing.T) {
+	taskDefinitionARN := "arn:aws:ecs:us-east-1:123456789012:task-definition/service_platform:1"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().DescribeTaskDefinition(gomock.Any()).Return(
+		&awsecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &awsecs.TaskDefinition{
+				ContainerDefinitions: []*awsecs.ContainerDefinition{&awsecs.ContainerDefinition{}},
+				EphemeralStorage:     &awsecs.EphemeralStorage{SizeInGiB: aws.Int64(50)},
+				Family:               aws.String("service_platform"),
+				RuntimePlatform: &awsecs.RuntimePlatform{
+					CpuArchitecture:       aws.String(CPUArchitectureARM64),
+					OperatingSystemFamily: aws.String(OperatingSystemFamilyLinux),
+				},
+				TaskDefinitionArn: aws.String(taskDefinitionARN),
+			},
+		},
+		nil,
+	)
+	mockECSAPI.EXPECT().RegisterTaskDefinition(gomock.Any()).DoAndReturn(
+		func(input *awsecs.RegisterTaskDefinitionInput) (*awsecs.RegisterTaskDefinitionOutput, error) {
+			if input.EphemeralStorage == nil || aws.Int64Value(input.EphemeralStorage.SizeInGiB) != 100 {
+				t.Errorf("expected ephemeral storage of 100 GiB, got %v", input.EphemeralStorage)
+			}
+
+			if expected, got := (RuntimePlatform{CPUArchitecture: "X86_64", OperatingSystemFamily: "LINUX"}), newRuntimePlatform(input.RuntimePlatform); expected != got {
+				t.Errorf("expected runtime platform %+v, got %+v", expected, got)
+			}
+
+			return &awsecs.RegisterTaskDefinitionOutput{
+				TaskDefinition: &awsecs.TaskDefinition{
+					TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/service_platform:2"),
+				},
+			}, nil
+		},
+	)
+
+	ephemeralStorage, runtimePlatform := ecs.GetEphemeralStorageAndRuntimePlatformFromTaskDefinition(taskDefinitionARN)
+
+	if ephemeralStorage != 50 {
+		t.Errorf("expected ephemeral storage 50, got %d", ephemeralStorage)
+	}
+
+	if expected := (RuntimePlatform{CPUArchitecture: "ARM64", OperatingSystemFamily: "LINUX"}); runtimePlatform != expected {
+		t.Errorf("expected runtime platform %+v, got %+v", expected, runtimePlatform)
+	}
+
+	ecs.UpdateTaskDefinition(
+		taskDefinitionARN,
+		TaskDefinitionUpdate{EphemeralStorage: 100, RuntimePlatform: RuntimePlatform{CPUArchitecture: CPUArchitectureX86_64}},
+	)
+}
+
+func TestRuntimePlatformSDK(t *testing.T) {
+	if got := (RuntimePlatform{}).sdk(); got != nil {
+		t.Errorf("expected no runtime platform, got %v", got)
+	}
+
+	runtimePlatform := RuntimePlatform{OperatingSystemFamily: OperatingSystemFamilyWindowsServer2022Core}
+
+	if got := newRuntimePlatform(runtimePlatform.sdk()); got != runtimePlatform {
+		t.Errorf("expected %+v, got %+v", runtimePlatform, got)
+	}
+
+	if got := runtimePlatform.sdk().CpuArchitecture; got != nil {
+		t.Errorf("expected no CPU architecture, got %s", aws.StringValue(got))
+	}
+}
@@ -10,51 +10,81 @@ import (
 	"github.com/awslabs/fargatecli/console"
 )
 
+const deploymentStatusPrimary = "PRIMARY"
+
 type CreateServiceInput struct {
-	Cluster               string
-	DesiredCount          int64
-	Name                  string
-	Port                  int64
-	SecurityGroupIds      []string
-	SubnetIds             []string
-	TargetGroupArn        string
-	TaskDefinitionArn     string
-	AssignPublicIpEnabled bool
+	CapacityProviderStrategy CapacityProviderStrategy
+	Cluster                  string
+	DesiredCount             int64
+	EnableExecuteCommand     bool
+	Name                     string
+	Port                     int64
+	SecurityGroupIds         []string
+	SubnetIds                []string
+	TargetGroupArn           string
+	TaskDefinitionArn        string
+	AssignPublicIpEnabled    bool
 }
 
 type Service struct {
-	Cluster           string
-	Cpu               string
-	Deployments       []Deployment
-	DesiredCount      int64
-	EnvVars           []EnvVar
-	Events            []Event
-	Image             string
-	Memory            string
-	Name              string
-	PendingCount      int64
-	RunningCount      int64
-	SecurityGroupIds  []string
-	TargetGroupArn    string
-	TaskDefinitionArn string
-	TaskRole          string
-	SubnetIds         []string
-	Status            string
+	CapacityProviderStrategy CapacityProviderStrategy `json:"capacityProviderStrategy,omitempty"`
+	Cluster                  string                   `json:"cluster"`
+	Containers               []Container              `json:"containers"`
+	Cpu                      string                   `json:"cpu"`
+	Deployments              []Deployment             `json:"deployments"`
+	DesiredCount             int64                    `json:"desiredCount"`
+	EnvVars                  []EnvVar                 `json:"envVars"`
+	EphemeralStorage         int64                    `json:"ephemeralStorage,omitempty"`
+	Events                   []Event                  `json:"events"`
+	HealthCheck              *HealthCheck             `json:"healthCheck,omitempty"`
+	Image                    string                   `json:"image"`
+	LaunchType               string                   `json:"launchType,omitempty"`
+	Memory                   string                   `json:"memory"`
+	Name                     string                   `json:"name"`
+	PendingCount             int64                    `json:"pendingCount"`
+	RequestedImage           string                   `json:"requestedImage,omitempty"`
+	RunningCount             int64                    `json:"runningCount"`
+	RuntimePlatform          RuntimePlatform          `json:"runtimePlatform"`
+	Secrets                  []Secret                 `json:"secrets"`
+	SecurityGroupIds         []string                 `json:"securityGroupIds"`
+	TargetGroupArn           string                   `json:"targetGroupArn"`
+	TaskDefinitionArn        string                   `json:"taskDefinitionArn"`
+	TaskRole                 string                   `json:"taskRole"`
+	SubnetIds                []string                 `json:"subnetIds"`
+	Status                   string                   `json:"status"`
+	Volumes                  []Volume                 `json:"volumes,omitempty"`
 }
 
 type Event struct {
-	CreatedAt time.Time
-	Message   string
+	CreatedAt time.Time `json:"createdAt"`
+	Message   string    `json:"message"`
 }
 
+// Deployment is a version of a service's task definition being run. If its image is pinned to a
+// digest, RequestedImage is the image, such as a tag, it was pinned from. Commit is the git commit
+// its image was built from, if known.
 type Deployment struct {
-	CreatedAt    time.Time
-	DesiredCount int64
-	Id           string
-	Image        string
-	PendingCount int64
-	RunningCount int64
-	Status       string
+	Commit            *Commit   `json:"commit,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
+	DesiredCount      int64     `json:"desiredCount"`
+	Digest            string    `json:"digest,omitempty"`
+	Id                string    `json:"id"`
+	Image             string    `json:"image"`
+	PendingCount      int64     `json:"pendingCount"`
+	RequestedImage    string    `json:"requestedImage,omitempty"`
+	RunningCount      int64     `json:"runningCount"`
+	Status            string    `json:"status"`
+	TaskDefinitionArn string    `json:"taskDefinitionArn"`
+}
+
+// IsPrimary returns true if the deployment is the most recent deployment of a service.
+func (d Deployment) IsPrimary() bool {
+	return d.Status == deploymentStatusPrimary
+}
+
+// IsComplete returns true if the deployment is running all of its desired tasks.
+func (d Deployment) IsComplete() bool {
+	return d.RunningCount == d.DesiredCount && d.PendingCount == 0
 }
 
 func (s *Service) AddEvent(e Event) {
@@ -74,11 +104,12 @@ func (ecs *ECS) CreateService(input *CreateServiceInput) {
 	}
 
 	createServiceInput := &awsecs.CreateServiceInput{
-		Cluster:        aws.String(input.Cluster),
-		DesiredCount:   aws.Int64(input.DesiredCount),
-		ServiceName:    aws.String(input.Name),
-		TaskDefinition: aws.String(input.TaskDefinitionArn),
-		LaunchType:     aws.String(awsecs.CompatibilityFargate),
+		Cluster:              aws.String(input.Cluster),
+		DesiredCount:         aws.Int64(input.DesiredCount),
+		EnableExecuteCommand: aws.Bool(input.EnableExecuteCommand),
+		ServiceName:          aws.String(input.Name),
+		TaskDefinition:       aws.String(input.TaskDefinitionArn),
+		LaunchType:           aws.String(awsecs.CompatibilityFargate),
 		NetworkConfiguration: &awsecs.NetworkConfiguration{
 			AwsvpcConfiguration: &awsecs.AwsVpcConfiguration{
 				AssignPublicIp: aws.String(assignPublicIP),
@@ -88,6 +119,11 @@ func (ecs *ECS) CreateService(input *CreateServiceInput) {
 		},
 	}
 
+	if len(input.CapacityProviderStrategy) > 0 {
+		createServiceInput.LaunchType = nil
+		createServiceInput.CapacityProviderStrategy = input.CapacityProviderStrategy.sdk()
+	}
+
 	if input.TargetGroupArn != "" && input.Port > 0 {
 		createServiceInput.SetLoadBalancers(
 			[]*awsecs.LoadBalancer{
@@ -159,8 +195,7 @@ func (ecs *ECS) ListServices() []Service {
 
 	err := ecs.svc.ListServicesPages(
 		&awsecs.ListServicesInput{
-			Cluster:    aws.String(ecs.ClusterName),
-			LaunchType: aws.String(awsecs.CompatibilityFargate),
+			Cluster: aws.String(ecs.ClusterName),
 		},
 
 		func(resp *awsecs.ListServicesOutput, lastPage bool) bool {
@@ -179,7 +214,9 @@ func (ecs *ECS) ListServices() []Service {
 	if len(serviceArnBatches) > 0 {
 		for _, serviceArnBatch := range serviceArnBatches {
 			for _, service := range ecs.DescribeServices(serviceArnBatch) {
-				services = append(services, service)
+				if service.LaunchType == awsecs.CompatibilityFargate || service.CapacityProviderStrategy.UsesFargate() {
+					services = append(services, service)
+				}
 			}
 		}
 	}
@@ -187,7 +224,72 @@ func (ecs *ECS) ListServices() []Service {
 	return services
 }
 
+// listServiceTaskDefinitionArns returns the task definitions run by the deployments of every service
+// in the cluster.
+func (ecs ECS) listServiceTaskDefinitionArns() ([]string, error) {
+	var (
+		serviceArnBatches  [][]string
+		taskDefinitionArns []string
+	)
+
+	err := ecs.svc.ListServicesPages(
+		&awsecs.ListServicesInput{
+			Cluster: aws.String(ecs.ClusterName),
+		},
+		func(resp *awsecs.ListServicesOutput, lastPage bool) bool {
+			if len(resp.ServiceArns) > 0 {
+				serviceArnBatches = append(serviceArnBatches, aws.StringValueSlice(resp.ServiceArns))
+			}
+
+			return true
+		},
+	)
+
+	if err != nil {
+		return taskDefinitionArns, err
+	}
+
+	for _, serviceArnBatch := range serviceArnBatches {
+		resp, err := ecs.svc.DescribeServices(
+			&awsecs.DescribeServicesInput{
+				Cluster:  aws.String(ecs.ClusterName),
+				Services: aws.StringSlice(serviceArnBatch),
+			},
+		)
+
+		if err != nil {
+			return taskDefinitionArns, err
+		}
+
+		for _, service := range resp.Services {
+			for _, deployment := range service.Deployments {
+				taskDefinitionArns = append(taskDefinitionArns, aws.StringValue(deployment.TaskDefinition))
+			}
+		}
+	}
+
+	return taskDefinitionArns, nil
+}
+
 func (ecs *ECS) DescribeServices(serviceArns []string) []Service {
+	return ecs.describeServices(serviceArns, "")
+}
+
+// DescribeServiceContainer describes a service in the same way as DescribeService, but with its image,
+// environment variables, secrets, health check, and deployment images taken from the named container
+// rather than the container named for the service. These are left empty if the service's task
+// definitions have no container of that name.
+func (ecs *ECS) DescribeServiceContainer(serviceName, containerName string) Service {
+	services := ecs.describeServices([]string{serviceName}, containerName)
+
+	if len(services) == 0 {
+		console.ErrorExit(fmt.Errorf("Could not find %s", serviceName), "Could not describe ECS service")
+	}
+
+	return services[0]
+}
+
+func (ecs *ECS) describeServices(serviceArns []string, containerName string) []Service {
 	var services []Service
 
 	resp, err := ecs.svc.DescribeServices(
@@ -210,14 +312,16 @@ func (ecs *ECS) DescribeServices(serviceArns []string) []Service {
 		}
 
 		s := Service{
-			DesiredCount:      aws.Int64Value(service.DesiredCount),
-			Name:              aws.StringValue(service.ServiceName),
-			PendingCount:      aws.Int64Value(service.PendingCount),
-			RunningCount:      aws.Int64Value(service.RunningCount),
-			SecurityGroupIds:  aws.StringValueSlice(securityGroupIds),
-			Status:            aws.StringValue(service.Status),
-			SubnetIds:         aws.StringValueSlice(subnetIds),
-			TaskDefinitionArn: aws.StringValue(service.TaskDefinition),
+			CapacityProviderStrategy: newCapacityProviderStrategy(service.CapacityProviderStrategy),
+			DesiredCount:             aws.Int64Value(service.DesiredCount),
+			LaunchType:               aws.StringValue(service.LaunchType),
+			Name:                     aws.StringValue(service.ServiceName),
+			PendingCount:             aws.Int64Value(service.PendingCount),
+			RunningCount:             aws.Int64Value(service.RunningCount),
+			SecurityGroupIds:         aws.StringValueSlice(securityGroupIds),
+			Status:                   aws.StringValue(service.Status),
+			SubnetIds:                aws.StringValueSlice(subnetIds),
+			TaskDefinitionArn:        aws.StringValue(service.TaskDefinition),
 		}
 
 		taskDefinition := ecs.DescribeTaskDefinition(aws.StringValue(service.TaskDefinition))
@@ -225,15 +329,20 @@ func (ecs *ECS) DescribeServices(serviceArns []string) []Service {
 		s.Cpu = aws.StringValue(taskDefinition.Cpu)
 		s.Memory = aws.StringValue(taskDefinition.Memory)
 		s.TaskRole = aws.StringValue(taskDefinition.TaskRoleArn)
+		s.EphemeralStorage, s.RuntimePlatform = ecs.GetEphemeralStorageAndRuntimePlatformFromTaskDefinition(s.TaskDefinitionArn)
 
 		if len(service.LoadBalancers) > 0 {
 			s.TargetGroupArn = aws.StringValue(service.LoadBalancers[0].TargetGroupArn)
 		}
 
-		if len(taskDefinition.ContainerDefinitions) > 0 {
-			s.Image = aws.StringValue(taskDefinition.ContainerDefinitions[0].Image)
+		s.Containers = newContainers(taskDefinition.ContainerDefinitions)
+		s.Volumes = newVolumes(taskDefinition.Volumes, taskDefinition.ContainerDefinitions)
 
-			for _, env := range taskDefinition.ContainerDefinitions[0].Environment {
+		if container := selectContainerDefinition(taskDefinition, s.Name, containerName); container != nil {
+			s.Image = aws.StringValue(container.Image)
+			s.RequestedImage = aws.StringValue(container.DockerLabels[LabelRequestedImage])
+
+			for _, env := range container.Environment {
 				s.EnvVars = append(
 					s.EnvVars,
 					EnvVar{
@@ -242,6 +351,9 @@ func (ecs *ECS) DescribeServices(serviceArns []string) []Service {
 					},
 				)
 			}
+
+			s.Secrets = newSecrets(container.Secrets)
+			s.HealthCheck = newHealthCheck(container.HealthCheck)
 		}
 
 		for _, event := range service.Events {
@@ -254,17 +366,16 @@ func (ecs *ECS) DescribeServices(serviceArns []string) []Service {
 		}
 
 		for _, d := range service.Deployments {
-			deployment := Deployment{
-				Status:       aws.StringValue(d.Status),
-				DesiredCount: aws.Int64Value(d.DesiredCount),
-				PendingCount: aws.Int64Value(d.PendingCount),
-				RunningCount: aws.Int64Value(d.RunningCount),
-				CreatedAt:    aws.TimeValue(d.CreatedAt),
-				Id:           ecs.getDeploymentId(aws.StringValue(d.TaskDefinition)),
+			deployment := newDeployment(d)
+			deploymentTaskDefinition := ecs.DescribeTaskDefinition(aws.StringValue(d.TaskDefinition))
+
+			if container := selectContainerDefinition(deploymentTaskDefinition, s.Name, containerName); container != nil {
+				deployment.Image = aws.StringValue(container.Image)
+				deployment.Digest = ImageDigest(deployment.Image)
+				deployment.RequestedImage = aws.StringValue(container.DockerLabels[LabelRequestedImage])
 			}
 
-			deploymentTaskDefinition := ecs.DescribeTaskDefinition(aws.StringValue(d.TaskDefinition))
-			deployment.Image = aws.StringValue(deploymentTaskDefinition.ContainerDefinitions[0].Image)
+			deployment.Commit = newCommit(taskDefinitionTagsCache[aws.StringValue(d.TaskDefinition)])
 
 			s.AddDeployment(deployment)
 		}
@@ -275,6 +386,64 @@ func (ecs *ECS) DescribeServices(serviceArns []string) []Service {
 	return services
 }
 
+// DescribeServiceDeployments returns a service populated with its counts, deployments, and events.
+// Unlike DescribeService, details from the service's task definitions are not included.
+func (ecs ECS) DescribeServiceDeployments(serviceName string) (Service, error) {
+	resp, err := ecs.svc.DescribeServices(
+		&awsecs.DescribeServicesInput{
+			Cluster:  aws.String(ecs.ClusterName),
+			Services: aws.StringSlice([]string{serviceName}),
+		},
+	)
+
+	if err != nil {
+		return Service{}, err
+	}
+
+	if len(resp.Services) == 0 {
+		return Service{}, fmt.Errorf("could not find service %s", serviceName)
+	}
+
+	service := resp.Services[0]
+	s := Service{
+		DesiredCount:      aws.Int64Value(service.DesiredCount),
+		Name:              aws.StringValue(service.ServiceName),
+		PendingCount:      aws.Int64Value(service.PendingCount),
+		RunningCount:      aws.Int64Value(service.RunningCount),
+		Status:            aws.StringValue(service.Status),
+		TaskDefinitionArn: aws.StringValue(service.TaskDefinition),
+	}
+
+	for _, event := range service.Events {
+		s.AddEvent(
+			Event{
+				CreatedAt: aws.TimeValue(event.CreatedAt),
+				Message:   aws.StringValue(event.Message),
+			},
+		)
+	}
+
+	for _, d := range service.Deployments {
+		s.AddDeployment(newDeployment(d))
+	}
+
+	return s, nil
+}
+
+func newDeployment(d *awsecs.Deployment) Deployment {
+	taskDefinitionArn := aws.StringValue(d.TaskDefinition)
+
+	return Deployment{
+		Status:            aws.StringValue(d.Status),
+		DesiredCount:      aws.Int64Value(d.DesiredCount),
+		PendingCount:      aws.Int64Value(d.PendingCount),
+		RunningCount:      aws.Int64Value(d.RunningCount),
+		CreatedAt:         aws.TimeValue(d.CreatedAt),
+		Id:                TaskDefinitionRevision(taskDefinitionArn),
+		TaskDefinitionArn: taskDefinitionArn,
+	}
+}
+
 func (ecs *ECS) UpdateServiceTaskDefinition(serviceName, taskDefinitionArn string) {
 	_, err := ecs.svc.UpdateService(
 		&awsecs.UpdateServiceInput{
@@ -289,6 +458,85 @@ func (ecs *ECS) UpdateServiceTaskDefinition(serviceName, taskDefinitionArn strin
 	}
 }
 
+// DeployTaskDefinition updates a service to run the given task definition, starting a new
+// deployment.
+func (ecs ECS) DeployTaskDefinition(serviceName, taskDefinitionArn string) error {
+	_, err := ecs.svc.UpdateService(
+		&awsecs.UpdateServiceInput{
+			Cluster:        aws.String(ecs.ClusterName),
+			Service:        aws.String(serviceName),
+			TaskDefinition: aws.String(taskDefinitionArn),
+		},
+	)
+
+	return err
+}
+
+// CreateShadowService creates a service running the given task definition alongside an existing
+// service, copying its desired count, network configuration, launch type or capacity provider
+// strategy, and load balancer container, but registering its tasks with another target group.
+func (ecs ECS) CreateShadowService(serviceName, shadowServiceName, taskDefinitionArn, targetGroupArn string) error {
+	resp, err := ecs.svc.DescribeServices(
+		&awsecs.DescribeServicesInput{
+			Cluster:  aws.String(ecs.ClusterName),
+			Services: aws.StringSlice([]string{serviceName}),
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Services) == 0 {
+		return fmt.Errorf("could not find service %s", serviceName)
+	}
+
+	service := resp.Services[0]
+	input := &awsecs.CreateServiceInput{
+		Cluster:              aws.String(ecs.ClusterName),
+		DesiredCount:         service.DesiredCount,
+		EnableExecuteCommand: service.EnableExecuteCommand,
+		NetworkConfiguration: service.NetworkConfiguration,
+		ServiceName:          aws.String(shadowServiceName),
+		TaskDefinition:       aws.String(taskDefinitionArn),
+	}
+
+	if len(service.CapacityProviderStrategy) > 0 {
+		input.CapacityProviderStrategy = service.CapacityProviderStrategy
+	} else {
+		input.LaunchType = aws.String(awsecs.CompatibilityFargate)
+	}
+
+	if len(service.LoadBalancers) > 0 {
+		input.SetLoadBalancers(
+			[]*awsecs.LoadBalancer{
+				&awsecs.LoadBalancer{
+					ContainerName:  service.LoadBalancers[0].ContainerName,
+					ContainerPort:  service.LoadBalancers[0].ContainerPort,
+					TargetGroupArn: aws.String(targetGroupArn),
+				},
+			},
+		)
+	}
+
+	_, err = ecs.svc.CreateService(input)
+
+	return err
+}
+
+// DeleteService deletes a service without first scaling it down, stopping its tasks.
+func (ecs ECS) DeleteService(serviceName string) error {
+	_, err := ecs.svc.DeleteService(
+		&awsecs.DeleteServiceInput{
+			Cluster: aws.String(ecs.ClusterName),
+			Force:   aws.Bool(true),
+			Service: aws.String(serviceName),
+		},
+	)
+
+	return err
+}
+
 func (ecs *ECS) RestartService(serviceName string) {
 	_, err := ecs.svc.UpdateService(
 		&awsecs.UpdateServiceInput{
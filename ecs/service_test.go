@@ -0,0 +1,234 @@
+package ecs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/awslabs/fargatecli/ecs/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+func TestDescribeServiceDeployments(t *testing.T) {
+	createdAt := time.Now()
+	taskDefinitionARN := "arn:aws:ecs:us-east-1:123456789012:task-definition/fargate_service_web:4"
+	oldTaskDefinitionARN := "arn:aws:ecs:us-east-1:123456789012:task-definition/fargate_service_web:3"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+	i := &awsecs.DescribeServicesInput{
+		Cluster:  aws.String("fargate"),
+		Services: aws.StringSlice([]string{"web"}),
+	}
+	o := &awsecs.DescribeServicesOutput{
+		Services: []*awsecs.Service{
+			&awsecs.Service{
+				DesiredCount:   aws.Int64(2),
+				RunningCount:   aws.Int64(3),
+				PendingCount:   aws.Int64(1),
+				ServiceName:    aws.String("web"),
+				Status:         aws.String("ACTIVE"),
+				TaskDefinition: aws.String(taskDefinitionARN),
+				Deployments: []*awsecs.Deployment{
+					&awsecs.Deployment{
+						CreatedAt:      aws.Time(createdAt),
+						DesiredCount:   aws.Int64(2),
+						PendingCount:   aws.Int64(1),
+						RunningCount:   aws.Int64(1),
+						Status:         aws.String("PRIMARY"),
+						TaskDefinition: aws.String(taskDefinitionARN),
+					},
+					&awsecs.Deployment{
+						DesiredCount:   aws.Int64(0),
+						RunningCount:   aws.Int64(2),
+						Status:         aws.String("ACTIVE"),
+						TaskDefinition: aws.String(oldTaskDefinitionARN),
+					},
+				},
+				Events: []*awsecs.ServiceEvent{
+					&awsecs.ServiceEvent{
+						CreatedAt: aws.Time(createdAt),
+						Message:   aws.String("(service web) has started 1 tasks"),
+					},
+				},
+			},
+		},
+	}
+
+	mockECSAPI.EXPECT().DescribeServices(i).Return(o, nil)
+
+	service, err := ecs.DescribeServiceDeployments("web")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if service.Name != "web" || service.DesiredCount != 2 || service.TaskDefinitionArn != taskDefinitionARN {
+		t.Errorf("unexpected service: %+v", service)
+	}
+
+	if len(service.Deployments) != 2 {
+		t.Fatalf("expected 2 deployments, got %d", len(service.Deployments))
+	}
+
+	primary := service.Deployments[0]
+
+	if !primary.IsPrimary() || primary.IsComplete() {
+		t.Errorf("expected incomplete primary deployment, got %+v", primary)
+	}
+
+	if primary.Id != "4" || primary.TaskDefinitionArn != taskDefinitionARN {
+		t.Errorf("expected deployment of revision 4, got %s (%s)", primary.Id, primary.TaskDefinitionArn)
+	}
+
+	if service.Deployments[1].IsPrimary() {
+		t.Errorf("expected second deployment not to be primary")
+	}
+
+	if len(service.Events) != 1 || service.Events[0].Message != "(service web) has started 1 tasks" {
+		t.Errorf("unexpected events: %+v", service.Events)
+	}
+}
+
+func TestDescribeServiceDeploymentsNotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().DescribeServices(gomock.Any()).Return(&awsecs.DescribeServicesOutput{}, nil)
+
+	if _, err := ecs.DescribeServiceDeployments("web"); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+func TestDescribeServiceDeploymentsError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().DescribeServices(gomock.Any()).Return(nil, errors.New("boom"))
+
+	if _, err := ecs.DescribeServiceDeployments("web"); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+func TestDeploymentIsComplete(t *testing.T) {
+	var tests = []struct {
+		deployment Deployment
+		complete   bool
+	}{
+		{Deployment{DesiredCount: 2, RunningCount: 2}, true},
+		{Deployment{DesiredCount: 2, RunningCount: 1, PendingCount: 1}, false},
+		{Deployment{DesiredCount: 2, RunningCount: 2, PendingCount: 1}, false},
+		{Deployment{DesiredCount: 0, RunningCount: 0}, true},
+	}
+
+	for _, test := range tests {
+		if test.deployment.IsComplete() != test.complete {
+			t.Errorf("expected deployment %+v complete == %t", test.deployment, test.complete)
+		}
+	}
+}
+
+func TestCreateShadowService(t *testing.T) {
+	taskDefinitionARN := "arn:aws:ecs:us-east-1:123456789012:task-definition/fargate_service_web:4"
+	targetGroupARN := "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/fargate-web-green/2453ed029918f21f"
+	networkConfiguration := &awsecs.NetworkConfiguration{
+		AwsvpcConfiguration: &awsecs.AwsVpcConfiguration{
+			AssignPublicIp: aws.String("ENABLED"),
+			SecurityGroups: aws.StringSlice([]string{"sg-1234567"}),
+			Subnets:        aws.StringSlice([]string{"subnet-1234567"}),
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().DescribeServices(gomock.Any()).Return(
+		&awsecs.DescribeServicesOutput{
+			Services: []*awsecs.Service{
+				&awsecs.Service{
+					DesiredCount: aws.Int64(3),
+					LoadBalancers: []*awsecs.LoadBalancer{
+						&awsecs.LoadBalancer{
+							ContainerName:  aws.String("web"),
+							ContainerPort:  aws.Int64(80),
+							TargetGroupArn: aws.String("arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/fargate-web/73e2d6bc24d8a067"),
+						},
+					},
+					NetworkConfiguration: networkConfiguration,
+					ServiceName:          aws.String("web"),
+				},
+			},
+		},
+		nil,
+	)
+	mockECSAPI.EXPECT().CreateService(
+		&awsecs.CreateServiceInput{
+			Cluster:      aws.String("fargate"),
+			DesiredCount: aws.Int64(3),
+			LaunchType:   aws.String("FARGATE"),
+			LoadBalancers: []*awsecs.LoadBalancer{
+				&awsecs.LoadBalancer{
+					ContainerName:  aws.String("web"),
+					ContainerPort:  aws.Int64(80),
+					TargetGroupArn: aws.String(targetGroupARN),
+				},
+			},
+			NetworkConfiguration: networkConfiguration,
+			ServiceName:          aws.String("web-green"),
+			TaskDefinition:       aws.String(taskDefinitionARN),
+		},
+	).Return(&awsecs.CreateServiceOutput{}, nil)
+
+	if err := ecs.CreateShadowService("web", "web-green", taskDefinitionARN, targetGroupARN); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestCreateShadowServiceNotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().DescribeServices(gomock.Any()).Return(&awsecs.DescribeServicesOutput{}, nil)
+
+	if err := ecs.CreateShadowService("web", "web-green", "taskDefinitionARN", "targetGroupARN"); err == nil {
+		t.Error("expected error, got none")
+	}
+}
+
+func TestDeleteService(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+	i := &awsecs.DeleteServiceInput{
+		Cluster: aws.String("fargate"),
+		Force:   aws.Bool(true),
+		Service: aws.String("web-green"),
+	}
+
+	mockECSAPI.EXPECT().DeleteService(i).Return(&awsecs.DeleteServiceOutput{}, errors.New("boom"))
+
+	if err := ecs.DeleteService("web-green"); err == nil {
+		t.Error("expected error, got none")
+	}
+}
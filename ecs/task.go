@@ -14,25 +14,48 @@ import (
 const (
 	detailNetworkInterfaceId  = "networkInterfaceId"
 	detailSubnetId            = "subnetId"
+	describeTasksLimit        = 100
 	startedByFormat           = "fargate:%s"
 	taskGroupStartedByPattern = "fargate:(.*)"
 )
 
 type Task struct {
-	Cpu              string
-	CreatedAt        time.Time
-	DeploymentId     string
-	DesiredStatus    string
-	EniId            string
-	EnvVars          []EnvVar
-	Image            string
-	LastStatus       string
-	Memory           string
-	SecurityGroupIds []string
-	StartedBy        string
-	SubnetId         string
-	TaskId           string
-	TaskRole         string
+	CapacityProviderName string            `json:"capacityProviderName,omitempty"`
+	ContainerStatuses    []ContainerStatus `json:"containerStatuses,omitempty"`
+	Containers           []Container       `json:"containers"`
+	Cpu                  string            `json:"cpu"`
+	CreatedAt            time.Time         `json:"createdAt"`
+	DeploymentId         string            `json:"deploymentId"`
+	DesiredStatus        string            `json:"desiredStatus"`
+	EniId                string            `json:"eniId"`
+	EnvVars              []EnvVar          `json:"envVars"`
+	EphemeralStorage     int64             `json:"ephemeralStorage,omitempty"`
+	HealthCheck          *HealthCheck      `json:"healthCheck,omitempty"`
+	HealthStatus         string            `json:"healthStatus"`
+	Image                string            `json:"image"`
+	LastStatus           string            `json:"lastStatus"`
+	Memory               string            `json:"memory"`
+	RuntimePlatform      RuntimePlatform   `json:"runtimePlatform"`
+	Secrets              []Secret          `json:"secrets"`
+	SecurityGroupIds     []string          `json:"securityGroupIds"`
+	StartedBy            string            `json:"startedBy"`
+	StopCode             string            `json:"stopCode,omitempty"`
+	StoppedAt            time.Time         `json:"stoppedAt"`
+	StoppedReason        string            `json:"stoppedReason"`
+	SubnetId             string            `json:"subnetId"`
+	TaskDefinitionArn    string            `json:"taskDefinitionArn"`
+	TaskId               string            `json:"taskId"`
+	TaskRole             string            `json:"taskRole"`
+	Volumes              []Volume          `json:"volumes,omitempty"`
+}
+
+// ContainerStatus is the status of a container in a task, including its exit code once it has
+// stopped.
+type ContainerStatus struct {
+	ExitCode   *int64 `json:"exitCode,omitempty"`
+	LastStatus string `json:"lastStatus"`
+	Name       string `json:"name"`
+	Reason     string `json:"reason,omitempty"`
 }
 
 func (t *Task) RunningFor() time.Duration {
@@ -40,47 +63,74 @@ func (t *Task) RunningFor() time.Duration {
 }
 
 type TaskGroup struct {
-	TaskGroupName string
-	Instances     int64
+	TaskGroupName string `json:"taskGroupName"`
+	Instances     int64  `json:"instances"`
 }
 
 type RunTaskInput struct {
-	ClusterName       string
-	Count             int64
-	SecurityGroupIds  []string
-	SubnetIds         []string
-	TaskDefinitionArn string
-	TaskName          string
-}
-
-func (ecs *ECS) RunTask(i *RunTaskInput) {
-	_, err := ecs.svc.RunTask(
-		&awsecs.RunTaskInput{
-			Cluster:        aws.String(i.ClusterName),
-			Count:          aws.Int64(i.Count),
-			TaskDefinition: aws.String(i.TaskDefinitionArn),
-			LaunchType:     aws.String(awsecs.CompatibilityFargate),
-			StartedBy:      aws.String(fmt.Sprintf(startedByFormat, i.TaskName)),
-			NetworkConfiguration: &awsecs.NetworkConfiguration{
-				AwsvpcConfiguration: &awsecs.AwsVpcConfiguration{
-					AssignPublicIp: aws.String(awsecs.AssignPublicIpEnabled),
-					Subnets:        aws.StringSlice(i.SubnetIds),
-					SecurityGroups: aws.StringSlice(i.SecurityGroupIds),
-				},
+	CapacityProviderStrategy CapacityProviderStrategy
+	ClusterName              string
+	Count                    int64
+	EnableExecuteCommand     bool
+	SecurityGroupIds         []string
+	SubnetIds                []string
+	TaskDefinitionArn        string
+	TaskName                 string
+}
+
+// RunTask starts tasks and returns their IDs.
+func (ecs *ECS) RunTask(i *RunTaskInput) []string {
+	var taskIDs []string
+
+	runTaskInput := &awsecs.RunTaskInput{
+		Cluster:              aws.String(i.ClusterName),
+		Count:                aws.Int64(i.Count),
+		EnableExecuteCommand: aws.Bool(i.EnableExecuteCommand),
+		TaskDefinition:       aws.String(i.TaskDefinitionArn),
+		LaunchType:           aws.String(awsecs.CompatibilityFargate),
+		StartedBy:            aws.String(fmt.Sprintf(startedByFormat, i.TaskName)),
+		NetworkConfiguration: &awsecs.NetworkConfiguration{
+			AwsvpcConfiguration: &awsecs.AwsVpcConfiguration{
+				AssignPublicIp: aws.String(awsecs.AssignPublicIpEnabled),
+				Subnets:        aws.StringSlice(i.SubnetIds),
+				SecurityGroups: aws.StringSlice(i.SecurityGroupIds),
 			},
 		},
-	)
+	}
+
+	if len(i.CapacityProviderStrategy) > 0 {
+		runTaskInput.LaunchType = nil
+		runTaskInput.CapacityProviderStrategy = i.CapacityProviderStrategy.sdk()
+	}
+
+	resp, err := ecs.svc.RunTask(runTaskInput)
 
 	if err != nil {
 		console.ErrorExit(err, "Could not run ECS task")
 	}
+
+	if len(resp.Failures) > 0 {
+		var reasons []string
+
+		for _, failure := range resp.Failures {
+			reasons = append(reasons, aws.StringValue(failure.Reason))
+		}
+
+		console.ErrorExit(fmt.Errorf(strings.Join(reasons, ", ")), "Could not run ECS task")
+	}
+
+	for _, t := range resp.Tasks {
+		contents := strings.Split(aws.StringValue(t.TaskArn), "/")
+		taskIDs = append(taskIDs, contents[len(contents)-1])
+	}
+
+	return taskIDs
 }
 
 func (ecs *ECS) DescribeTasksForService(serviceName string) []Task {
 	return ecs.listTasks(
 		&awsecs.ListTasksInput{
 			Cluster:     aws.String(ecs.ClusterName),
-			LaunchType:  aws.String(awsecs.CompatibilityFargate),
 			ServiceName: aws.String(serviceName),
 		},
 	)
@@ -180,6 +230,16 @@ func (ecs *ECS) listTasks(input *awsecs.ListTasksInput) []Task {
 	return tasks
 }
 
+// taskContainerName returns the name of the container running a task's own image: the task group
+// it was run as by fargate, or the service which started it.
+func taskContainerName(t *awsecs.Task) string {
+	if matches := regexp.MustCompile(taskGroupStartedByPattern).FindStringSubmatch(aws.StringValue(t.StartedBy)); len(matches) == 2 {
+		return matches[1]
+	}
+
+	return strings.TrimPrefix(aws.StringValue(t.Group), "service:")
+}
+
 func (ecs *ECS) DescribeTasks(taskIds []string) []Task {
 	var tasks []Task
 
@@ -204,28 +264,44 @@ func (ecs *ECS) DescribeTasks(taskIds []string) []Task {
 		taskId := contents[len(contents)-1]
 
 		task := Task{
-			Cpu:           aws.StringValue(t.Cpu),
-			CreatedAt:     aws.TimeValue(t.CreatedAt),
-			DeploymentId:  ecs.getDeploymentId(aws.StringValue(t.TaskDefinitionArn)),
-			DesiredStatus: aws.StringValue(t.DesiredStatus),
-			LastStatus:    aws.StringValue(t.LastStatus),
-			Memory:        aws.StringValue(t.Memory),
-			TaskId:        taskId,
-			StartedBy:     aws.StringValue(t.StartedBy),
+			CapacityProviderName: aws.StringValue(t.CapacityProviderName),
+			ContainerStatuses:    newContainerStatuses(t.Containers),
+			Cpu:                  aws.StringValue(t.Cpu),
+			CreatedAt:            aws.TimeValue(t.CreatedAt),
+			DeploymentId:         TaskDefinitionRevision(aws.StringValue(t.TaskDefinitionArn)),
+			DesiredStatus:        aws.StringValue(t.DesiredStatus),
+			HealthStatus:         aws.StringValue(t.HealthStatus),
+			LastStatus:           aws.StringValue(t.LastStatus),
+			Memory:               aws.StringValue(t.Memory),
+			TaskId:               taskId,
+			StartedBy:            aws.StringValue(t.StartedBy),
+			StopCode:             aws.StringValue(t.StopCode),
+			StoppedAt:            aws.TimeValue(t.StoppedAt),
+			StoppedReason:        aws.StringValue(t.StoppedReason),
+			TaskDefinitionArn:    aws.StringValue(t.TaskDefinitionArn),
 		}
 
 		taskDefinition := ecs.DescribeTaskDefinition(aws.StringValue(t.TaskDefinitionArn))
-		task.Image = aws.StringValue(taskDefinition.ContainerDefinitions[0].Image)
+		task.Containers = newContainers(taskDefinition.ContainerDefinitions)
+		task.Volumes = newVolumes(taskDefinition.Volumes, taskDefinition.ContainerDefinitions)
 		task.TaskRole = aws.StringValue(taskDefinition.TaskRoleArn)
+		task.EphemeralStorage, task.RuntimePlatform = ecs.GetEphemeralStorageAndRuntimePlatformFromTaskDefinition(task.TaskDefinitionArn)
+
+		if container := mainContainerDefinition(taskDefinition, taskContainerName(t)); container != nil {
+			task.Image = aws.StringValue(container.Image)
+
+			for _, environment := range container.Environment {
+				task.EnvVars = append(
+					task.EnvVars,
+					EnvVar{
+						Key:   aws.StringValue(environment.Name),
+						Value: aws.StringValue(environment.Value),
+					},
+				)
+			}
 
-		for _, environment := range taskDefinition.ContainerDefinitions[0].Environment {
-			task.EnvVars = append(
-				task.EnvVars,
-				EnvVar{
-					Key:   aws.StringValue(environment.Name),
-					Value: aws.StringValue(environment.Value),
-				},
-			)
+			task.Secrets = newSecrets(container.Secrets)
+			task.HealthCheck = newHealthCheck(container.HealthCheck)
 		}
 
 		if len(t.Attachments) == 1 {
@@ -244,3 +320,117 @@ func (ecs *ECS) DescribeTasks(taskIds []string) []Task {
 
 	return tasks
 }
+
+// ListStoppedServiceTasks returns the tasks of a service which have recently stopped. Unlike
+// DescribeTasksForService, details from the tasks' task definitions are not included.
+func (ecs ECS) ListStoppedServiceTasks(serviceName string) ([]Task, error) {
+	var (
+		taskArns []string
+		tasks    []Task
+	)
+
+	err := ecs.svc.ListTasksPages(
+		&awsecs.ListTasksInput{
+			Cluster:       aws.String(ecs.ClusterName),
+			DesiredStatus: aws.String(awsecs.DesiredStatusStopped),
+			ServiceName:   aws.String(serviceName),
+		},
+		func(resp *awsecs.ListTasksOutput, lastPage bool) bool {
+			taskArns = append(taskArns, aws.StringValueSlice(resp.TaskArns)...)
+
+			return true
+		},
+	)
+
+	if err != nil {
+		return tasks, err
+	}
+
+	return ecs.DescribeTaskStatuses(taskArns)
+}
+
+// DescribeTaskStatuses returns the status of tasks, given by ID or ARN, including the exit code of
+// each of their containers once stopped. Unlike DescribeTasks, details from the tasks' task
+// definitions are not included.
+func (ecs ECS) DescribeTaskStatuses(taskIDs []string) ([]Task, error) {
+	var tasks []Task
+
+	for len(taskIDs) > 0 {
+		batch := taskIDs
+
+		if len(batch) > describeTasksLimit {
+			batch = batch[:describeTasksLimit]
+		}
+
+		taskIDs = taskIDs[len(batch):]
+
+		resp, err := ecs.svc.DescribeTasks(
+			&awsecs.DescribeTasksInput{
+				Cluster: aws.String(ecs.ClusterName),
+				Tasks:   aws.StringSlice(batch),
+			},
+		)
+
+		if err != nil {
+			return tasks, err
+		}
+
+		for _, t := range resp.Tasks {
+			contents := strings.Split(aws.StringValue(t.TaskArn), "/")
+
+			tasks = append(tasks,
+				Task{
+					CapacityProviderName: aws.StringValue(t.CapacityProviderName),
+					ContainerStatuses:    newContainerStatuses(t.Containers),
+					CreatedAt:            aws.TimeValue(t.CreatedAt),
+					DesiredStatus:        aws.StringValue(t.DesiredStatus),
+					LastStatus:           aws.StringValue(t.LastStatus),
+					StartedBy:            aws.StringValue(t.StartedBy),
+					StopCode:             aws.StringValue(t.StopCode),
+					StoppedAt:            aws.TimeValue(t.StoppedAt),
+					StoppedReason:        aws.StringValue(t.StoppedReason),
+					TaskDefinitionArn:    aws.StringValue(t.TaskDefinitionArn),
+					TaskId:               contents[len(contents)-1],
+				},
+			)
+		}
+	}
+
+	return tasks, nil
+}
+
+// StopTasksWithReason stops tasks, recording why they were stopped.
+func (ecs ECS) StopTasksWithReason(taskIDs []string, reason string) error {
+	for _, taskID := range taskIDs {
+		_, err := ecs.svc.StopTask(
+			&awsecs.StopTaskInput{
+				Cluster: aws.String(ecs.ClusterName),
+				Reason:  aws.String(reason),
+				Task:    aws.String(taskID),
+			},
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newContainerStatuses(containers []*awsecs.Container) []ContainerStatus {
+	var statuses []ContainerStatus
+
+	for _, container := range containers {
+		statuses = append(statuses,
+			ContainerStatus{
+				ExitCode:   container.ExitCode,
+				LastStatus: aws.StringValue(container.LastStatus),
+				Name:       aws.StringValue(container.Name),
+				Reason:     aws.StringValue(container.Reason),
+			},
+		)
+	}
+
+	return statuses
+}
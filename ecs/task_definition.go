@@ -3,34 +3,95 @@ package ecs
 import (
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	awsecs "github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/awslabs/fargatecli/console"
 )
 
-const logStreamPrefix = "fargate"
+const (
+	healthCheckCommandShell = "CMD-SHELL"
+	healthCheckCommandExec  = "CMD"
+	logStreamPrefix         = "fargate"
 
-var taskDefinitionCache = make(map[string]*awsecs.TaskDefinition)
+	// LabelRequestedImage is the Docker label of a container whose image is pinned to a digest,
+	// recording the image, such as a tag, it was pinned from.
+	LabelRequestedImage = "com.github.awslabs.fargatecli.image.requested"
+)
+
+var (
+	taskDefinitionCache     = make(map[string]*awsecs.TaskDefinition)
+	taskDefinitionTagsCache = make(map[string][]*awsecs.Tag)
+)
 
 type CreateTaskDefinitionInput struct {
+	Commit           *Commit
 	Cpu              string
 	EnvVars          []EnvVar
+	EphemeralStorage int64
 	ExecutionRoleArn string
+	HealthCheck      *HealthCheck
 	Image            string
 	Memory           string
 	Name             string
 	Port             int64
+	RequestedImage   string
 	LogGroupName     string
+	RuntimePlatform  RuntimePlatform
 	LogRegion        string
+	MountPoints      []MountPoint
+	Secrets          []Secret
+	Sidecars         []Container
 	TaskRole         string
 	Type             string
 	TaskCommand      []string
+	Volumes          []Volume
 }
 
 type EnvVar struct {
-	Key   string
-	Value string
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Secret is an environment variable whose value is read from an SSM Parameter Store parameter or
+// Secrets Manager secret when a container starts.
+type Secret struct {
+	Key       string `json:"key"`
+	ValueFrom string `json:"valueFrom"`
+}
+
+// HealthCheck is a command run inside a container to determine whether it is healthy. The command is
+// run by the container's default shell. Zero values use the ECS defaults when creating a task
+// definition and are left unchanged when updating one.
+type HealthCheck struct {
+	Command     string `json:"command"`
+	Interval    int64  `json:"interval,omitempty"`
+	Retries     int64  `json:"retries,omitempty"`
+	StartPeriod int64  `json:"startPeriod,omitempty"`
+}
+
+// TaskDefinitionUpdate is a set of changes used to register a new revision of a task definition.
+// Empty values are left unchanged. The health check and mount points are changed on the named
+// container, or the first container if no name is given. Mount points are removed by container path.
+type TaskDefinitionUpdate struct {
+	Container         string
+	Cpu               string
+	EphemeralStorage  int64
+	HealthCheck       *HealthCheck
+	Memory            string
+	MountPoints       []MountPoint
+	RemoveMountPoints []string
+	RemoveVolumes     []string
+	RuntimePlatform   RuntimePlatform
+	Volumes           []Volume
+}
+
+// UpdatesVolumes returns whether the update changes the volumes or mount points of the task
+// definition.
+func (update TaskDefinitionUpdate) UpdatesVolumes() bool {
+	return len(update.MountPoints) > 0 || len(update.RemoveMountPoints) > 0 || len(update.RemoveVolumes) > 0 || len(update.Volumes) > 0
 }
 
 func (ecs *ECS) CreateTaskDefinition(input *CreateTaskDefinitionInput) string {
@@ -46,12 +107,16 @@ func (ecs *ECS) CreateTaskDefinition(input *CreateTaskDefinitionInput) string {
 	}
 
 	containerDefinition := &awsecs.ContainerDefinition{
+		DockerLabels:     requestedImageLabels(nil, input.RequestedImage),
 		Environment:      input.Environment(),
 		Essential:        aws.Bool(true),
 		Image:            aws.String(input.Image),
 		LogConfiguration: logConfiguration,
 		Name:             aws.String(input.Name),
 		Command:          aws.StringSlice(input.TaskCommand),
+		HealthCheck:      containerHealthCheck(nil, input.HealthCheck),
+		MountPoints:      mountPointDefinitions(input.MountPoints, readOnlyVolumes(input.Volumes)),
+		Secrets:          input.ContainerSecrets(),
 	}
 
 	if input.Port != 0 {
@@ -64,16 +129,28 @@ func (ecs *ECS) CreateTaskDefinition(input *CreateTaskDefinitionInput) string {
 		)
 	}
 
+	containerDefinitions := []*awsecs.ContainerDefinition{containerDefinition}
+
+	for _, sidecar := range input.Sidecars {
+		definition := sidecar.definition(logConfiguration)
+		definition.MountPoints = mountPointDefinitions(sidecar.MountPoints, readOnlyVolumes(input.Volumes))
+		containerDefinitions = append(containerDefinitions, definition)
+	}
+
 	resp, err := ecs.svc.RegisterTaskDefinition(
 		&awsecs.RegisterTaskDefinitionInput{
-			ContainerDefinitions:    []*awsecs.ContainerDefinition{containerDefinition},
+			ContainerDefinitions:    containerDefinitions,
 			Cpu:                     aws.String(input.Cpu),
+			EphemeralStorage:        ephemeralStorageDefinition(input.EphemeralStorage),
 			ExecutionRoleArn:        aws.String(input.ExecutionRoleArn),
 			Family:                  aws.String(fmt.Sprintf("%s_%s", input.Type, input.Name)),
 			Memory:                  aws.String(input.Memory),
 			NetworkMode:             aws.String(awsecs.NetworkModeAwsvpc),
 			RequiresCompatibilities: aws.StringSlice([]string{awsecs.CompatibilityFargate}),
+			RuntimePlatform:         input.RuntimePlatform.sdk(),
+			Tags:                    commitTags(nil, input.Commit),
 			TaskRoleArn:             aws.String(input.TaskRole),
+			Volumes:                 volumeDefinitions(input.Volumes),
 		},
 	)
 
@@ -103,6 +180,21 @@ func (input *CreateTaskDefinitionInput) Environment() []*awsecs.KeyValuePair {
 	return environment
 }
 
+func (input *CreateTaskDefinitionInput) ContainerSecrets() []*awsecs.Secret {
+	var secrets []*awsecs.Secret
+
+	for _, secret := range input.Secrets {
+		secrets = append(secrets,
+			&awsecs.Secret{
+				Name:      aws.String(secret.Key),
+				ValueFrom: aws.String(secret.ValueFrom),
+			},
+		)
+	}
+
+	return secrets
+}
+
 func (ecs *ECS) DescribeTaskDefinition(taskDefinitionArn string) *awsecs.TaskDefinition {
 	if taskDefinitionCache[taskDefinitionArn] != nil {
 		return taskDefinitionCache[taskDefinitionArn]
@@ -110,6 +202,7 @@ func (ecs *ECS) DescribeTaskDefinition(taskDefinitionArn string) *awsecs.TaskDef
 
 	resp, err := ecs.svc.DescribeTaskDefinition(
 		&awsecs.DescribeTaskDefinitionInput{
+			Include:        aws.StringSlice([]string{awsecs.TaskDefinitionFieldTags}),
 			TaskDefinition: aws.String(taskDefinitionArn),
 		},
 	)
@@ -119,23 +212,33 @@ func (ecs *ECS) DescribeTaskDefinition(taskDefinitionArn string) *awsecs.TaskDef
 	}
 
 	taskDefinitionCache[taskDefinitionArn] = resp.TaskDefinition
+	taskDefinitionTagsCache[taskDefinitionArn] = resp.Tags
 
 	return taskDefinitionCache[taskDefinitionArn]
 }
 
-func (ecs *ECS) UpdateTaskDefinitionImage(taskDefinitionArn, image string) string {
+// UpdateTaskDefinitionImage registers a new revision of a task definition running the given image in
+// the named container, or the first container if no name is given. If the image is pinned to a
+// digest, the image it was pinned from is recorded in the container's labels. The git commit the
+// image was built from, if known, is recorded in the task definition's tags.
+func (ecs *ECS) UpdateTaskDefinitionImage(taskDefinitionArn, container, image, requestedImage string, commit *Commit) string {
 	taskDefinition := ecs.DescribeTaskDefinition(taskDefinitionArn)
-	taskDefinition.ContainerDefinitions[0].Image = aws.String(image)
+	definition := containerDefinition(taskDefinition, container)
+	definition.Image = aws.String(image)
+	definition.DockerLabels = requestedImageLabels(definition.DockerLabels, requestedImage)
 
 	resp, err := ecs.svc.RegisterTaskDefinition(
 		&awsecs.RegisterTaskDefinitionInput{
 			ContainerDefinitions:    taskDefinition.ContainerDefinitions,
 			Cpu:                     taskDefinition.Cpu,
+			EphemeralStorage:        taskDefinition.EphemeralStorage,
 			ExecutionRoleArn:        taskDefinition.ExecutionRoleArn,
 			Family:                  taskDefinition.Family,
 			Memory:                  taskDefinition.Memory,
 			NetworkMode:             taskDefinition.NetworkMode,
 			RequiresCompatibilities: taskDefinition.RequiresCompatibilities,
+			RuntimePlatform:         taskDefinition.RuntimePlatform,
+			Tags:                    commitTags(taskDefinitionTagsCache[taskDefinitionArn], commit),
 			TaskRoleArn:             taskDefinition.TaskRoleArn,
 			Volumes:                 taskDefinition.Volumes,
 		},
@@ -148,8 +251,42 @@ func (ecs *ECS) UpdateTaskDefinitionImage(taskDefinitionArn, image string) strin
 	return aws.StringValue(resp.TaskDefinition.TaskDefinitionArn)
 }
 
-func (ecs *ECS) AddEnvVarsToTaskDefinition(taskDefinitionArn string, envVars []EnvVar) string {
+// ImageDigest returns the digest an image is pinned to, or an empty string if it is not pinned.
+func ImageDigest(image string) string {
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+
+	return ""
+}
+
+// requestedImageLabels returns a container's labels recording the image it was pinned from, or
+// without the label if it was not pinned.
+func requestedImageLabels(labels map[string]*string, requestedImage string) map[string]*string {
+	updated := make(map[string]*string)
+
+	for key, value := range labels {
+		if key != LabelRequestedImage {
+			updated[key] = value
+		}
+	}
+
+	if requestedImage != "" {
+		updated[LabelRequestedImage] = aws.String(requestedImage)
+	}
+
+	if len(updated) == 0 {
+		return nil
+	}
+
+	return updated
+}
+
+// AddEnvVarsToTaskDefinition registers a new revision of a task definition with the given
+// environment variables added to the named container, or the first container if no name is given.
+func (ecs *ECS) AddEnvVarsToTaskDefinition(taskDefinitionArn, container string, envVars []EnvVar) string {
 	taskDefinition := ecs.DescribeTaskDefinition(taskDefinitionArn)
+	containerDefinition := containerDefinition(taskDefinition, container)
 
 	for _, envVar := range envVars {
 		keyValuePair := &awsecs.KeyValuePair{
@@ -157,21 +294,21 @@ func (ecs *ECS) AddEnvVarsToTaskDefinition(taskDefinitionArn string, envVars []E
 			Value: aws.String(envVar.Value),
 		}
 
-		taskDefinition.ContainerDefinitions[0].Environment = append(
-			taskDefinition.ContainerDefinitions[0].Environment,
-			keyValuePair,
-		)
+		containerDefinition.Environment = append(containerDefinition.Environment, keyValuePair)
 	}
 
 	resp, err := ecs.svc.RegisterTaskDefinition(
 		&awsecs.RegisterTaskDefinitionInput{
 			ContainerDefinitions:    taskDefinition.ContainerDefinitions,
 			Cpu:                     taskDefinition.Cpu,
+			EphemeralStorage:        taskDefinition.EphemeralStorage,
 			ExecutionRoleArn:        taskDefinition.ExecutionRoleArn,
 			Family:                  taskDefinition.Family,
 			Memory:                  taskDefinition.Memory,
 			NetworkMode:             taskDefinition.NetworkMode,
 			RequiresCompatibilities: taskDefinition.RequiresCompatibilities,
+			RuntimePlatform:         taskDefinition.RuntimePlatform,
+			Tags:                    taskDefinitionTagsCache[taskDefinitionArn],
 			TaskRoleArn:             taskDefinition.TaskRoleArn,
 			Volumes:                 taskDefinition.Volumes,
 		},
@@ -184,33 +321,41 @@ func (ecs *ECS) AddEnvVarsToTaskDefinition(taskDefinitionArn string, envVars []E
 	return aws.StringValue(resp.TaskDefinition.TaskDefinitionArn)
 }
 
-func (ecs *ECS) RemoveEnvVarsFromTaskDefinition(taskDefinitionArn string, keys []string) string {
+// RemoveEnvVarsFromTaskDefinition registers a new revision of a task definition without the
+// environment variables with the given keys in the named container, or the first container if no
+// name is given.
+func (ecs *ECS) RemoveEnvVarsFromTaskDefinition(taskDefinitionArn, container string, keys []string) string {
 	var newEnvironment []*awsecs.KeyValuePair
 
 	taskDefinition := ecs.DescribeTaskDefinition(taskDefinitionArn)
-	environment := taskDefinition.ContainerDefinitions[0].Environment
+	containerDefinition := containerDefinition(taskDefinition, container)
+	environment := containerDefinition.Environment
 
+OUTER:
 	for _, keyValuePair := range environment {
 		for _, key := range keys {
 			if aws.StringValue(keyValuePair.Name) == key {
-				continue
+				continue OUTER
 			}
-
-			newEnvironment = append(newEnvironment, keyValuePair)
 		}
+
+		newEnvironment = append(newEnvironment, keyValuePair)
 	}
 
-	taskDefinition.ContainerDefinitions[0].Environment = newEnvironment
+	containerDefinition.Environment = newEnvironment
 
 	resp, err := ecs.svc.RegisterTaskDefinition(
 		&awsecs.RegisterTaskDefinitionInput{
 			ContainerDefinitions:    taskDefinition.ContainerDefinitions,
 			Cpu:                     taskDefinition.Cpu,
+			EphemeralStorage:        taskDefinition.EphemeralStorage,
 			ExecutionRoleArn:        taskDefinition.ExecutionRoleArn,
 			Family:                  taskDefinition.Family,
 			Memory:                  taskDefinition.Memory,
 			NetworkMode:             taskDefinition.NetworkMode,
 			RequiresCompatibilities: taskDefinition.RequiresCompatibilities,
+			RuntimePlatform:         taskDefinition.RuntimePlatform,
+			Tags:                    taskDefinitionTagsCache[taskDefinitionArn],
 			TaskRoleArn:             taskDefinition.TaskRoleArn,
 			Volumes:                 taskDefinition.Volumes,
 		},
@@ -223,12 +368,14 @@ func (ecs *ECS) RemoveEnvVarsFromTaskDefinition(taskDefinitionArn string, keys [
 	return aws.StringValue(resp.TaskDefinition.TaskDefinitionArn)
 }
 
-func (ecs *ECS) GetEnvVarsFromTaskDefinition(taskDefinitionArn string) []EnvVar {
+// GetEnvVarsFromTaskDefinition returns the environment variables of the named container of a task
+// definition, or the first container if no name is given.
+func (ecs *ECS) GetEnvVarsFromTaskDefinition(taskDefinitionArn, container string) []EnvVar {
 	var envVars []EnvVar
 
 	taskDefinition := ecs.DescribeTaskDefinition(taskDefinitionArn)
 
-	for _, keyValuePair := range taskDefinition.ContainerDefinitions[0].Environment {
+	for _, keyValuePair := range containerDefinition(taskDefinition, container).Environment {
 		envVars = append(envVars,
 			EnvVar{
 				Key:   aws.StringValue(keyValuePair.Name),
@@ -240,26 +387,164 @@ func (ecs *ECS) GetEnvVarsFromTaskDefinition(taskDefinitionArn string) []EnvVar
 	return envVars
 }
 
+// AddSecretsToTaskDefinition registers a new revision of a task definition with the given secrets
+// in the named container, or the first container if no name is given, replacing any existing
+// secrets with the same keys.
+func (ecs *ECS) AddSecretsToTaskDefinition(taskDefinitionArn, container string, secrets []Secret) string {
+	var keys []string
+
+	for _, secret := range secrets {
+		keys = append(keys, secret.Key)
+	}
+
+	taskDefinition := ecs.DescribeTaskDefinition(taskDefinitionArn)
+	containerDefinition := containerDefinition(taskDefinition, container)
+	containerDefinition.Secrets = withoutSecrets(containerDefinition.Secrets, keys)
+
+	for _, secret := range secrets {
+		containerDefinition.Secrets = append(
+			containerDefinition.Secrets,
+			&awsecs.Secret{
+				Name:      aws.String(secret.Key),
+				ValueFrom: aws.String(secret.ValueFrom),
+			},
+		)
+	}
+
+	resp, err := ecs.svc.RegisterTaskDefinition(
+		&awsecs.RegisterTaskDefinitionInput{
+			ContainerDefinitions:    taskDefinition.ContainerDefinitions,
+			Cpu:                     taskDefinition.Cpu,
+			EphemeralStorage:        taskDefinition.EphemeralStorage,
+			ExecutionRoleArn:        taskDefinition.ExecutionRoleArn,
+			Family:                  taskDefinition.Family,
+			Memory:                  taskDefinition.Memory,
+			NetworkMode:             taskDefinition.NetworkMode,
+			RequiresCompatibilities: taskDefinition.RequiresCompatibilities,
+			RuntimePlatform:         taskDefinition.RuntimePlatform,
+			Tags:                    taskDefinitionTagsCache[taskDefinitionArn],
+			TaskRoleArn:             taskDefinition.TaskRoleArn,
+			Volumes:                 taskDefinition.Volumes,
+		},
+	)
+
+	if err != nil {
+		console.ErrorExit(err, "Could not register ECS task definition")
+	}
+
+	return aws.StringValue(resp.TaskDefinition.TaskDefinitionArn)
+}
+
+// RemoveSecretsFromTaskDefinition registers a new revision of a task definition without the secrets
+// with the given keys in the named container, or the first container if no name is given.
+func (ecs *ECS) RemoveSecretsFromTaskDefinition(taskDefinitionArn, container string, keys []string) string {
+	taskDefinition := ecs.DescribeTaskDefinition(taskDefinitionArn)
+	containerDefinition := containerDefinition(taskDefinition, container)
+	containerDefinition.Secrets = withoutSecrets(containerDefinition.Secrets, keys)
+
+	resp, err := ecs.svc.RegisterTaskDefinition(
+		&awsecs.RegisterTaskDefinitionInput{
+			ContainerDefinitions:    taskDefinition.ContainerDefinitions,
+			Cpu:                     taskDefinition.Cpu,
+			EphemeralStorage:        taskDefinition.EphemeralStorage,
+			ExecutionRoleArn:        taskDefinition.ExecutionRoleArn,
+			Family:                  taskDefinition.Family,
+			Memory:                  taskDefinition.Memory,
+			NetworkMode:             taskDefinition.NetworkMode,
+			RequiresCompatibilities: taskDefinition.RequiresCompatibilities,
+			RuntimePlatform:         taskDefinition.RuntimePlatform,
+			Tags:                    taskDefinitionTagsCache[taskDefinitionArn],
+			TaskRoleArn:             taskDefinition.TaskRoleArn,
+			Volumes:                 taskDefinition.Volumes,
+		},
+	)
+
+	if err != nil {
+		console.ErrorExit(err, "Could not register ECS task definition")
+	}
+
+	return aws.StringValue(resp.TaskDefinition.TaskDefinitionArn)
+}
+
+// GetSecretsFromTaskDefinition returns the secrets of the named container of a task definition, or
+// the first container if no name is given.
+func (ecs *ECS) GetSecretsFromTaskDefinition(taskDefinitionArn, container string) []Secret {
+	taskDefinition := ecs.DescribeTaskDefinition(taskDefinitionArn)
+
+	return newSecrets(containerDefinition(taskDefinition, container).Secrets)
+}
+
+func newSecrets(containerSecrets []*awsecs.Secret) []Secret {
+	var secrets []Secret
+
+	for _, secret := range containerSecrets {
+		secrets = append(secrets,
+			Secret{
+				Key:       aws.StringValue(secret.Name),
+				ValueFrom: aws.StringValue(secret.ValueFrom),
+			},
+		)
+	}
+
+	return secrets
+}
+
+func withoutSecrets(secrets []*awsecs.Secret, keys []string) []*awsecs.Secret {
+	var remaining []*awsecs.Secret
+
+OUTER:
+	for _, secret := range secrets {
+		for _, key := range keys {
+			if aws.StringValue(secret.Name) == key {
+				continue OUTER
+			}
+		}
+
+		remaining = append(remaining, secret)
+	}
+
+	return remaining
+}
+
 func (ecs *ECS) UpdateTaskDefinitionCpuAndMemory(taskDefinitionArn, cpu, memory string) string {
+	return ecs.UpdateTaskDefinition(taskDefinitionArn, TaskDefinitionUpdate{Cpu: cpu, Memory: memory})
+}
+
+// UpdateTaskDefinition registers a new revision of a task definition with the given changes.
+func (ecs *ECS) UpdateTaskDefinition(taskDefinitionArn string, update TaskDefinitionUpdate) string {
 	taskDefinition := ecs.DescribeTaskDefinition(taskDefinitionArn)
 
-	if cpu != "" {
-		taskDefinition.Cpu = aws.String(cpu)
+	if update.Cpu != "" {
+		taskDefinition.Cpu = aws.String(update.Cpu)
+	}
+
+	if update.Memory != "" {
+		taskDefinition.Memory = aws.String(update.Memory)
+	}
+
+	if update.HealthCheck != nil {
+		containerDefinition := containerDefinition(taskDefinition, update.Container)
+		containerDefinition.HealthCheck = containerHealthCheck(containerDefinition.HealthCheck, update.HealthCheck)
 	}
 
-	if memory != "" {
-		taskDefinition.Memory = aws.String(memory)
+	if update.UpdatesVolumes() {
+		updateVolumes(taskDefinition, update)
 	}
 
+	updateRuntimeParameters(taskDefinition, update.EphemeralStorage, update.RuntimePlatform)
+
 	resp, err := ecs.svc.RegisterTaskDefinition(
 		&awsecs.RegisterTaskDefinitionInput{
 			ContainerDefinitions:    taskDefinition.ContainerDefinitions,
 			Cpu:                     taskDefinition.Cpu,
+			EphemeralStorage:        taskDefinition.EphemeralStorage,
 			ExecutionRoleArn:        taskDefinition.ExecutionRoleArn,
 			Family:                  taskDefinition.Family,
 			Memory:                  taskDefinition.Memory,
 			NetworkMode:             taskDefinition.NetworkMode,
 			RequiresCompatibilities: taskDefinition.RequiresCompatibilities,
+			RuntimePlatform:         taskDefinition.RuntimePlatform,
+			Tags:                    taskDefinitionTagsCache[taskDefinitionArn],
 			TaskRoleArn:             taskDefinition.TaskRoleArn,
 			Volumes:                 taskDefinition.Volumes,
 		},
@@ -272,11 +557,193 @@ func (ecs *ECS) UpdateTaskDefinitionCpuAndMemory(taskDefinitionArn, cpu, memory
 	return aws.StringValue(resp.TaskDefinition.TaskDefinitionArn)
 }
 
-func (ecs *ECS) getDeploymentId(taskDefinitionArn string) string {
+// GetHealthCheckFromTaskDefinition returns the health check of the named container of a task
+// definition, or the first container if no name is given. Nil is returned if the container has no
+// health check.
+func (ecs *ECS) GetHealthCheckFromTaskDefinition(taskDefinitionArn, container string) *HealthCheck {
+	taskDefinition := ecs.DescribeTaskDefinition(taskDefinitionArn)
+
+	return newHealthCheck(containerDefinition(taskDefinition, container).HealthCheck)
+}
+
+// containerHealthCheck returns the container health check resulting from applying the non-zero
+// values of a health check to the current one.
+func containerHealthCheck(current *awsecs.HealthCheck, healthCheck *HealthCheck) *awsecs.HealthCheck {
+	if healthCheck == nil {
+		return current
+	}
+
+	containerHealthCheck := &awsecs.HealthCheck{}
+
+	if current != nil {
+		*containerHealthCheck = *current
+	}
+
+	if healthCheck.Command != "" {
+		containerHealthCheck.Command = aws.StringSlice([]string{healthCheckCommandShell, healthCheck.Command})
+	}
+
+	if healthCheck.Interval != 0 {
+		containerHealthCheck.Interval = aws.Int64(healthCheck.Interval)
+	}
+
+	if healthCheck.Retries != 0 {
+		containerHealthCheck.Retries = aws.Int64(healthCheck.Retries)
+	}
+
+	if healthCheck.StartPeriod != 0 {
+		containerHealthCheck.StartPeriod = aws.Int64(healthCheck.StartPeriod)
+	}
+
+	return containerHealthCheck
+}
+
+func newHealthCheck(containerHealthCheck *awsecs.HealthCheck) *HealthCheck {
+	if containerHealthCheck == nil || len(containerHealthCheck.Command) == 0 {
+		return nil
+	}
+
+	command := aws.StringValueSlice(containerHealthCheck.Command)
+
+	switch command[0] {
+	case healthCheckCommandShell, healthCheckCommandExec:
+		command = command[1:]
+	}
+
+	return &HealthCheck{
+		Command:     strings.Join(command, " "),
+		Interval:    aws.Int64Value(containerHealthCheck.Interval),
+		Retries:     aws.Int64Value(containerHealthCheck.Retries),
+		StartPeriod: aws.Int64Value(containerHealthCheck.StartPeriod),
+	}
+}
+
+// TaskDefinitionRevision returns the revision of a task definition from its ARN.
+func TaskDefinitionRevision(taskDefinitionArn string) string {
 	contents := strings.Split(taskDefinitionArn, ":")
 	return contents[len(contents)-1]
 }
 
+func taskDefinitionFamily(taskDefinitionArn string) string {
+	name := taskDefinitionArn[strings.LastIndex(taskDefinitionArn, "/")+1:]
+	return strings.TrimSuffix(name, ":"+TaskDefinitionRevision(taskDefinitionArn))
+}
+
+// ListTaskDefinitionRevisions returns the ARNs of the active revisions of a task definition family,
+// most recent first.
+func (ecs ECS) ListTaskDefinitionRevisions(family string) ([]string, error) {
+	var taskDefinitionArns []string
+
+	err := ecs.svc.ListTaskDefinitionsPages(
+		&awsecs.ListTaskDefinitionsInput{
+			FamilyPrefix: aws.String(family),
+			Sort:         aws.String(awsecs.SortOrderDesc),
+			Status:       aws.String(awsecs.TaskDefinitionStatusActive),
+		},
+		func(resp *awsecs.ListTaskDefinitionsOutput, lastPage bool) bool {
+			for _, taskDefinitionArn := range aws.StringValueSlice(resp.TaskDefinitionArns) {
+				// FamilyPrefix matches any family starting with the given name, e.g. service_web2 for
+				// service_web, so only keep revisions of the exact family.
+				if taskDefinitionFamily(taskDefinitionArn) == family {
+					taskDefinitionArns = append(taskDefinitionArns, taskDefinitionArn)
+				}
+			}
+
+			return true
+		},
+	)
+
+	return taskDefinitionArns, err
+}
+
+// describeTaskDefinitionInterval is the least time between DescribeTaskDefinition requests made when
+// describing every active task definition, so that accounts with many are not throttled.
+var describeTaskDefinitionInterval = 50 * time.Millisecond
+
+// taskDefinitionImages caches the images of task definitions by ARN. A revision's containers cannot
+// change once it is registered, so its images only need to be described once.
+var taskDefinitionImages = struct {
+	sync.Mutex
+	images map[string][]string
+}{images: make(map[string][]string)}
+
+// ListActiveTaskDefinitionImages returns the images of the containers of every active task
+// definition, of any family, and of the task definitions of the cluster's services' deployments, so
+// that images still in use can be told apart from those which are not. An error is returned if any
+// task definition cannot be described, as the images it refers to would not be known.
+func (ecs ECS) ListActiveTaskDefinitionImages() ([]string, error) {
+	var taskDefinitionArns []string
+
+	err := ecs.svc.ListTaskDefinitionsPages(
+		&awsecs.ListTaskDefinitionsInput{
+			Status: aws.String(awsecs.TaskDefinitionStatusActive),
+		},
+		func(resp *awsecs.ListTaskDefinitionsOutput, lastPage bool) bool {
+			taskDefinitionArns = append(taskDefinitionArns, aws.StringValueSlice(resp.TaskDefinitionArns)...)
+
+			return true
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	serviceTaskDefinitionArns, err := ecs.listServiceTaskDefinitionArns()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		images     []string
+		described  = make(map[string]bool)
+		lastCalled time.Time
+	)
+
+	taskDefinitionImages.Lock()
+	defer taskDefinitionImages.Unlock()
+
+	for _, taskDefinitionArn := range append(taskDefinitionArns, serviceTaskDefinitionArns...) {
+		if described[taskDefinitionArn] {
+			continue
+		}
+
+		described[taskDefinitionArn] = true
+
+		if containerImages, ok := taskDefinitionImages.images[taskDefinitionArn]; ok {
+			images = append(images, containerImages...)
+			continue
+		}
+
+		if wait := describeTaskDefinitionInterval - time.Since(lastCalled); wait > 0 {
+			time.Sleep(wait)
+		}
+
+		lastCalled = time.Now()
+		resp, err := ecs.svc.DescribeTaskDefinition(
+			&awsecs.DescribeTaskDefinitionInput{
+				TaskDefinition: aws.String(taskDefinitionArn),
+			},
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var containerImages []string
+
+		for _, container := range resp.TaskDefinition.ContainerDefinitions {
+			containerImages = append(containerImages, aws.StringValue(container.Image))
+		}
+
+		taskDefinitionImages.images[taskDefinitionArn] = containerImages
+		images = append(images, containerImages...)
+	}
+
+	return images, nil
+}
+
 func (ecs *ECS) GetCpuAndMemoryFromTaskDefinition(taskDefinitionArn string) (string, string) {
 	taskDefinition := ecs.DescribeTaskDefinition(taskDefinitionArn)
 
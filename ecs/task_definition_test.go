@@ -0,0 +1,496 @@
+package ecs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/awslabs/fargatecli/ecs/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+func TestTaskDefinitionRevision(t *testing.T) {
+	arn := "arn:aws:ecs:us-east-1:123456789012:task-definition/service_web:12"
+
+	if expected, got := "12", TaskDefinitionRevision(arn); expected != got {
+		t.Errorf("expected revision %s, got %s", expected, got)
+	}
+
+	if expected, got := "service_web", taskDefinitionFamily(arn); expected != got {
+		t.Errorf("expected family %s, got %s", expected, got)
+	}
+}
+
+func TestListTaskDefinitionRevisions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+	input := &awsecs.ListTaskDefinitionsInput{
+		FamilyPrefix: aws.String("service_web"),
+		Sort:         aws.String(awsecs.SortOrderDesc),
+		Status:       aws.String(awsecs.TaskDefinitionStatusActive),
+	}
+
+	mockECSAPI.EXPECT().ListTaskDefinitionsPages(input, gomock.Any()).Do(
+		func(input *awsecs.ListTaskDefinitionsInput, fn func(*awsecs.ListTaskDefinitionsOutput, bool) bool) {
+			fn(
+				&awsecs.ListTaskDefinitionsOutput{
+					TaskDefinitionArns: aws.StringSlice(
+						[]string{
+							"arn:aws:ecs:us-east-1:123456789012:task-definition/service_web2:9",
+							"arn:aws:ecs:us-east-1:123456789012:task-definition/service_web:3",
+						},
+					),
+				},
+				false,
+			)
+			fn(
+				&awsecs.ListTaskDefinitionsOutput{
+					TaskDefinitionArns: aws.StringSlice(
+						[]string{"arn:aws:ecs:us-east-1:123456789012:task-definition/service_web:1"},
+					),
+				},
+				true,
+			)
+		},
+	).Return(nil)
+
+	arns, err := ecs.ListTaskDefinitionRevisions("service_web")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(arns) != 2 {
+		t.Fatalf("expected 2 revisions, got %d: %v", len(arns), arns)
+	}
+
+	if expected := "arn:aws:ecs:us-east-1:123456789012:task-definition/service_web:3"; arns[0] != expected {
+		t.Errorf("expected %s, got %s", expected, arns[0])
+	}
+
+	if expected := "arn:aws:ecs:us-east-1:123456789012:task-definition/service_web:1"; arns[1] != expected {
+		t.Errorf("expected %s, got %s", expected, arns[1])
+	}
+}
+
+func TestListTaskDefinitionRevisionsError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().ListTaskDefinitionsPages(gomock.Any(), gomock.Any()).Return(errors.New("boom"))
+
+	if _, err := ecs.ListTaskDefinitionRevisions("service_web"); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+func TestAddSecretsToTaskDefinition(t *testing.T) {
+	taskDefinitionARN := "arn:aws:ecs:us-east-1:123456789012:task-definition/service_secrets:1"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().DescribeTaskDefinition(gomock.Any()).Return(
+		&awsecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &awsecs.TaskDefinition{
+				ContainerDefinitions: []*awsecs.ContainerDefinition{
+					&awsecs.ContainerDefinition{
+						Secrets: []*awsecs.Secret{
+							&awsecs.Secret{Name: aws.String("A"), ValueFrom: aws.String("a")},
+							&awsecs.Secret{Name: aws.String("B"), ValueFrom: aws.String("b")},
+						},
+					},
+				},
+				Family:            aws.String("service_secrets"),
+				TaskDefinitionArn: aws.String(taskDefinitionARN),
+			},
+		},
+		nil,
+	)
+	mockECSAPI.EXPECT().RegisterTaskDefinition(gomock.Any()).DoAndReturn(
+		func(input *awsecs.RegisterTaskDefinitionInput) (*awsecs.RegisterTaskDefinitionOutput, error) {
+			secrets := newSecrets(input.ContainerDefinitions[0].Secrets)
+			expected := []Secret{Secret{Key: "A", ValueFrom: "a"}, Secret{Key: "B", ValueFrom: "/prod/b"}}
+
+			if len(secrets) != len(expected) || secrets[0] != expected[0] || secrets[1] != expected[1] {
+				t.Errorf("expected secrets %v, got %v", expected, secrets)
+			}
+
+			return &awsecs.RegisterTaskDefinitionOutput{
+				TaskDefinition: &awsecs.TaskDefinition{
+					TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/service_secrets:2"),
+				},
+			}, nil
+		},
+	)
+
+	arn := ecs.AddSecretsToTaskDefinition(taskDefinitionARN, "", []Secret{Secret{Key: "B", ValueFrom: "/prod/b"}})
+
+	if expected := "arn:aws:ecs:us-east-1:123456789012:task-definition/service_secrets:2"; arn != expected {
+		t.Errorf("expected %s, got %s", expected, arn)
+	}
+}
+
+func TestUpdateTaskDefinitionHealthCheck(t *testing.T) {
+	taskDefinitionARN := "arn:aws:ecs:us-east-1:123456789012:task-definition/service_health:1"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().DescribeTaskDefinition(gomock.Any()).Return(
+		&awsecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &awsecs.TaskDefinition{
+				ContainerDefinitions: []*awsecs.ContainerDefinition{
+					&awsecs.ContainerDefinition{
+						HealthCheck: &awsecs.HealthCheck{
+							Command:  aws.StringSlice([]string{"CMD", "/bin/check", "--quick"}),
+							Interval: aws.Int64(30),
+							Retries:  aws.Int64(3),
+							Timeout:  aws.Int64(5),
+						},
+					},
+				},
+				Cpu:               aws.String("256"),
+				Family:            aws.String("service_health"),
+				Memory:            aws.String("512"),
+				TaskDefinitionArn: aws.String(taskDefinitionARN),
+			},
+		},
+		nil,
+	)
+	mockECSAPI.EXPECT().RegisterTaskDefinition(gomock.Any()).DoAndReturn(
+		func(input *awsecs.RegisterTaskDefinitionInput) (*awsecs.RegisterTaskDefinitionOutput, error) {
+			healthCheck := input.ContainerDefinitions[0].HealthCheck
+
+			if expected, got := []string{"CMD-SHELL", "curl -f http://localhost/ || exit 1"}, aws.StringValueSlice(healthCheck.Command); !reflect.DeepEqual(expected, got) {
+				t.Errorf("expected command %v, got %v", expected, got)
+			}
+
+			if aws.Int64Value(healthCheck.Interval) != 30 || aws.Int64Value(healthCheck.Retries) != 5 || aws.Int64Value(healthCheck.Timeout) != 5 {
+				t.Errorf("expected interval 30, retries 5, and timeout 5, got %v", healthCheck)
+			}
+
+			if aws.StringValue(input.Cpu) != "512" || aws.StringValue(input.Memory) != "512" {
+				t.Errorf("expected 512 CPU units / 512 MiB, got %s / %s", aws.StringValue(input.Cpu), aws.StringValue(input.Memory))
+			}
+
+			return &awsecs.RegisterTaskDefinitionOutput{
+				TaskDefinition: &awsecs.TaskDefinition{
+					TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/service_health:2"),
+				},
+			}, nil
+		},
+	)
+
+	if expected, got := (&HealthCheck{Command: "/bin/check --quick", Interval: 30, Retries: 3}), ecs.GetHealthCheckFromTaskDefinition(taskDefinitionARN, ""); *got != *expected {
+		t.Errorf("expected health check %+v, got %+v", expected, got)
+	}
+
+	arn := ecs.UpdateTaskDefinition(
+		taskDefinitionARN,
+		TaskDefinitionUpdate{
+			Cpu:         "512",
+			HealthCheck: &HealthCheck{Command: "curl -f http://localhost/ || exit 1", Retries: 5},
+		},
+	)
+
+	if expected := "arn:aws:ecs:us-east-1:123456789012:task-definition/service_health:2"; arn != expected {
+		t.Errorf("expected %s, got %s", expected, arn)
+	}
+}
+
+func TestNewHealthCheck(t *testing.T) {
+	if healthCheck := newHealthCheck(nil); healthCheck != nil {
+		t.Errorf("expected no health check, got %+v", healthCheck)
+	}
+
+	healthCheck := newHealthCheck(
+		&awsecs.HealthCheck{
+			Command:     aws.StringSlice([]string{"CMD-SHELL", "pgrep server"}),
+			StartPeriod: aws.Int64(60),
+		},
+	)
+
+	if expected := (HealthCheck{Command: "pgrep server", StartPeriod: 60}); *healthCheck != expected {
+		t.Errorf("expected health check %+v, got %+v", expected, healthCheck)
+	}
+}
+
+func TestUpdateTaskDefinitionImagePinned(t *testing.T) {
+	taskDefinitionARN := "arn:aws:ecs:us-east-1:123456789012:task-definition/service_pinned:1"
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	image := "123456789012.dkr.ecr.us-east-1.amazonaws.com/web@" + digest
+	requestedImage := "123456789012.dkr.ecr.us-east-1.amazonaws.com/web:abc1234"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().DescribeTaskDefinition(gomock.Any()).Return(
+		&awsecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &awsecs.TaskDefinition{
+				ContainerDefinitions: []*awsecs.ContainerDefinition{
+					&awsecs.ContainerDefinition{
+						DockerLabels: map[string]*string{"team": aws.String("web")},
+						Image:        aws.String("123456789012.dkr.ecr.us-east-1.amazonaws.com/web:0000000"),
+					},
+				},
+				Family:            aws.String("service_pinned"),
+				TaskDefinitionArn: aws.String(taskDefinitionARN),
+			},
+		},
+		nil,
+	)
+	mockECSAPI.EXPECT().RegisterTaskDefinition(gomock.Any()).DoAndReturn(
+		func(input *awsecs.RegisterTaskDefinitionInput) (*awsecs.RegisterTaskDefinitionOutput, error) {
+			definition := input.ContainerDefinitions[0]
+
+			if got := aws.StringValue(definition.Image); got != image {
+				t.Errorf("expected image %s, got %s", image, got)
+			}
+
+			if got := aws.StringValue(definition.DockerLabels[LabelRequestedImage]); got != requestedImage {
+				t.Errorf("expected requested image label %s, got %s", requestedImage, got)
+			}
+
+			if got := aws.StringValue(definition.DockerLabels["team"]); got != "web" {
+				t.Errorf("expected existing labels to be kept, got %v", definition.DockerLabels)
+			}
+
+			return &awsecs.RegisterTaskDefinitionOutput{
+				TaskDefinition: &awsecs.TaskDefinition{
+					TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/service_pinned:2"),
+				},
+			}, nil
+		},
+	)
+
+	ecs.UpdateTaskDefinitionImage(taskDefinitionARN, "", image, requestedImage, nil)
+
+	if got := ImageDigest(image); got != digest {
+		t.Errorf("expected digest %s, got %s", digest, got)
+	}
+
+	if got := ImageDigest(requestedImage); got != "" {
+		t.Errorf("expected no digest, got %s", got)
+	}
+}
+
+func TestUpdateTaskDefinitionImageCommit(t *testing.T) {
+	taskDefinitionARN := "arn:aws:ecs:us-east-1:123456789012:task-definition/service_commit:1"
+	commit := &Commit{
+		Author:  "Jane Doe <jane@example.com>",
+		Branch:  "main",
+		Message: "Add labels\n\nRecord the commit.",
+		SHA:     "0123456789abcdef0123456789abcdef01234567",
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().DescribeTaskDefinition(
+		&awsecs.DescribeTaskDefinitionInput{
+			Include:        aws.StringSlice([]string{awsecs.TaskDefinitionFieldTags}),
+			TaskDefinition: aws.String(taskDefinitionARN),
+		},
+	).Return(
+		&awsecs.DescribeTaskDefinitionOutput{
+			Tags: []*awsecs.Tag{
+				&awsecs.Tag{Key: aws.String("team"), Value: aws.String("web")},
+				&awsecs.Tag{Key: aws.String(TagCommitSHA), Value: aws.String("fedcba9876543210fedcba9876543210fedcba98")},
+				&awsecs.Tag{Key: aws.String(TagCommitBranch), Value: aws.String("feature")},
+			},
+			TaskDefinition: &awsecs.TaskDefinition{
+				ContainerDefinitions: []*awsecs.ContainerDefinition{
+					&awsecs.ContainerDefinition{Image: aws.String("web:fedcba9")},
+				},
+				Family:            aws.String("service_commit"),
+				TaskDefinitionArn: aws.String(taskDefinitionARN),
+			},
+		},
+		nil,
+	)
+	mockECSAPI.EXPECT().RegisterTaskDefinition(gomock.Any()).DoAndReturn(
+		func(input *awsecs.RegisterTaskDefinitionInput) (*awsecs.RegisterTaskDefinitionOutput, error) {
+			expected := []*awsecs.Tag{
+				&awsecs.Tag{Key: aws.String("team"), Value: aws.String("web")},
+				&awsecs.Tag{Key: aws.String(TagCommitSHA), Value: aws.String(commit.SHA)},
+				&awsecs.Tag{Key: aws.String(TagCommitAuthor), Value: aws.String("Jane Doe jane@example.com")},
+				&awsecs.Tag{Key: aws.String(TagCommitBranch), Value: aws.String("main")},
+				&awsecs.Tag{Key: aws.String(TagCommitMessage), Value: aws.String("Add labels")},
+			}
+
+			if !reflect.DeepEqual(input.Tags, expected) {
+				t.Errorf("expected tags %v, got %v", expected, input.Tags)
+			}
+
+			return &awsecs.RegisterTaskDefinitionOutput{
+				TaskDefinition: &awsecs.TaskDefinition{
+					TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/service_commit:2"),
+				},
+			}, nil
+		},
+	)
+
+	ecs.UpdateTaskDefinitionImage(taskDefinitionARN, "", "web:0123456", "", commit)
+}
+
+func TestRequestedImageLabels(t *testing.T) {
+	labels := map[string]*string{LabelRequestedImage: aws.String("web:abc1234")}
+
+	if got := requestedImageLabels(labels, ""); got != nil {
+		t.Errorf("expected the requested image label to be removed, got %v", got)
+	}
+}
+
+func TestListActiveTaskDefinitionImages(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+	webArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/service_web:3"
+	reportArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/nightly-report:2"
+	apiArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/service_api:7"
+
+	mockECSAPI.EXPECT().ListTaskDefinitionsPages(
+		&awsecs.ListTaskDefinitionsInput{Status: aws.String(awsecs.TaskDefinitionStatusActive)},
+		gomock.Any(),
+	).Do(
+		func(input *awsecs.ListTaskDefinitionsInput, fn func(*awsecs.ListTaskDefinitionsOutput, bool) bool) {
+			fn(&awsecs.ListTaskDefinitionsOutput{TaskDefinitionArns: aws.StringSlice([]string{webArn})}, false)
+			fn(&awsecs.ListTaskDefinitionsOutput{TaskDefinitionArns: aws.StringSlice([]string{reportArn})}, true)
+		},
+	).Return(nil).Times(2)
+	mockECSAPI.EXPECT().ListServicesPages(
+		&awsecs.ListServicesInput{Cluster: aws.String("fargate")},
+		gomock.Any(),
+	).Do(
+		func(input *awsecs.ListServicesInput, fn func(*awsecs.ListServicesOutput, bool) bool) {
+			fn(&awsecs.ListServicesOutput{ServiceArns: aws.StringSlice([]string{"web", "api"})}, true)
+		},
+	).Return(nil).Times(2)
+	mockECSAPI.EXPECT().DescribeServices(
+		&awsecs.DescribeServicesInput{Cluster: aws.String("fargate"), Services: aws.StringSlice([]string{"web", "api"})},
+	).Return(
+		&awsecs.DescribeServicesOutput{
+			Services: []*awsecs.Service{
+				&awsecs.Service{Deployments: []*awsecs.Deployment{&awsecs.Deployment{TaskDefinition: aws.String(webArn)}}},
+				&awsecs.Service{Deployments: []*awsecs.Deployment{&awsecs.Deployment{TaskDefinition: aws.String(apiArn)}}},
+			},
+		},
+		nil,
+	).Times(2)
+	mockECSAPI.EXPECT().DescribeTaskDefinition(
+		&awsecs.DescribeTaskDefinitionInput{TaskDefinition: aws.String(webArn)},
+	).Return(
+		&awsecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &awsecs.TaskDefinition{
+				ContainerDefinitions: []*awsecs.ContainerDefinition{
+					&awsecs.ContainerDefinition{Image: aws.String("web:abc1234")},
+					&awsecs.ContainerDefinition{Image: aws.String("envoy:v1")},
+				},
+			},
+		},
+		nil,
+	)
+	mockECSAPI.EXPECT().DescribeTaskDefinition(
+		&awsecs.DescribeTaskDefinitionInput{TaskDefinition: aws.String(reportArn)},
+	).Return(
+		&awsecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &awsecs.TaskDefinition{
+				ContainerDefinitions: []*awsecs.ContainerDefinition{
+					&awsecs.ContainerDefinition{Image: aws.String("web:def5678")},
+				},
+			},
+		},
+		nil,
+	)
+	mockECSAPI.EXPECT().DescribeTaskDefinition(
+		&awsecs.DescribeTaskDefinitionInput{TaskDefinition: aws.String(apiArn)},
+	).Return(
+		&awsecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &awsecs.TaskDefinition{
+				ContainerDefinitions: []*awsecs.ContainerDefinition{
+					&awsecs.ContainerDefinition{Image: aws.String("web@sha256:abc")},
+				},
+			},
+		},
+		nil,
+	)
+
+	expected := []string{"web:abc1234", "envoy:v1", "web:def5678", "web@sha256:abc"}
+
+	// Task definitions are described once and their images cached for later calls.
+	for i := 0; i < 2; i++ {
+		images, err := ecs.ListActiveTaskDefinitionImages()
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if !reflect.DeepEqual(images, expected) {
+			t.Errorf("expected images %v, got %v", expected, images)
+		}
+	}
+}
+
+func TestListActiveTaskDefinitionImagesError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().ListTaskDefinitionsPages(gomock.Any(), gomock.Any()).Return(errors.New("boom"))
+
+	if _, err := ecs.ListActiveTaskDefinitionImages(); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+func TestListActiveTaskDefinitionImagesDescribeError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+	reportArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/weekly-report:1"
+
+	mockECSAPI.EXPECT().ListTaskDefinitionsPages(gomock.Any(), gomock.Any()).Do(
+		func(input *awsecs.ListTaskDefinitionsInput, fn func(*awsecs.ListTaskDefinitionsOutput, bool) bool) {
+			fn(&awsecs.ListTaskDefinitionsOutput{TaskDefinitionArns: aws.StringSlice([]string{reportArn})}, true)
+		},
+	).Return(nil)
+	mockECSAPI.EXPECT().ListServicesPages(gomock.Any(), gomock.Any()).Return(nil)
+	mockECSAPI.EXPECT().DescribeTaskDefinition(gomock.Any()).Return(nil, errors.New("throttled"))
+
+	images, err := ecs.ListActiveTaskDefinitionImages()
+
+	if err == nil {
+		t.Errorf("expected error, got none")
+	}
+
+	if images != nil {
+		t.Errorf("expected no images, got %v", images)
+	}
+}
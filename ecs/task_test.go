@@ -0,0 +1,104 @@
+package ecs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/awslabs/fargatecli/ecs/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+func TestListStoppedServiceTasks(t *testing.T) {
+	taskARN := "arn:aws:ecs:us-east-1:123456789012:task/fargate/0cc43cdb-3bee-4407-9c26-c0e6ea5bee84"
+	taskDefinitionARN := "arn:aws:ecs:us-east-1:123456789012:task-definition/fargate_service_web:4"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+	listTasksInput := &awsecs.ListTasksInput{
+		Cluster:       aws.String("fargate"),
+		DesiredStatus: aws.String(awsecs.DesiredStatusStopped),
+		ServiceName:   aws.String("web"),
+	}
+	describeTasksInput := &awsecs.DescribeTasksInput{
+		Cluster: aws.String("fargate"),
+		Tasks:   aws.StringSlice([]string{taskARN}),
+	}
+	describeTasksOutput := &awsecs.DescribeTasksOutput{
+		Tasks: []*awsecs.Task{
+			&awsecs.Task{
+				LastStatus:        aws.String("STOPPED"),
+				StoppedReason:     aws.String("Essential container in task exited"),
+				TaskArn:           aws.String(taskARN),
+				TaskDefinitionArn: aws.String(taskDefinitionARN),
+			},
+		},
+	}
+
+	mockECSAPI.EXPECT().ListTasksPages(listTasksInput, gomock.Any()).Do(
+		func(input *awsecs.ListTasksInput, fn func(*awsecs.ListTasksOutput, bool) bool) {
+			fn(&awsecs.ListTasksOutput{TaskArns: aws.StringSlice([]string{taskARN})}, true)
+		},
+	).Return(nil)
+	mockECSAPI.EXPECT().DescribeTasks(describeTasksInput).Return(describeTasksOutput, nil)
+
+	tasks, err := ecs.ListStoppedServiceTasks("web")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+
+	if expected := "0cc43cdb-3bee-4407-9c26-c0e6ea5bee84"; tasks[0].TaskId != expected {
+		t.Errorf("expected task ID %s, got %s", expected, tasks[0].TaskId)
+	}
+
+	if tasks[0].TaskDefinitionArn != taskDefinitionARN {
+		t.Errorf("expected task definition ARN %s, got %s", taskDefinitionARN, tasks[0].TaskDefinitionArn)
+	}
+
+	if expected := "Essential container in task exited"; tasks[0].StoppedReason != expected {
+		t.Errorf("expected stopped reason %s, got %s", expected, tasks[0].StoppedReason)
+	}
+}
+
+func TestListStoppedServiceTasksNone(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().ListTasksPages(gomock.Any(), gomock.Any()).Return(nil)
+
+	tasks, err := ecs.ListStoppedServiceTasks("web")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(tasks) != 0 {
+		t.Errorf("expected no tasks, got %d", len(tasks))
+	}
+}
+
+func TestListStoppedServiceTasksError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().ListTasksPages(gomock.Any(), gomock.Any()).Return(errors.New("boom"))
+
+	if _, err := ecs.ListStoppedServiceTasks("web"); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
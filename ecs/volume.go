@@ -0,0 +1,230 @@
+package ecs
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+)
+
+// Volume is an Amazon EFS file system, or a directory within one, which can be mounted into the
+// containers of a task. Volumes mounted through an access point are mounted with encryption in
+// transit as EFS requires. Mount points of a read-only volume are read-only.
+type Volume struct {
+	AccessPointID string `json:"accessPointId,omitempty"`
+	FileSystemID  string `json:"fileSystemId"`
+	Name          string `json:"name"`
+	ReadOnly      bool   `json:"readOnly,omitempty"`
+	RootDirectory string `json:"rootDirectory,omitempty"`
+}
+
+// MountPoint is a path within a container at which a volume is mounted.
+type MountPoint struct {
+	ContainerPath string `json:"containerPath"`
+	ReadOnly      bool   `json:"readOnly,omitempty"`
+	Volume        string `json:"volume"`
+}
+
+// String returns the location of the volume in the form fs-id:/path.
+func (v Volume) String() string {
+	rootDirectory := v.RootDirectory
+
+	if rootDirectory == "" {
+		rootDirectory = "/"
+	}
+
+	return fmt.Sprintf("%s:%s", v.FileSystemID, rootDirectory)
+}
+
+// String returns the mount point in the form volume:/path.
+func (m MountPoint) String() string {
+	return fmt.Sprintf("%s:%s", m.Volume, m.ContainerPath)
+}
+
+func (v Volume) definition() *awsecs.Volume {
+	configuration := &awsecs.EFSVolumeConfiguration{FileSystemId: aws.String(v.FileSystemID)}
+
+	if v.RootDirectory != "" {
+		configuration.RootDirectory = aws.String(v.RootDirectory)
+	}
+
+	if v.AccessPointID != "" {
+		configuration.AuthorizationConfig = &awsecs.EFSAuthorizationConfig{AccessPointId: aws.String(v.AccessPointID)}
+		configuration.TransitEncryption = aws.String(awsecs.EFSTransitEncryptionEnabled)
+	}
+
+	return &awsecs.Volume{
+		Name:                   aws.String(v.Name),
+		EfsVolumeConfiguration: configuration,
+	}
+}
+
+func volumeDefinitions(volumes []Volume) []*awsecs.Volume {
+	var definitions []*awsecs.Volume
+
+	for _, volume := range volumes {
+		definitions = append(definitions, volume.definition())
+	}
+
+	return definitions
+}
+
+// mountPointDefinitions returns the mount points of a container, making mount points of read-only
+// volumes read-only.
+func mountPointDefinitions(mountPoints []MountPoint, readOnly map[string]bool) []*awsecs.MountPoint {
+	var definitions []*awsecs.MountPoint
+
+	for _, mountPoint := range mountPoints {
+		definitions = append(definitions,
+			&awsecs.MountPoint{
+				ContainerPath: aws.String(mountPoint.ContainerPath),
+				ReadOnly:      aws.Bool(mountPoint.ReadOnly || readOnly[mountPoint.Volume]),
+				SourceVolume:  aws.String(mountPoint.Volume),
+			},
+		)
+	}
+
+	return definitions
+}
+
+func readOnlyVolumes(volumes []Volume) map[string]bool {
+	readOnly := make(map[string]bool)
+
+	for _, volume := range volumes {
+		readOnly[volume.Name] = volume.ReadOnly
+	}
+
+	return readOnly
+}
+
+// newVolumes returns the EFS volumes of a task definition. A volume is read-only if it is mounted
+// and every mount point of it is read-only. Volumes of other types are not supported by fargate and
+// are omitted.
+func newVolumes(volumeDefinitions []*awsecs.Volume, containerDefinitions []*awsecs.ContainerDefinition) []Volume {
+	var volumes []Volume
+
+	for _, definition := range volumeDefinitions {
+		configuration := definition.EfsVolumeConfiguration
+
+		if configuration == nil {
+			continue
+		}
+
+		volume := Volume{
+			FileSystemID:  aws.StringValue(configuration.FileSystemId),
+			Name:          aws.StringValue(definition.Name),
+			RootDirectory: aws.StringValue(configuration.RootDirectory),
+		}
+
+		if configuration.AuthorizationConfig != nil {
+			volume.AccessPointID = aws.StringValue(configuration.AuthorizationConfig.AccessPointId)
+		}
+
+		mounted, readOnly := false, true
+
+		for _, containerDefinition := range containerDefinitions {
+			for _, mountPoint := range containerDefinition.MountPoints {
+				if aws.StringValue(mountPoint.SourceVolume) == volume.Name {
+					mounted = true
+					readOnly = readOnly && aws.BoolValue(mountPoint.ReadOnly)
+				}
+			}
+		}
+
+		volume.ReadOnly = mounted && readOnly
+		volumes = append(volumes, volume)
+	}
+
+	return volumes
+}
+
+func newMountPoints(mountPointDefinitions []*awsecs.MountPoint) []MountPoint {
+	var mountPoints []MountPoint
+
+	for _, definition := range mountPointDefinitions {
+		mountPoints = append(mountPoints,
+			MountPoint{
+				ContainerPath: aws.StringValue(definition.ContainerPath),
+				ReadOnly:      aws.BoolValue(definition.ReadOnly),
+				Volume:        aws.StringValue(definition.SourceVolume),
+			},
+		)
+	}
+
+	return mountPoints
+}
+
+// updateVolumes applies the volume and mount point changes of an update to a task definition. Adding
+// a volume with the name of an existing volume replaces it. Removing a volume removes its mount points
+// from every container.
+func updateVolumes(taskDefinition *awsecs.TaskDefinition, update TaskDefinitionUpdate) {
+	readOnly := readOnlyVolumes(newVolumes(taskDefinition.Volumes, taskDefinition.ContainerDefinitions))
+	removed := make(map[string]bool)
+	replaced := make(map[string]Volume)
+
+	for _, name := range update.RemoveVolumes {
+		removed[name] = true
+	}
+
+	for _, volume := range update.Volumes {
+		replaced[volume.Name] = volume
+		readOnly[volume.Name] = volume.ReadOnly
+	}
+
+	var volumes []*awsecs.Volume
+
+	for _, volume := range taskDefinition.Volumes {
+		name := aws.StringValue(volume.Name)
+
+		if _, ok := replaced[name]; !ok && !removed[name] {
+			volumes = append(volumes, volume)
+		}
+	}
+
+	taskDefinition.Volumes = append(volumes, volumeDefinitions(update.Volumes)...)
+
+	for _, containerDefinition := range taskDefinition.ContainerDefinitions {
+		var mountPoints []*awsecs.MountPoint
+
+		for _, mountPoint := range containerDefinition.MountPoints {
+			name := aws.StringValue(mountPoint.SourceVolume)
+
+			if removed[name] {
+				continue
+			}
+
+			if volume, ok := replaced[name]; ok {
+				mountPoint.ReadOnly = aws.Bool(volume.ReadOnly)
+			}
+
+			mountPoints = append(mountPoints, mountPoint)
+		}
+
+		containerDefinition.MountPoints = mountPoints
+	}
+
+	if len(update.MountPoints) == 0 && len(update.RemoveMountPoints) == 0 {
+		return
+	}
+
+	containerDefinition := containerDefinition(taskDefinition, update.Container)
+	paths := make(map[string]bool)
+
+	for _, path := range update.RemoveMountPoints {
+		paths[path] = true
+	}
+
+	for _, mountPoint := range update.MountPoints {
+		paths[mountPoint.ContainerPath] = true
+	}
+
+	var mountPoints []*awsecs.MountPoint
+
+	for _, mountPoint := range containerDefinition.MountPoints {
+		if !paths[aws.StringValue(mountPoint.ContainerPath)] {
+			mountPoints = append(mountPoints, mountPoint)
+		}
+	}
+
+	containerDefinition.MountPoints = append(mountPoints, mountPointDefinitions(update.MountPoints, readOnly)...)
+}
@@ -0,0 +1,150 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/awslabs/fargatecli/ecs/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+func TestVolumeDefinition(t *testing.T) {
+	volumes := []Volume{
+		Volume{FileSystemID: "fs-1234", Name: "uploads", RootDirectory: "/uploads"},
+		Volume{AccessPointID: "fsap-5678", FileSystemID: "fs-1234", Name: "cache", ReadOnly: true},
+	}
+	mountPoints := []MountPoint{
+		MountPoint{ContainerPath: "/var/uploads", Volume: "uploads"},
+		MountPoint{ContainerPath: "/var/cache", Volume: "cache"},
+	}
+
+	definitions := volumeDefinitions(volumes)
+
+	if configuration := definitions[0].EfsVolumeConfiguration; configuration.AuthorizationConfig != nil || configuration.TransitEncryption != nil {
+		t.Errorf("expected no access point or transit encryption, got %v", configuration)
+	}
+
+	if configuration := definitions[1].EfsVolumeConfiguration; aws.StringValue(configuration.TransitEncryption) != awsecs.EFSTransitEncryptionEnabled {
+		t.Errorf("expected transit encryption to be enabled with an access point, got %v", configuration)
+	}
+
+	containerDefinitions := []*awsecs.ContainerDefinition{
+		&awsecs.ContainerDefinition{MountPoints: mountPointDefinitions(mountPoints, readOnlyVolumes(volumes))},
+	}
+
+	if expected, got := volumes, newVolumes(definitions, containerDefinitions); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+
+	expectedMountPoints := []MountPoint{
+		MountPoint{ContainerPath: "/var/uploads", Volume: "uploads"},
+		MountPoint{ContainerPath: "/var/cache", ReadOnly: true, Volume: "cache"},
+	}
+
+	if got := newMountPoints(containerDefinitions[0].MountPoints); !reflect.DeepEqual(expectedMountPoints, got) {
+		t.Errorf("expected %+v, got %+v", expectedMountPoints, got)
+	}
+}
+
+func TestVolumeString(t *testing.T) {
+	if expected, got := "fs-1234:/", (Volume{FileSystemID: "fs-1234"}).String(); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if expected, got := "fs-1234:/uploads", (Volume{FileSystemID: "fs-1234", RootDirectory: "/uploads"}).String(); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if expected, got := "uploads:/var/uploads", (MountPoint{ContainerPath: "/var/uploads", Volume: "uploads"}).String(); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestNewVolumesSkipsOtherVolumeTypes(t *testing.T) {
+	definitions := []*awsecs.Volume{&awsecs.Volume{Name: aws.String("scratch")}}
+
+	if volumes := newVolumes(definitions, nil); len(volumes) != 0 {
+		t.Errorf("expected no volumes, got %+v", volumes)
+	}
+}
+
+func TestUpdateTaskDefinitionVolumes(t *testing.T) {
+	taskDefinitionARN := "arn:aws:ecs:us-east-1:123456789012:task-definition/service_volumes:1"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockECSAPI := sdk.NewMockECSAPI(mockCtrl)
+	ecs := ECS{svc: mockECSAPI, ClusterName: "fargate"}
+
+	mockECSAPI.EXPECT().DescribeTaskDefinition(gomock.Any()).Return(
+		&awsecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &awsecs.TaskDefinition{
+				ContainerDefinitions: []*awsecs.ContainerDefinition{
+					&awsecs.ContainerDefinition{
+						Name: aws.String("web"),
+						MountPoints: []*awsecs.MountPoint{
+							&awsecs.MountPoint{ContainerPath: aws.String("/var/uploads"), ReadOnly: aws.Bool(false), SourceVolume: aws.String("uploads")},
+							&awsecs.MountPoint{ContainerPath: aws.String("/var/cache"), ReadOnly: aws.Bool(false), SourceVolume: aws.String("cache")},
+						},
+					},
+					&awsecs.ContainerDefinition{
+						Name: aws.String("proxy"),
+						MountPoints: []*awsecs.MountPoint{
+							&awsecs.MountPoint{ContainerPath: aws.String("/cache"), ReadOnly: aws.Bool(false), SourceVolume: aws.String("cache")},
+						},
+					},
+				},
+				Family:            aws.String("service_volumes"),
+				TaskDefinitionArn: aws.String(taskDefinitionARN),
+				Volumes:           volumeDefinitions([]Volume{Volume{FileSystemID: "fs-1", Name: "uploads"}, Volume{FileSystemID: "fs-2", Name: "cache"}}),
+			},
+		},
+		nil,
+	)
+	mockECSAPI.EXPECT().RegisterTaskDefinition(gomock.Any()).DoAndReturn(
+		func(input *awsecs.RegisterTaskDefinitionInput) (*awsecs.RegisterTaskDefinitionOutput, error) {
+			expectedVolumes := []Volume{
+				Volume{FileSystemID: "fs-1", Name: "uploads", ReadOnly: true},
+				Volume{AccessPointID: "fsap-3", FileSystemID: "fs-3", Name: "assets", ReadOnly: true},
+			}
+
+			if got := newVolumes(input.Volumes, input.ContainerDefinitions); !reflect.DeepEqual(expectedVolumes, got) {
+				t.Errorf("expected volumes %+v, got %+v", expectedVolumes, got)
+			}
+
+			expectedMountPoints := []MountPoint{
+				MountPoint{ContainerPath: "/var/uploads", ReadOnly: true, Volume: "uploads"},
+				MountPoint{ContainerPath: "/var/assets", ReadOnly: true, Volume: "assets"},
+			}
+
+			if got := newMountPoints(input.ContainerDefinitions[0].MountPoints); !reflect.DeepEqual(expectedMountPoints, got) {
+				t.Errorf("expected mount points %+v, got %+v", expectedMountPoints, got)
+			}
+
+			if got := input.ContainerDefinitions[1].MountPoints; len(got) != 0 {
+				t.Errorf("expected mount points of removed volume to be removed, got %v", got)
+			}
+
+			return &awsecs.RegisterTaskDefinitionOutput{
+				TaskDefinition: &awsecs.TaskDefinition{
+					TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/service_volumes:2"),
+				},
+			}, nil
+		},
+	)
+
+	ecs.UpdateTaskDefinition(
+		taskDefinitionARN,
+		TaskDefinitionUpdate{
+			MountPoints:   []MountPoint{MountPoint{ContainerPath: "/var/assets", Volume: "assets"}},
+			RemoveVolumes: []string{"cache"},
+			Volumes: []Volume{
+				Volume{FileSystemID: "fs-1", Name: "uploads", ReadOnly: true},
+				Volume{AccessPointID: "fsap-3", FileSystemID: "fs-3", Name: "assets", ReadOnly: true},
+			},
+		},
+	)
+}
@@ -13,11 +13,11 @@ import (
 
 // Listener accepts incoming traffic on a load balancer based upon the provided routing rules.
 type Listener struct {
-	ARN             string
-	CertificateARNs []string
-	Port            int64
-	Protocol        string
-	Rules           []Rule
+	ARN             string   `json:"arn"`
+	CertificateARNs []string `json:"certificateArns"`
+	Port            int64    `json:"port"`
+	Protocol        string   `json:"protocol"`
+	Rules           []Rule   `json:"rules"`
 }
 
 // String returns a friendly representation of the listener.
@@ -41,12 +41,13 @@ func (l Listeners) String() string {
 
 // Rule defines a routing rule defining how traffic should be routed to a listener.
 type Rule struct {
-	ARN            string
-	IsDefault      bool
-	Priority       int
-	TargetGroupARN string
-	Type           string
-	Value          string
+	ARN            string `json:"arn"`
+	IsDefault      bool   `json:"isDefault"`
+	ListenerARN    string `json:"listenerArn,omitempty"`
+	Priority       int    `json:"priority"`
+	TargetGroupARN string `json:"targetGroupArn"`
+	Type           string `json:"type"`
+	Value          string `json:"value"`
 }
 
 // String returns a friendly representation of a rule.
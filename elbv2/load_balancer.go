@@ -10,16 +10,16 @@ import (
 
 // LoadBalancer represents an Elastic Load Balancing (v2) load balancer.
 type LoadBalancer struct {
-	ARN              string
-	DNSName          string
-	HostedZoneID     string
-	Listeners        Listeners
-	Name             string
-	SecurityGroupIDs []string
-	Status           string
-	SubnetIDs        []string
-	Type             string
-	VPCID            string
+	ARN              string    `json:"arn"`
+	DNSName          string    `json:"dnsName"`
+	HostedZoneID     string    `json:"hostedZoneId"`
+	Listeners        Listeners `json:"listeners"`
+	Name             string    `json:"name"`
+	SecurityGroupIDs []string  `json:"securityGroupIds"`
+	Status           string    `json:"status"`
+	SubnetIDs        []string  `json:"subnetIds"`
+	Type             string    `json:"type"`
+	VPCID            string    `json:"vpcId"`
 }
 
 // LoadBalancers is a collection of Elastic Load Balancing (v2) load balancers.
@@ -15,11 +15,19 @@ type Client interface {
 	CreateListener(CreateListenerParameters) (string, error)
 	DescribeListeners(string) (Listeners, error)
 
+	DescribeForwardRules(string) ([]Rule, error)
+	ModifyRuleWeights(Rule, []TargetGroupWeight) error
+
 	DescribeLoadBalancers() (LoadBalancers, error)
 	DescribeLoadBalancersByName([]string) (LoadBalancers, error)
 	CreateLoadBalancer(CreateLoadBalancerParameters) (string, error)
 
+	CloneTargetGroup(string, string) (string, error)
 	CreateTargetGroup(CreateTargetGroupParameters) (string, error)
+	DescribeTargetGroupSettings(string) (TargetGroupSettings, error)
+	DescribeTargetHealth(string) (TargetHealths, error)
+	DestroyTargetGroup(string) error
+	ModifyTargetGroup(ModifyTargetGroupParameters) error
 }
 
 // SDKClient implements access to Elastic Load Balancing (v2) via the AWS SDK.
@@ -33,6 +33,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// CloneTargetGroup mocks base method
+func (m *MockClient) CloneTargetGroup(arg0, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloneTargetGroup", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloneTargetGroup indicates an expected call of CloneTargetGroup
+func (mr *MockClientMockRecorder) CloneTargetGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneTargetGroup", reflect.TypeOf((*MockClient)(nil).CloneTargetGroup), arg0, arg1)
+}
+
 // CreateListener mocks base method
 func (m *MockClient) CreateListener(arg0 elbv2.CreateListenerParameters) (string, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +93,21 @@ func (mr *MockClientMockRecorder) CreateTargetGroup(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTargetGroup", reflect.TypeOf((*MockClient)(nil).CreateTargetGroup), arg0)
 }
 
+// DescribeForwardRules mocks base method
+func (m *MockClient) DescribeForwardRules(arg0 string) ([]elbv2.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeForwardRules", arg0)
+	ret0, _ := ret[0].([]elbv2.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeForwardRules indicates an expected call of DescribeForwardRules
+func (mr *MockClientMockRecorder) DescribeForwardRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeForwardRules", reflect.TypeOf((*MockClient)(nil).DescribeForwardRules), arg0)
+}
+
 // DescribeListeners mocks base method
 func (m *MockClient) DescribeListeners(arg0 string) (elbv2.Listeners, error) {
 	m.ctrl.T.Helper()
@@ -122,3 +152,75 @@ func (mr *MockClientMockRecorder) DescribeLoadBalancersByName(arg0 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLoadBalancersByName", reflect.TypeOf((*MockClient)(nil).DescribeLoadBalancersByName), arg0)
 }
+
+// DescribeTargetGroupSettings mocks base method
+func (m *MockClient) DescribeTargetGroupSettings(arg0 string) (elbv2.TargetGroupSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTargetGroupSettings", arg0)
+	ret0, _ := ret[0].(elbv2.TargetGroupSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTargetGroupSettings indicates an expected call of DescribeTargetGroupSettings
+func (mr *MockClientMockRecorder) DescribeTargetGroupSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTargetGroupSettings", reflect.TypeOf((*MockClient)(nil).DescribeTargetGroupSettings), arg0)
+}
+
+// DescribeTargetHealth mocks base method
+func (m *MockClient) DescribeTargetHealth(arg0 string) (elbv2.TargetHealths, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTargetHealth", arg0)
+	ret0, _ := ret[0].(elbv2.TargetHealths)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTargetHealth indicates an expected call of DescribeTargetHealth
+func (mr *MockClientMockRecorder) DescribeTargetHealth(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTargetHealth", reflect.TypeOf((*MockClient)(nil).DescribeTargetHealth), arg0)
+}
+
+// DestroyTargetGroup mocks base method
+func (m *MockClient) DestroyTargetGroup(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyTargetGroup", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyTargetGroup indicates an expected call of DestroyTargetGroup
+func (mr *MockClientMockRecorder) DestroyTargetGroup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyTargetGroup", reflect.TypeOf((*MockClient)(nil).DestroyTargetGroup), arg0)
+}
+
+// ModifyRuleWeights mocks base method
+func (m *MockClient) ModifyRuleWeights(arg0 elbv2.Rule, arg1 []elbv2.TargetGroupWeight) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyRuleWeights", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModifyRuleWeights indicates an expected call of ModifyRuleWeights
+func (mr *MockClientMockRecorder) ModifyRuleWeights(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyRuleWeights", reflect.TypeOf((*MockClient)(nil).ModifyRuleWeights), arg0, arg1)
+}
+
+// ModifyTargetGroup mocks base method
+func (m *MockClient) ModifyTargetGroup(arg0 elbv2.ModifyTargetGroupParameters) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyTargetGroup", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModifyTargetGroup indicates an expected call of ModifyTargetGroup
+func (mr *MockClientMockRecorder) ModifyTargetGroup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyTargetGroup", reflect.TypeOf((*MockClient)(nil).ModifyTargetGroup), arg0)
+}
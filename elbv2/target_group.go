@@ -1,32 +1,125 @@
 package elbv2
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/aws/aws-sdk-go/aws"
 	awselbv2 "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/awslabs/fargatecli/console"
 )
 
 type TargetGroup struct {
-	Name            string
-	Arn             string
-	LoadBalancerARN string
+	Name            string `json:"name"`
+	Arn             string `json:"arn"`
+	LoadBalancerARN string `json:"loadBalancerArn"`
+}
+
+const deregistrationDelayAttribute = "deregistration_delay.timeout_seconds"
+
+// HealthCheck is the configuration of the health checks a target group performs on its targets.
+// Zero values are left at their defaults when creating a target group and unchanged when modifying
+// one.
+type HealthCheck struct {
+	HealthyThreshold   int64  `json:"healthyThreshold,omitempty"`
+	IntervalSeconds    int64  `json:"intervalSeconds,omitempty"`
+	Matcher            string `json:"matcher,omitempty"`
+	Path               string `json:"path,omitempty"`
+	UnhealthyThreshold int64  `json:"unhealthyThreshold,omitempty"`
+}
+
+// IsEmpty returns whether no health check settings are configured.
+func (h HealthCheck) IsEmpty() bool {
+	return h == HealthCheck{}
+}
+
+// TargetGroupSettings are the health check and deregistration settings of a target group.
+type TargetGroupSettings struct {
+	DeregistrationDelay int64       `json:"deregistrationDelay"`
+	HealthCheck         HealthCheck `json:"healthCheck"`
 }
 
+// TargetHealth is the health of a target registered with a target group.
+type TargetHealth struct {
+	Description string `json:"description,omitempty"`
+	ID          string `json:"id"`
+	Port        int64  `json:"port"`
+	Reason      string `json:"reason,omitempty"`
+	State       string `json:"state"`
+}
+
+// TargetHealths is a collection of target healths.
+type TargetHealths []TargetHealth
+
 type CreateTargetGroupParameters struct {
-	Name     string
-	Port     int64
-	Protocol string
-	VPCID    string
+	DeregistrationDelay *int64
+	HealthCheck         HealthCheck
+	Name                string
+	Port                int64
+	Protocol            string
+	VPCID               string
+}
+
+// ModifyTargetGroupParameters are the parameters required to change the health check and
+// deregistration settings of a target group. A nil DeregistrationDelay is left unchanged.
+type ModifyTargetGroupParameters struct {
+	ARN                 string
+	DeregistrationDelay *int64
+	HealthCheck         HealthCheck
 }
 
 func (elbv2 SDKClient) CreateTargetGroup(i CreateTargetGroupParameters) (string, error) {
-	resp, err := elbv2.client.CreateTargetGroup(
-		&awselbv2.CreateTargetGroupInput{
-			Name:       aws.String(i.Name),
-			Port:       aws.Int64(i.Port),
-			Protocol:   aws.String(i.Protocol),
-			TargetType: aws.String(awselbv2.TargetTypeEnumIp),
-			VpcId:      aws.String(i.VPCID),
+	input := &awselbv2.CreateTargetGroupInput{
+		Name:       aws.String(i.Name),
+		Port:       aws.Int64(i.Port),
+		Protocol:   aws.String(i.Protocol),
+		TargetType: aws.String(awselbv2.TargetTypeEnumIp),
+		VpcId:      aws.String(i.VPCID),
+	}
+
+	if i.HealthCheck.Path != "" {
+		input.HealthCheckPath = aws.String(i.HealthCheck.Path)
+	}
+
+	if i.HealthCheck.IntervalSeconds != 0 {
+		input.HealthCheckIntervalSeconds = aws.Int64(i.HealthCheck.IntervalSeconds)
+	}
+
+	if i.HealthCheck.HealthyThreshold != 0 {
+		input.HealthyThresholdCount = aws.Int64(i.HealthCheck.HealthyThreshold)
+	}
+
+	if i.HealthCheck.UnhealthyThreshold != 0 {
+		input.UnhealthyThresholdCount = aws.Int64(i.HealthCheck.UnhealthyThreshold)
+	}
+
+	if i.HealthCheck.Matcher != "" {
+		input.Matcher = &awselbv2.Matcher{HttpCode: aws.String(i.HealthCheck.Matcher)}
+	}
+
+	resp, err := elbv2.client.CreateTargetGroup(input)
+
+	if err != nil {
+		return "", err
+	}
+
+	targetGroupARN := aws.StringValue(resp.TargetGroups[0].TargetGroupArn)
+
+	if i.DeregistrationDelay != nil {
+		if err := elbv2.modifyDeregistrationDelay(targetGroupARN, *i.DeregistrationDelay); err != nil {
+			return targetGroupARN, err
+		}
+	}
+
+	return targetGroupARN, nil
+}
+
+// CloneTargetGroup creates a target group with the given name and the port, protocol, VPC, health
+// check, and deregistration settings of an existing target group, returning its ARN.
+func (elbv2 SDKClient) CloneTargetGroup(targetGroupARN, name string) (string, error) {
+	resp, err := elbv2.client.DescribeTargetGroups(
+		&awselbv2.DescribeTargetGroupsInput{
+			TargetGroupArns: aws.StringSlice([]string{targetGroupARN}),
 		},
 	)
 
@@ -34,7 +127,162 @@ func (elbv2 SDKClient) CreateTargetGroup(i CreateTargetGroupParameters) (string,
 		return "", err
 	}
 
-	return aws.StringValue(resp.TargetGroups[0].TargetGroupArn), nil
+	if len(resp.TargetGroups) != 1 {
+		return "", fmt.Errorf("could not find target group %s", targetGroupARN)
+	}
+
+	settings, err := elbv2.DescribeTargetGroupSettings(targetGroupARN)
+
+	if err != nil {
+		return "", err
+	}
+
+	targetGroup := resp.TargetGroups[0]
+
+	return elbv2.CreateTargetGroup(
+		CreateTargetGroupParameters{
+			DeregistrationDelay: aws.Int64(settings.DeregistrationDelay),
+			HealthCheck:         settings.HealthCheck,
+			Name:                name,
+			Port:                aws.Int64Value(targetGroup.Port),
+			Protocol:            aws.StringValue(targetGroup.Protocol),
+			VPCID:               aws.StringValue(targetGroup.VpcId),
+		},
+	)
+}
+
+// ModifyTargetGroup changes the health check and deregistration settings of a target group.
+func (elbv2 SDKClient) ModifyTargetGroup(p ModifyTargetGroupParameters) error {
+	if !p.HealthCheck.IsEmpty() {
+		input := &awselbv2.ModifyTargetGroupInput{
+			TargetGroupArn: aws.String(p.ARN),
+		}
+
+		if p.HealthCheck.Path != "" {
+			input.HealthCheckPath = aws.String(p.HealthCheck.Path)
+		}
+
+		if p.HealthCheck.IntervalSeconds != 0 {
+			input.HealthCheckIntervalSeconds = aws.Int64(p.HealthCheck.IntervalSeconds)
+		}
+
+		if p.HealthCheck.HealthyThreshold != 0 {
+			input.HealthyThresholdCount = aws.Int64(p.HealthCheck.HealthyThreshold)
+		}
+
+		if p.HealthCheck.UnhealthyThreshold != 0 {
+			input.UnhealthyThresholdCount = aws.Int64(p.HealthCheck.UnhealthyThreshold)
+		}
+
+		if p.HealthCheck.Matcher != "" {
+			input.Matcher = &awselbv2.Matcher{HttpCode: aws.String(p.HealthCheck.Matcher)}
+		}
+
+		if _, err := elbv2.client.ModifyTargetGroup(input); err != nil {
+			return err
+		}
+	}
+
+	if p.DeregistrationDelay != nil {
+		return elbv2.modifyDeregistrationDelay(p.ARN, *p.DeregistrationDelay)
+	}
+
+	return nil
+}
+
+// DescribeTargetGroupSettings returns the health check and deregistration settings of a target
+// group.
+func (elbv2 SDKClient) DescribeTargetGroupSettings(targetGroupARN string) (TargetGroupSettings, error) {
+	var settings TargetGroupSettings
+
+	resp, err := elbv2.client.DescribeTargetGroups(
+		&awselbv2.DescribeTargetGroupsInput{
+			TargetGroupArns: aws.StringSlice([]string{targetGroupARN}),
+		},
+	)
+
+	if err != nil {
+		return settings, err
+	}
+
+	for _, targetGroup := range resp.TargetGroups {
+		settings.HealthCheck = HealthCheck{
+			HealthyThreshold:   aws.Int64Value(targetGroup.HealthyThresholdCount),
+			IntervalSeconds:    aws.Int64Value(targetGroup.HealthCheckIntervalSeconds),
+			Path:               aws.StringValue(targetGroup.HealthCheckPath),
+			UnhealthyThreshold: aws.Int64Value(targetGroup.UnhealthyThresholdCount),
+		}
+
+		if targetGroup.Matcher != nil {
+			settings.HealthCheck.Matcher = aws.StringValue(targetGroup.Matcher.HttpCode)
+		}
+	}
+
+	attributesResp, err := elbv2.client.DescribeTargetGroupAttributes(
+		&awselbv2.DescribeTargetGroupAttributesInput{
+			TargetGroupArn: aws.String(targetGroupARN),
+		},
+	)
+
+	if err != nil {
+		return settings, err
+	}
+
+	for _, attribute := range attributesResp.Attributes {
+		if aws.StringValue(attribute.Key) == deregistrationDelayAttribute {
+			settings.DeregistrationDelay, _ = strconv.ParseInt(aws.StringValue(attribute.Value), 10, 64)
+		}
+	}
+
+	return settings, nil
+}
+
+// DescribeTargetHealth returns the health of each target registered with a target group.
+func (elbv2 SDKClient) DescribeTargetHealth(targetGroupARN string) (TargetHealths, error) {
+	var targetHealths TargetHealths
+
+	resp, err := elbv2.client.DescribeTargetHealth(
+		&awselbv2.DescribeTargetHealthInput{
+			TargetGroupArn: aws.String(targetGroupARN),
+		},
+	)
+
+	if err != nil {
+		return targetHealths, err
+	}
+
+	for _, description := range resp.TargetHealthDescriptions {
+		targetHealth := TargetHealth{
+			ID:   aws.StringValue(description.Target.Id),
+			Port: aws.Int64Value(description.Target.Port),
+		}
+
+		if description.TargetHealth != nil {
+			targetHealth.Description = aws.StringValue(description.TargetHealth.Description)
+			targetHealth.Reason = aws.StringValue(description.TargetHealth.Reason)
+			targetHealth.State = aws.StringValue(description.TargetHealth.State)
+		}
+
+		targetHealths = append(targetHealths, targetHealth)
+	}
+
+	return targetHealths, nil
+}
+
+func (elbv2 SDKClient) modifyDeregistrationDelay(targetGroupARN string, seconds int64) error {
+	_, err := elbv2.client.ModifyTargetGroupAttributes(
+		&awselbv2.ModifyTargetGroupAttributesInput{
+			Attributes: []*awselbv2.TargetGroupAttribute{
+				&awselbv2.TargetGroupAttribute{
+					Key:   aws.String(deregistrationDelayAttribute),
+					Value: aws.String(strconv.FormatInt(seconds, 10)),
+				},
+			},
+			TargetGroupArn: aws.String(targetGroupARN),
+		},
+	)
+
+	return err
 }
 
 func (elbv2 SDKClient) DeleteTargetGroup(targetGroupName string) {
@@ -49,6 +297,17 @@ func (elbv2 SDKClient) DeleteTargetGroup(targetGroupName string) {
 	)
 }
 
+// DestroyTargetGroup deletes a target group by ARN.
+func (elbv2 SDKClient) DestroyTargetGroup(targetGroupARN string) error {
+	_, err := elbv2.client.DeleteTargetGroup(
+		&awselbv2.DeleteTargetGroupInput{
+			TargetGroupArn: aws.String(targetGroupARN),
+		},
+	)
+
+	return err
+}
+
 func (elbv2 SDKClient) DeleteTargetGroupByArn(targetGroupARN string) {
 	_, err := elbv2.client.DeleteTargetGroup(
 		&awselbv2.DeleteTargetGroupInput{
@@ -84,3 +84,307 @@ func TestCreateTargetGroupError(t *testing.T) {
 		t.Errorf("expected empty ARN, got %s", arn)
 	}
 }
+
+func TestCreateTargetGroupWithHealthCheck(t *testing.T) {
+	targetGroupARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-targets/73e2d6bc24d8a067"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockELBV2API := sdk.NewMockELBV2API(mockCtrl)
+	elbv2 := SDKClient{client: mockELBV2API}
+
+	i := &awselbv2.CreateTargetGroupInput{
+		HealthCheckIntervalSeconds: aws.Int64(10),
+		HealthCheckPath:            aws.String("/healthz"),
+		HealthyThresholdCount:      aws.Int64(2),
+		Matcher:                    &awselbv2.Matcher{HttpCode: aws.String("200-299")},
+		Name:                       aws.String("default"),
+		Port:                       aws.Int64(80),
+		Protocol:                   aws.String("HTTP"),
+		TargetType:                 aws.String("ip"),
+		UnhealthyThresholdCount:    aws.Int64(3),
+		VpcId:                      aws.String("vpc-1234567"),
+	}
+	o := &awselbv2.CreateTargetGroupOutput{
+		TargetGroups: []*awselbv2.TargetGroup{
+			&awselbv2.TargetGroup{
+				TargetGroupArn: aws.String(targetGroupARN),
+			},
+		},
+	}
+	attributesInput := &awselbv2.ModifyTargetGroupAttributesInput{
+		Attributes: []*awselbv2.TargetGroupAttribute{
+			&awselbv2.TargetGroupAttribute{
+				Key:   aws.String("deregistration_delay.timeout_seconds"),
+				Value: aws.String("30"),
+			},
+		},
+		TargetGroupArn: aws.String(targetGroupARN),
+	}
+
+	mockELBV2API.EXPECT().CreateTargetGroup(i).Return(o, nil)
+	mockELBV2API.EXPECT().ModifyTargetGroupAttributes(attributesInput).Return(&awselbv2.ModifyTargetGroupAttributesOutput{}, nil)
+
+	arn, err := elbv2.CreateTargetGroup(
+		CreateTargetGroupParameters{
+			DeregistrationDelay: aws.Int64(30),
+			HealthCheck: HealthCheck{
+				HealthyThreshold:   2,
+				IntervalSeconds:    10,
+				Matcher:            "200-299",
+				Path:               "/healthz",
+				UnhealthyThreshold: 3,
+			},
+			Name:     "default",
+			Port:     80,
+			Protocol: "HTTP",
+			VPCID:    "vpc-1234567",
+		},
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if arn != targetGroupARN {
+		t.Errorf("expected ARN %s, got %s", targetGroupARN, arn)
+	}
+}
+
+func TestModifyTargetGroup(t *testing.T) {
+	targetGroupARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-targets/73e2d6bc24d8a067"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockELBV2API := sdk.NewMockELBV2API(mockCtrl)
+	elbv2 := SDKClient{client: mockELBV2API}
+
+	i := &awselbv2.ModifyTargetGroupInput{
+		HealthCheckPath: aws.String("/healthz"),
+		TargetGroupArn:  aws.String(targetGroupARN),
+	}
+
+	mockELBV2API.EXPECT().ModifyTargetGroup(i).Return(&awselbv2.ModifyTargetGroupOutput{}, nil)
+
+	err := elbv2.ModifyTargetGroup(
+		ModifyTargetGroupParameters{
+			ARN:         targetGroupARN,
+			HealthCheck: HealthCheck{Path: "/healthz"},
+		},
+	)
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestModifyTargetGroupDeregistrationDelayOnly(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockELBV2API := sdk.NewMockELBV2API(mockCtrl)
+	elbv2 := SDKClient{client: mockELBV2API}
+
+	mockELBV2API.EXPECT().ModifyTargetGroupAttributes(gomock.Any()).Return(nil, errors.New("boom"))
+
+	err := elbv2.ModifyTargetGroup(
+		ModifyTargetGroupParameters{
+			ARN:                 "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-targets/73e2d6bc24d8a067",
+			DeregistrationDelay: aws.Int64(0),
+		},
+	)
+
+	if err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+func TestDescribeTargetGroupSettings(t *testing.T) {
+	targetGroupARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-targets/73e2d6bc24d8a067"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockELBV2API := sdk.NewMockELBV2API(mockCtrl)
+	elbv2 := SDKClient{client: mockELBV2API}
+
+	mockELBV2API.EXPECT().DescribeTargetGroups(
+		&awselbv2.DescribeTargetGroupsInput{TargetGroupArns: aws.StringSlice([]string{targetGroupARN})},
+	).Return(
+		&awselbv2.DescribeTargetGroupsOutput{
+			TargetGroups: []*awselbv2.TargetGroup{
+				&awselbv2.TargetGroup{
+					HealthCheckIntervalSeconds: aws.Int64(30),
+					HealthCheckPath:            aws.String("/healthz"),
+					HealthyThresholdCount:      aws.Int64(5),
+					Matcher:                    &awselbv2.Matcher{HttpCode: aws.String("200")},
+					UnhealthyThresholdCount:    aws.Int64(2),
+				},
+			},
+		}, nil,
+	)
+	mockELBV2API.EXPECT().DescribeTargetGroupAttributes(
+		&awselbv2.DescribeTargetGroupAttributesInput{TargetGroupArn: aws.String(targetGroupARN)},
+	).Return(
+		&awselbv2.DescribeTargetGroupAttributesOutput{
+			Attributes: []*awselbv2.TargetGroupAttribute{
+				&awselbv2.TargetGroupAttribute{Key: aws.String("stickiness.enabled"), Value: aws.String("false")},
+				&awselbv2.TargetGroupAttribute{Key: aws.String("deregistration_delay.timeout_seconds"), Value: aws.String("300")},
+			},
+		}, nil,
+	)
+
+	settings, err := elbv2.DescribeTargetGroupSettings(targetGroupARN)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := TargetGroupSettings{
+		DeregistrationDelay: 300,
+		HealthCheck: HealthCheck{
+			HealthyThreshold:   5,
+			IntervalSeconds:    30,
+			Matcher:            "200",
+			Path:               "/healthz",
+			UnhealthyThreshold: 2,
+		},
+	}
+
+	if settings != expected {
+		t.Errorf("expected %+v, got %+v", expected, settings)
+	}
+}
+
+func TestDescribeTargetHealth(t *testing.T) {
+	targetGroupARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-targets/73e2d6bc24d8a067"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockELBV2API := sdk.NewMockELBV2API(mockCtrl)
+	elbv2 := SDKClient{client: mockELBV2API}
+
+	mockELBV2API.EXPECT().DescribeTargetHealth(
+		&awselbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(targetGroupARN)},
+	).Return(
+		&awselbv2.DescribeTargetHealthOutput{
+			TargetHealthDescriptions: []*awselbv2.TargetHealthDescription{
+				&awselbv2.TargetHealthDescription{
+					Target:       &awselbv2.TargetDescription{Id: aws.String("10.0.0.1"), Port: aws.Int64(80)},
+					TargetHealth: &awselbv2.TargetHealth{State: aws.String("healthy")},
+				},
+				&awselbv2.TargetHealthDescription{
+					Target: &awselbv2.TargetDescription{Id: aws.String("10.0.0.2"), Port: aws.Int64(80)},
+					TargetHealth: &awselbv2.TargetHealth{
+						Description: aws.String("Health checks failed with these codes: [404]"),
+						Reason:      aws.String("Target.ResponseCodeMismatch"),
+						State:       aws.String("unhealthy"),
+					},
+				},
+			},
+		}, nil,
+	)
+
+	targetHealths, err := elbv2.DescribeTargetHealth(targetGroupARN)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(targetHealths) != 2 {
+		t.Fatalf("expected 2 target healths, got %d", len(targetHealths))
+	}
+
+	expected := TargetHealth{
+		Description: "Health checks failed with these codes: [404]",
+		ID:          "10.0.0.2",
+		Port:        80,
+		Reason:      "Target.ResponseCodeMismatch",
+		State:       "unhealthy",
+	}
+
+	if targetHealths[1] != expected {
+		t.Errorf("expected %+v, got %+v", expected, targetHealths[1])
+	}
+}
+
+func TestDescribeTargetHealthError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockELBV2API := sdk.NewMockELBV2API(mockCtrl)
+	elbv2 := SDKClient{client: mockELBV2API}
+
+	mockELBV2API.EXPECT().DescribeTargetHealth(gomock.Any()).Return(nil, errors.New("boom"))
+
+	if _, err := elbv2.DescribeTargetHealth("arn"); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+func TestCloneTargetGroup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockELBV2API := sdk.NewMockELBV2API(mockCtrl)
+	elbv2 := SDKClient{client: mockELBV2API}
+
+	describeOutput := &awselbv2.DescribeTargetGroupsOutput{
+		TargetGroups: []*awselbv2.TargetGroup{
+			&awselbv2.TargetGroup{
+				HealthCheckPath: aws.String("/healthz"),
+				Port:            aws.Int64(80),
+				Protocol:        aws.String("HTTP"),
+				TargetGroupArn:  aws.String(blueTargetGroupARN),
+				VpcId:           aws.String("vpc-1234567"),
+			},
+		},
+	}
+	createInput := &awselbv2.CreateTargetGroupInput{
+		HealthCheckPath: aws.String("/healthz"),
+		Name:            aws.String("fargate-web-green"),
+		Port:            aws.Int64(80),
+		Protocol:        aws.String("HTTP"),
+		TargetType:      aws.String("ip"),
+		VpcId:           aws.String("vpc-1234567"),
+	}
+
+	mockELBV2API.EXPECT().DescribeTargetGroups(gomock.Any()).Return(describeOutput, nil).Times(2)
+	mockELBV2API.EXPECT().DescribeTargetGroupAttributes(gomock.Any()).Return(
+		&awselbv2.DescribeTargetGroupAttributesOutput{
+			Attributes: []*awselbv2.TargetGroupAttribute{
+				&awselbv2.TargetGroupAttribute{Key: aws.String("deregistration_delay.timeout_seconds"), Value: aws.String("30")},
+			},
+		},
+		nil,
+	)
+	mockELBV2API.EXPECT().CreateTargetGroup(createInput).Return(
+		&awselbv2.CreateTargetGroupOutput{
+			TargetGroups: []*awselbv2.TargetGroup{
+				&awselbv2.TargetGroup{TargetGroupArn: aws.String(greenTargetGroupARN)},
+			},
+		},
+		nil,
+	)
+	mockELBV2API.EXPECT().ModifyTargetGroupAttributes(
+		&awselbv2.ModifyTargetGroupAttributesInput{
+			Attributes: []*awselbv2.TargetGroupAttribute{
+				&awselbv2.TargetGroupAttribute{Key: aws.String("deregistration_delay.timeout_seconds"), Value: aws.String("30")},
+			},
+			TargetGroupArn: aws.String(greenTargetGroupARN),
+		},
+	).Return(&awselbv2.ModifyTargetGroupAttributesOutput{}, nil)
+
+	arn, err := elbv2.CloneTargetGroup(blueTargetGroupARN, "fargate-web-green")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if arn != greenTargetGroupARN {
+		t.Errorf("expected ARN %s, got %s", greenTargetGroupARN, arn)
+	}
+}
@@ -0,0 +1,166 @@
+package elbv2
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awselbv2 "github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+const ruleTypeDefault = "DEFAULT"
+
+// TargetGroupWeight is the relative share of traffic a forward action sends to a target group.
+type TargetGroupWeight struct {
+	ARN    string `json:"arn"`
+	Weight int64  `json:"weight"`
+}
+
+// DescribeForwardRules returns the listener rules, including default actions, on the load balancer of
+// a target group which forward traffic to the target group.
+func (elbv2 SDKClient) DescribeForwardRules(targetGroupARN string) ([]Rule, error) {
+	var rules []Rule
+
+	resp, err := elbv2.client.DescribeTargetGroups(
+		&awselbv2.DescribeTargetGroupsInput{
+			TargetGroupArns: aws.StringSlice([]string{targetGroupARN}),
+		},
+	)
+
+	if err != nil {
+		return rules, err
+	}
+
+	if len(resp.TargetGroups) != 1 {
+		return rules, fmt.Errorf("could not find target group %s", targetGroupARN)
+	}
+
+	for _, lbARN := range resp.TargetGroups[0].LoadBalancerArns {
+		listeners, err := elbv2.DescribeListeners(aws.StringValue(lbARN))
+
+		if err != nil {
+			return rules, err
+		}
+
+		for _, listener := range listeners {
+			rulesResp, err := elbv2.client.DescribeRules(
+				&awselbv2.DescribeRulesInput{
+					ListenerArn: aws.String(listener.ARN),
+				},
+			)
+
+			if err != nil {
+				return rules, err
+			}
+
+			for _, r := range rulesResp.Rules {
+				if !forwardsTo(r.Actions, targetGroupARN) {
+					continue
+				}
+
+				rules = append(rules, newForwardRule(listener.ARN, r, targetGroupARN))
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+// ModifyRuleWeights changes the forward action of a listener rule or default action to split traffic
+// between target groups by weight. Given a single target group, the rule forwards all traffic to it.
+func (elbv2 SDKClient) ModifyRuleWeights(rule Rule, weights []TargetGroupWeight) error {
+	action := &awselbv2.Action{
+		Type: aws.String(awselbv2.ActionTypeEnumForward),
+	}
+
+	if len(weights) == 1 {
+		action.TargetGroupArn = aws.String(weights[0].ARN)
+	} else {
+		var targetGroups []*awselbv2.TargetGroupTuple
+
+		for _, weight := range weights {
+			targetGroups = append(targetGroups,
+				&awselbv2.TargetGroupTuple{
+					TargetGroupArn: aws.String(weight.ARN),
+					Weight:         aws.Int64(weight.Weight),
+				},
+			)
+		}
+
+		action.ForwardConfig = &awselbv2.ForwardActionConfig{TargetGroups: targetGroups}
+	}
+
+	if rule.IsDefault {
+		_, err := elbv2.client.ModifyListener(
+			&awselbv2.ModifyListenerInput{
+				ListenerArn:    aws.String(rule.ListenerARN),
+				DefaultActions: []*awselbv2.Action{action},
+			},
+		)
+
+		return err
+	}
+
+	_, err := elbv2.client.ModifyRule(
+		&awselbv2.ModifyRuleInput{
+			RuleArn: aws.String(rule.ARN),
+			Actions: []*awselbv2.Action{action},
+		},
+	)
+
+	return err
+}
+
+func forwardsTo(actions []*awselbv2.Action, targetGroupARN string) bool {
+	for _, action := range actions {
+		if aws.StringValue(action.Type) != awselbv2.ActionTypeEnumForward {
+			continue
+		}
+
+		if aws.StringValue(action.TargetGroupArn) == targetGroupARN {
+			return true
+		}
+
+		if action.ForwardConfig == nil {
+			continue
+		}
+
+		for _, targetGroup := range action.ForwardConfig.TargetGroups {
+			if aws.StringValue(targetGroup.TargetGroupArn) == targetGroupARN {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func newForwardRule(listenerARN string, r *awselbv2.Rule, targetGroupARN string) Rule {
+	priority, _ := strconv.Atoi(aws.StringValue(r.Priority))
+
+	rule := Rule{
+		ARN:            aws.StringValue(r.RuleArn),
+		IsDefault:      aws.BoolValue(r.IsDefault),
+		ListenerARN:    listenerARN,
+		Priority:       priority,
+		TargetGroupARN: targetGroupARN,
+		Type:           ruleTypeDefault,
+	}
+
+	if rule.IsDefault || len(r.Conditions) == 0 {
+		return rule
+	}
+
+	switch aws.StringValue(r.Conditions[0].Field) {
+	case "host-header":
+		rule.Type = "HOST"
+	case "path-pattern":
+		rule.Type = "PATH"
+	}
+
+	if len(r.Conditions[0].Values) > 0 {
+		rule.Value = aws.StringValue(r.Conditions[0].Values[0])
+	}
+
+	return rule
+}
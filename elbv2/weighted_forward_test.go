@@ -0,0 +1,185 @@
+package elbv2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awselbv2 "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/awslabs/fargatecli/elbv2/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+const (
+	blueTargetGroupARN  = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/fargate-web/73e2d6bc24d8a067"
+	greenTargetGroupARN = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/fargate-web-green/2453ed029918f21f"
+	listenerARN         = "arn:aws:elasticloadbalancing:us-east-1:123456789012:listener/app/web/50dc6c495c0c9188/f2f7dc8efc522ab2"
+	loadBalancerARN     = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/web/50dc6c495c0c9188"
+	ruleARN             = "arn:aws:elasticloadbalancing:us-east-1:123456789012:listener-rule/app/web/50dc6c495c0c9188/f2f7dc8efc522ab2/9683b2d02a6cabee"
+)
+
+func TestDescribeForwardRules(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockELBV2API := sdk.NewMockELBV2API(mockCtrl)
+	elbv2 := SDKClient{client: mockELBV2API}
+
+	mockELBV2API.EXPECT().DescribeTargetGroups(
+		&awselbv2.DescribeTargetGroupsInput{TargetGroupArns: aws.StringSlice([]string{blueTargetGroupARN})},
+	).Return(
+		&awselbv2.DescribeTargetGroupsOutput{
+			TargetGroups: []*awselbv2.TargetGroup{
+				&awselbv2.TargetGroup{
+					LoadBalancerArns: aws.StringSlice([]string{loadBalancerARN}),
+					TargetGroupArn:   aws.String(blueTargetGroupARN),
+				},
+			},
+		},
+		nil,
+	)
+	mockELBV2API.EXPECT().DescribeListenersPages(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(i *awselbv2.DescribeListenersInput, fn func(*awselbv2.DescribeListenersOutput, bool) bool) error {
+			fn(
+				&awselbv2.DescribeListenersOutput{
+					Listeners: []*awselbv2.Listener{
+						&awselbv2.Listener{ListenerArn: aws.String(listenerARN), Port: aws.Int64(80), Protocol: aws.String("HTTP")},
+					},
+				},
+				true,
+			)
+
+			return nil
+		},
+	)
+	mockELBV2API.EXPECT().DescribeRules(&awselbv2.DescribeRulesInput{ListenerArn: aws.String(listenerARN)}).Return(
+		&awselbv2.DescribeRulesOutput{
+			Rules: []*awselbv2.Rule{
+				&awselbv2.Rule{
+					Actions: []*awselbv2.Action{
+						&awselbv2.Action{Type: aws.String("forward"), TargetGroupArn: aws.String(blueTargetGroupARN)},
+					},
+					Conditions: []*awselbv2.RuleCondition{
+						&awselbv2.RuleCondition{Field: aws.String("host-header"), Values: aws.StringSlice([]string{"web.example.com"})},
+					},
+					Priority: aws.String("10"),
+					RuleArn:  aws.String(ruleARN),
+				},
+				&awselbv2.Rule{
+					Actions: []*awselbv2.Action{
+						&awselbv2.Action{
+							Type: aws.String("forward"),
+							ForwardConfig: &awselbv2.ForwardActionConfig{
+								TargetGroups: []*awselbv2.TargetGroupTuple{
+									&awselbv2.TargetGroupTuple{TargetGroupArn: aws.String("arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/fargate-api/1")},
+								},
+							},
+						},
+					},
+					Conditions: []*awselbv2.RuleCondition{
+						&awselbv2.RuleCondition{Field: aws.String("path-pattern"), Values: aws.StringSlice([]string{"/api/*"})},
+					},
+					Priority: aws.String("20"),
+					RuleArn:  aws.String("arn:aws:elasticloadbalancing:us-east-1:123456789012:listener-rule/app/web/50dc6c495c0c9188/f2f7dc8efc522ab2/1"),
+				},
+				&awselbv2.Rule{
+					Actions: []*awselbv2.Action{
+						&awselbv2.Action{
+							Type: aws.String("forward"),
+							ForwardConfig: &awselbv2.ForwardActionConfig{
+								TargetGroups: []*awselbv2.TargetGroupTuple{
+									&awselbv2.TargetGroupTuple{TargetGroupArn: aws.String(blueTargetGroupARN), Weight: aws.Int64(90)},
+									&awselbv2.TargetGroupTuple{TargetGroupArn: aws.String(greenTargetGroupARN), Weight: aws.Int64(10)},
+								},
+							},
+						},
+					},
+					IsDefault: aws.Bool(true),
+					Priority:  aws.String("default"),
+					RuleArn:   aws.String("arn:aws:elasticloadbalancing:us-east-1:123456789012:listener-rule/app/web/50dc6c495c0c9188/f2f7dc8efc522ab2/2"),
+				},
+			},
+		},
+		nil,
+	)
+
+	rules, err := elbv2.DescribeForwardRules(blueTargetGroupARN)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d: %+v", len(rules), rules)
+	}
+
+	if rules[0].ARN != ruleARN || rules[0].ListenerARN != listenerARN || rules[0].String() != "HOST=web.example.com" || rules[0].Priority != 10 {
+		t.Errorf("unexpected rule %+v", rules[0])
+	}
+
+	if !rules[1].IsDefault || rules[1].Type != "DEFAULT" || rules[1].ListenerARN != listenerARN {
+		t.Errorf("expected default rule, got %+v", rules[1])
+	}
+}
+
+func TestModifyRuleWeights(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockELBV2API := sdk.NewMockELBV2API(mockCtrl)
+	elbv2 := SDKClient{client: mockELBV2API}
+
+	i := &awselbv2.ModifyRuleInput{
+		RuleArn: aws.String(ruleARN),
+		Actions: []*awselbv2.Action{
+			&awselbv2.Action{
+				Type: aws.String("forward"),
+				ForwardConfig: &awselbv2.ForwardActionConfig{
+					TargetGroups: []*awselbv2.TargetGroupTuple{
+						&awselbv2.TargetGroupTuple{TargetGroupArn: aws.String(blueTargetGroupARN), Weight: aws.Int64(90)},
+						&awselbv2.TargetGroupTuple{TargetGroupArn: aws.String(greenTargetGroupARN), Weight: aws.Int64(10)},
+					},
+				},
+			},
+		},
+	}
+
+	mockELBV2API.EXPECT().ModifyRule(i).Return(&awselbv2.ModifyRuleOutput{}, nil)
+
+	err := elbv2.ModifyRuleWeights(
+		Rule{ARN: ruleARN, ListenerARN: listenerARN},
+		[]TargetGroupWeight{
+			TargetGroupWeight{ARN: blueTargetGroupARN, Weight: 90},
+			TargetGroupWeight{ARN: greenTargetGroupARN, Weight: 10},
+		},
+	)
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestModifyRuleWeightsDefaultAction(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockELBV2API := sdk.NewMockELBV2API(mockCtrl)
+	elbv2 := SDKClient{client: mockELBV2API}
+
+	i := &awselbv2.ModifyListenerInput{
+		ListenerArn: aws.String(listenerARN),
+		DefaultActions: []*awselbv2.Action{
+			&awselbv2.Action{Type: aws.String("forward"), TargetGroupArn: aws.String(blueTargetGroupARN)},
+		},
+	}
+
+	mockELBV2API.EXPECT().ModifyListener(i).Return(&awselbv2.ModifyListenerOutput{}, nil)
+
+	err := elbv2.ModifyRuleWeights(
+		Rule{IsDefault: true, ListenerARN: listenerARN},
+		[]TargetGroupWeight{TargetGroupWeight{ARN: blueTargetGroupARN, Weight: 100}},
+	)
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
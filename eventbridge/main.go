@@ -0,0 +1,30 @@
+// Package eventbridge is a client for Amazon EventBridge.
+package eventbridge
+
+//go:generate mockgen -package client -destination=mock/client/client.go github.com/awslabs/fargatecli/eventbridge Client
+//go:generate mockgen -package sdk -source ../vendor/github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface/interface.go -destination=mock/sdk/eventbridgeiface.go github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface EventBridgeAPI
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+)
+
+// Client represents a method for accessing Amazon EventBridge.
+type Client interface {
+	DeleteScheduledTask(string, string) error
+	ListScheduledTasks(string) (ScheduledTasks, error)
+	PutScheduledTask(PutScheduledTaskParameters) error
+}
+
+// SDKClient implements access to Amazon EventBridge via the AWS SDK.
+type SDKClient struct {
+	client eventbridgeiface.EventBridgeAPI
+}
+
+// New returns an SDKClient configured with the given session.
+func New(sess *session.Session) SDKClient {
+	return SDKClient{
+		client: eventbridge.New(sess),
+	}
+}
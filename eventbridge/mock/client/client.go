@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/awslabs/fargatecli/eventbridge (interfaces: Client)
+
+// Package client is a generated GoMock package.
+package client
+
+import (
+	eventbridge "github.com/awslabs/fargatecli/eventbridge"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockClient is a mock of Client interface
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// DeleteScheduledTask mocks base method
+func (m *MockClient) DeleteScheduledTask(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduledTask", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduledTask indicates an expected call of DeleteScheduledTask
+func (mr *MockClientMockRecorder) DeleteScheduledTask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduledTask", reflect.TypeOf((*MockClient)(nil).DeleteScheduledTask), arg0, arg1)
+}
+
+// ListScheduledTasks mocks base method
+func (m *MockClient) ListScheduledTasks(arg0 string) (eventbridge.ScheduledTasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduledTasks", arg0)
+	ret0, _ := ret[0].(eventbridge.ScheduledTasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledTasks indicates an expected call of ListScheduledTasks
+func (mr *MockClientMockRecorder) ListScheduledTasks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledTasks", reflect.TypeOf((*MockClient)(nil).ListScheduledTasks), arg0)
+}
+
+// PutScheduledTask mocks base method
+func (m *MockClient) PutScheduledTask(arg0 eventbridge.PutScheduledTaskParameters) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutScheduledTask", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutScheduledTask indicates an expected call of PutScheduledTask
+func (mr *MockClientMockRecorder) PutScheduledTask(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutScheduledTask", reflect.TypeOf((*MockClient)(nil).PutScheduledTask), arg0)
+}
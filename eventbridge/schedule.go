@@ -0,0 +1,218 @@
+package eventbridge
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	cronFields     = 6
+	maxSearchYears = 5
+)
+
+var (
+	rateExpressionRegexp = regexp.MustCompile(`^([1-9][0-9]*) (minute|minutes|hour|hours|day|days)$`)
+	cronExpressionRegexp = regexp.MustCompile(`^cron\((.*)\)$`)
+
+	monthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	dayOfWeekNames = map[string]int{
+		"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7,
+	}
+)
+
+// CronExpression returns a cron() schedule expression from its six fields (minutes, hours,
+// day-of-month, month, day-of-week, and year), e.g. 0 3 * * ? *. One of day-of-month or day-of-week
+// must be ?.
+func CronExpression(fields string) (string, error) {
+	f := strings.Fields(fields)
+
+	if len(f) != cronFields {
+		return "", fmt.Errorf("cron expression %q must have 6 fields: minutes hours day-of-month month day-of-week year", fields)
+	}
+
+	if f[2] != "?" && f[4] != "?" {
+		return "", fmt.Errorf("cron expression %q must use ? for either day-of-month or day-of-week", fields)
+	}
+
+	return fmt.Sprintf("cron(%s)", strings.Join(f, " ")), nil
+}
+
+// RateExpression returns a rate() schedule expression from a value and unit, e.g. 5 minutes. The unit
+// must be singular when the value is 1.
+func RateExpression(rate string) (string, error) {
+	rate = strings.Join(strings.Fields(rate), " ")
+	matches := rateExpressionRegexp.FindStringSubmatch(rate)
+
+	if matches == nil {
+		return "", fmt.Errorf("rate expression %q must be a positive number followed by minutes, hours, or days", rate)
+	}
+
+	if singular := matches[1] == "1"; singular != !strings.HasSuffix(matches[2], "s") {
+		return "", fmt.Errorf("rate expression %q must use a singular unit for a value of 1 and a plural unit otherwise", rate)
+	}
+
+	return fmt.Sprintf("rate(%s)", rate), nil
+}
+
+// NextRuns returns up to count times after the given time at which a cron() schedule expression will
+// next run, in UTC. The L, W, and # day wildcards are not supported. The runs of rate() expressions
+// depend on when their rule was created and cannot be determined.
+func NextRuns(expression string, after time.Time, count int) ([]time.Time, error) {
+	var runs []time.Time
+
+	matches := cronExpressionRegexp.FindStringSubmatch(expression)
+
+	if matches == nil {
+		return runs, fmt.Errorf("next runs of %s cannot be determined", expression)
+	}
+
+	fields := strings.Fields(matches[1])
+
+	if len(fields) != cronFields {
+		return runs, fmt.Errorf("cron expression %s must have 6 fields", expression)
+	}
+
+	minutes, err := parseCronField(fields[0], 0, 59, nil)
+
+	if err != nil {
+		return runs, err
+	}
+
+	hours, err := parseCronField(fields[1], 0, 23, nil)
+
+	if err != nil {
+		return runs, err
+	}
+
+	daysOfMonth, err := parseCronField(fields[2], 1, 31, nil)
+
+	if err != nil {
+		return runs, err
+	}
+
+	months, err := parseCronField(fields[3], 1, 12, monthNames)
+
+	if err != nil {
+		return runs, err
+	}
+
+	daysOfWeek, err := parseCronField(fields[4], 1, 7, dayOfWeekNames)
+
+	if err != nil {
+		return runs, err
+	}
+
+	years, err := parseCronField(fields[5], 1970, 2199, nil)
+
+	if err != nil {
+		return runs, err
+	}
+
+	after = after.UTC()
+	day := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, time.UTC)
+	end := day.AddDate(maxSearchYears, 0, 0)
+	sortedHours, sortedMinutes := sortedValues(hours), sortedValues(minutes)
+
+	for ; day.Before(end) && len(runs) < count; day = day.AddDate(0, 0, 1) {
+		if !years[day.Year()] || !months[int(day.Month())] || !daysOfMonth[day.Day()] || !daysOfWeek[int(day.Weekday())+1] {
+			continue
+		}
+
+		for _, hour := range sortedHours {
+			for _, minute := range sortedMinutes {
+				run := day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+
+				if run.After(after) && len(runs) < count {
+					runs = append(runs, run)
+				}
+			}
+		}
+	}
+
+	return runs, nil
+}
+
+// parseCronField returns the values matched by a cron field made up of comma separated values,
+// ranges, names, and increments, e.g. 0/15, MON-FRI, or 1,15.
+func parseCronField(field string, min, max int, names map[string]int) (map[int]bool, error) {
+	values := make(map[int]bool)
+
+	for _, part := range strings.Split(field, ",") {
+		var err error
+
+		start, stop, step := min, max, 1
+
+		if i := strings.Index(part, "/"); i >= 0 {
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return values, fmt.Errorf("invalid increment in cron field %s", field)
+			}
+
+			part = part[:i]
+		}
+
+		switch {
+		case part == "*" || part == "?":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+
+			if start, err = parseCronValue(bounds[0], names); err != nil {
+				return values, err
+			}
+
+			if stop, err = parseCronValue(bounds[1], names); err != nil {
+				return values, err
+			}
+		default:
+			if start, err = parseCronValue(part, names); err != nil {
+				return values, err
+			}
+
+			if step == 1 {
+				stop = start
+			}
+		}
+
+		if start < min || stop > max || start > stop {
+			return values, fmt.Errorf("cron field %s is out of range %d-%d", field, min, max)
+		}
+
+		for value := start; value <= stop; value += step {
+			values[value] = true
+		}
+	}
+
+	return values, nil
+}
+
+func parseCronValue(value string, names map[string]int) (int, error) {
+	if n, ok := names[strings.ToUpper(value)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(value)
+
+	if err != nil {
+		return 0, fmt.Errorf("cron value %s is not supported", value)
+	}
+
+	return n, nil
+}
+
+func sortedValues(values map[int]bool) []int {
+	var sorted []int
+
+	for value := range values {
+		sorted = append(sorted, value)
+	}
+
+	sort.Ints(sorted)
+
+	return sorted
+}
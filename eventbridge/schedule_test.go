@@ -0,0 +1,126 @@
+package eventbridge
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronExpression(t *testing.T) {
+	var tests = []struct {
+		fields     string
+		expression string
+		valid      bool
+	}{
+		{"0 3 * * ? *", "cron(0 3 * * ? *)", true},
+		{" 0/15  8-17 ? * MON-FRI * ", "cron(0/15 8-17 ? * MON-FRI *)", true},
+		{"0 3 * * *", "", false},
+		{"0 3 * * MON *", "", false},
+	}
+
+	for _, test := range tests {
+		expression, err := CronExpression(test.fields)
+
+		if test.valid && err != nil {
+			t.Errorf("expected %q to be valid, got %v", test.fields, err)
+		}
+
+		if !test.valid && err == nil {
+			t.Errorf("expected %q to be invalid, got none", test.fields)
+		}
+
+		if expression != test.expression {
+			t.Errorf("expected %q, got %q", test.expression, expression)
+		}
+	}
+}
+
+func TestRateExpression(t *testing.T) {
+	var tests = []struct {
+		rate       string
+		expression string
+		valid      bool
+	}{
+		{"5 minutes", "rate(5 minutes)", true},
+		{"1 hour", "rate(1 hour)", true},
+		{"2  days", "rate(2 days)", true},
+		{"1 hours", "", false},
+		{"5 minute", "", false},
+		{"0 minutes", "", false},
+		{"5 weeks", "", false},
+		{"minutes", "", false},
+	}
+
+	for _, test := range tests {
+		expression, err := RateExpression(test.rate)
+
+		if test.valid && err != nil {
+			t.Errorf("expected %q to be valid, got %v", test.rate, err)
+		}
+
+		if !test.valid && err == nil {
+			t.Errorf("expected %q to be invalid, got none", test.rate)
+		}
+
+		if expression != test.expression {
+			t.Errorf("expected %q, got %q", test.expression, expression)
+		}
+	}
+}
+
+func TestNextRuns(t *testing.T) {
+	after := time.Date(2020, time.January, 31, 3, 0, 0, 0, time.UTC) // Friday
+
+	var tests = []struct {
+		expression string
+		expected   []string
+	}{
+		{"cron(0 3 * * ? *)", []string{"2020-02-01 03:00", "2020-02-02 03:00", "2020-02-03 03:00"}},
+		{"cron(0/20 3 * * ? *)", []string{"2020-01-31 03:20", "2020-01-31 03:40", "2020-02-01 03:00"}},
+		{"cron(30 8 ? * MON-FRI *)", []string{"2020-01-31 08:30", "2020-02-03 08:30", "2020-02-04 08:30"}},
+		{"cron(0 0 29 FEB ? *)", []string{"2020-02-29 00:00", "2024-02-29 00:00"}},
+		{"cron(0 12 1,15 * ? 2020)", []string{"2020-02-01 12:00", "2020-02-15 12:00", "2020-03-01 12:00"}},
+		{"cron(0 6 ? * 1 *)", []string{"2020-02-02 06:00", "2020-02-09 06:00", "2020-02-16 06:00"}},
+	}
+
+	for _, test := range tests {
+		runs, err := NextRuns(test.expression, after, 3)
+
+		if err != nil {
+			t.Errorf("expected no error for %s, got %v", test.expression, err)
+			continue
+		}
+
+		var got []string
+
+		for _, run := range runs {
+			got = append(got, run.Format("2006-01-02 15:04"))
+		}
+
+		if len(got) != len(test.expected) {
+			t.Errorf("expected %v for %s, got %v", test.expected, test.expression, got)
+			continue
+		}
+
+		for i := range got {
+			if got[i] != test.expected[i] {
+				t.Errorf("expected %v for %s, got %v", test.expected, test.expression, got)
+				break
+			}
+		}
+	}
+}
+
+func TestNextRunsErrors(t *testing.T) {
+	for _, expression := range []string{
+		"rate(5 minutes)",
+		"cron(0 3 * *)",
+		"cron(0 3 L * ? *)",
+		"cron(0 3 ? * 6#3 *)",
+		"cron(0 25 * * ? *)",
+		"cron(0/0 3 * * ? *)",
+	} {
+		if _, err := NextRuns(expression, time.Now(), 3); err == nil {
+			t.Errorf("expected error for %s, got none", expression)
+		}
+	}
+}
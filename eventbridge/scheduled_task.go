@@ -0,0 +1,242 @@
+package eventbridge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awseb "github.com/aws/aws-sdk-go/service/eventbridge"
+)
+
+const (
+	capacityProviderFargateSpot = "FARGATE_SPOT"
+	ruleNameFormat              = "fargate-%s-%s"
+	ruleDescriptionFormat       = "Runs fargate task %s in cluster %s"
+	targetID                    = "fargate"
+)
+
+// ScheduledTask is an EventBridge rule which runs an ECS task on a schedule.
+type ScheduledTask struct {
+	ClusterARN        string   `json:"clusterArn"`
+	Name              string   `json:"name"`
+	RoleARN           string   `json:"roleArn"`
+	RuleName          string   `json:"ruleName"`
+	Schedule          string   `json:"schedule"`
+	SecurityGroupIDs  []string `json:"securityGroupIds"`
+	State             string   `json:"state"`
+	SubnetIDs         []string `json:"subnetIds"`
+	TaskCount         int64    `json:"taskCount"`
+	TaskDefinitionARN string   `json:"taskDefinitionArn"`
+}
+
+// ScheduledTasks is a collection of scheduled tasks.
+type ScheduledTasks []ScheduledTask
+
+// PutScheduledTaskParameters are the parameters required to create or update a scheduled task.
+// Schedule is a rate() or cron() expression in UTC. Spot runs the tasks on Fargate Spot, which
+// requires the FARGATE_SPOT capacity provider to be attached to the cluster. EnableExecuteCommand
+// enables ECS Exec on the tasks.
+type PutScheduledTaskParameters struct {
+	ClusterARN           string
+	ClusterName          string
+	EnableExecuteCommand bool
+	Name                 string
+	RoleARN              string
+	Schedule             string
+	SecurityGroupIDs     []string
+	Spot                 bool
+	SubnetIDs            []string
+	TaskCount            int64
+	TaskDefinitionARN    string
+}
+
+// RuleName returns the name of the EventBridge rule which runs a task group in a cluster.
+func RuleName(clusterName, taskName string) string {
+	return fmt.Sprintf(ruleNameFormat, clusterName, taskName)
+}
+
+// PutScheduledTask creates or updates the rule which runs a task group on a schedule.
+func (eb SDKClient) PutScheduledTask(p PutScheduledTaskParameters) error {
+	ruleName := RuleName(p.ClusterName, p.Name)
+
+	_, err := eb.client.PutRule(
+		&awseb.PutRuleInput{
+			Description:        aws.String(fmt.Sprintf(ruleDescriptionFormat, p.Name, p.ClusterName)),
+			Name:               aws.String(ruleName),
+			ScheduleExpression: aws.String(p.Schedule),
+			State:              aws.String(awseb.RuleStateEnabled),
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	ecsParameters := &awseb.EcsParameters{
+		Group:      aws.String(p.Name),
+		LaunchType: aws.String(awseb.LaunchTypeFargate),
+		NetworkConfiguration: &awseb.NetworkConfiguration{
+			AwsvpcConfiguration: &awseb.AwsVpcConfiguration{
+				AssignPublicIp: aws.String(awseb.AssignPublicIpEnabled),
+				SecurityGroups: aws.StringSlice(p.SecurityGroupIDs),
+				Subnets:        aws.StringSlice(p.SubnetIDs),
+			},
+		},
+		TaskCount:         aws.Int64(p.TaskCount),
+		TaskDefinitionArn: aws.String(p.TaskDefinitionARN),
+	}
+
+	if p.EnableExecuteCommand {
+		ecsParameters.EnableExecuteCommand = aws.Bool(true)
+	}
+
+	if p.Spot {
+		ecsParameters.LaunchType = nil
+		ecsParameters.CapacityProviderStrategy = []*awseb.CapacityProviderStrategyItem{
+			&awseb.CapacityProviderStrategyItem{
+				CapacityProvider: aws.String(capacityProviderFargateSpot),
+				Weight:           aws.Int64(1),
+			},
+		}
+	}
+
+	resp, err := eb.client.PutTargets(
+		&awseb.PutTargetsInput{
+			Rule: aws.String(ruleName),
+			Targets: []*awseb.Target{
+				&awseb.Target{
+					Arn:           aws.String(p.ClusterARN),
+					EcsParameters: ecsParameters,
+					Id:            aws.String(targetID),
+					RoleArn:       aws.String(p.RoleARN),
+				},
+			},
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if aws.Int64Value(resp.FailedEntryCount) > 0 {
+		var reasons []string
+
+		for _, entry := range resp.FailedEntries {
+			reasons = append(reasons, aws.StringValue(entry.ErrorMessage))
+		}
+
+		return fmt.Errorf("could not set target of rule %s: %s", ruleName, strings.Join(reasons, ", "))
+	}
+
+	return nil
+}
+
+// DeleteScheduledTask removes the target of the rule which runs a task group and deletes the rule.
+func (eb SDKClient) DeleteScheduledTask(clusterName, taskName string) error {
+	ruleName := RuleName(clusterName, taskName)
+
+	_, err := eb.client.RemoveTargets(
+		&awseb.RemoveTargetsInput{
+			Ids:  aws.StringSlice([]string{targetID}),
+			Rule: aws.String(ruleName),
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = eb.client.DeleteRule(
+		&awseb.DeleteRuleInput{
+			Name: aws.String(ruleName),
+		},
+	)
+
+	return err
+}
+
+// ListScheduledTasks returns the task groups scheduled to run in a cluster.
+func (eb SDKClient) ListScheduledTasks(clusterName string) (ScheduledTasks, error) {
+	var (
+		nextToken      *string
+		scheduledTasks ScheduledTasks
+	)
+
+	prefix := RuleName(clusterName, "")
+
+	for {
+		resp, err := eb.client.ListRules(
+			&awseb.ListRulesInput{
+				NamePrefix: aws.String(prefix),
+				NextToken:  nextToken,
+			},
+		)
+
+		if err != nil {
+			return scheduledTasks, err
+		}
+
+		for _, rule := range resp.Rules {
+			scheduledTask, ok, err := eb.describeTarget(rule, clusterName)
+
+			if err != nil {
+				return scheduledTasks, err
+			}
+
+			if ok {
+				scheduledTask.Name = strings.TrimPrefix(scheduledTask.RuleName, prefix)
+				scheduledTasks = append(scheduledTasks, scheduledTask)
+			}
+		}
+
+		if nextToken = resp.NextToken; nextToken == nil {
+			break
+		}
+	}
+
+	return scheduledTasks, nil
+}
+
+// describeTarget returns the scheduled task a rule runs. Rules which do not run a task in the given
+// cluster, such as those of a cluster whose name shares the prefix, are skipped.
+func (eb SDKClient) describeTarget(rule *awseb.Rule, clusterName string) (ScheduledTask, bool, error) {
+	scheduledTask := ScheduledTask{
+		RuleName: aws.StringValue(rule.Name),
+		Schedule: aws.StringValue(rule.ScheduleExpression),
+		State:    aws.StringValue(rule.State),
+	}
+
+	resp, err := eb.client.ListTargetsByRule(
+		&awseb.ListTargetsByRuleInput{
+			Rule: rule.Name,
+		},
+	)
+
+	if err != nil {
+		return scheduledTask, false, err
+	}
+
+	for _, target := range resp.Targets {
+		if aws.StringValue(target.Id) != targetID || target.EcsParameters == nil {
+			continue
+		}
+
+		if !strings.HasSuffix(aws.StringValue(target.Arn), ":cluster/"+clusterName) {
+			continue
+		}
+
+		scheduledTask.ClusterARN = aws.StringValue(target.Arn)
+		scheduledTask.RoleARN = aws.StringValue(target.RoleArn)
+		scheduledTask.TaskCount = aws.Int64Value(target.EcsParameters.TaskCount)
+		scheduledTask.TaskDefinitionARN = aws.StringValue(target.EcsParameters.TaskDefinitionArn)
+
+		if network := target.EcsParameters.NetworkConfiguration; network != nil && network.AwsvpcConfiguration != nil {
+			scheduledTask.SecurityGroupIDs = aws.StringValueSlice(network.AwsvpcConfiguration.SecurityGroups)
+			scheduledTask.SubnetIDs = aws.StringValueSlice(network.AwsvpcConfiguration.Subnets)
+		}
+
+		return scheduledTask, true, nil
+	}
+
+	return scheduledTask, false, nil
+}
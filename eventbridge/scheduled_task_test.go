@@ -0,0 +1,305 @@
+package eventbridge
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awseb "github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/awslabs/fargatecli/eventbridge/mock/sdk"
+	"github.com/golang/mock/gomock"
+)
+
+const clusterARN = "arn:aws:ecs:us-east-1:123456789012:cluster/fargate"
+
+func TestRuleName(t *testing.T) {
+	if expected, got := "fargate-fargate-nightly", RuleName("fargate", "nightly"); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestPutScheduledTask(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockEventBridgeAPI(mockCtrl)
+	eb := SDKClient{client: mockAPI}
+
+	ruleInput := &awseb.PutRuleInput{
+		Description:        aws.String("Runs fargate task nightly in cluster fargate"),
+		Name:               aws.String("fargate-fargate-nightly"),
+		ScheduleExpression: aws.String("cron(0 3 * * ? *)"),
+		State:              aws.String("ENABLED"),
+	}
+	targetsInput := &awseb.PutTargetsInput{
+		Rule: aws.String("fargate-fargate-nightly"),
+		Targets: []*awseb.Target{
+			&awseb.Target{
+				Arn: aws.String(clusterARN),
+				EcsParameters: &awseb.EcsParameters{
+					Group:      aws.String("nightly"),
+					LaunchType: aws.String("FARGATE"),
+					NetworkConfiguration: &awseb.NetworkConfiguration{
+						AwsvpcConfiguration: &awseb.AwsVpcConfiguration{
+							AssignPublicIp: aws.String("ENABLED"),
+							SecurityGroups: aws.StringSlice([]string{"sg-1234567"}),
+							Subnets:        aws.StringSlice([]string{"subnet-1234567", "subnet-abcdef"}),
+						},
+					},
+					TaskCount:         aws.Int64(2),
+					TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/fargate_task_nightly:3"),
+				},
+				Id:      aws.String("fargate"),
+				RoleArn: aws.String("arn:aws:iam::123456789012:role/ecsEventsRole"),
+			},
+		},
+	}
+
+	gomock.InOrder(
+		mockAPI.EXPECT().PutRule(ruleInput).Return(&awseb.PutRuleOutput{}, nil),
+		mockAPI.EXPECT().PutTargets(targetsInput).Return(&awseb.PutTargetsOutput{FailedEntryCount: aws.Int64(0)}, nil),
+	)
+
+	err := eb.PutScheduledTask(
+		PutScheduledTaskParameters{
+			ClusterARN:        clusterARN,
+			ClusterName:       "fargate",
+			Name:              "nightly",
+			RoleARN:           "arn:aws:iam::123456789012:role/ecsEventsRole",
+			Schedule:          "cron(0 3 * * ? *)",
+			SecurityGroupIDs:  []string{"sg-1234567"},
+			SubnetIDs:         []string{"subnet-1234567", "subnet-abcdef"},
+			TaskCount:         2,
+			TaskDefinitionARN: "arn:aws:ecs:us-east-1:123456789012:task-definition/fargate_task_nightly:3",
+		},
+	)
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestPutScheduledTaskSpot(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockEventBridgeAPI(mockCtrl)
+	eb := SDKClient{client: mockAPI}
+
+	mockAPI.EXPECT().PutRule(gomock.Any()).Return(&awseb.PutRuleOutput{}, nil)
+	mockAPI.EXPECT().PutTargets(gomock.Any()).DoAndReturn(
+		func(input *awseb.PutTargetsInput) (*awseb.PutTargetsOutput, error) {
+			ecsParameters := input.Targets[0].EcsParameters
+			expected := []*awseb.CapacityProviderStrategyItem{
+				&awseb.CapacityProviderStrategyItem{CapacityProvider: aws.String("FARGATE_SPOT"), Weight: aws.Int64(1)},
+			}
+
+			if ecsParameters.LaunchType != nil {
+				t.Errorf("expected no launch type, got %s", aws.StringValue(ecsParameters.LaunchType))
+			}
+
+			if !reflect.DeepEqual(expected, ecsParameters.CapacityProviderStrategy) {
+				t.Errorf("expected capacity provider strategy %v, got %v", expected, ecsParameters.CapacityProviderStrategy)
+			}
+
+			return &awseb.PutTargetsOutput{FailedEntryCount: aws.Int64(0)}, nil
+		},
+	)
+
+	if err := eb.PutScheduledTask(PutScheduledTaskParameters{ClusterName: "fargate", Name: "nightly", Spot: true}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestPutScheduledTaskEnableExecuteCommand(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockEventBridgeAPI(mockCtrl)
+	eb := SDKClient{client: mockAPI}
+
+	mockAPI.EXPECT().PutRule(gomock.Any()).Return(&awseb.PutRuleOutput{}, nil)
+	mockAPI.EXPECT().PutTargets(gomock.Any()).DoAndReturn(
+		func(input *awseb.PutTargetsInput) (*awseb.PutTargetsOutput, error) {
+			if !aws.BoolValue(input.Targets[0].EcsParameters.EnableExecuteCommand) {
+				t.Errorf("expected execute command to be enabled")
+			}
+
+			return &awseb.PutTargetsOutput{FailedEntryCount: aws.Int64(0)}, nil
+		},
+	)
+
+	if err := eb.PutScheduledTask(PutScheduledTaskParameters{ClusterName: "fargate", EnableExecuteCommand: true, Name: "nightly"}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestPutScheduledTaskFailedEntries(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockEventBridgeAPI(mockCtrl)
+	eb := SDKClient{client: mockAPI}
+
+	mockAPI.EXPECT().PutRule(gomock.Any()).Return(&awseb.PutRuleOutput{}, nil)
+	mockAPI.EXPECT().PutTargets(gomock.Any()).Return(
+		&awseb.PutTargetsOutput{
+			FailedEntryCount: aws.Int64(1),
+			FailedEntries: []*awseb.PutTargetsResultEntry{
+				&awseb.PutTargetsResultEntry{ErrorMessage: aws.String("role is invalid")},
+			},
+		},
+		nil,
+	)
+
+	err := eb.PutScheduledTask(PutScheduledTaskParameters{ClusterName: "fargate", Name: "nightly"})
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if expected := "could not set target of rule fargate-fargate-nightly: role is invalid"; err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err)
+	}
+}
+
+func TestPutScheduledTaskError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockEventBridgeAPI(mockCtrl)
+	eb := SDKClient{client: mockAPI}
+
+	mockAPI.EXPECT().PutRule(gomock.Any()).Return(&awseb.PutRuleOutput{}, errors.New("boom"))
+
+	if err := eb.PutScheduledTask(PutScheduledTaskParameters{ClusterName: "fargate", Name: "nightly"}); err == nil {
+		t.Error("expected error, got none")
+	}
+}
+
+func TestDeleteScheduledTask(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockEventBridgeAPI(mockCtrl)
+	eb := SDKClient{client: mockAPI}
+
+	gomock.InOrder(
+		mockAPI.EXPECT().RemoveTargets(
+			&awseb.RemoveTargetsInput{
+				Ids:  aws.StringSlice([]string{"fargate"}),
+				Rule: aws.String("fargate-fargate-nightly"),
+			},
+		).Return(&awseb.RemoveTargetsOutput{}, nil),
+		mockAPI.EXPECT().DeleteRule(
+			&awseb.DeleteRuleInput{Name: aws.String("fargate-fargate-nightly")},
+		).Return(&awseb.DeleteRuleOutput{}, nil),
+	)
+
+	if err := eb.DeleteScheduledTask("fargate", "nightly"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestListScheduledTasks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAPI := sdk.NewMockEventBridgeAPI(mockCtrl)
+	eb := SDKClient{client: mockAPI}
+
+	gomock.InOrder(
+		mockAPI.EXPECT().ListRules(
+			&awseb.ListRulesInput{NamePrefix: aws.String("fargate-fargate-")},
+		).Return(
+			&awseb.ListRulesOutput{
+				Rules: []*awseb.Rule{
+					&awseb.Rule{
+						Name:               aws.String("fargate-fargate-nightly"),
+						ScheduleExpression: aws.String("cron(0 3 * * ? *)"),
+						State:              aws.String("ENABLED"),
+					},
+				},
+				NextToken: aws.String("1"),
+			},
+			nil,
+		),
+		mockAPI.EXPECT().ListTargetsByRule(
+			&awseb.ListTargetsByRuleInput{Rule: aws.String("fargate-fargate-nightly")},
+		).Return(
+			&awseb.ListTargetsByRuleOutput{
+				Targets: []*awseb.Target{
+					&awseb.Target{
+						Arn: aws.String(clusterARN),
+						EcsParameters: &awseb.EcsParameters{
+							NetworkConfiguration: &awseb.NetworkConfiguration{
+								AwsvpcConfiguration: &awseb.AwsVpcConfiguration{
+									SecurityGroups: aws.StringSlice([]string{"sg-1234567"}),
+									Subnets:        aws.StringSlice([]string{"subnet-1234567"}),
+								},
+							},
+							TaskCount:         aws.Int64(1),
+							TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/fargate_task_nightly:3"),
+						},
+						Id:      aws.String("fargate"),
+						RoleArn: aws.String("arn:aws:iam::123456789012:role/ecsEventsRole"),
+					},
+				},
+			},
+			nil,
+		),
+		mockAPI.EXPECT().ListRules(
+			&awseb.ListRulesInput{NamePrefix: aws.String("fargate-fargate-"), NextToken: aws.String("1")},
+		).Return(
+			&awseb.ListRulesOutput{
+				Rules: []*awseb.Rule{
+					&awseb.Rule{
+						Name:               aws.String("fargate-fargate-staging-hourly"),
+						ScheduleExpression: aws.String("rate(1 hour)"),
+					},
+				},
+			},
+			nil,
+		),
+		mockAPI.EXPECT().ListTargetsByRule(
+			&awseb.ListTargetsByRuleInput{Rule: aws.String("fargate-fargate-staging-hourly")},
+		).Return(
+			&awseb.ListTargetsByRuleOutput{
+				Targets: []*awseb.Target{
+					&awseb.Target{
+						Arn:           aws.String("arn:aws:ecs:us-east-1:123456789012:cluster/fargate-staging"),
+						EcsParameters: &awseb.EcsParameters{},
+						Id:            aws.String("fargate"),
+					},
+				},
+			},
+			nil,
+		),
+	)
+
+	scheduledTasks, err := eb.ListScheduledTasks("fargate")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := ScheduledTasks{
+		ScheduledTask{
+			ClusterARN:        clusterARN,
+			Name:              "nightly",
+			RoleARN:           "arn:aws:iam::123456789012:role/ecsEventsRole",
+			RuleName:          "fargate-fargate-nightly",
+			Schedule:          "cron(0 3 * * ? *)",
+			SecurityGroupIDs:  []string{"sg-1234567"},
+			State:             "ENABLED",
+			SubnetIDs:         []string{"subnet-1234567"},
+			TaskCount:         1,
+			TaskDefinitionARN: "arn:aws:ecs:us-east-1:123456789012:task-definition/fargate_task_nightly:3",
+		},
+	}
+
+	if !reflect.DeepEqual(expected, scheduledTasks) {
+		t.Errorf("expected %+v, got %+v", expected, scheduledTasks)
+	}
+}
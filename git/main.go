@@ -26,9 +26,92 @@ func GetShortSha() string {
 	return sha
 }
 
+// GetSha returns the full SHA of the HEAD commit.
+func GetSha() string {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+
+	if console.Verbose {
+		cmd.Stderr = os.Stderr
+	}
+
+	out, err := cmd.Output()
+
+	if err != nil {
+		console.ErrorExit(err, "Could not find git HEAD SHA")
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+// GetBranch returns the name of the checked out branch, or an empty string if HEAD is detached.
+func GetBranch() string {
+	out, err := exec.Command("git", "symbolic-ref", "--quiet", "--short", "HEAD").Output()
+
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
 func IsCwdGitRepo() bool {
 	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
 	err := cmd.Run()
 
 	return err == nil
 }
+
+// IsDirty returns whether the working tree has uncommitted changes, including untracked files which
+// are not ignored.
+func IsDirty() bool {
+	cmd := exec.Command("git", "status", "--porcelain")
+
+	if console.Verbose {
+		cmd.Stderr = os.Stderr
+	}
+
+	out, err := cmd.Output()
+
+	if err != nil {
+		console.ErrorExit(err, "Could not find git working tree status")
+	}
+
+	return strings.TrimSpace(string(out)) != ""
+}
+
+// GetTags returns the names of the tags pointing at the HEAD commit.
+func GetTags() []string {
+	out, err := exec.Command("git", "tag", "--points-at", "HEAD").Output()
+
+	if err != nil {
+		return nil
+	}
+
+	return strings.Fields(string(out))
+}
+
+// GetAuthor returns the name and email address of the author of the HEAD commit.
+func GetAuthor() string {
+	return logFormat("%an <%ae>")
+}
+
+// GetMessage returns the message of the HEAD commit.
+func GetMessage() string {
+	return logFormat("%B")
+}
+
+func logFormat(format string) string {
+	cmd := exec.Command("git", "log", "-1", "--format="+format, "HEAD")
+
+	if console.Verbose {
+		cmd.Stderr = os.Stderr
+	}
+
+	out, err := cmd.Output()
+
+	if err != nil {
+		console.ErrorExit(err, "Could not read git HEAD commit")
+	}
+
+	return strings.TrimSpace(string(out))
+}
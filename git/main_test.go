@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -93,3 +94,104 @@ func TestIsCwdGitRepoAgainstARepo(t *testing.T) {
 		t.Errorf("wanted true, got %+v", isCwdGitRepo)
 	}
 }
+
+func TestGetShaAndBranch(t *testing.T) {
+	cwd, err := os.Getwd()
+
+	if err != nil {
+		t.Error("Could not read current working directory", err)
+		return
+	}
+
+	dir, err := ioutil.TempDir("", "fargate-tests")
+
+	if err != nil {
+		t.Error("Could not create temporary directory", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	os.Chdir(dir)
+	defer os.Chdir(cwd)
+
+	exec.Command("git", "init").Run()
+	exec.Command("git", "checkout", "-b", "feature/labels").Run()
+
+	if output, err := exec.Command("git", "commit", "--allow-empty", "--message", "dummy commit").CombinedOutput(); err != nil {
+		t.Fatalf("Could not create dummy git commit: %v: %s", err, output)
+	}
+
+	sha := GetSha()
+
+	if len(sha) != 40 || !strings.HasPrefix(sha, GetShortSha()) {
+		t.Errorf("expected full SHA starting with %s, got %s", GetShortSha(), sha)
+	}
+
+	if branch := GetBranch(); branch != "feature/labels" {
+		t.Errorf("expected branch feature/labels, got %s", branch)
+	}
+
+	exec.Command("git", "checkout", "--detach").Run()
+
+	if branch := GetBranch(); branch != "" {
+		t.Errorf("expected no branch when detached, got %s", branch)
+	}
+}
+
+func TestIsDirtyAndCommitDetails(t *testing.T) {
+	cwd, err := os.Getwd()
+
+	if err != nil {
+		t.Error("Could not read current working directory", err)
+		return
+	}
+
+	dir, err := ioutil.TempDir("", "fargate-tests")
+
+	if err != nil {
+		t.Error("Could not create temporary directory", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	os.Chdir(dir)
+	defer os.Chdir(cwd)
+
+	exec.Command("git", "init").Run()
+
+	commit := exec.Command("git", "commit", "--allow-empty", "--message", "Add labels\n\nRecord the commit.")
+	commit.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Jane Doe", "GIT_AUTHOR_EMAIL=jane@example.com")
+
+	if output, err := commit.CombinedOutput(); err != nil {
+		t.Fatalf("Could not create dummy git commit: %v: %s", err, output)
+	}
+
+	if IsDirty() {
+		t.Errorf("expected clean working tree, got dirty")
+	}
+
+	if tags := GetTags(); len(tags) != 0 {
+		t.Errorf("expected no tags, got %v", tags)
+	}
+
+	exec.Command("git", "tag", "v1.0.0").Run()
+	exec.Command("git", "tag", "release").Run()
+
+	if tags := GetTags(); !reflect.DeepEqual(tags, []string{"release", "v1.0.0"}) {
+		t.Errorf("expected tags release and v1.0.0, got %v", tags)
+	}
+
+	if author := GetAuthor(); author != "Jane Doe <jane@example.com>" {
+		t.Errorf("expected author Jane Doe <jane@example.com>, got %s", author)
+	}
+
+	if message := GetMessage(); message != "Add labels\n\nRecord the commit." {
+		t.Errorf("expected commit message, got %q", message)
+	}
+
+	ioutil.WriteFile("Dockerfile", []byte("FROM scratch\n"), 0600)
+
+	if !IsDirty() {
+		t.Errorf("expected dirty working tree, got clean")
+	}
+}
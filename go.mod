@@ -1,7 +1,7 @@
 module github.com/awslabs/fargatecli
 
 require (
-	github.com/aws/aws-sdk-go v1.30.7
+	github.com/aws/aws-sdk-go v1.44.332
 	github.com/go-ini/ini v1.32.0 // indirect
 	github.com/golang/mock v1.4.3
 	github.com/hashicorp/golang-lru v0.0.0-20180201235237-0fb14efe8c47
@@ -12,10 +12,11 @@ require (
 	github.com/mgutz/ansi v0.0.0-20170206155736-9520e82c474b
 	github.com/smartystreets/goconvey v0.0.0-20190306220146-200a235640ff // indirect
 	github.com/spf13/cobra v0.0.3
-	github.com/spf13/pflag v1.0.2 // indirect
-	golang.org/x/crypto v0.0.0-20190308221718-c2843e01d9a2
+	github.com/spf13/pflag v1.0.2
+	golang.org/x/crypto v0.0.0-20210921155107-089bfa567519
 	golang.org/x/time v0.0.0-20170927054726-6dc17368e09b
 	gopkg.in/ini.v1 v1.42.0 // indirect
+	gopkg.in/yaml.v2 v2.2.8
 )
 
 go 1.13
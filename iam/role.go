@@ -1,15 +1,39 @@
 package iam
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	awsiam "github.com/aws/aws-sdk-go/service/iam"
 )
 
 const ecsTaskExecutionRoleName = "ecsTaskExecutionRole"
 const ecsTaskExecutionPolicyArn = "arn:aws:iam::aws:policy/service-role/AmazonECSTaskExecutionRolePolicy"
+const ecsTaskExecutionSecretsPolicyName = "fargate-secrets"
+const ssmParameterArnFormat = "arn:aws:ssm:%s:*:parameter/%s"
+const executeCommandPolicyName = "fargate-exec"
+const fargateTaskRoleName = "fargateTaskRole"
+const ecsEventsRoleName = "ecsEventsRole"
+const ecsEventsPolicyArn = "arn:aws:iam::aws:policy/service-role/AmazonEC2ContainerServiceEventsRole"
+const ecsEventsRoleAssumeRolePolicyDocument = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "events.amazonaws.com"
+      },
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}`
 const ecsTaskExecutionRoleAssumeRolePolicyDocument = `{
   "Version": "2012-10-17",
   "Statement": [
@@ -63,3 +87,282 @@ func (iam *IAM) CreateEcsTaskExecutionRole() string {
 
 	return ecsTaskExecutionRoleArn
 }
+
+// CreateFargateTaskRole returns the ARN of the role assumed by tasks which are not given a task
+// role, creating it if it does not exist. The role has no permissions until they are granted.
+func (iam *IAM) CreateFargateTaskRole() (string, error) {
+	getRoleResp, err := iam.svc.GetRole(
+		&awsiam.GetRoleInput{
+			RoleName: aws.String(fargateTaskRoleName),
+		},
+	)
+
+	if err == nil {
+		return aws.StringValue(getRoleResp.Role.Arn), nil
+	}
+
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != awsiam.ErrCodeNoSuchEntityException {
+		return "", err
+	}
+
+	createRoleResp, err := iam.svc.CreateRole(
+		&awsiam.CreateRoleInput{
+			AssumeRolePolicyDocument: aws.String(ecsTaskExecutionRoleAssumeRolePolicyDocument),
+			RoleName:                 aws.String(fargateTaskRoleName),
+		},
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(createRoleResp.Role.Arn), nil
+}
+
+// CreateEcsEventsRole returns the ARN of the role EventBridge assumes to run scheduled tasks,
+// creating it if it does not exist.
+func (iam *IAM) CreateEcsEventsRole() (string, error) {
+	getRoleResp, err := iam.svc.GetRole(
+		&awsiam.GetRoleInput{
+			RoleName: aws.String(ecsEventsRoleName),
+		},
+	)
+
+	if err == nil {
+		return aws.StringValue(getRoleResp.Role.Arn), nil
+	}
+
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != awsiam.ErrCodeNoSuchEntityException {
+		return "", err
+	}
+
+	createRoleResp, err := iam.svc.CreateRole(
+		&awsiam.CreateRoleInput{
+			AssumeRolePolicyDocument: aws.String(ecsEventsRoleAssumeRolePolicyDocument),
+			RoleName:                 aws.String(ecsEventsRoleName),
+		},
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	_, err = iam.svc.AttachRolePolicy(
+		&awsiam.AttachRolePolicyInput{
+			PolicyArn: aws.String(ecsEventsPolicyArn),
+			RoleName:  aws.String(ecsEventsRoleName),
+		},
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(createRoleResp.Role.Arn), nil
+}
+
+// GrantExecuteCommandAccess allows a task role, given by name or ARN, to open the Session Manager
+// channels used by ECS Exec to run commands in its tasks' containers.
+func (iam *IAM) GrantExecuteCommandAccess(role string) error {
+	document, err := json.Marshal(executeCommandPolicyDocument())
+
+	if err != nil {
+		return err
+	}
+
+	_, err = iam.svc.PutRolePolicy(
+		&awsiam.PutRolePolicyInput{
+			PolicyDocument: aws.String(string(document)),
+			PolicyName:     aws.String(executeCommandPolicyName),
+			RoleName:       aws.String(RoleName(role)),
+		},
+	)
+
+	return err
+}
+
+func executeCommandPolicyDocument() policyDocument {
+	return policyDocument{
+		Version: "2012-10-17",
+		Statement: []policyStatement{
+			policyStatement{
+				Effect: "Allow",
+				Action: []string{
+					"ssmmessages:CreateControlChannel",
+					"ssmmessages:CreateDataChannel",
+					"ssmmessages:OpenControlChannel",
+					"ssmmessages:OpenDataChannel",
+				},
+				Resource: []string{"*"},
+			},
+		},
+	}
+}
+
+type policyDocument struct {
+	Version   string
+	Statement []policyStatement
+}
+
+type policyStatement struct {
+	Effect   string
+	Action   []string
+	Resource []string
+}
+
+// GrantSecretsAccess allows a task execution role to read the SSM Parameter Store parameters and
+// Secrets Manager secrets referenced by the given values, which may be ARNs or parameter names in the
+// given region, and to decrypt them with the given customer managed KMS keys. Access is recorded in
+// an inline policy on the role which accumulates across calls.
+func (iam *IAM) GrantSecretsAccess(roleArn, region string, valueFroms, kmsKeyArns []string) error {
+	var resources []string
+
+	roleName := RoleName(roleArn)
+
+	getRolePolicyResp, err := iam.svc.GetRolePolicy(
+		&awsiam.GetRolePolicyInput{
+			PolicyName: aws.String(ecsTaskExecutionSecretsPolicyName),
+			RoleName:   aws.String(roleName),
+		},
+	)
+
+	if err == nil {
+		resources, err = policyDocumentResources(aws.StringValue(getRolePolicyResp.PolicyDocument))
+
+		if err != nil {
+			return err
+		}
+	} else if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != awsiam.ErrCodeNoSuchEntityException {
+		return err
+	}
+
+	for _, valueFrom := range valueFroms {
+		resources = append(resources, SecretResourceArn(valueFrom, region))
+	}
+
+	resources = append(resources, kmsKeyArns...)
+
+	document, err := secretsPolicyDocument(resources)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = iam.svc.PutRolePolicy(
+		&awsiam.PutRolePolicyInput{
+			PolicyDocument: aws.String(document),
+			PolicyName:     aws.String(ecsTaskExecutionSecretsPolicyName),
+			RoleName:       aws.String(roleName),
+		},
+	)
+
+	return err
+}
+
+// RoleName returns the name of an IAM role from its ARN.
+func RoleName(roleArn string) string {
+	return roleArn[strings.LastIndex(roleArn, "/")+1:]
+}
+
+// SecretResourceArn returns the ARN to grant access to for a secret's value. Parameter names are
+// expanded to parameter ARNs in the given region, and the JSON key, version stage, and version ID
+// suffixes of Secrets Manager ARNs are removed.
+func SecretResourceArn(valueFrom, region string) string {
+	if !strings.HasPrefix(valueFrom, "arn:") {
+		return fmt.Sprintf(ssmParameterArnFormat, region, strings.TrimPrefix(valueFrom, "/"))
+	}
+
+	if fields := strings.Split(valueFrom, ":"); len(fields) > 7 && fields[2] == "secretsmanager" {
+		return strings.Join(fields[:7], ":")
+	}
+
+	return valueFrom
+}
+
+func secretsPolicyDocument(resources []string) (string, error) {
+	var keys, parameters, secrets []string
+
+	seen := make(map[string]bool)
+
+	sort.Strings(resources)
+
+	for _, resource := range resources {
+		if seen[resource] {
+			continue
+		}
+
+		seen[resource] = true
+
+		fields := strings.Split(resource, ":")
+
+		switch {
+		case len(fields) > 2 && fields[2] == "secretsmanager":
+			secrets = append(secrets, resource)
+		case len(fields) > 2 && fields[2] == "kms":
+			keys = append(keys, resource)
+		default:
+			parameters = append(parameters, resource)
+		}
+	}
+
+	document := policyDocument{Version: "2012-10-17"}
+
+	if len(parameters) > 0 {
+		document.Statement = append(document.Statement,
+			policyStatement{
+				Effect:   "Allow",
+				Action:   []string{"ssm:GetParameters"},
+				Resource: parameters,
+			},
+		)
+	}
+
+	if len(secrets) > 0 {
+		document.Statement = append(document.Statement,
+			policyStatement{
+				Effect:   "Allow",
+				Action:   []string{"secretsmanager:GetSecretValue"},
+				Resource: secrets,
+			},
+		)
+	}
+
+	if len(keys) > 0 {
+		document.Statement = append(document.Statement,
+			policyStatement{
+				Effect:   "Allow",
+				Action:   []string{"kms:Decrypt"},
+				Resource: keys,
+			},
+		)
+	}
+
+	b, err := json.Marshal(document)
+
+	return string(b), err
+}
+
+// policyDocumentResources returns the resources of a URL encoded policy document as returned by the
+// IAM API.
+func policyDocumentResources(encodedDocument string) ([]string, error) {
+	var (
+		document  policyDocument
+		resources []string
+	)
+
+	decodedDocument, err := url.QueryUnescape(encodedDocument)
+
+	if err != nil {
+		return resources, err
+	}
+
+	if err := json.Unmarshal([]byte(decodedDocument), &document); err != nil {
+		return resources, err
+	}
+
+	for _, statement := range document.Statement {
+		resources = append(resources, statement.Resource...)
+	}
+
+	return resources, nil
+}
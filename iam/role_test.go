@@ -0,0 +1,116 @@
+package iam
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestSecretResourceArn(t *testing.T) {
+	var tests = []struct {
+		valueFrom string
+		arn       string
+	}{
+		{"db-password", "arn:aws:ssm:us-east-1:*:parameter/db-password"},
+		{"/prod/db/password", "arn:aws:ssm:us-east-1:*:parameter/prod/db/password"},
+		{
+			"arn:aws:ssm:us-west-2:123456789012:parameter/prod/db/password",
+			"arn:aws:ssm:us-west-2:123456789012:parameter/prod/db/password",
+		},
+		{
+			"arn:aws:secretsmanager:us-east-1:123456789012:secret:prod/db-AbCdEf",
+			"arn:aws:secretsmanager:us-east-1:123456789012:secret:prod/db-AbCdEf",
+		},
+		{
+			"arn:aws:secretsmanager:us-east-1:123456789012:secret:prod/db-AbCdEf:password::",
+			"arn:aws:secretsmanager:us-east-1:123456789012:secret:prod/db-AbCdEf",
+		},
+	}
+
+	for _, test := range tests {
+		if arn := SecretResourceArn(test.valueFrom, "us-east-1"); arn != test.arn {
+			t.Errorf("expected %s for %s, got %s", test.arn, test.valueFrom, arn)
+		}
+	}
+}
+
+func TestRoleName(t *testing.T) {
+	if expected, got := "ecsTaskExecutionRole", RoleName("arn:aws:iam::123456789012:role/ecsTaskExecutionRole"); expected != got {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestSecretsPolicyDocument(t *testing.T) {
+	resources := []string{
+		"arn:aws:ssm:us-east-1:*:parameter/b",
+		"arn:aws:secretsmanager:us-east-1:123456789012:secret:db-AbCdEf",
+		"arn:aws:ssm:us-east-1:*:parameter/a",
+		"arn:aws:ssm:us-east-1:*:parameter/b",
+	}
+	expected := `{"Version":"2012-10-17","Statement":[` +
+		`{"Effect":"Allow","Action":["ssm:GetParameters"],"Resource":["arn:aws:ssm:us-east-1:*:parameter/a","arn:aws:ssm:us-east-1:*:parameter/b"]},` +
+		`{"Effect":"Allow","Action":["secretsmanager:GetSecretValue"],"Resource":["arn:aws:secretsmanager:us-east-1:123456789012:secret:db-AbCdEf"]}]}`
+
+	document, err := secretsPolicyDocument(resources)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if document != expected {
+		t.Errorf("expected %s, got %s", expected, document)
+	}
+
+	got, err := policyDocumentResources(url.QueryEscape(document))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{
+		"arn:aws:ssm:us-east-1:*:parameter/a",
+		"arn:aws:ssm:us-east-1:*:parameter/b",
+		"arn:aws:secretsmanager:us-east-1:123456789012:secret:db-AbCdEf",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected resources %v, got %v", want, got)
+	}
+}
+
+func TestSecretsPolicyDocumentKMSKeys(t *testing.T) {
+	resources := []string{
+		"arn:aws:ssm:us-east-1:*:parameter/a",
+		"arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+	}
+	expected := `{"Version":"2012-10-17","Statement":[` +
+		`{"Effect":"Allow","Action":["ssm:GetParameters"],"Resource":["arn:aws:ssm:us-east-1:*:parameter/a"]},` +
+		`{"Effect":"Allow","Action":["kms:Decrypt"],"Resource":["arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"]}]}`
+
+	document, err := secretsPolicyDocument(resources)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if document != expected {
+		t.Errorf("expected %s, got %s", expected, document)
+	}
+}
+
+func TestExecuteCommandPolicyDocument(t *testing.T) {
+	expected := `{"Version":"2012-10-17","Statement":[` +
+		`{"Effect":"Allow","Action":["ssmmessages:CreateControlChannel","ssmmessages:CreateDataChannel",` +
+		`"ssmmessages:OpenControlChannel","ssmmessages:OpenDataChannel"],"Resource":["*"]}]}`
+
+	document, err := json.Marshal(executeCommandPolicyDocument())
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if string(document) != expected {
+		t.Errorf("expected %s, got %s", expected, document)
+	}
+}
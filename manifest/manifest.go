@@ -0,0 +1,225 @@
+// Package manifest reads declarative descriptions of the services, task groups, load balancers,
+// and certificates managed by fargate.
+package manifest
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	defaultCpu    = "256"
+	defaultMemory = "512"
+	defaultNum    = 1
+	defaultScheme = "internet-facing"
+)
+
+// Manifest describes the desired state of resources managed by fargate.
+type Manifest struct {
+	Certificates  []Certificate  `yaml:"certificates"`
+	LoadBalancers []LoadBalancer `yaml:"loadBalancers"`
+	Services      []Service      `yaml:"services"`
+	TaskGroups    []TaskGroup    `yaml:"tasks"`
+}
+
+// Certificate describes a certificate to be requested from AWS Certificate Manager.
+type Certificate struct {
+	Aliases    []string `yaml:"aliases"`
+	DomainName string   `yaml:"domainName"`
+}
+
+// LoadBalancer describes an Elastic Load Balancing (v2) load balancer.
+type LoadBalancer struct {
+	Certificates     []string `yaml:"certificates"`
+	Name             string   `yaml:"name"`
+	Ports            []string `yaml:"ports"`
+	Scheme           string   `yaml:"scheme"`
+	SecurityGroupIDs []string `yaml:"securityGroupIds"`
+	SubnetIDs        []string `yaml:"subnetIds"`
+}
+
+// Service describes a long-lived set of tasks maintained by the ECS service scheduler.
+type Service struct {
+	AssignPublicIP   *bool             `yaml:"assignPublicIp"`
+	Command          []string          `yaml:"command"`
+	Cpu              string            `yaml:"cpu"`
+	Env              map[string]string `yaml:"env"`
+	Image            string            `yaml:"image"`
+	LoadBalancer     string            `yaml:"lb"`
+	Memory           string            `yaml:"memory"`
+	Name             string            `yaml:"name"`
+	Num              int64             `yaml:"num"`
+	Port             string            `yaml:"port"`
+	Rules            []string          `yaml:"rules"`
+	SecurityGroupIDs []string          `yaml:"securityGroupIds"`
+	SubnetIDs        []string          `yaml:"subnetIds"`
+	TaskRole         string            `yaml:"taskRole"`
+}
+
+// TaskGroup describes a group of one-off tasks of which a number of instances should be running.
+type TaskGroup struct {
+	Command          []string          `yaml:"command"`
+	Cpu              string            `yaml:"cpu"`
+	Env              map[string]string `yaml:"env"`
+	Image            string            `yaml:"image"`
+	Memory           string            `yaml:"memory"`
+	Name             string            `yaml:"name"`
+	Num              int64             `yaml:"num"`
+	SecurityGroupIDs []string          `yaml:"securityGroupIds"`
+	SubnetIDs        []string          `yaml:"subnetIds"`
+	TaskRole         string            `yaml:"taskRole"`
+}
+
+// Load reads and parses the manifest at the given path.
+func Load(path string) (Manifest, error) {
+	b, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	return Parse(b)
+}
+
+// Parse decodes a YAML manifest and fills in default values for omitted settings. Unknown keys are
+// rejected so that typos are not silently ignored.
+func Parse(b []byte) (Manifest, error) {
+	var m Manifest
+
+	if err := yaml.UnmarshalStrict(b, &m); err != nil {
+		return Manifest{}, err
+	}
+
+	m.setDefaults()
+
+	return m, nil
+}
+
+// Validate returns any errors found in the manifest's structure.
+func (m Manifest) Validate() (errs []error) {
+	certificates := make(map[string]bool)
+	loadBalancers := make(map[string]bool)
+	services := make(map[string]bool)
+	taskGroups := make(map[string]bool)
+
+	for _, c := range m.Certificates {
+		switch {
+		case c.DomainName == "":
+			errs = append(errs, fmt.Errorf("certificates must have a domainName"))
+		case certificates[c.DomainName]:
+			errs = append(errs, fmt.Errorf("certificate %s is declared more than once", c.DomainName))
+		}
+
+		certificates[c.DomainName] = true
+	}
+
+	for _, lb := range m.LoadBalancers {
+		switch {
+		case lb.Name == "":
+			errs = append(errs, fmt.Errorf("load balancers must have a name"))
+		case loadBalancers[lb.Name]:
+			errs = append(errs, fmt.Errorf("load balancer %s is declared more than once", lb.Name))
+		}
+
+		if len(lb.Ports) == 0 {
+			errs = append(errs, fmt.Errorf("load balancer %s must have at least one port", lb.Name))
+		}
+
+		loadBalancers[lb.Name] = true
+	}
+
+	for _, s := range m.Services {
+		switch {
+		case s.Name == "":
+			errs = append(errs, fmt.Errorf("services must have a name"))
+		case services[s.Name]:
+			errs = append(errs, fmt.Errorf("service %s is declared more than once", s.Name))
+		}
+
+		if s.Image == "" {
+			errs = append(errs, fmt.Errorf("service %s must have an image", s.Name))
+		}
+
+		if s.LoadBalancer != "" && s.Port == "" {
+			errs = append(errs, fmt.Errorf("service %s must have a port to use load balancer %s", s.Name, s.LoadBalancer))
+		}
+
+		if len(s.Rules) > 0 && s.LoadBalancer == "" {
+			errs = append(errs, fmt.Errorf("service %s must have a load balancer to use rules", s.Name))
+		}
+
+		if s.Num < 1 {
+			errs = append(errs, fmt.Errorf("service %s must have a num of 1 or more", s.Name))
+		}
+
+		services[s.Name] = true
+	}
+
+	for _, t := range m.TaskGroups {
+		switch {
+		case t.Name == "":
+			errs = append(errs, fmt.Errorf("tasks must have a name"))
+		case taskGroups[t.Name]:
+			errs = append(errs, fmt.Errorf("task group %s is declared more than once", t.Name))
+		}
+
+		if t.Image == "" {
+			errs = append(errs, fmt.Errorf("task group %s must have an image", t.Name))
+		}
+
+		if t.Num < 1 {
+			errs = append(errs, fmt.Errorf("task group %s must have a num of 1 or more", t.Name))
+		}
+
+		taskGroups[t.Name] = true
+	}
+
+	return
+}
+
+func (m *Manifest) setDefaults() {
+	for i := range m.LoadBalancers {
+		if m.LoadBalancers[i].Scheme == "" {
+			m.LoadBalancers[i].Scheme = defaultScheme
+		}
+	}
+
+	for i := range m.Services {
+		s := &m.Services[i]
+
+		if s.Cpu == "" {
+			s.Cpu = defaultCpu
+		}
+
+		if s.Memory == "" {
+			s.Memory = defaultMemory
+		}
+
+		if s.Num == 0 {
+			s.Num = defaultNum
+		}
+
+		if s.AssignPublicIP == nil {
+			assignPublicIP := true
+			s.AssignPublicIP = &assignPublicIP
+		}
+	}
+
+	for i := range m.TaskGroups {
+		t := &m.TaskGroups[i]
+
+		if t.Cpu == "" {
+			t.Cpu = defaultCpu
+		}
+
+		if t.Memory == "" {
+			t.Memory = defaultMemory
+		}
+
+		if t.Num == 0 {
+			t.Num = defaultNum
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	m, err := Parse([]byte(`
+loadBalancers:
+  - name: web
+    ports: [HTTP:80]
+services:
+  - name: api
+    image: example/api:1.0
+    port: HTTP:8080
+    lb: web
+    env:
+      LOG_LEVEL: info
+tasks:
+  - name: worker
+    image: example/worker:1.0
+    num: 3
+`))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(m.LoadBalancers) != 1 || m.LoadBalancers[0].Scheme != defaultScheme {
+		t.Errorf("expected load balancer with scheme %s, got %+v", defaultScheme, m.LoadBalancers)
+	}
+
+	if len(m.Services) != 1 {
+		t.Fatalf("expected 1 service, got %d", len(m.Services))
+	}
+
+	s := m.Services[0]
+
+	if s.Cpu != defaultCpu || s.Memory != defaultMemory || s.Num != defaultNum {
+		t.Errorf("expected service defaults, got cpu=%s memory=%s num=%d", s.Cpu, s.Memory, s.Num)
+	}
+
+	if s.AssignPublicIP == nil || !*s.AssignPublicIP {
+		t.Errorf("expected assignPublicIp to default to true")
+	}
+
+	if expected := map[string]string{"LOG_LEVEL": "info"}; !reflect.DeepEqual(s.Env, expected) {
+		t.Errorf("expected env %v, got %v", expected, s.Env)
+	}
+
+	if len(m.TaskGroups) != 1 || m.TaskGroups[0].Num != 3 {
+		t.Errorf("expected task group with num 3, got %+v", m.TaskGroups)
+	}
+}
+
+func TestParseUnknownKey(t *testing.T) {
+	_, err := Parse([]byte(`
+services:
+  - name: api
+    imag: example/api:1.0
+`))
+
+	if err == nil {
+		t.Errorf("expected error for unknown key, got none")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var tests = []struct {
+		manifest Manifest
+		errs     int
+	}{
+		{
+			Manifest{
+				Services:   []Service{{Name: "api", Image: "api", Num: 1}},
+				TaskGroups: []TaskGroup{{Name: "worker", Image: "worker", Num: 1}},
+			},
+			0,
+		},
+		{Manifest{Certificates: []Certificate{{}}}, 1},
+		{Manifest{Certificates: []Certificate{{DomainName: "a.com"}, {DomainName: "a.com"}}}, 1},
+		{Manifest{LoadBalancers: []LoadBalancer{{Name: "web"}}}, 1},
+		{Manifest{Services: []Service{{Name: "api", Num: 1}}}, 1},
+		{Manifest{Services: []Service{{Name: "api", Image: "api", Num: 1, LoadBalancer: "web"}}}, 1},
+		{Manifest{Services: []Service{{Name: "api", Image: "api", Num: 1, Rules: []string{"path=/"}}}}, 1},
+		{Manifest{Services: []Service{{Name: "api", Image: "api"}}}, 1},
+		{Manifest{Services: []Service{{Name: "api", Image: "api", Num: 1}, {Name: "api", Image: "api", Num: 1}}}, 1},
+		{Manifest{TaskGroups: []TaskGroup{{Image: "worker", Num: 1}}}, 1},
+		{Manifest{TaskGroups: []TaskGroup{{Name: "worker"}}}, 2},
+	}
+
+	for i, test := range tests {
+		if errs := test.manifest.Validate(); len(errs) != test.errs {
+			t.Errorf("test %d: expected %d errors, got %d: %v", i, test.errs, len(errs), errs)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package ssmmessages
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Message types exchanged over a data channel.
+const (
+	messageTypeAcknowledge      = "acknowledge"
+	messageTypeChannelClosed    = "channel_closed"
+	messageTypeInputStreamData  = "input_stream_data"
+	messageTypeOutputStreamData = "output_stream_data"
+)
+
+// Payload types of stream data messages.
+const (
+	payloadTypeOutput            uint32 = 1
+	payloadTypeSize              uint32 = 3
+	payloadTypeHandshakeRequest  uint32 = 5
+	payloadTypeHandshakeResponse uint32 = 6
+	payloadTypeHandshakeComplete uint32 = 7
+	payloadTypeStdErr            uint32 = 11
+)
+
+// A client message is a fixed size binary header followed by its payload. The header length field
+// counts the bytes which precede the payload length field.
+const (
+	headerLengthOffset   = 0
+	messageTypeOffset    = 4
+	messageTypeLength    = 32
+	schemaVersionOffset  = 36
+	createdDateOffset    = 40
+	sequenceNumberOffset = 48
+	flagsOffset          = 56
+	messageIDOffset      = 64
+	payloadDigestOffset  = 80
+	payloadTypeOffset    = 112
+	payloadLengthOffset  = 116
+	payloadOffset        = 120
+
+	acknowledgeFlags uint64 = 3
+	schemaVersion    uint32 = 1
+)
+
+type clientMessage struct {
+	CreatedDate    time.Time
+	Flags          uint64
+	MessageID      string
+	MessageType    string
+	Payload        []byte
+	PayloadType    uint32
+	SchemaVersion  uint32
+	SequenceNumber int64
+}
+
+// MarshalBinary encodes the message in the data channel's wire format.
+func (m clientMessage) MarshalBinary() ([]byte, error) {
+	if len(m.MessageType) > messageTypeLength {
+		return nil, fmt.Errorf("message type %s is too long", m.MessageType)
+	}
+
+	messageID, err := uuidBytes(m.MessageID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, payloadOffset+len(m.Payload))
+	digest := sha256.Sum256(m.Payload)
+
+	binary.BigEndian.PutUint32(data[headerLengthOffset:], payloadLengthOffset)
+	copy(data[messageTypeOffset:], m.MessageType+strings.Repeat(" ", messageTypeLength-len(m.MessageType)))
+	binary.BigEndian.PutUint32(data[schemaVersionOffset:], m.SchemaVersion)
+	binary.BigEndian.PutUint64(data[createdDateOffset:], uint64(m.CreatedDate.UnixNano()/int64(time.Millisecond)))
+	binary.BigEndian.PutUint64(data[sequenceNumberOffset:], uint64(m.SequenceNumber))
+	binary.BigEndian.PutUint64(data[flagsOffset:], m.Flags)
+	copy(data[messageIDOffset:], messageID)
+	copy(data[payloadDigestOffset:], digest[:])
+	binary.BigEndian.PutUint32(data[payloadTypeOffset:], m.PayloadType)
+	binary.BigEndian.PutUint32(data[payloadLengthOffset:], uint32(len(m.Payload)))
+	copy(data[payloadOffset:], m.Payload)
+
+	return data, nil
+}
+
+// UnmarshalBinary decodes a message in the data channel's wire format, verifying its payload digest.
+func (m *clientMessage) UnmarshalBinary(data []byte) error {
+	if len(data) < payloadOffset {
+		return fmt.Errorf("message of %d bytes is shorter than its header", len(data))
+	}
+
+	headerLength := binary.BigEndian.Uint32(data[headerLengthOffset:])
+
+	if headerLength != payloadLengthOffset {
+		return fmt.Errorf("unexpected message header length %d", headerLength)
+	}
+
+	payloadLength := binary.BigEndian.Uint32(data[payloadLengthOffset:])
+
+	if uint64(len(data)) < uint64(payloadOffset)+uint64(payloadLength) {
+		return fmt.Errorf("message payload of %d bytes is truncated", payloadLength)
+	}
+
+	payload := data[payloadOffset : payloadOffset+payloadLength]
+	digest := sha256.Sum256(payload)
+
+	if !bytes.Equal(digest[:], data[payloadDigestOffset:payloadTypeOffset]) {
+		return errors.New("message payload digest does not match")
+	}
+
+	m.MessageType = strings.TrimRight(string(data[messageTypeOffset:schemaVersionOffset]), " \x00")
+	m.SchemaVersion = binary.BigEndian.Uint32(data[schemaVersionOffset:])
+	m.CreatedDate = time.Unix(0, int64(binary.BigEndian.Uint64(data[createdDateOffset:]))*int64(time.Millisecond))
+	m.SequenceNumber = int64(binary.BigEndian.Uint64(data[sequenceNumberOffset:]))
+	m.Flags = binary.BigEndian.Uint64(data[flagsOffset:])
+	m.MessageID = uuidString(data[messageIDOffset:payloadDigestOffset])
+	m.PayloadType = binary.BigEndian.Uint32(data[payloadTypeOffset:])
+	m.Payload = append([]byte(nil), payload...)
+
+	return nil
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() string {
+	u := make([]byte, 16)
+
+	rand.Read(u)
+
+	u[6] = (u[6] & 0x0F) | 0x40
+	u[8] = (u[8] & 0x3F) | 0x80
+
+	h := hex.EncodeToString(u)
+
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:32])
+}
+
+// uuidBytes encodes a UUID as it appears in a message header: its least significant eight bytes
+// followed by its most significant eight bytes.
+func uuidBytes(uuid string) ([]byte, error) {
+	u, err := hex.DecodeString(strings.Replace(uuid, "-", "", -1))
+
+	if err != nil || len(u) != 16 {
+		return nil, fmt.Errorf("invalid message ID %s", uuid)
+	}
+
+	return append(u[8:16], u[0:8]...), nil
+}
+
+func uuidString(b []byte) string {
+	h := hex.EncodeToString(append(append([]byte(nil), b[8:16]...), b[0:8]...))
+
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:32])
+}
@@ -0,0 +1,96 @@
+package ssmmessages
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientMessageRoundTrip(t *testing.T) {
+	message := clientMessage{
+		CreatedDate:    time.Unix(1600000000, 123000000),
+		Flags:          0,
+		MessageID:      "0b0e1c2d-3e4f-4a5b-8c6d-7e8f9a0b1c2d",
+		MessageType:    messageTypeOutputStreamData,
+		Payload:        []byte("hello"),
+		PayloadType:    payloadTypeOutput,
+		SchemaVersion:  schemaVersion,
+		SequenceNumber: 42,
+	}
+
+	data, err := message.MarshalBinary()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if expected, got := payloadOffset+len("hello"), len(data); expected != got {
+		t.Errorf("expected %d bytes, got %d", expected, got)
+	}
+
+	if expected, got := uint32(116), binary.BigEndian.Uint32(data); expected != got {
+		t.Errorf("expected header length %d, got %d", expected, got)
+	}
+
+	if expected, got := messageTypeOutputStreamData+strings.Repeat(" ", 32-len(messageTypeOutputStreamData)), string(data[4:36]); expected != got {
+		t.Errorf("expected padded message type %q, got %q", expected, got)
+	}
+
+	if expected, got := []byte{0x8c, 0x6d, 0x7e, 0x8f, 0x9a, 0x0b, 0x1c, 0x2d}, data[64:72]; !bytes.Equal(expected, got) {
+		t.Errorf("expected least significant bytes of message ID first, got %x", got)
+	}
+
+	var decoded clientMessage
+
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !reflect.DeepEqual(message, decoded) {
+		t.Errorf("expected %+v, got %+v", message, decoded)
+	}
+}
+
+func TestClientMessageUnmarshalErrors(t *testing.T) {
+	data, _ := clientMessage{MessageID: newUUID(), MessageType: messageTypeOutputStreamData, Payload: []byte("hello")}.MarshalBinary()
+	corrupted := append([]byte(nil), data...)
+	corrupted[len(corrupted)-1] = 'O'
+
+	var tests = []struct {
+		name string
+		data []byte
+	}{
+		{"short", data[:payloadOffset-1]},
+		{"truncated", data[:len(data)-1]},
+		{"digest", corrupted},
+	}
+
+	for _, test := range tests {
+		var message clientMessage
+
+		if err := message.UnmarshalBinary(test.data); err == nil {
+			t.Errorf("expected error for %s message, got none", test.name)
+		}
+	}
+}
+
+func TestNewUUID(t *testing.T) {
+	uuid := newUUID()
+
+	b, err := uuidBytes(uuid)
+
+	if err != nil {
+		t.Fatalf("expected valid UUID, got %v", err)
+	}
+
+	if got := uuidString(b); got != uuid {
+		t.Errorf("expected %s, got %s", uuid, got)
+	}
+
+	if uuid[14] != '4' {
+		t.Errorf("expected version 4 UUID, got %s", uuid)
+	}
+}
@@ -0,0 +1,389 @@
+// Package ssmmessages implements the client side of the AWS Systems Manager Session Manager data
+// channel, over which ECS Exec runs interactive commands in containers.
+package ssmmessages
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	clientVersion      = "1.2.0.0"
+	inputChunkSize     = 1024
+	openMessageVersion = "1.0"
+	resendTimeout      = time.Second
+
+	actionStatusSuccess     = 1
+	actionStatusUnsupported = 3
+	actionTypeSessionType   = "SessionType"
+)
+
+type openDataChannelInput struct {
+	ClientID             string `json:"ClientId"`
+	ClientVersion        string
+	MessageSchemaVersion string
+	RequestID            string `json:"RequestId"`
+	TokenValue           string
+}
+
+type acknowledgeContent struct {
+	AcknowledgedMessageID             string `json:"AcknowledgedMessageId"`
+	AcknowledgedMessageSequenceNumber int64
+	AcknowledgedMessageType           string
+	IsSequentialMessage               bool
+}
+
+type requestedClientAction struct {
+	ActionParameters json.RawMessage
+	ActionType       string
+}
+
+type handshakeRequest struct {
+	AgentVersion           string
+	RequestedClientActions []requestedClientAction
+}
+
+type processedClientAction struct {
+	ActionStatus int
+	ActionType   string
+	Error        string
+}
+
+type handshakeResponse struct {
+	ClientVersion          string
+	Errors                 []string
+	ProcessedClientActions []processedClientAction
+}
+
+type channelClosed struct {
+	Output    string
+	SessionID string `json:"SessionId"`
+}
+
+type terminalSize struct {
+	Cols int `json:"cols"`
+	Rows int `json:"rows"`
+}
+
+// Session is an interactive session over a Session Manager data channel.
+type Session struct {
+	conn *websocketConn
+
+	inputMu        sync.Mutex
+	inputSequence  int64
+	outputSequence int64
+	pending        map[int64]clientMessage
+	resendTimeout  time.Duration
+	unacknowledged map[int64]*sentMessage
+}
+
+// sentMessage is an input message the agent has not yet acknowledged and when it was last sent.
+type sentMessage struct {
+	message clientMessage
+	sentAt  time.Time
+}
+
+// Open connects to the data channel of a session and authenticates with the session's token.
+func Open(streamURL, tokenValue string) (*Session, error) {
+	conn, err := dialWebsocket(streamURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	session := newSession(conn)
+	open, err := json.Marshal(
+		openDataChannelInput{
+			ClientID:             newUUID(),
+			ClientVersion:        clientVersion,
+			MessageSchemaVersion: openMessageVersion,
+			RequestID:            newUUID(),
+			TokenValue:           tokenValue,
+		},
+	)
+
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if err := conn.WriteMessage(opText, open); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func newSession(conn *websocketConn) *Session {
+	return &Session{
+		conn:           conn,
+		pending:        make(map[int64]clientMessage),
+		resendTimeout:  resendTimeout,
+		unacknowledged: make(map[int64]*sentMessage),
+	}
+}
+
+// Run sends input to the session and writes its output until the session's channel is closed. Once
+// the session's handshake completes, the terminal size is sent if it is known (non-zero) and input
+// starts being read. Input the agent does not acknowledge is sent again until it is.
+func (s *Session) Run(input io.Reader, output io.Writer, cols, rows int) error {
+	done := make(chan struct{})
+	defer close(done)
+
+	go s.resendUnacknowledged(done)
+
+	for {
+		opcode, data, err := s.conn.ReadMessage()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if opcode != opBinary {
+			continue
+		}
+
+		var message clientMessage
+
+		if err := message.UnmarshalBinary(data); err != nil {
+			return err
+		}
+
+		switch message.MessageType {
+		case messageTypeAcknowledge:
+			var content acknowledgeContent
+
+			if err := json.Unmarshal(message.Payload, &content); err != nil {
+				return err
+			}
+
+			s.inputMu.Lock()
+			delete(s.unacknowledged, content.AcknowledgedMessageSequenceNumber)
+			s.inputMu.Unlock()
+		case messageTypeChannelClosed:
+			var closed channelClosed
+
+			if err := json.Unmarshal(message.Payload, &closed); err == nil && closed.Output != "" {
+				fmt.Fprintln(output, closed.Output)
+			}
+
+			return nil
+		case messageTypeOutputStreamData:
+			if err := s.acknowledge(message); err != nil {
+				return err
+			}
+
+			if message.SequenceNumber < s.outputSequence {
+				continue
+			}
+
+			s.pending[message.SequenceNumber] = message
+
+			for {
+				next, ok := s.pending[s.outputSequence]
+
+				if !ok {
+					break
+				}
+
+				delete(s.pending, s.outputSequence)
+				s.outputSequence++
+
+				if err := s.handleOutput(next, input, output, cols, rows); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// Resize sends the size of the local terminal to the session.
+func (s *Session) Resize(cols, rows int) error {
+	size, err := json.Marshal(terminalSize{Cols: cols, Rows: rows})
+
+	if err != nil {
+		return err
+	}
+
+	return s.sendInput(payloadTypeSize, size)
+}
+
+// Close closes the session's data channel.
+func (s *Session) Close() error {
+	return s.conn.Close()
+}
+
+func (s *Session) handleOutput(message clientMessage, input io.Reader, output io.Writer, cols, rows int) error {
+	switch message.PayloadType {
+	case payloadTypeOutput, payloadTypeStdErr:
+		_, err := output.Write(message.Payload)
+
+		return err
+	case payloadTypeHandshakeRequest:
+		return s.respondToHandshake(message.Payload)
+	case payloadTypeHandshakeComplete:
+		if cols > 0 && rows > 0 {
+			if err := s.Resize(cols, rows); err != nil {
+				return err
+			}
+		}
+
+		go s.copyInput(input)
+	}
+
+	return nil
+}
+
+func (s *Session) respondToHandshake(payload []byte) error {
+	var request handshakeRequest
+
+	if err := json.Unmarshal(payload, &request); err != nil {
+		return err
+	}
+
+	response := handshakeResponse{ClientVersion: clientVersion, Errors: []string{}}
+
+	for _, action := range request.RequestedClientActions {
+		processed := processedClientAction{ActionType: action.ActionType, ActionStatus: actionStatusSuccess}
+
+		if action.ActionType != actionTypeSessionType {
+			processed.ActionStatus = actionStatusUnsupported
+			processed.Error = fmt.Sprintf("%s is not supported", action.ActionType)
+		}
+
+		response.ProcessedClientActions = append(response.ProcessedClientActions, processed)
+	}
+
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		return err
+	}
+
+	return s.sendInput(payloadTypeHandshakeResponse, data)
+}
+
+func (s *Session) copyInput(input io.Reader) {
+	buf := make([]byte, inputChunkSize)
+
+	for {
+		n, err := input.Read(buf)
+
+		if n > 0 {
+			if s.sendInput(payloadTypeOutput, buf[:n]) != nil {
+				return
+			}
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *Session) sendInput(payloadType uint32, payload []byte) error {
+	s.inputMu.Lock()
+	defer s.inputMu.Unlock()
+
+	message := clientMessage{
+		CreatedDate:    time.Now(),
+		MessageID:      newUUID(),
+		MessageType:    messageTypeInputStreamData,
+		Payload:        payload,
+		PayloadType:    payloadType,
+		SchemaVersion:  schemaVersion,
+		SequenceNumber: s.inputSequence,
+	}
+
+	if err := s.send(message); err != nil {
+		return err
+	}
+
+	s.unacknowledged[message.SequenceNumber] = &sentMessage{message: message, sentAt: time.Now()}
+	s.inputSequence++
+
+	return nil
+}
+
+// resendUnacknowledged sends input messages again, in sequence order, once they have gone
+// unacknowledged for the resend timeout, until done is closed.
+func (s *Session) resendUnacknowledged(done <-chan struct{}) {
+	ticker := time.NewTicker(s.resendTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			s.inputMu.Lock()
+
+			var sequences []int64
+
+			for sequence, sent := range s.unacknowledged {
+				if now.Sub(sent.sentAt) >= s.resendTimeout {
+					sequences = append(sequences, sequence)
+				}
+			}
+
+			sort.Slice(sequences, func(i, j int) bool { return sequences[i] < sequences[j] })
+
+			for _, sequence := range sequences {
+				sent := s.unacknowledged[sequence]
+
+				if s.send(sent.message) != nil {
+					break
+				}
+
+				sent.sentAt = now
+			}
+
+			s.inputMu.Unlock()
+		}
+	}
+}
+
+func (s *Session) acknowledge(message clientMessage) error {
+	content, err := json.Marshal(
+		acknowledgeContent{
+			AcknowledgedMessageID:             message.MessageID,
+			AcknowledgedMessageSequenceNumber: message.SequenceNumber,
+			AcknowledgedMessageType:           message.MessageType,
+			IsSequentialMessage:               true,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return s.send(
+		clientMessage{
+			CreatedDate:   time.Now(),
+			Flags:         acknowledgeFlags,
+			MessageID:     newUUID(),
+			MessageType:   messageTypeAcknowledge,
+			Payload:       content,
+			SchemaVersion: schemaVersion,
+		},
+	)
+}
+
+func (s *Session) send(message clientMessage) error {
+	data, err := message.MarshalBinary()
+
+	if err != nil {
+		return err
+	}
+
+	return s.conn.WriteMessage(opBinary, data)
+}
@@ -0,0 +1,345 @@
+package ssmmessages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeAgent plays the agent's side of a data channel over a WebSocket served by a test server.
+type fakeAgent struct {
+	conn     *websocketConn
+	open     openDataChannelInput
+	received chan clientMessage
+	t        *testing.T
+}
+
+func newFakeDataChannel(t *testing.T, handler func(*fakeAgent)) *httptest.Server {
+	return httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Upgrade") != "websocket" {
+				http.Error(w, "expected WebSocket upgrade", http.StatusBadRequest)
+				return
+			}
+
+			netConn, rw, err := w.(http.Hijacker).Hijack()
+
+			if err != nil {
+				t.Errorf("could not hijack connection: %v", err)
+				return
+			}
+
+			fmt.Fprintf(rw,
+				"HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n",
+				websocketAccept(r.Header.Get("Sec-Websocket-Key")),
+			)
+			rw.Flush()
+
+			agent := &fakeAgent{
+				conn:     newWebsocketConn(netConn, rw.Reader, false),
+				received: make(chan clientMessage, 100),
+				t:        t,
+			}
+
+			opcode, data, err := agent.conn.ReadMessage()
+
+			if err != nil || opcode != opText {
+				t.Errorf("expected open data channel text message, got %d, %v", opcode, err)
+				return
+			}
+
+			json.Unmarshal(data, &agent.open)
+
+			done := make(chan struct{})
+
+			go func() {
+				defer close(done)
+
+				for {
+					_, data, err := agent.conn.ReadMessage()
+
+					if err != nil {
+						close(agent.received)
+						return
+					}
+
+					var message clientMessage
+
+					if err := message.UnmarshalBinary(data); err != nil {
+						t.Errorf("could not decode client message: %v", err)
+						continue
+					}
+
+					agent.received <- message
+				}
+			}()
+
+			handler(agent)
+			<-done
+			netConn.Close()
+		}),
+	)
+}
+
+func (a *fakeAgent) send(sequence int64, messageType string, payloadType uint32, payload []byte) {
+	data, err := clientMessage{
+		CreatedDate:    time.Now(),
+		MessageID:      newUUID(),
+		MessageType:    messageType,
+		Payload:        payload,
+		PayloadType:    payloadType,
+		SchemaVersion:  schemaVersion,
+		SequenceNumber: sequence,
+	}.MarshalBinary()
+
+	if err != nil {
+		a.t.Errorf("could not encode message: %v", err)
+	}
+
+	a.conn.WriteMessage(opBinary, data)
+}
+
+// expect returns received messages up to and including the first for which match returns true.
+func (a *fakeAgent) expect(match func(clientMessage) bool) []clientMessage {
+	var messages []clientMessage
+
+	for {
+		select {
+		case message, ok := <-a.received:
+			if !ok {
+				a.t.Errorf("channel closed before expected message")
+				return messages
+			}
+
+			messages = append(messages, message)
+
+			if match(message) {
+				return messages
+			}
+		case <-time.After(5 * time.Second):
+			a.t.Errorf("timed out waiting for message")
+			return messages
+		}
+	}
+}
+
+func isInput(payloadType uint32) func(clientMessage) bool {
+	return func(m clientMessage) bool {
+		return m.MessageType == messageTypeInputStreamData && m.PayloadType == payloadType
+	}
+}
+
+func TestSessionRun(t *testing.T) {
+	var (
+		handshake  handshakeResponse
+		received   []clientMessage
+		token      string
+		inputs     []string
+		size       terminalSize
+		ackedFirst bool
+		finished   = make(chan struct{})
+	)
+
+	server := newFakeDataChannel(t, func(agent *fakeAgent) {
+		defer close(finished)
+
+		token = agent.open.TokenValue
+
+		agent.send(0, messageTypeOutputStreamData, payloadTypeHandshakeRequest,
+			[]byte(`{"AgentVersion":"3.0.0.0","RequestedClientActions":[`+
+				`{"ActionType":"SessionType","ActionParameters":{"SessionType":"InteractiveCommands"}},`+
+				`{"ActionType":"KMSEncryption","ActionParameters":{"KMSKeyId":"key"}}]}`),
+		)
+
+		messages := agent.expect(isInput(payloadTypeHandshakeResponse))
+		received = append(received, messages...)
+		ackedFirst = messages[0].MessageType == messageTypeAcknowledge
+		json.Unmarshal(messages[len(messages)-1].Payload, &handshake)
+
+		agent.send(1, messageTypeOutputStreamData, payloadTypeHandshakeComplete, []byte(`{}`))
+
+		messages = agent.expect(isInput(payloadTypeSize))
+		received = append(received, messages...)
+		json.Unmarshal(messages[len(messages)-1].Payload, &size)
+
+		messages = agent.expect(isInput(payloadTypeOutput))
+		received = append(received, messages...)
+		inputs = append(inputs, string(messages[len(messages)-1].Payload))
+
+		// Output arrives out of order and with a resent message.
+		agent.send(3, messageTypeOutputStreamData, payloadTypeOutput, []byte("world\n"))
+		agent.send(2, messageTypeOutputStreamData, payloadTypeOutput, []byte("hello "))
+		agent.send(2, messageTypeOutputStreamData, payloadTypeOutput, []byte("hello "))
+		agent.send(0, messageTypeChannelClosed, 0, []byte(`{"Output":"Exiting session","SessionId":"ecs-execute-command-0123"}`))
+
+		for message := range agent.received {
+			received = append(received, message)
+		}
+	})
+	defer server.Close()
+
+	session, err := Open(strings.Replace(server.URL, "http", "ws", 1), "token")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var output bytes.Buffer
+
+	if err := session.Run(strings.NewReader("exit\n"), &output, 80, 24); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	session.Close()
+	<-finished
+
+	if token != "token" {
+		t.Errorf("expected token to be sent, got %q", token)
+	}
+
+	if !ackedFirst {
+		t.Errorf("expected handshake request to be acknowledged before it is answered")
+	}
+
+	if len(handshake.ProcessedClientActions) != 2 {
+		t.Fatalf("expected 2 processed actions, got %+v", handshake)
+	}
+
+	if expected, got := actionStatusSuccess, handshake.ProcessedClientActions[0].ActionStatus; expected != got {
+		t.Errorf("expected session type status %d, got %d", expected, got)
+	}
+
+	if expected, got := actionStatusUnsupported, handshake.ProcessedClientActions[1].ActionStatus; expected != got {
+		t.Errorf("expected KMS encryption status %d, got %d", expected, got)
+	}
+
+	if expected := (terminalSize{Cols: 80, Rows: 24}); size != expected {
+		t.Errorf("expected size %+v, got %+v", expected, size)
+	}
+
+	if expected := []string{"exit\n"}; len(inputs) != 1 || inputs[0] != expected[0] {
+		t.Errorf("expected input %q, got %q", expected, inputs)
+	}
+
+	if expected, got := "hello world\nExiting session\n", output.String(); expected != got {
+		t.Errorf("expected output %q, got %q", expected, got)
+	}
+
+	var (
+		acknowledged   = make(map[int64]int)
+		inputSequences []int64
+	)
+
+	for _, message := range received {
+		switch message.MessageType {
+		case messageTypeAcknowledge:
+			var content acknowledgeContent
+
+			json.Unmarshal(message.Payload, &content)
+			acknowledged[content.AcknowledgedMessageSequenceNumber]++
+		case messageTypeInputStreamData:
+			inputSequences = append(inputSequences, message.SequenceNumber)
+		}
+	}
+
+	for _, sequence := range []int64{0, 1, 2, 3} {
+		if acknowledged[sequence] == 0 {
+			t.Errorf("expected output message %d to be acknowledged", sequence)
+		}
+	}
+
+	if expected := []int64{0, 1, 2}; fmt.Sprint(expected) != fmt.Sprint(inputSequences) {
+		t.Errorf("expected input sequence numbers %v, got %v", expected, inputSequences)
+	}
+}
+
+func TestSessionRunResendsUnacknowledgedInput(t *testing.T) {
+	var (
+		first, resent clientMessage
+		later         []clientMessage
+		finished      = make(chan struct{})
+	)
+
+	server := newFakeDataChannel(t, func(agent *fakeAgent) {
+		defer close(finished)
+
+		agent.send(0, messageTypeOutputStreamData, payloadTypeHandshakeRequest, []byte(`{"AgentVersion":"3.0.0.0"}`))
+
+		messages := agent.expect(isInput(payloadTypeHandshakeResponse))
+		first = messages[len(messages)-1]
+
+		messages = agent.expect(isInput(payloadTypeHandshakeResponse))
+		resent = messages[len(messages)-1]
+
+		ack, _ := json.Marshal(
+			acknowledgeContent{
+				AcknowledgedMessageID:             resent.MessageID,
+				AcknowledgedMessageSequenceNumber: resent.SequenceNumber,
+				AcknowledgedMessageType:           resent.MessageType,
+				IsSequentialMessage:               true,
+			},
+		)
+
+		agent.send(0, messageTypeAcknowledge, 0, ack)
+		time.Sleep(300 * time.Millisecond)
+		agent.send(0, messageTypeChannelClosed, 0, []byte(`{}`))
+
+		for message := range agent.received {
+			later = append(later, message)
+		}
+	})
+	defer server.Close()
+
+	session, err := Open(strings.Replace(server.URL, "http", "ws", 1), "token")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	session.resendTimeout = 100 * time.Millisecond
+
+	if err := session.Run(strings.NewReader(""), &bytes.Buffer{}, 0, 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	session.Close()
+	<-finished
+
+	if first.MessageID != resent.MessageID || first.SequenceNumber != resent.SequenceNumber {
+		t.Errorf("expected message %s (%d) to be resent, got %s (%d)",
+			first.MessageID, first.SequenceNumber, resent.MessageID, resent.SequenceNumber)
+	}
+
+	for _, message := range later {
+		if message.MessageType == messageTypeInputStreamData {
+			t.Errorf("expected no input to be resent once acknowledged, got sequence number %d", message.SequenceNumber)
+		}
+	}
+
+	session.inputMu.Lock()
+	defer session.inputMu.Unlock()
+
+	if len(session.unacknowledged) != 0 {
+		t.Errorf("expected no unacknowledged input, got %d messages", len(session.unacknowledged))
+	}
+}
+
+func TestOpenHandshakeFailure(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	if _, err := Open(strings.Replace(server.URL, "http", "ws", 1), "token"); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+func TestOpenUnsupportedScheme(t *testing.T) {
+	if _, err := Open("https://ssmmessages.us-east-1.amazonaws.com/v1/data-channel/session", "token"); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
@@ -0,0 +1,298 @@
+package ssmmessages
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// A minimal WebSocket (RFC 6455) client, sufficient for the Session Manager data channel: messages
+// are written as single frames, fragmented messages are reassembled on read, and pings are answered.
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	opContinuation byte = 0x0
+	opText         byte = 0x1
+	opBinary       byte = 0x2
+	opClose        byte = 0x8
+	opPing         byte = 0x9
+	opPong         byte = 0xA
+)
+
+const maxFramePayloadLength = 16 << 20
+
+type websocketConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	masked bool
+
+	writeMu sync.Mutex
+}
+
+// dialWebsocket opens a WebSocket connection to a ws:// or wss:// URL.
+func dialWebsocket(rawURL string) (*websocketConn, error) {
+	var conn net.Conn
+
+	u, err := url.Parse(rawURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	addr := u.Host
+
+	if u.Port() == "" {
+		if u.Scheme == "wss" {
+			addr = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			addr = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+
+	switch u.Scheme {
+	case "wss":
+		conn, err = tls.Dial("tcp", addr, &tls.Config{ServerName: u.Hostname()})
+	case "ws":
+		conn, err = net.Dial("tcp", addr)
+	default:
+		return nil, fmt.Errorf("unsupported WebSocket URL scheme %s", u.Scheme)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := handshake(conn, u)
+
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return ws, nil
+}
+
+func handshake(conn net.Conn, u *url.URL) (*websocketConn, error) {
+	nonce := make([]byte, 16)
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	key := base64.StdEncoding.EncodeToString(nonce)
+	req := &http.Request{
+		Method:     "GET",
+		URL:        &url.URL{Path: u.Path, RawPath: u.RawPath, RawQuery: u.RawQuery},
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Host:       u.Host,
+		Header: http.Header{
+			"Connection":            []string{"Upgrade"},
+			"Sec-Websocket-Key":     []string{key},
+			"Sec-Websocket-Version": []string{"13"},
+			"Upgrade":               []string{"websocket"},
+		},
+	}
+
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, fmt.Errorf("WebSocket handshake failed: %s", resp.Status)
+	}
+
+	if resp.Header.Get("Sec-Websocket-Accept") != websocketAccept(key) {
+		return nil, errors.New("WebSocket handshake failed: invalid Sec-WebSocket-Accept")
+	}
+
+	return newWebsocketConn(conn, reader, true), nil
+}
+
+// newWebsocketConn wraps an upgraded connection. Frames sent by clients must be masked and frames
+// sent by servers must not be.
+func newWebsocketConn(conn net.Conn, reader *bufio.Reader, masked bool) *websocketConn {
+	return &websocketConn{conn: conn, reader: reader, masked: masked}
+}
+
+// websocketAccept returns the Sec-WebSocket-Accept value a server answers the given key with.
+func websocketAccept(key string) string {
+	hash := sha1.Sum([]byte(key + websocketGUID))
+
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// WriteMessage writes a message as a single frame.
+func (c *websocketConn) WriteMessage(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	header := []byte{0x80 | opcode, 0}
+
+	switch length := len(payload); {
+	case length < 126:
+		header[1] = byte(length)
+	case length <= 0xFFFF:
+		header[1] = 126
+		header = append(header, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(length))
+	default:
+		header[1] = 127
+		header = append(header, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(length))
+	}
+
+	if c.masked {
+		maskKey := make([]byte, 4)
+
+		if _, err := rand.Read(maskKey); err != nil {
+			return err
+		}
+
+		header[1] |= 0x80
+		header = append(header, maskKey...)
+		payload = maskPayload(maskKey, payload)
+	}
+
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ReadMessage returns the next text or binary message, answering pings along the way. io.EOF is
+// returned once the peer closes the connection.
+func (c *websocketConn) ReadMessage() (byte, []byte, error) {
+	var (
+		message       []byte
+		messageOpcode byte
+	)
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case opPing:
+			if err := c.WriteMessage(opPong, payload); err != nil {
+				return 0, nil, err
+			}
+
+			continue
+		case opPong:
+			continue
+		case opClose:
+			c.WriteMessage(opClose, nil)
+			return 0, nil, io.EOF
+		case opText, opBinary:
+			messageOpcode = opcode
+			message = payload
+		case opContinuation:
+			message = append(message, payload...)
+		default:
+			return 0, nil, fmt.Errorf("unknown WebSocket opcode %d", opcode)
+		}
+
+		if fin {
+			return messageOpcode, message, nil
+		}
+	}
+}
+
+func (c *websocketConn) readFrame() (bool, byte, []byte, error) {
+	header := make([]byte, 2)
+
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := header[0]&0x80 != 0
+	opcode := header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7F)
+
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+
+		if _, err := io.ReadFull(c.reader, extended); err != nil {
+			return false, 0, nil, err
+		}
+
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+
+		if _, err := io.ReadFull(c.reader, extended); err != nil {
+			return false, 0, nil, err
+		}
+
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	if length > maxFramePayloadLength {
+		return false, 0, nil, fmt.Errorf("WebSocket frame of %d bytes is too large", length)
+	}
+
+	var maskKey []byte
+
+	if masked {
+		maskKey = make([]byte, 4)
+
+		if _, err := io.ReadFull(c.reader, maskKey); err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+
+	if masked {
+		payload = maskPayload(maskKey, payload)
+	}
+
+	return fin, opcode, payload, nil
+}
+
+// Close sends a close frame and closes the underlying connection.
+func (c *websocketConn) Close() error {
+	c.WriteMessage(opClose, nil)
+
+	return c.conn.Close()
+}
+
+func maskPayload(maskKey, payload []byte) []byte {
+	masked := make([]byte, len(payload))
+
+	for i := range payload {
+		masked[i] = payload[i] ^ maskKey[i%4]
+	}
+
+	return masked
+}